@@ -65,6 +65,8 @@ describe('getCompletionItems()', () => {
             '-content',
             'context',
             'count',
+            'dedupe',
+            'explain',
             'file',
             '-file',
             'fork',
@@ -128,6 +130,8 @@ describe('getCompletionItems()', () => {
             '-content',
             'context',
             'count',
+            'dedupe',
+            'explain',
             'file',
             '-file',
             'fork',
@@ -168,6 +172,8 @@ describe('getCompletionItems()', () => {
             '-content',
             'context',
             'count',
+            'dedupe',
+            'explain',
             'file',
             '-file',
             'fork',
@@ -207,6 +213,8 @@ describe('getCompletionItems()', () => {
             '-content',
             'context',
             'count',
+            'dedupe',
+            'explain',
             'file',
             '-file',
             'fork',
@@ -246,6 +254,8 @@ describe('getCompletionItems()', () => {
             '-content',
             'context',
             'count',
+            'dedupe',
+            'explain',
             'file',
             '-file',
             'fork',
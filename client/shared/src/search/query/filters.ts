@@ -17,6 +17,8 @@ export enum FilterType {
     content = 'content',
     context = 'context',
     count = 'count',
+    dedupe = 'dedupe',
+    explain = 'explain',
     file = 'file',
     fork = 'fork',
     lang = 'lang',
@@ -231,6 +233,17 @@ export const FILTERS: Record<NegatableFilter, NegatableFilterDefinition> &
         placeholder: 'number',
         singular: true,
     },
+    [FilterType.dedupe]: {
+        description: 'Collapse file results with the same content, e.g. vendored copies.',
+        discreteValues: () => [{ label: 'content' }],
+        singular: true,
+    },
+    [FilterType.explain]: {
+        description: 'Stream an EXPLAIN ANALYZE style tree of the search jobs which ran.',
+        discreteValues: () => ['yes', 'no'].map(value => ({ label: value })),
+        default: 'no',
+        singular: true,
+    },
     [FilterType.file]: {
         alias: 'f',
         negatable: true,
@@ -25,7 +25,11 @@ export type SearchEvent =
     | { type: 'matches'; data: SearchMatch[] }
     | { type: 'progress'; data: Progress }
     | { type: 'filters'; data: Filter[] }
+    | { type: 'aggregates'; data: Aggregate[] }
     | { type: 'alert'; data: Alert }
+    | { type: 'explain'; data: Explain }
+    | { type: 'diagnostics'; data: QueryDiagnostic[] }
+    | { type: 'resume'; data: Resume }
     | { type: 'error'; data: ErrorLike }
     | { type: 'done'; data: {} }
 
@@ -41,6 +45,25 @@ export interface PathMatch {
     branches?: string[]
     commit?: string
     debug?: string
+    diffStatus?: DiffStatus
+    duplicates?: FileLocation[]
+}
+
+/**
+ * Set on file matches when comparing the results of a search at two
+ * revisions, e.g. `repo:foo@v1.2...v1.3`.
+ */
+export type DiffStatus = 'added' | 'removed'
+
+/**
+ * The location of a file with the same content as a file match, collapsed
+ * into it by `dedupe:content`.
+ */
+export interface FileLocation {
+    repositoryID: number
+    repository: string
+    commit?: string
+    path: string
 }
 
 export interface ContentMatch {
@@ -56,6 +79,8 @@ export interface ContentMatch {
     chunkMatches?: ChunkMatch[]
     hunks?: DecoratedHunk[]
     debug?: string
+    diffStatus?: DiffStatus
+    duplicates?: FileLocation[]
 }
 
 export interface DecoratedHunk {
@@ -105,6 +130,8 @@ export interface SymbolMatch {
     commit?: string
     symbols: MatchedSymbol[]
     debug?: string
+    diffStatus?: DiffStatus
+    duplicates?: FileLocation[]
 }
 
 export interface MatchedSymbol {
@@ -267,6 +294,26 @@ export interface Filter {
     kind: 'file' | 'repo' | 'lang' | 'utility'
 }
 
+/**
+ * The running count of matches for a value selected by the `select:` filter of
+ * a query, e.g. a repository name for `select:repo` or a symbol kind for
+ * `select:symbol`.
+ */
+export interface Aggregate {
+    value: string
+    count: number
+}
+
+/** The EXPLAIN ANALYZE style tree of the jobs which ran for a query with `explain:yes`. */
+export interface Explain {
+    tree: string
+}
+
+/** The token which continues an interrupted search with the repositories which were not completely searched. */
+export interface Resume {
+    token: string
+}
+
 export type SmartSearchAlertKind = 'smart-search-additional-results' | 'smart-search-pure-results'
 export type AlertKind = SmartSearchAlertKind | 'unowned-results'
 
@@ -275,6 +322,19 @@ interface Alert {
     description?: string | null
     kind?: AlertKind | null
     proposedQueries: ProposedQuery[] | null
+    diagnostics?: QueryDiagnostic[]
+}
+
+/**
+ * A problem found in the search query, e.g. an invalid filter or a `repo:`
+ * pattern too broad to use the repository name index. Ranges are on the single
+ * line of the query.
+ */
+export interface QueryDiagnostic {
+    range: Range
+    severity: 'error' | 'warning'
+    message: string
+    replacement?: string
 }
 
 // Same key values from internal/search/alert.go
@@ -293,6 +353,10 @@ interface BaseAggregateResults {
     results: SearchMatch[]
     alert?: Alert
     filters: Filter[]
+    aggregates?: Aggregate[]
+    explain?: Explain
+    diagnostics?: QueryDiagnostic[]
+    resume?: Resume
     progress: Progress
 }
 
@@ -352,12 +416,37 @@ export const switchAggregateSearchResults: OperatorFunction<SearchEvent, Aggrega
                                 filters: newEvent.value.data,
                             }
 
+                        case 'aggregates':
+                            return {
+                                ...results,
+                                // Aggregates are running totals and replace previous ones
+                                aggregates: newEvent.value.data,
+                            }
+
                         case 'alert':
                             return {
                                 ...results,
                                 alert: newEvent.value.data,
                             }
 
+                        case 'explain':
+                            return {
+                                ...results,
+                                explain: newEvent.value.data,
+                            }
+
+                        case 'diagnostics':
+                            return {
+                                ...results,
+                                diagnostics: newEvent.value.data,
+                            }
+
+                        case 'resume':
+                            return {
+                                ...results,
+                                resume: newEvent.value.data,
+                            }
+
                         default:
                             return results
                     }
@@ -461,6 +550,10 @@ export const messageHandlers: MessageHandlers = {
     matches: observeMessagesHandler,
     progress: observeMessagesHandler,
     filters: observeMessagesHandler,
+    aggregates: observeMessagesHandler,
+    explain: observeMessagesHandler,
+    diagnostics: observeMessagesHandler,
+    resume: observeMessagesHandler,
     alert: observeMessagesHandler,
 }
 
@@ -33,6 +33,8 @@ const firstMatchMessageHandlers: MessageHandlers = {
         }),
     progress: noopHandler,
     filters: noopHandler,
+    aggregates: noopHandler,
+    explain: noopHandler,
     alert: noopHandler,
 }
 
@@ -271,6 +271,75 @@ const CorruptionLogsContainer: FC<CorruptionLogProps> = props => {
     )
 }
 
+interface HealthReportProps {
+    repo: SettingsAreaRepositoryFields
+}
+
+const HealthReportContainer: FC<HealthReportProps> = ({ repo }) => {
+    const report = repo.mirrorInfo.healthReport
+
+    let details: JSX.Element
+    if (!report) {
+        details = <Text className="mb-0">The repository has not been checked yet.</Text>
+    } else {
+        const problems = [
+            ...report.missingObjects.map(oid => `missing object ${oid}`),
+            ...report.danglingRefs.map(ref => `ref ${ref} points to an object that doesn't exist`),
+            ...report.fsckErrors,
+        ]
+        if (report.packBloat) {
+            problems.push(`${report.packCount} pack files and ${report.looseObjectCount} loose objects need repacking`)
+        }
+        if (report.commitGraphStale) {
+            problems.push('the commit-graph is out of date')
+        }
+
+        details = (
+            <div className="flex-1">
+                <Alert
+                    className={classNames('mb-2', styles.alert)}
+                    variant={report.corrupt ? 'danger' : problems.length > 0 ? 'warning' : 'success'}
+                >
+                    {problems.length === 0 ? 'No problems were found' : `${problems.length} problems were found`} when
+                    the repository was checked <Timestamp date={report.checkedAt} />.
+                </Alert>
+                {problems.length > 0 && (
+                    <pre className={styles.log}>
+                        <Code>{problems.join('\n')}</Code>
+                    </pre>
+                )}
+                {report.repairs.length > 0 && (
+                    <ul className="list-group">
+                        {report.repairs.map(repair => (
+                            <li key={repair.action} className="list-group-item px-2 py-1">
+                                <strong>{repair.action}</strong>{' '}
+                                {repair.error === null ? (
+                                    <span className="text-success">succeeded</span>
+                                ) : (
+                                    <span className="text-danger">failed: {repair.error}</span>
+                                )}
+                            </li>
+                        ))}
+                    </ul>
+                )}
+            </div>
+        )
+    }
+
+    return (
+        <BaseActionContainer
+            title="Repository health"
+            description={
+                <span>
+                    The result of the last periodic <Code>git fsck</Code> of the repository and the repairs attempted
+                    for the problems found.
+                </span>
+            }
+            details={details}
+        />
+    )
+}
+
 interface RepoSettingsMirrorPageProps {
     repo: SettingsAreaRepositoryFields
 }
@@ -404,6 +473,7 @@ export const RepoSettingsMirrorPage: FC<RepoSettingsMirrorPageProps> = props =>
                     </Alert>
                 )}
                 <CorruptionLogsContainer repo={repo} />
+                <HealthReportContainer repo={repo} />
             </Container>
         </>
     )
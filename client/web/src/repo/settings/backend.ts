@@ -36,6 +36,21 @@ export const settingsAreaRepositoryFragment = gql`
                 timestamp
                 reason
             }
+            healthReport {
+                checkedAt
+                corrupt
+                missingObjects
+                danglingRefs
+                fsckErrors
+                packCount
+                looseObjectCount
+                packBloat
+                commitGraphStale
+                repairs {
+                    action
+                    error
+                }
+            }
             lastError
             updateSchedule {
                 due
@@ -112,6 +112,7 @@ func (r *GitTreeEntryResolver) Content(ctx context.Context, args *GitTreeContent
 			r.commit.repoResolver.RepoName(),
 			api.CommitID(r.commit.OID()),
 			r.Path(),
+			gitserver.ReadFileOptions{ResolveLFS: true},
 		)
 	})
 
@@ -222,6 +222,89 @@ func (r *corruptionLogResolver) Reason() (string, error) {
 	return r.log.Reason, nil
 }
 
+func (r *repositoryMirrorInfoResolver) HealthReport(ctx context.Context) (*repositoryHealthReportResolver, error) {
+	info, err := r.computeGitserverRepo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.HealthReport == nil {
+		return nil, nil
+	}
+	return &repositoryHealthReportResolver{report: info.HealthReport}, nil
+}
+
+type repositoryHealthReportResolver struct {
+	report *types.RepoHealthReport
+}
+
+func (r *repositoryHealthReportResolver) CheckedAt() gqlutil.DateTime {
+	return gqlutil.DateTime{Time: r.report.CheckedAt}
+}
+
+func (r *repositoryHealthReportResolver) Corrupt() bool {
+	return r.report.Corrupt()
+}
+
+func (r *repositoryHealthReportResolver) MissingObjects() []string {
+	return nonNilStrings(r.report.MissingObjects)
+}
+
+func (r *repositoryHealthReportResolver) DanglingRefs() []string {
+	return nonNilStrings(r.report.DanglingRefs)
+}
+
+func (r *repositoryHealthReportResolver) FsckErrors() []string {
+	return nonNilStrings(r.report.FsckErrors)
+}
+
+func (r *repositoryHealthReportResolver) PackCount() int32 {
+	return int32(r.report.PackCount)
+}
+
+func (r *repositoryHealthReportResolver) LooseObjectCount() int32 {
+	return int32(r.report.LooseObjectCount)
+}
+
+func (r *repositoryHealthReportResolver) PackBloat() bool {
+	return r.report.PackBloat
+}
+
+func (r *repositoryHealthReportResolver) CommitGraphStale() bool {
+	return r.report.CommitGraphStale
+}
+
+func (r *repositoryHealthReportResolver) Repairs() []*repositoryRepairResolver {
+	repairs := make([]*repositoryRepairResolver, 0, len(r.report.Repairs))
+	for _, repair := range r.report.Repairs {
+		repairs = append(repairs, &repositoryRepairResolver{repair: repair})
+	}
+	return repairs
+}
+
+// nonNilStrings returns s, or an empty slice if s is nil, for non-null lists.
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+type repositoryRepairResolver struct {
+	repair types.RepoRepair
+}
+
+func (r *repositoryRepairResolver) Action() string {
+	return r.repair.Action
+}
+
+func (r *repositoryRepairResolver) Error() *string {
+	if r.repair.Error == "" {
+		return nil
+	}
+	return &r.repair.Error
+}
+
 func (r *repositoryMirrorInfoResolver) ByteSize(ctx context.Context) (BigInt, error) {
 	info, err := r.computeGitserverRepo(ctx)
 	if err != nil {
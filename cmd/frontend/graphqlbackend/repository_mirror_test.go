@@ -341,3 +341,77 @@ func TestRepositoryMirrorInfoCloneProgressFetchedFromDatabase(t *testing.T) {
 		`,
 	})
 }
+
+func TestRepositoryMirrorInfoHealthReport(t *testing.T) {
+	users := database.NewMockUserStore()
+	users.GetByCurrentAuthUserFunc.SetDefaultReturn(&types.User{SiteAdmin: true}, nil)
+
+	gitserverRepos := database.NewMockGitserverRepoStore()
+	gitserverRepos.GetByIDFunc.SetDefaultReturn(&types.GitserverRepo{
+		CloneStatus: types.CloneStatusCloned,
+		HealthReport: &types.RepoHealthReport{
+			CheckedAt:      time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC),
+			MissingObjects: []string{"e8960bf2b8d05ecd2435a205010631c7c5c47bf4"},
+			PackCount:      3,
+			Repairs: []types.RepoRepair{
+				{Action: "repack", Error: "repository is still corrupt after repack"},
+				{Action: "refetch"},
+			},
+		},
+	}, nil)
+
+	db := database.NewMockDB()
+	db.UsersFunc.SetDefaultReturn(users)
+	db.GitserverReposFunc.SetDefaultReturn(gitserverRepos)
+
+	backend.Mocks.Repos.GetByName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{ID: 4752134, Name: "repo-name", CreatedAt: time.Now()}, nil
+	}
+	t.Cleanup(func() {
+		backend.Mocks = backend.MockServices{}
+	})
+
+	RunTest(t, &Test{
+		Schema: mustParseGraphQLSchema(t, db),
+		Query: `
+			{
+				repository(name: "my/repo") {
+					mirrorInfo {
+						healthReport {
+							checkedAt
+							corrupt
+							missingObjects
+							danglingRefs
+							packCount
+							packBloat
+							repairs {
+								action
+								error
+							}
+						}
+					}
+				}
+			}
+		`,
+		ExpectedResult: `
+			{
+				"repository": {
+					"mirrorInfo": {
+						"healthReport": {
+							"checkedAt": "2023-06-01T12:00:00Z",
+							"corrupt": true,
+							"missingObjects": ["e8960bf2b8d05ecd2435a205010631c7c5c47bf4"],
+							"danglingRefs": [],
+							"packCount": 3,
+							"packBloat": false,
+							"repairs": [
+								{"action": "repack", "error": "repository is still corrupt after repack"},
+								{"action": "refetch", "error": null}
+							]
+						}
+					}
+				}
+			}
+		`,
+	})
+}
@@ -49,6 +49,14 @@ func (a searchAlertResolver) ProposedQueries() *[]*searchQueryDescriptionResolve
 	return &proposedQueries
 }
 
+func (a searchAlertResolver) Diagnostics() *[]*searchQueryDiagnosticResolver {
+	if len(a.alert.Diagnostics) == 0 {
+		return nil
+	}
+	diagnostics := toSearchQueryDiagnosticResolvers(a.alert.Diagnostics)
+	return &diagnostics
+}
+
 func (a searchAlertResolver) wrapSearchImplementer(db database.DB) *alertSearchImplementer {
 	return &alertSearchImplementer{
 		db:    db,
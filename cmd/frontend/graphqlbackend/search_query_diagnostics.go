@@ -0,0 +1,53 @@
+package graphqlbackend
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+type searchQueryDiagnosticsArgs struct {
+	Query       string
+	PatternType string
+}
+
+func (r *schemaResolver) SearchQueryDiagnostics(args *searchQueryDiagnosticsArgs) ([]*searchQueryDiagnosticResolver, error) {
+	searchType, err := client.SearchTypeFromString(args.PatternType)
+	if err != nil {
+		return nil, err
+	}
+	return toSearchQueryDiagnosticResolvers(query.Lint(args.Query, searchType)), nil
+}
+
+func toSearchQueryDiagnosticResolvers(diagnostics []query.Diagnostic) []*searchQueryDiagnosticResolver {
+	resolvers := make([]*searchQueryDiagnosticResolver, 0, len(diagnostics))
+	for _, d := range diagnostics {
+		resolvers = append(resolvers, &searchQueryDiagnosticResolver{d})
+	}
+	return resolvers
+}
+
+type searchQueryDiagnosticResolver struct {
+	diagnostic query.Diagnostic
+}
+
+func (d *searchQueryDiagnosticResolver) Severity() string {
+	return strings.ToUpper(string(d.diagnostic.Severity))
+}
+
+func (d *searchQueryDiagnosticResolver) Message() string {
+	return d.diagnostic.Message
+}
+
+func (d *searchQueryDiagnosticResolver) Start() int32 {
+	return int32(d.diagnostic.Range.Start.Column)
+}
+
+func (d *searchQueryDiagnosticResolver) End() int32 {
+	return int32(d.diagnostic.Range.End.Column)
+}
+
+func (d *searchQueryDiagnosticResolver) Replacement() *string {
+	return d.diagnostic.Replacement
+}
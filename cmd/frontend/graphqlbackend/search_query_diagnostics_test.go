@@ -0,0 +1,33 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func TestSearchQueryDiagnostics(t *testing.T) {
+	db := database.NewMockDB()
+
+	RunTest(t, &Test{
+		Schema:  mustParseGraphQLSchema(t, db),
+		Context: context.Background(),
+		Query: `
+		{
+			searchQueryDiagnostics(query: "repo:fo type:diff lang:py") {
+				severity
+				start
+				end
+				replacement
+			}
+		}`,
+		ExpectedResult: `{
+			"searchQueryDiagnostics": [
+				{"severity": "WARNING", "start": 0, "end": 7, "replacement": null},
+				{"severity": "WARNING", "start": 8, "end": 17, "replacement": "type:diff after:\"1 month ago\""},
+				{"severity": "ERROR", "start": 18, "end": 25, "replacement": "lang:python"}
+			]
+		}`,
+	})
+}
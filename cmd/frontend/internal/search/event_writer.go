@@ -2,6 +2,7 @@ package search
 
 import (
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
 	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
@@ -47,6 +48,29 @@ func (e *eventWriter) Filters(fs []*streaming.Filter) error {
 	return nil
 }
 
+func (e *eventWriter) Aggregates(aggs []*streaming.Aggregate) error {
+	buf := make([]streamhttp.EventAggregate, 0, len(aggs))
+	for _, a := range aggs {
+		buf = append(buf, streamhttp.EventAggregate{
+			Value: a.Value,
+			Count: a.Count,
+		})
+	}
+	return e.inner.Event("aggregates", buf)
+}
+
+func (e *eventWriter) Explain(tree string) error {
+	return e.inner.Event("explain", streamhttp.EventExplain{Tree: tree})
+}
+
+func (e *eventWriter) Diagnostics(ds []query.Diagnostic) error {
+	return e.inner.Event("diagnostics", toQueryDiagnostics(ds))
+}
+
+func (e *eventWriter) Resume(token string) error {
+	return e.inner.Event("resume", streamhttp.EventResume{Token: token})
+}
+
 func (e *eventWriter) Error(err error) error {
 	return e.inner.Event("error", streamhttp.EventError{Message: err.Error()})
 }
@@ -70,5 +94,22 @@ func (e *eventWriter) Alert(alert *search.Alert) error {
 		Description:     alert.Description,
 		Kind:            alert.Kind,
 		ProposedQueries: pqs,
+		Diagnostics:     toQueryDiagnostics(alert.Diagnostics),
 	})
 }
+
+func toQueryDiagnostics(ds []query.Diagnostic) []streamhttp.QueryDiagnostic {
+	var diagnostics []streamhttp.QueryDiagnostic
+	for _, d := range ds {
+		diagnostics = append(diagnostics, streamhttp.QueryDiagnostic{
+			Range: streamhttp.Range{
+				Start: streamhttp.Location{Offset: d.Range.Start.Column, Column: d.Range.Start.Column},
+				End:   streamhttp.Location{Offset: d.Range.End.Column, Column: d.Range.End.Column},
+			},
+			Severity:    string(d.Severity),
+			Message:     d.Message,
+			Replacement: d.Replacement,
+		})
+	}
+	return diagnostics
+}
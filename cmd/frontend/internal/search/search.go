@@ -28,9 +28,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/client"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/printer"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	streamapi "github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
 	streamclient "github.com/sourcegraph/sourcegraph/internal/search/streaming/client"
 	streamhttp "github.com/sourcegraph/sourcegraph/internal/search/streaming/http"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -109,13 +114,40 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter
 	if err != nil {
 		var queryErr *client.QueryError
 		if errors.As(err, &queryErr) {
-			eventWriter.Alert(search.AlertForQuery(queryErr.Query, queryErr.Err))
+			alert := search.AlertForQuery(queryErr.Query, queryErr.Err)
+			alert.Diagnostics = queryErr.Diagnostics
+			eventWriter.Alert(alert)
 			return nil
 		} else {
 			return err
 		}
 	}
 
+	// Valid queries may still have problems worth pointing out, like
+	// expensive filters. We send these before any results.
+	if diagnostics := query.Lint(inputs.OriginalQuery, inputs.PatternType); len(diagnostics) > 0 {
+		eventWriter.Diagnostics(diagnostics)
+	}
+
+	// Searches continuing from a resume token skip the repositories which
+	// were completely searched by the interrupted search.
+	var resume streaming.ResumeState
+	if args.Resume != "" {
+		resume, err = streamhttp.DecodeResumeToken(args.resumeQuery(), args.Resume)
+		if err != nil {
+			return err
+		}
+		ctx = streaming.WithResume(ctx, resume)
+	}
+
+	// Queries with explain:yes record the jobs which run, which we stream as
+	// an EXPLAIN ANALYZE style tree once the search is done.
+	var explain *job.ExplainNode
+	if inputs.Query.BoolValue(query.FieldExplain) {
+		explain = job.NewExplain("Search")
+		ctx = job.WithExplain(ctx, explain)
+	}
+
 	// Display is the number of results we send down. If display is < 0 we
 	// want to send everything we find before hitting a limit. Otherwise we
 	// can only send up to limit results.
@@ -133,6 +165,14 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter
 		RepoNamer:    streamclient.RepoNamer(ctx, h.db),
 	}
 
+	// Queries with a select: filter additionally stream running counts of
+	// matches grouped by the selected value.
+	var aggregates *streaming.SearchAggregates
+	if v, _ := inputs.Query.StringValue(query.FieldSelect); v != "" {
+		selector, _ := filter.SelectPathFromString(v) // Invariant: select is validated
+		aggregates = streaming.NewSearchAggregates(selector)
+	}
+
 	var latency *time.Duration
 	logLatency := func() {
 		elapsed := time.Since(start)
@@ -157,10 +197,13 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter
 			h.db,
 			eventWriter,
 			progress,
+			aggregates,
 			h.flushTickerInternal,
 			h.pingTickerInterval,
 			displayLimit,
 			args.EnableChunkMatches,
+			resume,
+			args.resumeQuery(),
 			logLatency,
 		)
 		defer eventHandler.Done()
@@ -173,6 +216,11 @@ func (h *streamHandler) serveHTTP(r *http.Request, tr *trace.Trace, eventWriter
 	if alert != nil {
 		eventWriter.Alert(alert)
 	}
+	if explain != nil {
+		explain.Duration = time.Since(start)
+		explain.Results = int64(progress.MatchCount)
+		eventWriter.Explain(printer.ExplainAnalyze(explain))
+	}
 	logSearch(ctx, h.logger, alert, err, time.Since(start), latency, inputs.OriginalQuery, progress)
 	return err
 }
@@ -217,6 +265,12 @@ type args struct {
 	Display            int
 	EnableChunkMatches bool
 	SearchMode         int
+	Resume             string
+}
+
+// resumeQuery identifies the search a resume token was created for.
+func (a *args) resumeQuery() string {
+	return strings.Join([]string{a.Version, a.PatternType, strconv.Itoa(a.SearchMode), a.Query}, "\x00")
 }
 
 func parseURLQuery(q url.Values) (*args, error) {
@@ -232,6 +286,7 @@ func parseURLQuery(q url.Values) (*args, error) {
 		Query:       get("q", ""),
 		Version:     get("v", "V3"),
 		PatternType: get("t", ""),
+		Resume:      get("resume", ""),
 	}
 
 	if a.Query == "" {
@@ -296,6 +351,8 @@ func fromPathMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searche
 		Repository:   string(fm.Repo.Name),
 		RepositoryID: int32(fm.Repo.ID),
 		Commit:       string(fm.CommitID),
+		DiffStatus:   fromRevisionDiff(fm.RevisionDiff),
+		Duplicates:   fromDuplicates(fm.Duplicates),
 	}
 
 	if r, ok := repoCache[fm.Repo.ID]; ok {
@@ -314,6 +371,33 @@ func fromPathMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searche
 	return pathEvent
 }
 
+func fromRevisionDiff(d result.RevisionDiff) streamapi.DiffStatus {
+	switch d {
+	case result.RevisionDiffAdded:
+		return streamapi.DiffStatusAdded
+	case result.RevisionDiffRemoved:
+		return streamapi.DiffStatusRemoved
+	default:
+		return ""
+	}
+}
+
+func fromDuplicates(files []result.File) []streamhttp.EventFileLocation {
+	if len(files) == 0 {
+		return nil
+	}
+	res := make([]streamhttp.EventFileLocation, 0, len(files))
+	for _, f := range files {
+		res = append(res, streamhttp.EventFileLocation{
+			RepositoryID: int32(f.Repo.ID),
+			Repository:   string(f.Repo.Name),
+			Commit:       string(f.CommitID),
+			Path:         f.Path,
+		})
+	}
+	return res
+}
+
 func fromChunkMatches(cms result.ChunkMatches) []streamhttp.ChunkMatch {
 	res := make([]streamhttp.ChunkMatch, 0, len(cms))
 	for _, cm := range cms {
@@ -379,6 +463,8 @@ func fromContentMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Sear
 		Commit:       string(fm.CommitID),
 		LineMatches:  eventLineMatches,
 		ChunkMatches: eventChunkMatches,
+		DiffStatus:   fromRevisionDiff(fm.RevisionDiff),
+		Duplicates:   fromDuplicates(fm.Duplicates),
 	}
 
 	if fm.InputRev != nil {
@@ -422,6 +508,8 @@ func fromSymbolMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Searc
 		RepositoryID: int32(fm.Repo.ID),
 		Commit:       string(fm.CommitID),
 		Symbols:      symbols,
+		DiffStatus:   fromRevisionDiff(fm.RevisionDiff),
+		Duplicates:   fromDuplicates(fm.Duplicates),
 	}
 
 	if r, ok := repoCache[fm.Repo.ID]; ok {
@@ -593,10 +681,13 @@ func newEventHandler(
 	db database.DB,
 	eventWriter *eventWriter,
 	progress *streamclient.ProgressAggregator,
+	aggregates *streaming.SearchAggregates,
 	flushInterval time.Duration,
 	progressInterval time.Duration,
 	displayLimit int,
 	enableChunkMatches bool,
+	resume streaming.ResumeState,
+	resumeQuery string,
 	logLatency func(),
 ) *eventHandler {
 	// Store marshalled matches and flush periodically or when we go over
@@ -613,11 +704,14 @@ func newEventHandler(
 		eventWriter:        eventWriter,
 		matchesBuf:         matchesBuf,
 		filters:            &streaming.SearchFilters{},
+		aggregates:         aggregates,
 		flushInterval:      flushInterval,
 		progress:           progress,
 		progressInterval:   progressInterval,
 		displayRemaining:   displayLimit,
 		enableChunkMatches: enableChunkMatches,
+		resume:             resume,
+		resumeQuery:        resumeQuery,
 		first:              true,
 		logLatency:         logLatency,
 	}
@@ -642,6 +736,7 @@ type eventHandler struct {
 	enableChunkMatches bool
 	flushInterval      time.Duration
 	progressInterval   time.Duration
+	resumeQuery        string
 
 	logLatency func()
 
@@ -654,6 +749,14 @@ type eventHandler struct {
 	filters    *streaming.SearchFilters
 	progress   *streamclient.ProgressAggregator
 
+	// aggregates is nil unless the query contains a select: filter.
+	aggregates *streaming.SearchAggregates
+
+	// resume is how far the search got, including the state it was resumed
+	// from. resumeDirty is true if it changed since the last resume event.
+	resume      streaming.ResumeState
+	resumeDirty bool
+
 	// These timers will be non-nil unless Done() was called
 	flushTimer    *time.Timer
 	progressTimer *time.Timer
@@ -668,6 +771,13 @@ func (h *eventHandler) Send(event streaming.SearchEvent) {
 
 	h.progress.Update(event)
 	h.filters.Update(event)
+	if h.aggregates != nil {
+		h.aggregates.Update(event)
+	}
+	if len(event.Stats.Resume) > 0 {
+		h.resume.Update(event.Stats.Resume)
+		h.resumeDirty = true
+	}
 
 	h.displayRemaining = event.Results.Limit(h.displayRemaining)
 
@@ -715,7 +825,9 @@ func (h *eventHandler) Done() {
 
 	// Flush the final state
 	h.eventWriter.Filters(h.filters.Compute())
+	h.flushAggregates()
 	h.matchesBuf.Flush()
+	h.flushResume()
 	h.eventWriter.Progress(h.progress.Final())
 }
 
@@ -739,7 +851,9 @@ func (h *eventHandler) flushTick() {
 	// a nil flushTimer indicates that Done() was called
 	if h.flushTimer != nil {
 		h.eventWriter.Filters(h.filters.Compute())
+		h.flushAggregates()
 		h.matchesBuf.Flush()
+		h.flushResume()
 		if h.progress.Dirty {
 			h.eventWriter.Progress(h.progress.Current())
 		}
@@ -748,3 +862,28 @@ func (h *eventHandler) flushTick() {
 		h.flushTimer = time.AfterFunc(h.flushInterval, h.flushTick)
 	}
 }
+
+// flushResume writes a resume event if the search got further since the last
+// flush. It must be called after flushing the matches, since the token
+// skips the repositories of matches which were sent before it. The caller
+// must hold h.mu.
+func (h *eventHandler) flushResume() {
+	if !h.resumeDirty {
+		return
+	}
+	token, err := streamhttp.EncodeResumeToken(h.resumeQuery, h.resume)
+	if err != nil {
+		h.logger.Error("failed to encode resume token", log.Error(err))
+		return
+	}
+	h.eventWriter.Resume(token)
+	h.resumeDirty = false
+}
+
+// flushAggregates writes the aggregates event if they changed since the last
+// flush. The caller must hold h.mu.
+func (h *eventHandler) flushAggregates() {
+	if h.aggregates != nil && h.aggregates.Dirty {
+		h.eventWriter.Aggregates(h.aggregates.Compute())
+	}
+}
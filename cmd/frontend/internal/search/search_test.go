@@ -128,6 +128,50 @@ func TestServeStream_chunkMatches(t *testing.T) {
 	require.Len(t, chunkMatches[0].Ranges, 1)
 }
 
+func TestServeStream_diagnostics(t *testing.T) {
+	settings.MockCurrentUserFinal = &schema.Settings{}
+	t.Cleanup(func() { settings.MockCurrentUserFinal = nil })
+
+	mock := client.NewMockSearchClient()
+	mock.PlanFunc.SetDefaultReturn(&search.Inputs{
+		OriginalQuery: "repo:a.*b foo",
+		PatternType:   query.SearchTypeStandard,
+	}, nil)
+
+	ts := httptest.NewServer(&streamHandler{
+		logger:              logtest.Scoped(t),
+		flushTickerInternal: 1 * time.Millisecond,
+		pingTickerInterval:  1 * time.Millisecond,
+		searchClient:        mock,
+	})
+	defer ts.Close()
+
+	res, err := http.Get(ts.URL + "?q=test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	// Diagnostics of valid queries are sent even if there is no alert.
+	var diagnostics []streamhttp.QueryDiagnostic
+	var alert *streamhttp.EventAlert
+	decoder := streamhttp.FrontendStreamDecoder{
+		OnDiagnostics: func(ds []streamhttp.QueryDiagnostic) {
+			diagnostics = append(diagnostics, ds...)
+		},
+		OnAlert: func(ea *streamhttp.EventAlert) {
+			alert = ea
+		},
+	}
+	if err := decoder.ReadAll(res.Body); err != nil {
+		t.Fatal(err)
+	}
+	require.Nil(t, alert)
+	require.Len(t, diagnostics, 1)
+	require.Equal(t, "warning", diagnostics[0].Severity)
+	require.Equal(t, 9, diagnostics[0].Range.End.Column)
+}
+
 func TestDisplayLimit(t *testing.T) {
 	cases := []struct {
 		queryString         string
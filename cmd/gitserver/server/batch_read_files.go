@@ -0,0 +1,182 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// batchReadChunkSize is the maximum size of the chunks of file contents sent
+// by BatchReadFiles.
+const batchReadChunkSize = 1 << 20
+
+// errRevisionNotFound is returned by batchReadFiles if the commit doesn't
+// exist in the repository.
+var errRevisionNotFound = errors.New("revision not found")
+
+// catFileBatch reads objects from a repository with a single long-lived
+// git cat-file --batch process, rather than spawning a process per object.
+type catFileBatch struct {
+	cmd    *exec.Cmd
+	stdin  io.WriteCloser
+	stdout *bufio.Reader
+	stderr bytes.Buffer
+	buf    []byte
+}
+
+// startCatFileBatch starts git cat-file --batch in dir with the additional
+// environment env. It must be closed with Close.
+func startCatFileBatch(ctx context.Context, dir common.GitDir, env []string) (*catFileBatch, error) {
+	c := &catFileBatch{
+		cmd: exec.CommandContext(ctx, "git", "cat-file", "--batch"),
+		buf: make([]byte, batchReadChunkSize),
+	}
+	dir.Set(c.cmd)
+	c.cmd.Env = append(os.Environ(), env...)
+	c.cmd.Stderr = &c.stderr
+
+	stdin, err := c.cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := c.cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := c.cmd.Start(); err != nil {
+		return nil, errors.Wrap(err, "starting git cat-file")
+	}
+	c.stdin = stdin
+	c.stdout = bufio.NewReaderSize(stdout, 64*1024)
+	return c, nil
+}
+
+// Close stops the git process.
+func (c *catFileBatch) Close() error {
+	c.stdin.Close()
+	if err := c.cmd.Wait(); err != nil {
+		return errors.Wrapf(err, "git cat-file failed. Output: %s", c.stderr.String())
+	}
+	return nil
+}
+
+// read reads the object with the given name and calls onChunk with its
+// contents in chunks of at most batchReadChunkSize bytes, or once with no
+// data if it is empty. It returns the type of the object, or an empty string
+// if it doesn't exist. onChunk is not called if want is not the type of the
+// object.
+func (c *catFileBatch) read(object, want string, onChunk func([]byte) error) (typ string, err error) {
+	if _, err := io.WriteString(c.stdin, object+"\n"); err != nil {
+		return "", err
+	}
+
+	// The header is either "<oid> <type> <size>", or "<object> missing" (or
+	// "ambiguous") if the object doesn't exist.
+	header, err := c.stdout.ReadString('\n')
+	if err != nil {
+		return "", errors.Wrap(err, "reading git cat-file header")
+	}
+	header = header[:len(header)-1]
+	if header == object+" missing" || header == object+" ambiguous" {
+		return "", nil
+	}
+	fields := bytes.Fields([]byte(header))
+	if len(fields) != 3 {
+		return "", errors.Newf("unexpected git cat-file header: %q", header)
+	}
+	typ = string(fields[1])
+	size, err := strconv.ParseInt(string(fields[2]), 10, 64)
+	if err != nil {
+		return "", errors.Wrapf(err, "unexpected git cat-file header: %q", header)
+	}
+
+	// The contents are followed by a newline.
+	if typ != want {
+		if _, err := c.stdout.Discard(int(size) + 1); err != nil {
+			return "", err
+		}
+		return typ, nil
+	}
+	chunk := c.buf
+	for {
+		if size < int64(len(chunk)) {
+			chunk = chunk[:size]
+		}
+		n, err := io.ReadFull(c.stdout, chunk)
+		if err != nil {
+			return "", errors.Wrap(err, "reading git cat-file contents")
+		}
+		if err := onChunk(chunk[:n]); err != nil {
+			return "", err
+		}
+		size -= int64(n)
+		if size == 0 {
+			break
+		}
+	}
+	if _, err := c.stdout.Discard(1); err != nil {
+		return "", err
+	}
+	return typ, nil
+}
+
+// batchReadFiles reads the files at paths at commit in repo with a single git
+// process and sends their contents with send, in the order of paths. Paths
+// which aren't files at commit are sent as not found.
+func (s *Server) batchReadFiles(ctx context.Context, logger log.Logger, repo api.RepoName, commit string, paths []string, send func(*proto.BatchReadFilesResponse) error) error {
+	dir := s.dir(repo)
+
+	// Partial clones lazily fetch the blobs cat-file reads one by one, so we
+	// fetch all of them which are missing upfront.
+	var env []string
+	if isPartialClone(dir) {
+		remoteURL := s.partialCloneRemoteURL(ctx, repo)
+		pathspecs := make([]string, len(paths))
+		for i, path := range paths {
+			pathspecs[i] = ":(literal)" + path
+		}
+		if err := fetchMissingObjects(ctx, dir, remoteURL, commit, pathspecs); err != nil {
+			logger.Warn("failed to fetch missing objects of files", log.Error(err))
+		}
+		env = promisorRemoteEnv(remoteURL)
+	}
+
+	c, err := startCatFileBatch(ctx, dir, env)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if typ, err := c.read(commit, "", nil); err != nil {
+		return err
+	} else if typ != "commit" {
+		return errRevisionNotFound
+	}
+
+	for _, path := range paths {
+		typ, err := c.read(fmt.Sprintf("%s:%s", commit, path), "blob", func(chunk []byte) error {
+			return send(&proto.BatchReadFilesResponse{Path: path, Data: chunk})
+		})
+		if err != nil {
+			return err
+		}
+		if typ != "blob" {
+			if err := send(&proto.BatchReadFilesResponse{Path: path, NotFound: true}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+)
+
+func TestBatchReadFiles(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	remote := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	}
+	large := bytes.Repeat([]byte("0123456789abcdef"), batchReadChunkSize/8)
+	files := map[string][]byte{
+		"README.md":   []byte("hello world"),
+		"empty":       nil,
+		"dir/a..b":    []byte("dots"),
+		"dir/large":   large,
+		"with spaces": []byte("spaces"),
+	}
+	cmd("git", "init", ".")
+	for path, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(remote, path)), os.ModePerm))
+		require.NoError(t, os.WriteFile(filepath.Join(remote, path), content, 0o644))
+	}
+	cmd("git", "add", ".")
+	cmd("git", "commit", "-m", "files")
+	commit := strings.TrimSpace(cmd("git", "rev-parse", "HEAD"))
+
+	s := makeTestServer(ctx, t, t.TempDir(), remote, nil)
+	_, err := s.cloneRepo(ctx, repoName, &cloneOptions{Block: true})
+	require.NoError(t, err)
+
+	var responses []*proto.BatchReadFilesResponse
+	send := func(resp *proto.BatchReadFilesResponse) error {
+		// The data is only valid until send returns.
+		resp.Data = append([]byte(nil), resp.Data...)
+		responses = append(responses, resp)
+		return nil
+	}
+	err = s.batchReadFiles(ctx, logtest.Scoped(t), repoName, commit, []string{"README.md", "missing", "dir", "empty", "dir/a..b", "with spaces", "dir/large"}, send)
+	require.NoError(t, err)
+
+	want := []*proto.BatchReadFilesResponse{
+		{Path: "README.md", Data: []byte("hello world")},
+		{Path: "missing", NotFound: true},
+		{Path: "dir", NotFound: true},
+		{Path: "empty", Data: []byte{}},
+		{Path: "dir/a..b", Data: []byte("dots")},
+		{Path: "with spaces", Data: []byte("spaces")},
+		{Path: "dir/large", Data: large[:batchReadChunkSize]},
+		{Path: "dir/large", Data: large[batchReadChunkSize:]},
+	}
+	require.Len(t, responses, len(want))
+	for i := range want {
+		require.Equal(t, want[i].Path, responses[i].Path)
+		require.Equal(t, want[i].NotFound, responses[i].NotFound)
+		require.True(t, bytes.Equal(want[i].Data, responses[i].Data), "unexpected data for %s", want[i].Path)
+	}
+
+	err = s.batchReadFiles(ctx, logtest.Scoped(t), repoName, strings.Repeat("a", 40), []string{"README.md"}, send)
+	require.ErrorIs(t, err, errRevisionNotFound)
+}
@@ -0,0 +1,481 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// blameCacheDirName is the name of the directory under ReposDir in which we
+// cache the blame of files.
+const blameCacheDirName = ".blame-cache"
+
+var (
+	blameCacheSizeMB      = env.MustGetInt("SRC_BLAME_CACHE_SIZE_MB", 1024, "the size in MiB the cache of file blames on gitserver is trimmed to by the janitor")
+	blameCacheMaxDistance = env.MustGetInt("SRC_BLAME_CACHE_MAX_DISTANCE", 100, "the number of first-parent ancestors of a commit searched for a cached blame of a file to derive its blame from")
+)
+
+var blameCacheResults = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_blame_cache",
+	Help: "number of file blames by how they were computed (hit, derived or full)",
+}, []string{"result"})
+
+// fileBlame is the blame of a whole file at a commit, as stored in the blame
+// cache.
+type fileBlame struct {
+	Hunks   []blameHunk            `json:"hunks"`
+	Commits map[string]blameCommit `json:"commits"`
+}
+
+// blameHunk is a range of lines which were last changed by the same commit.
+// Adjacent lines with the same commit and filename are always in the same
+// hunk.
+type blameHunk struct {
+	StartLine int    `json:"startLine"` // 1-indexed start line number
+	EndLine   int    `json:"endLine"`   // 1-indexed end line number (exclusive)
+	Commit    string `json:"commit"`
+	Filename  string `json:"filename"` // path of the file in Commit
+}
+
+type blameCommit struct {
+	AuthorName  string    `json:"authorName"`
+	AuthorEmail string    `json:"authorEmail"`
+	AuthorDate  time.Time `json:"authorDate"`
+	Summary     string    `json:"summary"`
+}
+
+// blameLine is the blame of a single line.
+type blameLine struct {
+	commit   string
+	filename string
+}
+
+// lines returns the blame of each line of the file.
+func (b *fileBlame) lines() []blameLine {
+	var lines []blameLine
+	for _, h := range b.Hunks {
+		for i := h.StartLine; i < h.EndLine; i++ {
+			lines = append(lines, blameLine{commit: h.Commit, filename: h.Filename})
+		}
+	}
+	return lines
+}
+
+// newFileBlame returns the blame of a file from the blame of each of its
+// lines. Only the commits the lines refer to are kept.
+func newFileBlame(lines []blameLine, commits map[string]blameCommit) *fileBlame {
+	b := &fileBlame{Hunks: []blameHunk{}, Commits: map[string]blameCommit{}}
+	for i, l := range lines {
+		if n := len(b.Hunks); n > 0 && b.Hunks[n-1].Commit == l.commit && b.Hunks[n-1].Filename == l.filename {
+			b.Hunks[n-1].EndLine++
+			continue
+		}
+		b.Hunks = append(b.Hunks, blameHunk{StartLine: i + 1, EndLine: i + 2, Commit: l.commit, Filename: l.filename})
+		b.Commits[l.commit] = commits[l.commit]
+	}
+	return b
+}
+
+// blamer computes the blame of files in a repository. Blames are cached per
+// commit and path, and the blame of a file at a commit is derived from the
+// cached blame of the file at an ancestor by blaming only the commits in
+// between.
+type blamer struct {
+	s    *Server
+	repo api.RepoName
+	dir  common.GitDir
+	// env is additional environment of the git commands we run.
+	env []string
+}
+
+// blame sends the hunks of the blame of path at commit in repo between
+// startLine and endLine (1-indexed, 0 for the beginning and end of the file)
+// with send.
+func (s *Server) blame(ctx context.Context, logger log.Logger, repo api.RepoName, commit, path string, startLine, endLine int, send func(*proto.BlameHunk) error) error {
+	b := &blamer{s: s, repo: repo, dir: s.dir(repo)}
+
+	// Partial clones lazily fetch the blobs git blame reads.
+	if isPartialClone(b.dir) {
+		b.env = promisorRemoteEnv(s.partialCloneRemoteURL(ctx, repo))
+	}
+
+	content, err := b.readFile(ctx, commit, path)
+	if err != nil {
+		return err
+	}
+	offsets := lineOffsets(content)
+	numLines := len(offsets) - 1
+
+	if startLine == 0 {
+		startLine = 1
+	}
+	if endLine == 0 || endLine > numLines {
+		endLine = numLines
+	}
+	if startLine > endLine {
+		startLine, endLine = endLine, startLine
+	}
+	if numLines == 0 {
+		return nil
+	}
+	if startLine > numLines {
+		return errors.Newf("file %s has only %d lines", path, numLines)
+	}
+
+	fb, err := b.fileBlame(ctx, logger, commit, path, numLines)
+	if err != nil {
+		return err
+	}
+
+	// Like git blame -L, byte offsets are relative to the first line of the
+	// range.
+	base := offsets[startLine-1]
+	for _, h := range fb.Hunks {
+		start, end := h.StartLine, h.EndLine
+		if end <= startLine || start > endLine {
+			continue
+		}
+		if start < startLine {
+			start = startLine
+		}
+		if end > endLine+1 {
+			end = endLine + 1
+		}
+		c := fb.Commits[h.Commit]
+		err := send(&proto.BlameHunk{
+			StartLine: uint32(start),
+			EndLine:   uint32(end),
+			StartByte: uint32(offsets[start-1] - base),
+			EndByte:   uint32(offsets[end-1] - base),
+			Commit:    h.Commit,
+			Author: &proto.CommitMatch_Signature{
+				Name:  c.AuthorName,
+				Email: c.AuthorEmail,
+				Date:  timestamppb.New(c.AuthorDate),
+			},
+			Message:  c.Summary,
+			Filename: h.Filename,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lineOffsets returns the byte offsets of the starts of the lines of content,
+// followed by the offset of its end. Every line counts with a trailing
+// newline, whether or not the last line has one, as in the output of git
+// blame.
+func lineOffsets(content []byte) []int {
+	offsets := []int{0}
+	for len(content) > 0 {
+		line, rest, _ := bytes.Cut(content, []byte("\n"))
+		offsets = append(offsets, offsets[len(offsets)-1]+len(line)+1)
+		content = rest
+	}
+	return offsets
+}
+
+// fileBlame returns the blame of the file at path at commit, which has
+// numLines lines, computing and caching it if it isn't cached.
+func (b *blamer) fileBlame(ctx context.Context, logger log.Logger, commit, path string, numLines int) (*fileBlame, error) {
+	fb, err := b.cached(commit, path)
+	if err != nil {
+		logger.Warn("failed to read cached blame", log.String("commit", commit), log.String("path", path), log.Error(err))
+	} else if fb != nil {
+		blameCacheResults.WithLabelValues("hit").Inc()
+		return fb, nil
+	}
+
+	ancestor, ancestorBlame, err := b.cachedAncestor(ctx, commit, path)
+	if err != nil {
+		return nil, err
+	}
+	if ancestorBlame != nil {
+		fb, err = b.derive(ctx, ancestor, ancestorBlame, commit, path, numLines)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			logger.Warn("failed to derive blame from ancestor", log.String("commit", commit), log.String("ancestor", ancestor), log.String("path", path), log.Error(err))
+		} else {
+			blameCacheResults.WithLabelValues("derived").Inc()
+		}
+	}
+	if fb == nil {
+		lines := make([]blameLine, numLines)
+		commits := map[string]blameCommit{}
+		if err := b.runBlame(ctx, commit, path, lines, commits, nil); err != nil {
+			return nil, err
+		}
+		fb = newFileBlame(lines, commits)
+		blameCacheResults.WithLabelValues("full").Inc()
+	}
+
+	if err := b.store(commit, path, fb); err != nil {
+		logger.Warn("failed to cache blame", log.String("commit", commit), log.String("path", path), log.Error(err))
+	}
+	return fb, nil
+}
+
+// cachePath returns the path of the cached blame of path at commit.
+func (b *blamer) cachePath(commit, path string) string {
+	sum := sha256.Sum256([]byte(path))
+	return filepath.Join(b.s.ReposDir, blameCacheDirName, filepath.FromSlash(string(b.repo)), commit[:2], commit, hex.EncodeToString(sum[:]))
+}
+
+// cached returns the cached blame of path at commit, or nil if it isn't
+// cached.
+func (b *blamer) cached(commit, path string) (*fileBlame, error) {
+	p := b.cachePath(commit, path)
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	var fb fileBlame
+	if err := json.Unmarshal(data, &fb); err != nil {
+		return nil, err
+	}
+	// The janitor evicts the least recently used blames first.
+	now := time.Now()
+	_ = os.Chtimes(p, now, now)
+	return &fb, nil
+}
+
+// store caches the blame of path at commit.
+func (b *blamer) store(commit, path string, fb *fileBlame) error {
+	data, err := json.Marshal(fb)
+	if err != nil {
+		return err
+	}
+	tmp, err := b.s.tempDir("blame")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+	f := filepath.Join(tmp, "blame")
+	if err := os.WriteFile(f, data, 0o600); err != nil {
+		return err
+	}
+	dst := b.cachePath(commit, path)
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+	return os.Rename(f, dst)
+}
+
+// cachedAncestor returns the closest first-parent ancestor of commit, at most
+// blameCacheMaxDistance commits away, for which the blame of path is cached.
+// It returns an empty commit if there is none.
+func (b *blamer) cachedAncestor(ctx context.Context, commit, path string) (string, *fileBlame, error) {
+	if blameCacheMaxDistance <= 0 {
+		return "", nil, nil
+	}
+	out, err := b.git(ctx, "rev-list", "--first-parent", fmt.Sprintf("--max-count=%d", blameCacheMaxDistance+1), commit)
+	if err != nil {
+		return "", nil, err
+	}
+	ancestors := strings.Fields(string(out))
+	if len(ancestors) > 0 {
+		ancestors = ancestors[1:]
+	}
+	for _, ancestor := range ancestors {
+		if _, err := os.Stat(b.cachePath(ancestor, path)); err != nil {
+			continue
+		}
+		fb, err := b.cached(ancestor, path)
+		if err != nil || fb == nil {
+			continue
+		}
+		return ancestor, fb, nil
+	}
+	return "", nil, nil
+}
+
+// derive returns the blame of path at commit, which has numLines lines, from
+// the blame ancestorBlame of path at ancestor. We only blame the commits
+// between ancestor and commit: git blame attributes the lines it doesn't
+// find a change of in them to ancestor, and those keep their blame at
+// ancestor. This gives the same result as blaming the whole history, also
+// for lines which were removed and added back in between.
+func (b *blamer) derive(ctx context.Context, ancestor string, ancestorBlame *fileBlame, commit, path string, numLines int) (*fileBlame, error) {
+	commits := make(map[string]blameCommit, len(ancestorBlame.Commits))
+	for id, c := range ancestorBlame.Commits {
+		commits[id] = c
+	}
+	lines := make([]blameLine, numLines)
+	origins := map[int]int{}
+	if err := b.runBlame(ctx, ancestor+".."+commit, path, lines, commits, origins); err != nil {
+		return nil, err
+	}
+
+	old := ancestorBlame.lines()
+	for line, origin := range origins {
+		l := lines[line-1]
+		// Lines can reach a boundary other than ancestor through merges of
+		// branches based on older commits, and ancestor can have the file
+		// at another path if it was renamed.
+		if l.commit != ancestor || l.filename != path {
+			return nil, errors.Newf("line %d reaches %s:%s instead of the ancestor", line, l.commit, l.filename)
+		}
+		if origin < 1 || origin > len(old) {
+			return nil, errors.New("git blame does not match blame of ancestor")
+		}
+		lines[line-1] = old[origin-1]
+	}
+	return newFileBlame(lines, commits), nil
+}
+
+// runBlame blames path at rev, which is a commit or a range of commits. It
+// sets the blame of each line in lines and adds the commits they refer to to
+// commits. If origins isn't nil, the original line numbers of the lines
+// blamed on a boundary commit of the range are added to it.
+func (b *blamer) runBlame(ctx context.Context, rev, path string, lines []blameLine, commits map[string]blameCommit, origins map[int]int) error {
+	out, err := b.git(ctx, "blame", "-w", "--porcelain", rev, "--", path)
+	if err != nil {
+		return err
+	}
+
+	blamed, err := parseBlamePorcelain(out, lines, commits, origins)
+	if err != nil {
+		return err
+	}
+	if blamed != len(lines) {
+		return errors.Newf("git blame returned %d lines, expected %d", blamed, len(lines))
+	}
+	return nil
+}
+
+// parseBlamePorcelain parses the output of git blame --porcelain. It sets the
+// blame of each line in lines and adds the commits to commits. If origins
+// isn't nil, the original line numbers of the lines blamed on a boundary
+// commit are added to it. It returns the number of lines it parsed.
+func parseBlamePorcelain(out []byte, lines []blameLine, commits map[string]blameCommit, origins map[int]int) (int, error) {
+	var (
+		blamed     int
+		commit     string
+		line       int
+		origin     int
+		filenames  = map[string]string{}
+		boundaries = map[string]bool{}
+	)
+	for len(out) > 0 {
+		var l []byte
+		if i := bytes.IndexByte(out, '\n'); i >= 0 {
+			l, out = out[:i], out[i+1:]
+		} else {
+			l, out = out, nil
+		}
+
+		// The contents of a line end the entry of the line.
+		if len(l) > 0 && l[0] == '\t' {
+			if commit == "" || line < 1 || line > len(lines) {
+				return 0, errors.Newf("unexpected line %d in git blame output", line)
+			}
+			lines[line-1] = blameLine{commit: commit, filename: filenames[commit]}
+			if origins != nil && boundaries[commit] {
+				origins[line] = origin
+			}
+			blamed++
+			commit = ""
+			continue
+		}
+
+		key, value, _ := strings.Cut(string(l), " ")
+		if commit == "" {
+			// <commit> <original line> <final line> [<lines in group>]
+			fields := strings.Fields(value)
+			if len(key) != 40 || len(fields) < 2 {
+				return 0, errors.Newf("invalid git blame header %q", l)
+			}
+			o, err := strconv.Atoi(fields[0])
+			if err != nil {
+				return 0, errors.Newf("invalid git blame header %q", l)
+			}
+			n, err := strconv.Atoi(fields[1])
+			if err != nil {
+				return 0, errors.Newf("invalid git blame header %q", l)
+			}
+			commit, line, origin = key, n, o
+			continue
+		}
+
+		c := commits[commit]
+		switch key {
+		case "author":
+			c.AuthorName = value
+		case "author-mail":
+			c.AuthorEmail = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "author-time":
+			t, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return 0, errors.Newf("invalid author-time %q", value)
+			}
+			c.AuthorDate = time.Unix(t, 0).UTC()
+		case "summary":
+			c.Summary = value
+		case "filename":
+			filenames[commit] = value
+		case "boundary":
+			boundaries[commit] = true
+		}
+		commits[commit] = c
+	}
+	return blamed, nil
+}
+
+// readFile returns the contents of the file at path at commit.
+func (b *blamer) readFile(ctx context.Context, commit, path string) ([]byte, error) {
+	out, err := b.git(ctx, "cat-file", "blob", commit+":"+path)
+	if err == nil {
+		return out, nil
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	if _, err := b.git(ctx, "cat-file", "-e", commit+"^{commit}"); err != nil {
+		return nil, errRevisionNotFound
+	}
+	return nil, errors.Newf("no such file %q at commit %s", path, commit)
+}
+
+// git runs git with args in the repository and returns its output.
+func (b *blamer) git(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	b.dir.Set(cmd)
+	cmd.Env = append(cmd.Env, b.env...)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, wrapCmdError(cmd, err)
+	}
+	return out, nil
+}
+
+// cleanupBlameCache deletes the least recently used blames from the cache
+// until it is no larger than blameCacheSizeMB.
+func (s *Server) cleanupBlameCache() error {
+	return s.trimCache(filepath.Join(s.ReposDir, blameCacheDirName), int64(blameCacheSizeMB)*1024*1024)
+}
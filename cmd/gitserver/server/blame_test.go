@@ -0,0 +1,161 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+)
+
+func TestParseBlamePorcelain(t *testing.T) {
+	c1, c2 := strings.Repeat("1", 40), strings.Repeat("2", 40)
+	out := c2 + ` 1 1 1
+author a
+author-mail <a@a.com>
+author-time 1600000000
+author-tz +0000
+summary second
+filename f
+	L1
+` + c1 + ` 1 2 2
+author a
+author-mail <a@a.com>
+author-time 1500000000
+author-tz +0000
+summary first
+boundary
+filename g
+	l1
+` + c1 + ` 3 3
+	l3
+`
+	lines := make([]blameLine, 3)
+	commits := map[string]blameCommit{}
+	origins := map[int]int{}
+	blamed, err := parseBlamePorcelain([]byte(out), lines, commits, origins)
+	require.NoError(t, err)
+	require.Equal(t, 3, blamed)
+	require.Equal(t, []blameLine{{c2, "f"}, {c1, "g"}, {c1, "g"}}, lines)
+	require.Equal(t, map[int]int{2: 1, 3: 3}, origins)
+	require.Equal(t, "first", commits[c1].Summary)
+}
+
+func TestBlame(t *testing.T) {
+	ctx := context.Background()
+	s := &Server{Logger: logtest.Scoped(t), ReposDir: t.TempDir()}
+	repo := api.RepoName("example.com/foo/bar")
+	dir := filepath.Join(s.ReposDir, string(repo))
+	require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+	runCmd(t, dir, "git", "init", ".")
+
+	commit := func(files map[string]string) string {
+		for name, content := range files {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+			runCmd(t, dir, "git", "add", name)
+		}
+		runCmd(t, dir, "git", "commit", "-m", "commit")
+		return strings.TrimSpace(runCmd(t, dir, "git", "rev-parse", "HEAD"))
+	}
+	c1 := commit(map[string]string{"f": "l1\nl2\nl3\nl4\nl5\nl6\n"})
+	c2 := commit(map[string]string{"f": "l1\nL2\nl3\nl4\nnew1\nnew2\nl5\n"})
+	c3 := commit(map[string]string{"f": "  l1\nL2\nl3\nl4\nnew1\nnew2\nl5\nend\n", "g": "g\n"})
+
+	type hunk struct {
+		startLine, endLine, startByte, endByte int
+		commit                                 string
+	}
+	blame := func(t *testing.T, commit string, startLine, endLine int) []hunk {
+		t.Helper()
+		var hunks []hunk
+		err := s.blame(ctx, s.Logger, repo, commit, "f", startLine, endLine, func(h *proto.BlameHunk) error {
+			hunks = append(hunks, hunk{int(h.StartLine), int(h.EndLine), int(h.StartByte), int(h.EndByte), h.Commit})
+			return nil
+		})
+		require.NoError(t, err)
+		return hunks
+	}
+	derived := func() float64 {
+		return testutil.ToFloat64(blameCacheResults.WithLabelValues("derived"))
+	}
+
+	// Whitespace changes keep the blame of a line, like git blame -w.
+	want := []hunk{
+		{1, 2, 0, 5, c1},
+		{2, 3, 5, 8, c2},
+		{3, 5, 8, 14, c1},
+		{5, 7, 14, 24, c2},
+		{7, 8, 24, 27, c1},
+		{8, 9, 27, 31, c3},
+	}
+
+	require.Equal(t, []hunk{{1, 7, 0, 18, c1}}, blame(t, c1, 0, 0))
+
+	t.Run("derives blame from ancestor", func(t *testing.T) {
+		before := derived()
+		require.Equal(t, want, blame(t, c3, 0, 0))
+		require.Equal(t, before+1, derived())
+	})
+
+	t.Run("matches full blame", func(t *testing.T) {
+		require.NoError(t, os.RemoveAll(filepath.Join(s.ReposDir, blameCacheDirName)))
+		before := derived()
+		require.Equal(t, want, blame(t, c3, 0, 0))
+		require.Equal(t, before, derived())
+	})
+
+	t.Run("lines removed and added back", func(t *testing.T) {
+		// The diff between c3 and c5 doesn't change l3, but git blame
+		// attributes it to c5 which added it back.
+		commit(map[string]string{"f": "  l1\nL2\nl4\nnew1\nnew2\nl5\nend\n"})
+		c5 := commit(map[string]string{"f": "  l1\nL2\nl3\nl4\nnew1\nnew2\nl5\nend\n"})
+		before := derived()
+		got := blame(t, c5, 0, 0)
+		require.Equal(t, before+1, derived())
+
+		require.NoError(t, os.RemoveAll(filepath.Join(s.ReposDir, blameCacheDirName)))
+		require.Equal(t, blame(t, c5, 0, 0), got)
+		require.Equal(t, []hunk{
+			{1, 2, 0, 5, c1},
+			{2, 3, 5, 8, c2},
+			{3, 4, 8, 11, c5},
+			{4, 5, 11, 14, c1},
+			{5, 7, 14, 24, c2},
+			{7, 8, 24, 27, c1},
+			{8, 9, 27, 31, c3},
+		}, got)
+	})
+
+	t.Run("range", func(t *testing.T) {
+		require.Equal(t, []hunk{
+			{2, 3, 0, 3, c2},
+			{3, 5, 3, 9, c1},
+			{5, 6, 9, 14, c2},
+		}, blame(t, c3, 2, 5))
+	})
+
+	t.Run("commit metadata", func(t *testing.T) {
+		var got *proto.BlameHunk
+		err := s.blame(ctx, s.Logger, repo, c2, "f", 2, 2, func(h *proto.BlameHunk) error {
+			got = h
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, "a", got.GetAuthor().GetName())
+		require.Equal(t, "a@a.com", got.GetAuthor().GetEmail())
+		require.Equal(t, "commit", got.GetMessage())
+		require.Equal(t, "f", got.GetFilename())
+	})
+
+	t.Run("revision not found", func(t *testing.T) {
+		err := s.blame(ctx, s.Logger, repo, strings.Repeat("1", 40), "f", 0, 0, func(*proto.BlameHunk) error { return nil })
+		require.ErrorIs(t, err, errRevisionNotFound)
+	})
+}
@@ -172,6 +172,7 @@ const reposStatsName = "repos-stats.json"
 // 9. Perform sg-maintenance
 // 10. Git prune
 // 11. Set sizes of repos
+// 12. Check the health of repos and repair them
 func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.GitserverAddresses) {
 	janitorRunning.Set(1)
 	janitorStart := time.Now()
@@ -221,17 +222,28 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.Gits
 		size := dirSize(dir.Path("."))
 		stats.GitDirBytes += size
 		name := s.name(dir)
-		repoToSize[name] = size
+		addr := s.addrForRepo(name, gitServerAddrs)
+
+		// The size of a repo in the database is its size on its primary
+		// gitserver, so we don't record the size of replicas.
+		isReplica := !s.hostnameMatch(addr) && s.holdsRepo(name, gitServerAddrs)
+		if !isReplica {
+			repoToSize[name] = size
+		}
 
 		// Record the number and disk usage used of repos that should
 		// not belong on this instance and remove up to SRC_WRONG_SHARD_DELETE_LIMIT in a single Janitor run.
-		addr := s.addrForRepo(name, gitServerAddrs)
-
-		if !s.hostnameMatch(addr) {
+		// Replicas of repos belong on this instance.
+		if !s.hostnameMatch(addr) && !isReplica {
 			wrongShardRepoCount++
 			wrongShardRepoSize += size
 
 			if knownGitServerShard && wrongShardReposDeleteLimit > 0 && wrongShardReposDeleted < int64(wrongShardReposDeleteLimit) {
+				// The gitserver the repo moved to clones it from us, so we
+				// keep it until that gitserver owns it.
+				if s.ownsRepo(bCtx, name) {
+					return false, nil
+				}
 				logger.Info(
 					"removing repo cloned on the wrong shard",
 					log.String("dir", string(dir)),
@@ -436,6 +448,10 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.Gits
 		return false, pruneIfNeeded(dir, looseObjectsLimit)
 	}
 
+	maybeCheckHealth := func(dir common.GitDir) (done bool, err error) {
+		return false, s.maybeQueueHealthCheck(dir)
+	}
+
 	type cleanupFn struct {
 		Name string
 		Do   func(common.GitDir) (bool, error)
@@ -457,6 +473,11 @@ func (s *Server) cleanupRepos(ctx context.Context, gitServerAddrs gitserver.Gits
 		// happen if several git-gc operations are running at the same time.
 		// We only disable if sg is managing gc.
 		{"auto gc config", ensureAutoGC},
+		// Periodically queue the repo for a check with git fsck, which tries
+		// to repair the problems found. Repos which can't be repaired otherwise
+		// are marked as corrupt, which makes "maybe re-clone" reclone them on a
+		// later run.
+		{"maybe check health", maybeCheckHealth},
 	}
 
 	if gitGCMode == gitGCModeJanitorAutoGC {
@@ -802,6 +823,11 @@ func (s *Server) removeRepoDirectory(gitDir common.GitDir, logger log.Logger, up
 		s.setCloneStatusNonFatal(ctx, s.name(gitDir), types.CloneStatusNotCloned)
 	}
 
+	// The LFS objects cached for the repo are only read through it.
+	if err := s.removeLFSCache(s.name(gitDir)); err != nil {
+		logger.Warn("failed to remove LFS cache", log.String("dir", dir), log.Error(err))
+	}
+
 	// Cleanup empty parent directories. We just attempt to remove and if we
 	// have a failure we assume it's due to the directory having other
 	// children. If we checked first we could race with someone else adding a
@@ -1052,6 +1078,7 @@ func gitIsNonBareBestEffort(dir common.GitDir) bool {
 func gitGC(dir common.GitDir) error {
 	cmd := exec.Command("git", "-c", "gc.auto=1", "-c", "gc.autoDetach=false", "gc", "--auto")
 	dir.Set(cmd)
+	cmd.Env = partialCloneMaintenanceEnv(dir)
 	err := cmd.Run()
 	if err != nil {
 		return errors.Wrapf(wrapCmdError(cmd, err), "failed to git-gc")
@@ -1135,6 +1162,7 @@ func sgMaintenance(logger log.Logger, dir common.GitDir) (err error) {
 
 	cmd := exec.Command("sh")
 	dir.Set(cmd)
+	cmd.Env = partialCloneMaintenanceEnv(dir)
 
 	cmd.Stdin = strings.NewReader(sgMaintenanceScript)
 
@@ -1218,6 +1246,7 @@ func pruneIfNeeded(dir common.GitDir, limit int) (err error) {
 	// continuously trigger repacks until the loose objects expire.
 	cmd := exec.Command("git", "prune", "--expire", "now")
 	dir.Set(cmd)
+	cmd.Env = partialCloneMaintenanceEnv(dir)
 	err = cmd.Run()
 	if err != nil {
 		return errors.Wrapf(wrapCmdError(cmd, err), "failed to git-prune")
@@ -1227,12 +1256,13 @@ func pruneIfNeeded(dir common.GitDir, limit int) (err error) {
 
 func needsMaintenance(dir common.GitDir) (bool, string, error) {
 	// Bitmaps store reachability information about the set of objects in a
-	// packfile which speeds up clone and fetch operations.
+	// packfile which speeds up clone and fetch operations. Git doesn't write
+	// bitmaps for the promisor packs of partial clones.
 	hasBm, err := hasBitmap(dir)
 	if err != nil {
 		return false, "", err
 	}
-	if !hasBm {
+	if !hasBm && !isPartialClone(dir) {
 		return true, "bitmap", nil
 	}
 
@@ -230,6 +230,42 @@ func TestCleanupWrongShard(t *testing.T) {
 			t.Error("expected repoD assigned to different shard to be removed")
 		}
 	})
+	t.Run("replicaShard", func(t *testing.T) {
+		root := t.TempDir()
+		// should be allocated to shard gitserver-1, with a replica on gitserver-0
+		testRepoD := "testrepo-D"
+
+		repoA := path.Join(root, testRepoA, ".git")
+		cmd := exec.Command("git", "--bare", "init", repoA)
+		if err := cmd.Run(); err != nil {
+			t.Fatal(err)
+		}
+		repoD := path.Join(root, testRepoD, ".git")
+		cmdD := exec.Command("git", "--bare", "init", repoD)
+		if err := cmdD.Run(); err != nil {
+			t.Fatal(err)
+		}
+
+		s := &Server{
+			ReposDir:       root,
+			Logger:         logger,
+			ObservationCtx: observation.TestContextTB(t),
+			DB:             database.NewMockDB(),
+		}
+		s.testSetup(t)
+		s.Hostname = "gitserver-0"
+		s.cleanupRepos(context.Background(), gitserver.GitserverAddresses{
+			Addresses:         []string{"gitserver-0.cluster.local:3178", "gitserver-1.cluster.local:3178"},
+			ReplicationFactor: 2,
+		})
+
+		if _, err := os.Stat(repoA); err != nil {
+			t.Error("expected repoA not to be removed")
+		}
+		if _, err := os.Stat(repoD); err != nil {
+			t.Error("expected replica of repoD not to be removed")
+		}
+	})
 	t.Run("cleanupDisabled", func(t *testing.T) {
 		root := t.TempDir()
 		// should be allocated to shard gitserver-1
@@ -1514,6 +1550,36 @@ git commit-graph write --reachable --changed-paths
 	}
 }
 
+func TestNeedsMaintenance_PartialClone(t *testing.T) {
+	dir := t.TempDir()
+	gitDir := prepareEmptyGitRepo(t, dir)
+
+	// Git doesn't write bitmaps for the promisor packs of partial clones.
+	script := `echo acont > afile
+git add afile
+git commit -am amsg
+git repack -d -l -A
+touch "$(ls .git/objects/pack/*.pack | sed 's/\.pack$/.promisor/')"
+git commit-graph write --reachable --changed-paths
+`
+	cmd := exec.Command("/bin/sh", "-euxc", script)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("out=%s, err=%s", out, err)
+	}
+
+	needed, reason, err := needsMaintenance(gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason != "skipped" {
+		t.Fatalf("want %s, got %s", "skipped", reason)
+	}
+	if needed {
+		t.Fatal("partial clones without a bitmap don't need maintenance")
+	}
+}
+
 func TestPruneIfNeeded(t *testing.T) {
 	gitDir := prepareEmptyGitRepo(t, t.TempDir())
 
@@ -0,0 +1,94 @@
+package server
+
+import (
+	"context"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/search"
+)
+
+var enableCommitIndex = env.MustGetBool("SRC_COMMIT_INDEX_ENABLED", true, "maintain an index of the commits of each repository to skip commits that can't match commit and diff searches")
+
+var commitIndexBuildConcurrency = env.MustGetInt("SRC_COMMIT_INDEX_BUILD_CONCURRENCY", 1, "maximum number of commit indexes of newly cloned repositories to build at the same time")
+
+// commitIndexBuildQueueSize is the number of repositories whose commit index
+// can wait to be built. The others are queued on a later update.
+const commitIndexBuildQueueSize = 100
+
+// StartCommitIndexer starts the workers which build the commit indexes of
+// repositories which don't have one yet. Building an index reads every commit
+// of a repository, so we don't build it during the update of the repository,
+// which holds its update lock.
+func (s *Server) StartCommitIndexer(ctx context.Context) {
+	if !enableCommitIndex {
+		return
+	}
+
+	s.commitIndexBuildsMu.Lock()
+	s.commitIndexBuilds = make(chan api.RepoName, commitIndexBuildQueueSize)
+	s.commitIndexBuildsPending = make(map[api.RepoName]struct{})
+	s.commitIndexBuildsMu.Unlock()
+
+	logger := s.Logger.Scoped("commitIndexer", "builds the commit indexes of repositories")
+	for i := 0; i < commitIndexBuildConcurrency; i++ {
+		go func() {
+			for {
+				select {
+				case repo := <-s.commitIndexBuilds:
+					// The janitor may have removed the repository since
+					// queueing the build.
+					if repoCloned(s.dir(repo)) {
+						if err := search.UpdateCommitIndex(ctx, s.dir(repo).Path()); err != nil {
+							logger.Warn("failed to build commit index", log.String("repo", string(repo)), log.Error(err))
+						}
+					}
+					s.commitIndexBuildsMu.Lock()
+					delete(s.commitIndexBuildsPending, repo)
+					s.commitIndexBuildsMu.Unlock()
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+}
+
+// updateCommitIndex indexes the commits of repo that became reachable since
+// the last update of its commit index. If repo has no commit index yet, it is
+// queued to be built by the workers started by StartCommitIndexer instead.
+// Only the primary gitserver of repo maintains its commit index. Searches
+// which fail over to a replica search all commits.
+func (s *Server) updateCommitIndex(ctx context.Context, repo api.RepoName) error {
+	if !enableCommitIndex || s.isReplica(repo) {
+		return nil
+	}
+
+	dir := s.dir(repo).Path()
+	s.commitIndexBuildsMu.Lock()
+	if s.commitIndexBuilds == nil {
+		s.commitIndexBuildsMu.Unlock()
+		return nil
+	}
+	if _, ok := s.commitIndexBuildsPending[repo]; ok {
+		// The index is being built from the refs at the time the build
+		// started. Later updates index the commits added since.
+		s.commitIndexBuildsMu.Unlock()
+		return nil
+	}
+	ok, err := search.HasCommitIndex(dir)
+	if err != nil || !ok {
+		select {
+		case s.commitIndexBuilds <- repo:
+			s.commitIndexBuildsPending[repo] = struct{}{}
+		default:
+		}
+		s.commitIndexBuildsMu.Unlock()
+		return err
+	}
+	s.commitIndexBuildsMu.Unlock()
+
+	return search.UpdateCommitIndex(ctx, dir)
+}
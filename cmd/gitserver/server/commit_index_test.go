@@ -0,0 +1,52 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/search"
+)
+
+func TestUpdateCommitIndex(t *testing.T) {
+	ctx := context.Background()
+	reposDir := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+
+	repoDir := filepath.Join(reposDir, string(repoName))
+	require.NoError(t, os.MkdirAll(repoDir, os.ModePerm))
+	cmd := func(name string, arg ...string) string {
+		return runCmd(t, repoDir, name, arg...)
+	}
+	cmd("git", "init", ".")
+	cmd("git", "commit", "--allow-empty", "-m", "init")
+	gitDir := filepath.Join(repoDir, ".git")
+
+	s := &Server{ReposDir: reposDir}
+	s.commitIndexBuilds = make(chan api.RepoName, 1)
+	s.commitIndexBuildsPending = make(map[api.RepoName]struct{})
+
+	// The first build is queued rather than run by the update.
+	require.NoError(t, s.updateCommitIndex(ctx, repoName))
+	require.NoError(t, s.updateCommitIndex(ctx, repoName))
+	require.Len(t, s.commitIndexBuilds, 1)
+	ok, err := search.HasCommitIndex(gitDir)
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	require.Equal(t, repoName, <-s.commitIndexBuilds)
+	require.NoError(t, search.UpdateCommitIndex(ctx, gitDir))
+	delete(s.commitIndexBuildsPending, repoName)
+
+	// Later updates extend the index themselves.
+	cmd("git", "commit", "--allow-empty", "-m", "second")
+	require.NoError(t, s.updateCommitIndex(ctx, repoName))
+	require.Len(t, s.commitIndexBuilds, 0)
+	segments, err := filepath.Glob(filepath.Join(gitDir, "sg_commit_index", "*.gob"))
+	require.NoError(t, err)
+	require.Len(t, segments, 2)
+}
@@ -0,0 +1,498 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// How often the janitor checks the health of each repository. git fsck reads
+// every object of a repository, so we don't want to check too often.
+var repoHealthCheckInterval = env.MustGetDuration("SRC_REPO_HEALTH_CHECK_INTERVAL", 7*24*time.Hour, "how often to check the health of each repository with git fsck, or 0 to disable health checks")
+
+var repoHealthCheckConcurrency = env.MustGetInt("SRC_REPO_HEALTH_CHECK_CONCURRENCY", 1, "maximum number of repository health checks and repairs to run at the same time")
+
+const (
+	// gitConfigHealthCheckTime is the key in git config we record the time of
+	// the last health check of a repository under.
+	gitConfigHealthCheckTime = "sourcegraph.healthCheckTimestamp"
+
+	// maxHealthReportItems is the maximum number of missing objects, dangling
+	// refs and errors we keep in a health report.
+	maxHealthReportItems = 100
+
+	// healthCheckQueueSize is the number of repositories which can wait for a
+	// health check. The janitor queues the others on a later run.
+	healthCheckQueueSize = 100
+)
+
+// errRepoUpdateInProgress is returned by refetchRepo if repo is being updated.
+var errRepoUpdateInProgress = errors.New("repository update in progress")
+
+var (
+	repoHealthChecks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_repo_health_checks",
+		Help: "number of repository health checks by result (healthy, degraded or corrupt)",
+	}, []string{"result"})
+	repoRepairs = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_gitserver_repo_repairs",
+		Help: "number of repairs of repositories which failed their health check, by action and success",
+	}, []string{"action", "success"})
+)
+
+// StartHealthChecker starts the workers which check the health of the
+// repositories the janitor finds due for a check. Checks read every object of
+// a repository, so we run at most repoHealthCheckConcurrency of them at once
+// instead of blocking the janitor with them.
+func (s *Server) StartHealthChecker(ctx context.Context) {
+	if repoHealthCheckInterval <= 0 {
+		return
+	}
+
+	s.healthChecksMu.Lock()
+	s.healthChecks = make(chan common.GitDir, healthCheckQueueSize)
+	s.healthChecksPending = make(map[common.GitDir]struct{})
+	s.healthChecksMu.Unlock()
+
+	logger := s.Logger.Scoped("healthChecker", "checks the health of repositories")
+	for i := 0; i < repoHealthCheckConcurrency; i++ {
+		go func() {
+			for {
+				select {
+				case dir := <-s.healthChecks:
+					if err := s.checkAndRepairRepo(ctx, logger, dir); err != nil {
+						logger.Error("checking repository health", log.String("repo", string(s.name(dir))), log.Error(err))
+					}
+					s.healthChecksMu.Lock()
+					delete(s.healthChecksPending, dir)
+					s.healthChecksMu.Unlock()
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+}
+
+// maybeQueueHealthCheck queues a health check of the repository in dir if it
+// is due. If the queue is full, the repository is queued on a later janitor
+// run.
+func (s *Server) maybeQueueHealthCheck(dir common.GitDir) error {
+	if repoHealthCheckInterval <= 0 {
+		return nil
+	}
+
+	// The report is stored per repository, so we leave checking it to the
+	// gitserver owning it.
+	if s.isReplica(s.name(dir)) {
+		return nil
+	}
+
+	lastCheck, err := getHealthCheckTime(dir)
+	if err != nil {
+		return err
+	}
+	// Add a jitter to spread out the checks of repos cloned at the same time.
+	if time.Since(lastCheck) < repoHealthCheckInterval+jitterDuration(string(dir), repoHealthCheckInterval/4) {
+		return nil
+	}
+
+	s.healthChecksMu.Lock()
+	defer s.healthChecksMu.Unlock()
+	if s.healthChecks == nil {
+		return nil
+	}
+	if _, ok := s.healthChecksPending[dir]; ok {
+		return nil
+	}
+	select {
+	case s.healthChecks <- dir:
+		s.healthChecksPending[dir] = struct{}{}
+	default:
+	}
+	return nil
+}
+
+// checkAndRepairRepo checks the health of the repository in dir, tries to
+// repair the problems it finds and stores the report in the database.
+func (s *Server) checkAndRepairRepo(ctx context.Context, logger log.Logger, dir common.GitDir) error {
+	// The janitor may have removed the repository since queueing the check.
+	if _, err := os.Stat(dir.Path("HEAD")); errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	// Record the check before running it, so that we don't check a repo on
+	// every janitor run if checking it fails.
+	if err := setHealthCheckTime(dir, time.Now()); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, conf.GitLongCommandTimeout())
+	defer cancel()
+
+	repo := s.name(dir)
+	report, err := checkRepoHealth(ctx, dir)
+	if err != nil {
+		return err
+	}
+
+	result := "healthy"
+	if report.Corrupt() {
+		result = "corrupt"
+	} else if !report.Healthy() {
+		result = "degraded"
+	}
+	repoHealthChecks.WithLabelValues(result).Inc()
+
+	if !report.Healthy() {
+		logger.Warn("repository health check found problems",
+			log.String("repo", string(repo)),
+			log.Int("missingObjects", len(report.MissingObjects)),
+			log.Strings("danglingRefs", report.DanglingRefs),
+			log.Int("fsckErrors", len(report.FsckErrors)),
+			log.Bool("packBloat", report.PackBloat),
+			log.Bool("commitGraphStale", report.CommitGraphStale))
+		s.repairRepo(ctx, logger, repo, dir, report)
+	}
+
+	return s.DB.GitserverRepos().SetHealthReport(ctx, repo, report, s.Hostname)
+}
+
+// checkRepoHealth checks the repository in dir for missing objects and refs
+// pointing to them with git fsck, and for pack bloat and a stale commit-graph.
+func checkRepoHealth(ctx context.Context, dir common.GitDir) (*types.RepoHealthReport, error) {
+	report := &types.RepoHealthReport{CheckedAt: time.Now()}
+
+	// We only check connectivity, which finds missing objects without
+	// inflating every blob.
+	cmd := exec.CommandContext(ctx, "git", "fsck", "--connectivity-only", "--no-dangling", "--no-progress")
+	dir.Set(cmd)
+	out, err := cmd.CombinedOutput()
+	// git fsck exits with a non-zero code if it finds problems, which we
+	// parse from its output.
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "git fsck")
+	}
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	parseFsckOutput(out, report)
+
+	packs, looseObjects, err := countObjects(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	report.PackCount = packs
+	report.LooseObjectCount = looseObjects
+	report.PackBloat = packs > autoPackLimit || looseObjects > looseObjectsLimit
+
+	report.CommitGraphStale, err = commitGraphStale(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// parseFsckOutput adds the problems git fsck reported in out to report.
+func parseFsckOutput(out []byte, report *types.RepoHealthReport) {
+	add := func(items []string, item string) []string {
+		if len(items) >= maxHealthReportItems {
+			return items
+		}
+		return append(items, item)
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case strings.HasPrefix(line, "missing "):
+			// missing <type> <oid>
+			if fields := strings.Fields(line); len(fields) == 3 {
+				report.MissingObjects = add(report.MissingObjects, fields[2])
+			}
+		case strings.HasPrefix(line, "error: ") && strings.Contains(line, ": invalid sha1 pointer "):
+			// error: <ref>: invalid sha1 pointer <oid>
+			ref, _, _ := strings.Cut(strings.TrimPrefix(line, "error: "), ":")
+			report.DanglingRefs = add(report.DanglingRefs, ref)
+		case strings.HasPrefix(line, "error: "), strings.HasPrefix(line, "fatal: "):
+			report.FsckErrors = add(report.FsckErrors, line)
+		}
+		// We ignore the broken links, which are reported as missing objects
+		// too, as well as notices and warnings.
+	}
+}
+
+// countObjects returns the number of pack files and loose objects in the
+// repository in dir.
+func countObjects(ctx context.Context, dir common.GitDir) (packs, looseObjects int, err error) {
+	cmd := exec.CommandContext(ctx, "git", "count-objects", "-v")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return 0, 0, errors.Wrap(wrapCmdError(cmd, err), "git count-objects")
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, ok := strings.Cut(scanner.Text(), ": ")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "count":
+			looseObjects, _ = strconv.Atoi(value)
+		case "packs":
+			packs, _ = strconv.Atoi(value)
+		}
+	}
+	return packs, looseObjects, scanner.Err()
+}
+
+// commitGraphStale returns true if the repository in dir has pack files newer
+// than its commit-graph, or no commit-graph at all.
+func commitGraphStale(dir common.GitDir) (bool, error) {
+	packs, err := filepath.Glob(dir.Path("objects", "pack", "*.pack"))
+	if err != nil {
+		return false, err
+	}
+	var newestPack time.Time
+	for _, p := range packs {
+		fi, err := os.Stat(p)
+		if err != nil {
+			return false, err
+		}
+		if fi.ModTime().After(newestPack) {
+			newestPack = fi.ModTime()
+		}
+	}
+	if newestPack.IsZero() {
+		return false, nil
+	}
+
+	for _, graph := range []string{
+		dir.Path("objects", "info", "commit-graph"),
+		dir.Path("objects", "info", "commit-graphs", "commit-graph-chain"),
+	} {
+		fi, err := os.Stat(graph)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return false, err
+		}
+		return fi.ModTime().Before(newestPack), nil
+	}
+	return true, nil
+}
+
+// repairRepo tries to fix the problems report found in the repository in dir,
+// recording the repairs it attempts in report. Corrupt repositories are
+// repacked first, refetched if that doesn't fix them, and recloned as a last
+// resort.
+func (s *Server) repairRepo(ctx context.Context, logger log.Logger, repo api.RepoName, dir common.GitDir, report *types.RepoHealthReport) {
+	attempt := func(action string, repair func() error) {
+		r := types.RepoRepair{Action: action}
+		if err := repair(); err != nil {
+			r.Error = err.Error()
+			logger.Warn("repository repair failed", log.String("repo", string(repo)), log.String("action", action), log.Error(err))
+		}
+		report.Repairs = append(report.Repairs, r)
+		repoRepairs.WithLabelValues(action, strconv.FormatBool(r.Error == "")).Inc()
+	}
+
+	corrupt := report.Corrupt()
+	checkFixed := func(action string) error {
+		again, err := checkRepoHealth(ctx, dir)
+		if err != nil {
+			return err
+		}
+		if again.Corrupt() {
+			return errors.Newf("repository is still corrupt after %s", action)
+		}
+		corrupt = false
+		return nil
+	}
+
+	if report.PackBloat || corrupt {
+		// Repacking drops corrupt copies of objects we have intact copies of.
+		attempt("repack", func() error {
+			if err := repackRepo(ctx, dir); err != nil {
+				return err
+			}
+			if corrupt {
+				return checkFixed("repack")
+			}
+			return nil
+		})
+	}
+	if corrupt {
+		err := s.refetchRepo(ctx, repo, dir, report.DanglingRefs)
+		if errors.Is(err, errRepoUpdateInProgress) {
+			// We don't reclone a repo we may be able to refetch, so we check
+			// it again on the next janitor run instead.
+			logger.Info("skipping repair of repository being updated", log.String("repo", string(repo)))
+			if err := setHealthCheckTime(dir, time.Unix(0, 0)); err != nil {
+				logger.Warn("failed to reset health check timestamp", log.String("repo", string(repo)), log.Error(err))
+			}
+			return
+		}
+		attempt("refetch", func() error {
+			if err != nil {
+				return err
+			}
+			return checkFixed("refetch")
+		})
+	}
+	if corrupt {
+		attempt("reclone", func() error {
+			return s.scheduleReclone(ctx, logger, repo, dir, report)
+		})
+		return
+	}
+	if report.CommitGraphStale {
+		attempt("write commit-graph", func() error {
+			return writeCommitGraph(ctx, dir)
+		})
+	}
+}
+
+// repackRepo packs all objects of the repository in dir into a single pack.
+func repackRepo(ctx context.Context, dir common.GitDir) error {
+	err, unlock := lockRepoForGC(dir)
+	if err != nil {
+		return errors.Wrap(err, "locking repository for repack")
+	}
+	defer unlock()
+
+	cmd := exec.CommandContext(ctx, "git", "repack", "-a", "-d")
+	dir.Set(cmd)
+	cmd.Env = partialCloneMaintenanceEnv(dir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrap(&common.GitCommandError{Err: wrapCmdError(cmd, err), Output: string(out)}, "git repack")
+	}
+	return nil
+}
+
+// writeCommitGraph writes a commit-graph of all commits reachable from the
+// refs of the repository in dir.
+func writeCommitGraph(ctx context.Context, dir common.GitDir) error {
+	err, unlock := lockRepoForGC(dir)
+	if err != nil {
+		return errors.Wrap(err, "locking repository for writing commit-graph")
+	}
+	defer unlock()
+
+	cmd := exec.CommandContext(ctx, "git", "commit-graph", "write", "--reachable", "--changed-paths")
+	dir.Set(cmd)
+	cmd.Env = partialCloneMaintenanceEnv(dir)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrap(&common.GitCommandError{Err: wrapCmdError(cmd, err), Output: string(out)}, "git commit-graph write")
+	}
+	return nil
+}
+
+// refetchRepo fetches all objects of repo from its remote again. The
+// danglingRefs, which point to objects that don't exist, are deleted first
+// since they break the fetch. The fetch recreates those the remote still has.
+// If repo is being updated, errRepoUpdateInProgress is returned since the
+// update and the refetch would race.
+func (s *Server) refetchRepo(ctx context.Context, repo api.RepoName, dir common.GitDir, danglingRefs []string) error {
+	unlock, ok := s.tryLockRepoUpdate(repo)
+	if !ok {
+		return errRepoUpdateInProgress
+	}
+	defer unlock()
+
+	ctx = actor.WithInternalActor(ctx)
+
+	syncer, err := s.GetVCSSyncer(ctx, repo)
+	if err != nil {
+		return errors.Wrap(err, "get VCS syncer")
+	}
+	gitSyncer, ok := syncer.(*GitRepoSyncer)
+	if !ok {
+		return errors.Newf("refetching %s repositories is not supported", syncer.Type())
+	}
+	remoteURL, err := s.getRemoteURL(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range danglingRefs {
+		if !strings.HasPrefix(ref, "refs/") {
+			continue
+		}
+		cmd := exec.CommandContext(ctx, "git", "update-ref", "-d", "--no-deref", ref)
+		dir.Set(cmd)
+		if err := cmd.Run(); err != nil {
+			return errors.Wrapf(wrapCmdError(cmd, err), "deleting dangling ref %s", ref)
+		}
+	}
+
+	ctx, cancel, err := s.acquireCloneLimiter(ctx)
+	if err != nil {
+		return err
+	}
+	defer cancel()
+	if err := s.rpsLimiter.Wait(ctx); err != nil {
+		return err
+	}
+
+	return gitSyncer.Refetch(ctx, remoteURL, dir)
+}
+
+// scheduleReclone marks repo as corrupt, which makes the janitor reclone it.
+func (s *Server) scheduleReclone(ctx context.Context, logger log.Logger, repo api.RepoName, dir common.GitDir, report *types.RepoHealthReport) error {
+	reason := fmt.Sprintf("sourcegraph detected corrupt repo: health check found %d missing objects, %d dangling refs and %d other errors",
+		len(report.MissingObjects), len(report.DanglingRefs), len(report.FsckErrors))
+	if err := s.DB.GitserverRepos().LogCorruption(ctx, repo, reason, s.Hostname); err != nil {
+		logger.Warn("failed to log repo corruption", log.String("repo", string(repo)), log.Error(err))
+	}
+	return gitConfigSet(dir, gitConfigMaybeCorrupt, strconv.FormatInt(time.Now().Unix(), 10))
+}
+
+// setHealthCheckTime sets the time the health of a repository was last
+// checked.
+func setHealthCheckTime(dir common.GitDir, now time.Time) error {
+	return gitConfigSet(dir, gitConfigHealthCheckTime, strconv.FormatInt(now.Unix(), 10))
+}
+
+// getHealthCheckTime returns the time the health of a repository was last
+// checked. If the value is not stored in the repository, it is set to now, so
+// that the first check of a repository is an interval after we first see it
+// rather than at once for every repository.
+func getHealthCheckTime(dir common.GitDir) (time.Time, error) {
+	value, err := gitConfigGet(dir, gitConfigHealthCheckTime)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "failed to determine health check timestamp")
+	}
+	sec, err := strconv.ParseInt(value, 10, 0)
+	if value == "" || err != nil {
+		now := time.Now()
+		return now, setHealthCheckTime(dir, now)
+	}
+	return time.Unix(sec, 0), nil
+}
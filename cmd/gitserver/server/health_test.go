@@ -0,0 +1,157 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestParseFsckOutput(t *testing.T) {
+	out := `error: refs/heads/broken: invalid sha1 pointer 1111111111111111111111111111111111111111
+notice: HEAD points to an unborn branch (master)
+broken link from  commit 77dc5e995c02f024ed30cc75be4dc5fed3fb8618
+              to    tree e8960bf2b8d05ecd2435a205010631c7c5c47bf4
+missing tree e8960bf2b8d05ecd2435a205010631c7c5c47bf4
+error: unable to unpack header of ./objects/4b/825dc642cb6eb9a060e54bf8d69288fbee4904
+warning: garbage found: ./objects/pack/tmp_junk
+`
+	var report types.RepoHealthReport
+	parseFsckOutput([]byte(out), &report)
+
+	require.Equal(t, types.RepoHealthReport{
+		MissingObjects: []string{"e8960bf2b8d05ecd2435a205010631c7c5c47bf4"},
+		DanglingRefs:   []string{"refs/heads/broken"},
+		FsckErrors:     []string{"error: unable to unpack header of ./objects/4b/825dc642cb6eb9a060e54bf8d69288fbee4904"},
+	}, report)
+	require.True(t, report.Corrupt())
+}
+
+// makeHealthTestRepos creates a remote repository with a few commits and a
+// clone of it for the gitserver in reposDir, which keeps its objects loose.
+func makeHealthTestRepos(t *testing.T, reposDir string, repoName api.RepoName) (remote string, dir common.GitDir) {
+	remote = t.TempDir()
+	runCmd(t, remote, "git", "init", ".")
+	for _, f := range []string{"a", "b", "c"} {
+		require.NoError(t, os.WriteFile(filepath.Join(remote, f), []byte(f), 0o600))
+		runCmd(t, remote, "git", "add", f)
+		runCmd(t, remote, "git", "commit", "-m", f)
+	}
+
+	dir = common.GitDir(filepath.Join(reposDir, string(repoName), ".git"))
+	require.NoError(t, os.MkdirAll(string(dir), os.ModePerm))
+	runCmd(t, string(dir), "git", "init", "--bare", ".")
+	runCmd(t, string(dir), "git", "-c", "fetch.unpackLimit=1000", "fetch", remote, "+refs/heads/*:refs/heads/*")
+	return remote, dir
+}
+
+func TestCheckRepoHealth_StaleCommitGraph(t *testing.T) {
+	ctx := context.Background()
+	reposDir := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+	_, dir := makeHealthTestRepos(t, reposDir, repoName)
+	runCmd(t, string(dir), "git", "repack", "-a", "-d")
+
+	report, err := checkRepoHealth(ctx, dir)
+	require.NoError(t, err)
+	require.False(t, report.Corrupt())
+	require.Equal(t, 1, report.PackCount)
+	require.True(t, report.CommitGraphStale)
+
+	s := &Server{ReposDir: reposDir}
+	s.repairRepo(ctx, logtest.Scoped(t), repoName, dir, report)
+	require.Equal(t, []types.RepoRepair{{Action: "write commit-graph"}}, report.Repairs)
+
+	report, err = checkRepoHealth(ctx, dir)
+	require.NoError(t, err)
+	require.True(t, report.Healthy())
+}
+
+func TestRepairRepo_Refetch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reposDir := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+	remote, dir := makeHealthTestRepos(t, reposDir, repoName)
+
+	// Remove the tree of a commit and add a ref pointing nowhere.
+	tree := strings.TrimSpace(runCmd(t, string(dir), "git", "rev-parse", "HEAD~1^{tree}"))
+	require.NoError(t, os.Remove(dir.Path("objects", tree[:2], tree[2:])))
+	require.NoError(t, os.WriteFile(dir.Path("refs", "heads", "broken"), []byte(strings.Repeat("1", 40)+"\n"), 0o600))
+
+	report, err := checkRepoHealth(ctx, dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{tree}, report.MissingObjects)
+	require.Equal(t, []string{"refs/heads/broken"}, report.DanglingRefs)
+
+	s := makeTestServer(ctx, t, reposDir, remote, nil)
+	s.repairRepo(ctx, logtest.Scoped(t), repoName, dir, report)
+
+	require.Len(t, report.Repairs, 2)
+	require.Equal(t, "repack", report.Repairs[0].Action)
+	require.NotEmpty(t, report.Repairs[0].Error)
+	require.Equal(t, types.RepoRepair{Action: "refetch"}, report.Repairs[1])
+
+	report, err = checkRepoHealth(ctx, dir)
+	require.NoError(t, err)
+	require.False(t, report.Corrupt())
+}
+
+func TestRepairRepo_SkipsRepoBeingUpdated(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reposDir := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+	remote, dir := makeHealthTestRepos(t, reposDir, repoName)
+
+	require.NoError(t, os.WriteFile(dir.Path("refs", "heads", "broken"), []byte(strings.Repeat("1", 40)+"\n"), 0o600))
+	report, err := checkRepoHealth(ctx, dir)
+	require.NoError(t, err)
+	require.True(t, report.Corrupt())
+
+	s := makeTestServer(ctx, t, reposDir, remote, nil)
+	unlock, ok := s.tryLockRepoUpdate(repoName)
+	require.True(t, ok)
+	s.repairRepo(ctx, logtest.Scoped(t), repoName, dir, report)
+	unlock()
+
+	// The refetch and the reclone are skipped, and the repo is checked again
+	// on the next janitor run.
+	require.Len(t, report.Repairs, 1)
+	require.Equal(t, "repack", report.Repairs[0].Action)
+	require.FileExists(t, dir.Path("refs", "heads", "broken"))
+	lastCheck, err := getHealthCheckTime(dir)
+	require.NoError(t, err)
+	require.Equal(t, int64(0), lastCheck.Unix())
+}
+
+func TestMaybeQueueHealthCheck(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reposDir := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+	remote, dir := makeHealthTestRepos(t, reposDir, repoName)
+
+	s := makeTestServer(ctx, t, reposDir, remote, nil)
+	s.healthChecks = make(chan common.GitDir, 1)
+	s.healthChecksPending = make(map[common.GitDir]struct{})
+
+	// The first check of a repo is an interval after we first see it.
+	require.NoError(t, s.maybeQueueHealthCheck(dir))
+	require.Len(t, s.healthChecks, 0)
+
+	require.NoError(t, setHealthCheckTime(dir, time.Unix(0, 0)))
+	require.NoError(t, s.maybeQueueHealthCheck(dir))
+	require.NoError(t, s.maybeQueueHealthCheck(dir))
+	require.Len(t, s.healthChecks, 1)
+	require.Equal(t, dir, <-s.healthChecks)
+}
@@ -0,0 +1,315 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// lfsCacheDirName is the name of the directory under ReposDir in which we
+// cache the Git LFS objects fetched from code hosts.
+const lfsCacheDirName = ".lfs-cache"
+
+var (
+	lfsMaxObjectSize = env.MustGetInt("SRC_LFS_MAX_OBJECT_SIZE", 50*1024*1024, "the maximum size in bytes of the Git LFS objects gitserver fetches, at most 1 GiB")
+	lfsCacheSizeMB   = env.MustGetInt("SRC_LFS_CACHE_SIZE_MB", 10*1024, "the size in MiB the cache of Git LFS objects on gitserver is trimmed to by the janitor")
+)
+
+// errLFSObjectTooLarge is returned for LFS objects larger than
+// lfsMaxObjectSize.
+var errLFSObjectTooLarge = errors.New("LFS object is too large")
+
+// lfsHTTPClient returns the client we talk to the LFS servers of code hosts
+// with. Tests replace it.
+var lfsHTTPClient = func() (httpcli.Doer, error) {
+	return httpcli.UncachedExternalClientFactory.Doer()
+}
+
+const lfsMediaType = "application/vnd.git-lfs+json"
+
+// lfsObjectPath returns the path of the cached contents of the LFS object p
+// points to in repo, fetching the object from the code host if it isn't
+// cached yet.
+//
+// Objects are cached per repository, so that a repository can only read the
+// objects its own code host serves it.
+func (s *Server) lfsObjectPath(ctx context.Context, repo api.RepoName, p gitdomain.LFSPointer) (string, error) {
+	if !gitdomain.IsValidLFSOID(p.OID) {
+		return "", errors.Newf("invalid LFS object ID %q", p.OID)
+	}
+	if p.Size > int64(lfsMaxObjectSize) || p.Size > gitdomain.LFSObjectMaxSize {
+		return "", errLFSObjectTooLarge
+	}
+
+	path := filepath.Join(s.ReposDir, lfsCacheDirName, filepath.FromSlash(string(repo)), p.OID[:2], p.OID)
+	if fi, err := os.Stat(path); err == nil && fi.Size() == p.Size {
+		// The janitor evicts the least recently used objects first.
+		now := time.Now()
+		_ = os.Chtimes(path, now, now)
+		return path, nil
+	}
+
+	if err := s.fetchLFSObject(ctx, repo, p, path); err != nil {
+		return "", errors.Wrapf(err, "fetching LFS object %s", p.OID)
+	}
+	return path, nil
+}
+
+// fetchLFSObject downloads the LFS object p points to in repo from the LFS
+// server of the code host of repo to dst. The object is verified against p
+// before it is moved to dst.
+func (s *Server) fetchLFSObject(ctx context.Context, repo api.RepoName, p gitdomain.LFSPointer, dst string) error {
+	remoteURL, err := s.getRemoteURL(actor.WithInternalActor(ctx), repo)
+	if err != nil {
+		return err
+	}
+	if remoteURL.Scheme != "http" && remoteURL.Scheme != "https" {
+		return errors.New("LFS objects can only be fetched for repositories cloned over HTTP(S)")
+	}
+
+	cli, err := lfsHTTPClient()
+	if err != nil {
+		return err
+	}
+
+	// The LFS server of a repository is at <clone URL>.git/info/lfs. We send
+	// the credentials of the clone URL with basic auth instead.
+	endpoint := remoteURL.URL
+	endpoint.User = nil
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/")
+	if !strings.HasSuffix(endpoint.Path, ".git") {
+		endpoint.Path += ".git"
+	}
+	endpoint.Path += "/info/lfs"
+	auth := func(req *http.Request) {
+		if remoteURL.User == nil || req.URL.Host != endpoint.Host || req.Header.Get("Authorization") != "" {
+			return
+		}
+		password, _ := remoteURL.User.Password()
+		req.SetBasicAuth(remoteURL.User.Username(), password)
+	}
+
+	action, err := lfsDownloadAction(ctx, cli, endpoint.String(), auth, p)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", action.Href, nil)
+	if err != nil {
+		return err
+	}
+	for k, v := range action.Header {
+		req.Header.Set(k, v)
+	}
+	auth(req)
+	resp, err := cli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return errors.Newf("unexpected status code %d downloading LFS object", resp.StatusCode)
+	}
+
+	tmp, err := s.tempDir("lfs-object")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmp)
+
+	f, err := os.Create(filepath.Join(tmp, p.OID))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(f, h), io.LimitReader(resp.Body, p.Size+1))
+	if err != nil {
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if n != p.Size {
+		return errors.Newf("LFS object has size %d, expected %d", n, p.Size)
+	}
+	if oid := hex.EncodeToString(h.Sum(nil)); oid != p.OID {
+		return errors.Newf("LFS object has ID %s", oid)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(dst), os.ModePerm); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), dst)
+}
+
+type lfsBatchRequest struct {
+	Operation string           `json:"operation"`
+	Transfers []string         `json:"transfers"`
+	Objects   []lfsBatchObject `json:"objects"`
+}
+
+type lfsBatchResponse struct {
+	Objects []lfsBatchObject `json:"objects"`
+}
+
+type lfsBatchObject struct {
+	OID     string               `json:"oid"`
+	Size    int64                `json:"size"`
+	Actions map[string]lfsAction `json:"actions,omitempty"`
+	Error   *lfsObjectError      `json:"error,omitempty"`
+}
+
+type lfsAction struct {
+	Href   string            `json:"href"`
+	Header map[string]string `json:"header,omitempty"`
+}
+
+type lfsObjectError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// lfsDownloadAction asks the LFS server at endpoint how to download the object
+// p points to, using the batch API.
+//
+// See https://github.com/git-lfs/git-lfs/blob/main/docs/api/batch.md.
+func lfsDownloadAction(ctx context.Context, cli httpcli.Doer, endpoint string, auth func(*http.Request), p gitdomain.LFSPointer) (*lfsAction, error) {
+	body, err := json.Marshal(lfsBatchRequest{
+		Operation: "download",
+		Transfers: []string{"basic"},
+		Objects:   []lfsBatchObject{{OID: p.OID, Size: p.Size}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint+"/objects/batch", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", lfsMediaType)
+	req.Header.Set("Content-Type", lfsMediaType)
+	auth(req)
+
+	resp, err := cli.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Newf("unexpected status code %d from LFS batch API", resp.StatusCode)
+	}
+
+	var batch lfsBatchResponse
+	if err := json.NewDecoder(resp.Body).Decode(&batch); err != nil {
+		return nil, errors.Wrap(err, "decoding LFS batch API response")
+	}
+	for _, o := range batch.Objects {
+		if o.OID != p.OID {
+			continue
+		}
+		if o.Error != nil {
+			return nil, errors.Newf("LFS server returned error %d: %s", o.Error.Code, o.Error.Message)
+		}
+		action, ok := o.Actions["download"]
+		if !ok {
+			return nil, errors.New("LFS server returned no download action")
+		}
+		if u, err := url.Parse(action.Href); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, errors.New("LFS server returned an invalid download URL")
+		}
+		return &action, nil
+	}
+	return nil, errors.New("LFS server did not return the object")
+}
+
+// readLFSObject writes the contents of the LFS object req is for to w.
+func (s *Server) readLFSObject(ctx context.Context, req *protocol.ReadLFSObjectRequest, w io.Writer) error {
+	path, err := s.lfsObjectPath(ctx, req.Repo, gitdomain.LFSPointer{OID: req.OID, Size: req.Size})
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
+
+func (s *Server) handleReadLFSObject(w http.ResponseWriter, r *http.Request) {
+	var req protocol.ReadLFSObjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.Repo = protocol.NormalizeRepo(req.Repo)
+	if req.Repo == "" || !gitdomain.IsValidLFSOID(req.OID) {
+		http.Error(w, "invalid request", http.StatusBadRequest)
+		return
+	}
+
+	// Check the object before we start writing the response, so that we can
+	// still report errors with a status code.
+	path, err := s.lfsObjectPath(r.Context(), req.Repo, gitdomain.LFSPointer{OID: req.OID, Size: req.Size})
+	if errors.Is(err, errLFSObjectTooLarge) {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	} else if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.ServeFile(w, r, path)
+}
+
+// removeLFSCache deletes the LFS objects cached for repo. The caches of
+// repositories whose names are nested under the name of repo are kept.
+func (s *Server) removeLFSCache(repo api.RepoName) error {
+	dir := filepath.Join(s.ReposDir, lfsCacheDirName, filepath.FromSlash(string(repo)))
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	for _, e := range entries {
+		// Objects are stored in directories named after the first two
+		// characters of their ID.
+		if _, err := hex.DecodeString(e.Name()); err != nil || len(e.Name()) != 2 {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(dir, e.Name())); err != nil {
+			return err
+		}
+	}
+	// Fails if the directory holds the caches of nested repositories.
+	_ = os.Remove(dir)
+	return nil
+}
+
+// cleanupLFSCache deletes the least recently used LFS objects from the cache
+// until it is no larger than lfsCacheSizeMB.
+func (s *Server) cleanupLFSCache() error {
+	return s.trimCache(filepath.Join(s.ReposDir, lfsCacheDirName), int64(lfsCacheSizeMB)*1024*1024)
+}
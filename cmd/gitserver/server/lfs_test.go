@@ -0,0 +1,196 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// fakeLFSServer serves objects over the Git LFS batch API of the repository
+// foo/bar, requiring basic auth.
+type fakeLFSServer struct {
+	objects   map[string][]byte
+	downloads int
+}
+
+func (f *fakeLFSServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "secret" {
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/foo/bar.git/info/lfs/objects/batch":
+		var req lfsBatchRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var resp lfsBatchResponse
+		for _, o := range req.Objects {
+			if _, ok := f.objects[o.OID]; !ok {
+				o.Error = &lfsObjectError{Code: 404, Message: "not found"}
+			} else {
+				o.Actions = map[string]lfsAction{
+					"download": {Href: "http://" + r.Host + "/objects/" + o.OID},
+				}
+			}
+			resp.Objects = append(resp.Objects, o)
+		}
+		w.Header().Set("Content-Type", lfsMediaType)
+		_ = json.NewEncoder(w).Encode(resp)
+	default:
+		oid := filepath.Base(r.URL.Path)
+		data, ok := f.objects[oid]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		f.downloads++
+		_, _ = w.Write(data)
+	}
+}
+
+func lfsPointerTo(data []byte) gitdomain.LFSPointer {
+	sum := sha256.Sum256(data)
+	return gitdomain.LFSPointer{OID: hex.EncodeToString(sum[:]), Size: int64(len(data))}
+}
+
+func TestReadLFSObject(t *testing.T) {
+	orig := lfsHTTPClient
+	lfsHTTPClient = func() (httpcli.Doer, error) { return http.DefaultClient, nil }
+	t.Cleanup(func() { lfsHTTPClient = orig })
+
+	content := []byte("some large binary content")
+	corrupt := []byte("corrupt")
+	corruptPointer := lfsPointerTo([]byte("expected"))
+	corruptPointer.Size = int64(len(corrupt))
+
+	lfs := &fakeLFSServer{objects: map[string][]byte{
+		lfsPointerTo(content).OID: content,
+		corruptPointer.OID:        corrupt,
+	}}
+	ts := httptest.NewServer(lfs)
+	t.Cleanup(ts.Close)
+
+	s := &Server{
+		Logger:   logtest.Scoped(t),
+		ReposDir: t.TempDir(),
+		GetRemoteURLFunc: func(ctx context.Context, name api.RepoName) (string, error) {
+			// Only internal actors may read the credentials of a repository.
+			if !actor.FromContext(ctx).IsInternal() {
+				return "", errors.New("not an internal actor")
+			}
+			return fmt.Sprintf("http://user:secret@%s/foo/bar", ts.Listener.Addr()), nil
+		},
+	}
+	ctx := context.Background()
+	read := func(p gitdomain.LFSPointer) ([]byte, error) {
+		var buf bytes.Buffer
+		err := s.readLFSObject(ctx, &protocol.ReadLFSObjectRequest{Repo: "foo/bar", OID: p.OID, Size: p.Size}, &buf)
+		return buf.Bytes(), err
+	}
+
+	t.Run("fetches and caches objects", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			got, err := read(lfsPointerTo(content))
+			require.NoError(t, err)
+			require.Equal(t, content, got)
+		}
+		require.Equal(t, 1, lfs.downloads)
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		_, err := read(lfsPointerTo([]byte("missing")))
+		require.ErrorContains(t, err, "not found")
+	})
+
+	t.Run("object does not match pointer", func(t *testing.T) {
+		_, err := read(corruptPointer)
+		require.ErrorContains(t, err, "LFS object has ID")
+
+		_, err = os.Stat(filepath.Join(s.ReposDir, lfsCacheDirName, "foo/bar", corruptPointer.OID[:2], corruptPointer.OID))
+		require.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("object too large", func(t *testing.T) {
+		p := lfsPointerTo(content)
+		p.Size = int64(lfsMaxObjectSize) + 1
+		_, err := read(p)
+		require.ErrorIs(t, err, errLFSObjectTooLarge)
+	})
+}
+
+func TestCleanupLFSCache(t *testing.T) {
+	orig := lfsCacheSizeMB
+	lfsCacheSizeMB = 1
+	t.Cleanup(func() { lfsCacheSizeMB = orig })
+
+	s := &Server{Logger: logtest.Scoped(t), ReposDir: t.TempDir()}
+
+	// Cleaning up without a cache is fine.
+	require.NoError(t, s.cleanupLFSCache())
+
+	// Three objects of 512KiB, of which the oldest has to go.
+	now := time.Now()
+	var paths []string
+	for i := 0; i < 3; i++ {
+		path := filepath.Join(s.ReposDir, lfsCacheDirName, "foo/bar", "ab", fmt.Sprintf("object%d", i))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, make([]byte, 512*1024), 0o600))
+		mtime := now.Add(time.Duration(i-3) * time.Hour)
+		require.NoError(t, os.Chtimes(path, mtime, mtime))
+		paths = append(paths, path)
+	}
+
+	require.NoError(t, s.cleanupLFSCache())
+
+	_, err := os.Stat(paths[0])
+	require.True(t, os.IsNotExist(err))
+	for _, path := range paths[1:] {
+		_, err := os.Stat(path)
+		require.NoError(t, err)
+	}
+}
+
+func TestRemoveRepoDirectory_RemovesLFSCache(t *testing.T) {
+	logger := logtest.Scoped(t)
+	s := &Server{Logger: logger, ReposDir: t.TempDir()}
+
+	gitDir := common.GitDir(filepath.Join(s.ReposDir, "foo/bar/.git"))
+	require.NoError(t, os.MkdirAll(string(gitDir), os.ModePerm))
+	object := filepath.Join(s.ReposDir, lfsCacheDirName, "foo/bar", "ab", "object")
+	nestedObject := filepath.Join(s.ReposDir, lfsCacheDirName, "foo/bar/baz", "cd", "object")
+	for _, path := range []string{object, nestedObject} {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), os.ModePerm))
+		require.NoError(t, os.WriteFile(path, []byte("content"), 0o600))
+	}
+
+	require.NoError(t, s.removeRepoDirectory(gitDir, logger, false))
+
+	_, err := os.Stat(filepath.Dir(object))
+	require.True(t, os.IsNotExist(err))
+	// The cache of the repository foo/bar/baz is kept.
+	_, err = os.Stat(nestedObject)
+	require.NoError(t, err)
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"context"
+	"os/exec"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+	"github.com/sourcegraph/sourcegraph/internal/wrexec"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// The backoff after a failed push of a repo to its secondary remote. It
+// doubles with each consecutive failure, up to mirrorMaxBackoff.
+const (
+	mirrorMinBackoff = time.Minute
+	mirrorMaxBackoff = 6 * time.Hour
+)
+
+// mirrorRetryBatchSize is the maximum number of repos the janitor retries
+// failed pushes of per run.
+const mirrorRetryBatchSize = 100
+
+var repoMirrorPushes = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_repo_mirror_push",
+	Help: "number of pushes of repos to the secondary remote of their code host connection by result (success, error or backoff)",
+}, []string{"result"})
+
+// mirrorBackoff returns how long to wait before pushing to the secondary remote
+// again after the given number of consecutive failed pushes.
+func mirrorBackoff(failures int) time.Duration {
+	backoff := mirrorMinBackoff
+	for i := 1; i < failures && backoff < mirrorMaxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > mirrorMaxBackoff {
+		backoff = mirrorMaxBackoff
+	}
+	return backoff
+}
+
+// getMirrorURL returns the URL of the secondary remote the code host connection
+// of repo configures to push repo to, or nil if it doesn't configure one.
+func (s *Server) getMirrorURL(ctx context.Context, repo api.RepoName) (*vcs.URL, error) {
+	if s.GetMirrorURLFunc == nil {
+		return nil, nil
+	}
+
+	mirrorURL, err := s.GetMirrorURLFunc(ctx, repo)
+	if err != nil {
+		return nil, errors.Wrap(err, "GetMirrorURLFunc")
+	}
+	if mirrorURL == "" {
+		return nil, nil
+	}
+	return vcs.ParseURL(mirrorURL)
+}
+
+// mirrorRepo pushes all refs of repo to the secondary remote configured for its
+// code host connection, if any, and records the outcome in gitserver_repos.
+// After a failed push, later calls don't push until the backoff recorded with
+// the failure has passed. Only the primary gitserver of repo pushes it.
+func (s *Server) mirrorRepo(ctx context.Context, repo api.RepoName) error {
+	if s.isReplica(repo) {
+		return nil
+	}
+
+	mirrorURL, err := s.getMirrorURL(ctx, repo)
+	if err != nil || mirrorURL == nil {
+		return err
+	}
+
+	store := s.DB.GitserverRepos()
+	gr, err := store.GetByName(ctx, repo)
+	if err != nil {
+		return err
+	}
+	if time.Now().Before(gr.NextMirrorAt) {
+		repoMirrorPushes.WithLabelValues("backoff").Inc()
+		return nil
+	}
+
+	dir := s.dir(repo)
+	var pushErr error
+	if isPartialClone(dir) {
+		// Pushing would fetch every object the partial clone left out.
+		pushErr = errors.New("partial clones can't be pushed to a mirror")
+	} else {
+		pushErr = pushMirror(ctx, mirrorURL, dir)
+	}
+	if pushErr != nil {
+		repoMirrorPushes.WithLabelValues("error").Inc()
+		nextMirrorAt := time.Now().Add(mirrorBackoff(gr.MirrorFailures + 1))
+		if err := store.SetMirrorError(ctx, repo, pushErr.Error(), nextMirrorAt, s.Hostname); err != nil {
+			return errors.Append(pushErr, err)
+		}
+		return pushErr
+	}
+
+	repoMirrorPushes.WithLabelValues("success").Inc()
+	return store.SetLastMirrored(ctx, repo, time.Now(), s.Hostname)
+}
+
+// retryMirrors pushes the repos of this gitserver whose last push to their
+// secondary remote failed once their backoff has passed. Updates push repos
+// too, but repos which don't change are rarely updated.
+func (s *Server) retryMirrors(ctx context.Context) error {
+	repos, err := s.DB.GitserverRepos().ListReposDueForMirror(ctx, s.Hostname, mirrorRetryBatchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range repos {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if !repoCloned(s.dir(repo)) {
+			continue
+		}
+		// A running update pushes the repo when it's done.
+		unlock, ok := s.tryLockRepoUpdate(repo)
+		if !ok {
+			continue
+		}
+		pushCtx, cancel := context.WithTimeout(ctx, conf.GitLongCommandTimeout())
+		err := s.mirrorRepo(pushCtx, repo)
+		cancel()
+		unlock()
+		if err != nil {
+			s.Logger.Warn("failed to push to mirror", log.String("repo", string(repo)), log.Error(err))
+		}
+	}
+	return nil
+}
+
+// pushMirror pushes all refs of the repository in dir to remoteURL, deleting
+// the refs of the remote which the repository doesn't have.
+func pushMirror(ctx context.Context, remoteURL *vcs.URL, dir common.GitDir) error {
+	cmd := exec.CommandContext(ctx, "git", "push", "--mirror", remoteURL.String())
+	dir.Set(cmd)
+	if output, err := runRemoteGitCommand(ctx, wrexec.Wrap(ctx, log.NoOp(), cmd), true, nil); err != nil {
+		return &common.GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(output))}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestMirrorBackoff(t *testing.T) {
+	require.Equal(t, time.Minute, mirrorBackoff(1))
+	require.Equal(t, 2*time.Minute, mirrorBackoff(2))
+	require.Equal(t, 8*time.Minute, mirrorBackoff(4))
+	require.Equal(t, mirrorMaxBackoff, mirrorBackoff(20))
+	require.Equal(t, mirrorMaxBackoff, mirrorBackoff(1000))
+}
+
+func TestMirrorRepo(t *testing.T) {
+	ctx := context.Background()
+	reposDir := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+
+	repoDir := filepath.Join(reposDir, string(repoName))
+	require.NoError(t, os.MkdirAll(repoDir, os.ModePerm))
+	runCmd(t, repoDir, "git", "init", ".")
+	runCmd(t, repoDir, "git", "commit", "--allow-empty", "-m", "init")
+	runCmd(t, repoDir, "git", "tag", "v1")
+
+	mirrorDir := filepath.Join(t.TempDir(), "mirror.git")
+	runCmd(t, filepath.Dir(mirrorDir), "git", "init", "--bare", mirrorDir)
+
+	gitserverRepo := &types.GitserverRepo{}
+	gitserverRepos := database.NewMockGitserverRepoStore()
+	gitserverRepos.GetByNameFunc.SetDefaultHook(func(context.Context, api.RepoName) (*types.GitserverRepo, error) {
+		return gitserverRepo, nil
+	})
+	db := database.NewMockDB()
+	db.GitserverReposFunc.SetDefaultReturn(gitserverRepos)
+
+	mirrorURL := ""
+	s := &Server{
+		ReposDir: reposDir,
+		DB:       db,
+		Hostname: "gitserver-0",
+		GetMirrorURLFunc: func(context.Context, api.RepoName) (string, error) {
+			return mirrorURL, nil
+		},
+	}
+
+	t.Run("not mirrored", func(t *testing.T) {
+		require.NoError(t, s.mirrorRepo(ctx, repoName))
+		require.Empty(t, gitserverRepos.GetByNameFunc.History())
+	})
+
+	mirrorURL = mirrorDir
+
+	t.Run("pushes all refs", func(t *testing.T) {
+		require.NoError(t, s.mirrorRepo(ctx, repoName))
+		require.Len(t, gitserverRepos.SetLastMirroredFunc.History(), 1)
+		require.Equal(t, runCmd(t, repoDir, "git", "for-each-ref"), runCmd(t, mirrorDir, "git", "for-each-ref"))
+
+		runCmd(t, repoDir, "git", "tag", "-d", "v1")
+		require.NoError(t, s.mirrorRepo(ctx, repoName))
+		require.Equal(t, runCmd(t, repoDir, "git", "for-each-ref"), runCmd(t, mirrorDir, "git", "for-each-ref"))
+	})
+
+	mirrorURL = filepath.Join(t.TempDir(), "missing.git")
+
+	t.Run("records failures", func(t *testing.T) {
+		gitserverRepo.MirrorFailures = 2
+		start := time.Now()
+		require.Error(t, s.mirrorRepo(ctx, repoName))
+
+		history := gitserverRepos.SetMirrorErrorFunc.History()
+		require.Len(t, history, 1)
+		require.Equal(t, repoName, history[0].Arg1)
+		require.NotEmpty(t, history[0].Arg2)
+		require.WithinDuration(t, start.Add(4*time.Minute), history[0].Arg3, time.Minute)
+		require.Equal(t, "gitserver-0", history[0].Arg4)
+	})
+
+	t.Run("backs off", func(t *testing.T) {
+		gitserverRepo.NextMirrorAt = time.Now().Add(time.Hour)
+		require.NoError(t, s.mirrorRepo(ctx, repoName))
+		require.Len(t, gitserverRepos.SetMirrorErrorFunc.History(), 1)
+		require.Len(t, gitserverRepos.SetLastMirroredFunc.History(), 2)
+	})
+}
+
+func TestRetryMirrors(t *testing.T) {
+	ctx := context.Background()
+	reposDir := t.TempDir()
+
+	mirrorDir := filepath.Join(t.TempDir(), "mirror.git")
+	runCmd(t, filepath.Dir(mirrorDir), "git", "init", "--bare", mirrorDir)
+
+	// The janitor skips repos which are being updated or aren't cloned.
+	due, busy, notCloned := api.RepoName("example.com/due"), api.RepoName("example.com/busy"), api.RepoName("example.com/not-cloned")
+	for _, repo := range []api.RepoName{due, busy} {
+		dir := filepath.Join(reposDir, string(repo))
+		require.NoError(t, os.MkdirAll(dir, os.ModePerm))
+		runCmd(t, dir, "git", "init", ".")
+		runCmd(t, dir, "git", "commit", "--allow-empty", "-m", "init")
+	}
+
+	gitserverRepos := database.NewMockGitserverRepoStore()
+	gitserverRepos.ListReposDueForMirrorFunc.SetDefaultReturn([]api.RepoName{due, busy, notCloned}, nil)
+	gitserverRepos.GetByNameFunc.SetDefaultReturn(&types.GitserverRepo{MirrorFailures: 1}, nil)
+	db := database.NewMockDB()
+	db.GitserverReposFunc.SetDefaultReturn(gitserverRepos)
+
+	s := &Server{
+		Logger:          logtest.Scoped(t),
+		ReposDir:        reposDir,
+		DB:              db,
+		Hostname:        "gitserver-0",
+		repoUpdateLocks: make(map[api.RepoName]*locks),
+		GetMirrorURLFunc: func(context.Context, api.RepoName) (string, error) {
+			return mirrorDir, nil
+		},
+	}
+
+	unlock, ok := s.tryLockRepoUpdate(busy)
+	require.True(t, ok)
+	defer unlock()
+
+	require.NoError(t, s.retryMirrors(ctx))
+
+	require.Equal(t, "gitserver-0", gitserverRepos.ListReposDueForMirrorFunc.History()[0].Arg1)
+	history := gitserverRepos.SetLastMirroredFunc.History()
+	require.Len(t, history, 1)
+	require.Equal(t, due, history[0].Arg1)
+	require.Empty(t, gitserverRepos.SetMirrorErrorFunc.History())
+}
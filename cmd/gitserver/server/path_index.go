@@ -0,0 +1,167 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"strings"
+
+	"github.com/sourcegraph/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+var pathIndexUpdateConcurrency = env.MustGetInt("SRC_PATH_INDEX_UPDATE_CONCURRENCY", 1, "maximum number of path index updates to run at the same time")
+
+// pathIndexUpdateQueueSize is the number of repositories which can wait for
+// an update of their path index. The others are queued on a later fetch.
+const pathIndexUpdateQueueSize = 100
+
+// StartPathIndexer starts the workers which update the path indexes of the
+// repositories queued by queuePathIndexUpdate. Updates list the files of a
+// repository and write them to the database, so we don't run them during the
+// update of the repository, which holds its update lock.
+func (s *Server) StartPathIndexer(ctx context.Context) {
+	s.pathIndexUpdatesMu.Lock()
+	s.pathIndexUpdates = make(chan api.RepoName, pathIndexUpdateQueueSize)
+	s.pathIndexUpdatesPending = make(map[api.RepoName]struct{})
+	s.pathIndexUpdatesMu.Unlock()
+
+	logger := s.Logger.Scoped("pathIndexer", "updates the path indexes of repositories")
+	for i := 0; i < pathIndexUpdateConcurrency; i++ {
+		go func() {
+			for {
+				select {
+				case repo := <-s.pathIndexUpdates:
+					// A fetch during the update queues the repo again,
+					// so that the index ends up at the fetched HEAD.
+					s.pathIndexUpdatesMu.Lock()
+					delete(s.pathIndexUpdatesPending, repo)
+					s.pathIndexUpdatesMu.Unlock()
+					// The janitor may have removed the repository since
+					// queueing the update.
+					if !repoCloned(s.dir(repo)) {
+						continue
+					}
+					if err := s.updatePathIndex(ctx, repo); err != nil {
+						logger.Warn("failed to update path index", log.String("repo", string(repo)), log.Error(err))
+					}
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+}
+
+// queuePathIndexUpdate queues an update of the path index of repo. If the
+// queue is full, the index is updated after a later fetch.
+func (s *Server) queuePathIndexUpdate(repo api.RepoName) {
+	if s.isReplica(repo) {
+		return
+	}
+
+	s.pathIndexUpdatesMu.Lock()
+	defer s.pathIndexUpdatesMu.Unlock()
+	if s.pathIndexUpdates == nil {
+		return
+	}
+	if _, ok := s.pathIndexUpdatesPending[repo]; ok {
+		return
+	}
+	select {
+	case s.pathIndexUpdates <- repo:
+		s.pathIndexUpdatesPending[repo] = struct{}{}
+	default:
+	}
+}
+
+// updatePathIndex updates the path index used to answer path-only searches
+// with the paths of the files at HEAD of repo. It is a no-op if the index is
+// already at HEAD or if the repository is empty. The index is stored in the
+// database, so only the primary gitserver of repo updates it.
+func (s *Server) updatePathIndex(ctx context.Context, repo api.RepoName) error {
+	if s.isReplica(repo) {
+		return nil
+	}
+	dir := s.dir(repo)
+
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", "HEAD^{commit}")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(out) == 0 {
+			// Empty repositories have nothing to index.
+			return nil
+		}
+		return errors.Wrap(err, "failed to resolve HEAD")
+	}
+	commitID := api.CommitID(bytes.TrimSpace(out))
+
+	r, err := s.DB.Repos().GetByName(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	store := s.DB.RepoPaths()
+	commits, err := store.PathIndexCommits(ctx, []api.RepoID{r.ID})
+	if err != nil {
+		return err
+	}
+	baseCommitID, ok := commits[r.ID]
+	if baseCommitID == commitID {
+		return nil
+	}
+
+	// Most fetches change few files, so we only apply the changes since the
+	// commit the index is at. We rebuild the index if that commit is gone.
+	if ok {
+		added, deleted, err := s.diffPaths(ctx, repo, baseCommitID, commitID)
+		if err == nil {
+			err = store.PatchPathIndex(ctx, r.ID, baseCommitID, commitID, added, deleted)
+			if !errors.Is(err, database.ErrPathIndexConflict) {
+				return err
+			}
+		}
+	}
+
+	cmd = exec.CommandContext(ctx, "git", "ls-tree", "-r", "-z", "--name-only", "--full-tree", string(commitID))
+	dir.Set(cmd)
+	out, err = cmd.Output()
+	if err != nil {
+		return errors.Wrap(err, "git ls-tree")
+	}
+
+	var paths []string
+	if len(out) > 0 {
+		paths = strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+	}
+	return store.UpdatePathIndex(ctx, r.ID, commitID, paths)
+}
+
+// diffPaths returns the paths of the files which were added and deleted
+// between the given commits of repo.
+func (s *Server) diffPaths(ctx context.Context, repo api.RepoName, base, head api.CommitID) (added, deleted []string, err error) {
+	cmd := exec.CommandContext(ctx, "git", "diff", "--name-status", "-z", "--no-renames", "--diff-filter=AD", string(base), string(head), "--")
+	s.dir(repo).Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "git diff")
+	}
+
+	// The output is a status followed by a path for each changed file.
+	fields := strings.Split(strings.TrimSuffix(string(out), "\x00"), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		switch fields[i] {
+		case "A":
+			added = append(added, fields[i+1])
+		case "D":
+			deleted = append(deleted, fields[i+1])
+		}
+	}
+	return added, deleted, nil
+}
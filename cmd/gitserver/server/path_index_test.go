@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestUpdatePathIndex(t *testing.T) {
+	ctx := context.Background()
+	reposDir := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+
+	repoDir := filepath.Join(reposDir, string(repoName))
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "dir"), os.ModePerm))
+	cmd := func(name string, arg ...string) string {
+		return runCmd(t, repoDir, name, arg...)
+	}
+	cmd("git", "init", ".")
+	cmd("sh", "-c", "echo a > a.txt && echo b > dir/b.go")
+
+	repos := database.NewMockRepoStore()
+	repos.GetByNameFunc.SetDefaultReturn(&types.Repo{ID: 1, Name: repoName}, nil)
+	commits := map[api.RepoID]api.CommitID{}
+	repoPaths := database.NewMockRepoPathStore()
+	repoPaths.PathIndexCommitsFunc.SetDefaultHook(func(context.Context, []api.RepoID) (map[api.RepoID]api.CommitID, error) {
+		return commits, nil
+	})
+	repoPaths.UpdatePathIndexFunc.SetDefaultHook(func(_ context.Context, repoID api.RepoID, commitID api.CommitID, _ []string) error {
+		commits[repoID] = commitID
+		return nil
+	})
+	repoPaths.PatchPathIndexFunc.SetDefaultHook(func(_ context.Context, repoID api.RepoID, _, commitID api.CommitID, _, _ []string) error {
+		commits[repoID] = commitID
+		return nil
+	})
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.RepoPathsFunc.SetDefaultReturn(repoPaths)
+
+	s := &Server{ReposDir: reposDir, DB: db}
+
+	// Empty repositories are not indexed.
+	require.NoError(t, s.updatePathIndex(ctx, repoName))
+	require.Empty(t, repoPaths.UpdatePathIndexFunc.History())
+
+	cmd("git", "add", ".")
+	cmd("git", "commit", "-m", "init")
+	head := api.CommitID(strings.TrimSpace(cmd("git", "rev-parse", "HEAD")))
+
+	require.NoError(t, s.updatePathIndex(ctx, repoName))
+	history := repoPaths.UpdatePathIndexFunc.History()
+	require.Len(t, history, 1)
+	require.Equal(t, api.RepoID(1), history[0].Arg1)
+	require.Equal(t, head, history[0].Arg2)
+	require.Equal(t, []string{"a.txt", "dir/b.go"}, history[0].Arg3)
+
+	// The index is already at HEAD.
+	require.NoError(t, s.updatePathIndex(ctx, repoName))
+	require.Len(t, repoPaths.UpdatePathIndexFunc.History(), 1)
+
+	// Later commits only patch the index with the changed paths.
+	cmd("sh", "-c", "git rm -q a.txt && echo c > c.txt && echo b2 > dir/b.go && git add . && git commit -q -m second")
+	head2 := api.CommitID(strings.TrimSpace(cmd("git", "rev-parse", "HEAD")))
+	require.NoError(t, s.updatePathIndex(ctx, repoName))
+	require.Len(t, repoPaths.UpdatePathIndexFunc.History(), 1)
+	patches := repoPaths.PatchPathIndexFunc.History()
+	require.Len(t, patches, 1)
+	require.Equal(t, head, patches[0].Arg2)
+	require.Equal(t, head2, patches[0].Arg3)
+	require.Equal(t, []string{"c.txt"}, patches[0].Arg4)
+	require.Equal(t, []string{"a.txt"}, patches[0].Arg5)
+
+	// The index is rebuilt if it is not at the expected commit.
+	commits[1] = "0000000000000000000000000000000000000000"
+	require.NoError(t, s.updatePathIndex(ctx, repoName))
+	history = repoPaths.UpdatePathIndexFunc.History()
+	require.Len(t, history, 2)
+	require.Equal(t, []string{"c.txt", "dir/b.go"}, history[1].Arg3)
+	require.Len(t, repoPaths.PatchPathIndexFunc.History(), 1)
+}
+
+func TestQueuePathIndexUpdate(t *testing.T) {
+	s := &Server{ReposDir: t.TempDir()}
+
+	// Updates are dropped until the path indexer is started.
+	s.queuePathIndexUpdate("example.com/foo/bar")
+
+	s.pathIndexUpdates = make(chan api.RepoName, 1)
+	s.pathIndexUpdatesPending = make(map[api.RepoName]struct{})
+	s.queuePathIndexUpdate("example.com/foo/bar")
+	s.queuePathIndexUpdate("example.com/foo/bar")
+	// The queue is full.
+	s.queuePathIndexUpdate("example.com/foo/baz")
+	require.Len(t, s.pathIndexUpdates, 1)
+	require.Equal(t, api.RepoName("example.com/foo/bar"), <-s.pathIndexUpdates)
+}
@@ -0,0 +1,281 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/sourcegraph/log"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
+	"github.com/sourcegraph/sourcegraph/internal/grpc/streamio"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// When the gitserver addresses change, repos move to a different gitserver.
+// The new owner of a repo copies it from the gitserver which owned it before
+// with the RepoPack RPC rather than cloning it from the code host again. The
+// previous owner is the shard_id of the repo in gitserver_repos, which the new
+// owner only sets to itself once the copy is complete. Until then, the
+// previous owner keeps the repo on disk and keeps serving it to the new owner,
+// and the new owner records the progress of the copy in gitserver_repos.
+
+var repoCopiedCounter = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_gitserver_repo_copied_from_peer",
+	Help: "Incremented each time we copy a repo from the gitserver which owned it before",
+}, []string{"success"})
+
+// repoPackRefsBatchSize is the maximum number of refs sent in one
+// RepoPackResponse, which keeps the messages of repos with many refs small.
+const repoPackRefsBatchSize = 1000
+
+// rebalanceSource returns the address of the gitserver repo should be copied
+// from. That is the gitserver which owns repo if it is still one of the
+// gitserver addresses and has repo cloned. It returns an empty string if repo
+// should be cloned from the code host. Only git repos are copied from other
+// gitservers, since the repos of other code hosts are converted by their
+// syncers when they are cloned.
+func (s *Server) rebalanceSource(ctx context.Context, repo api.RepoName, syncer VCSSyncer) string {
+	if _, ok := syncer.(*GitRepoSyncer); !ok {
+		return ""
+	}
+	gr, _ := s.DB.GitserverRepos().GetByName(ctx, repo)
+	if gr == nil || gr.ShardID == "" || gr.ShardID == s.Hostname || gr.CloneStatus != types.CloneStatusCloned {
+		return ""
+	}
+	return peerAddr(gr.ShardID, gitserver.NewGitserverAddressesFromConf(conf.Get()).Addresses)
+}
+
+// peerAddr returns the address in addrs of the gitserver with the given
+// hostname, or an empty string if there is none. The host of an address
+// matches if it is the hostname, or if its first DNS label is the hostname and
+// no other address has the same first label.
+func peerAddr(hostname string, addrs []string) string {
+	if hostname == "" {
+		return ""
+	}
+	var match string
+	var labelMatches int
+	for _, addr := range addrs {
+		host := addr
+		if h, _, err := net.SplitHostPort(addr); err == nil {
+			host = h
+		}
+		if host == hostname {
+			return addr
+		}
+		if label, _, _ := strings.Cut(host, "."); label == hostname {
+			match = addr
+			labelMatches++
+		}
+	}
+	if labelMatches != 1 {
+		return ""
+	}
+	return match
+}
+
+// ownsRepo returns true if this gitserver is the owner of repo in the
+// database. A repo which moved to another gitserver stays owned by this one
+// until the other gitserver copied it, so it must not be removed before.
+func (s *Server) ownsRepo(ctx context.Context, repo api.RepoName) bool {
+	gr, _ := s.DB.GitserverRepos().GetByName(ctx, repo)
+	return gr != nil && gr.ShardID == s.Hostname
+}
+
+// packRepo sends the ref HEAD points to, all refs and a pack of all objects
+// reachable from the refs of the repo in dir to send. The pack of a partial
+// clone lacks the objects the repo is missing as well.
+func packRepo(ctx context.Context, dir common.GitDir, send func(*proto.RepoPackResponse) error) error {
+	head, err := repoHead(ctx, dir)
+	if err != nil {
+		return err
+	}
+	refs, err := listRefs(ctx, dir)
+	if err != nil {
+		return err
+	}
+	promisor := isPartialClone(dir)
+
+	var oids strings.Builder
+	resp := &proto.RepoPackResponse{Head: head, Promisor: promisor}
+	for {
+		n := len(refs)
+		if n > repoPackRefsBatchSize {
+			n = repoPackRefsBatchSize
+		}
+		resp.Refs, refs = refs[:n], refs[n:]
+		for _, ref := range resp.Refs {
+			oids.WriteString(ref.Oid + "\n")
+		}
+		if err := send(resp); err != nil {
+			return err
+		}
+		if len(refs) == 0 {
+			break
+		}
+		resp = &proto.RepoPackResponse{}
+	}
+
+	args := []string{"pack-objects", "--revs", "--stdout", "--delta-base-offset", "--quiet"}
+	if promisor {
+		args = append(args, "--missing=allow-promisor")
+	}
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	cmd.Env = partialCloneMaintenanceEnv(dir)
+	cmd.Stdin = strings.NewReader(oids.String())
+	cmd.Stdout = streamio.NewWriter(func(p []byte) error {
+		return send(&proto.RepoPackResponse{Data: p})
+	})
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "git pack-objects failed. Output: %s", stderr.String())
+	}
+	return nil
+}
+
+// repoHead returns the ref HEAD of the repo in dir points to. A pack doesn't
+// record it, so it is sent along with the pack of a repo.
+func repoHead(ctx context.Context, dir common.GitDir) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "symbolic-ref", "HEAD")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", errors.Wrap(wrapCmdError(cmd, err), "git symbolic-ref HEAD")
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// listRefs returns all refs of the repo in dir.
+func listRefs(ctx context.Context, dir common.GitDir) ([]*proto.RepoPackRef, error) {
+	cmd := exec.CommandContext(ctx, "git", "for-each-ref", "--format=%(objectname) %(refname)")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "git for-each-ref")
+	}
+
+	var refs []*proto.RepoPackRef
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		oid, name, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		refs = append(refs, &proto.RepoPackRef{Name: name, Oid: oid})
+	}
+	return refs, scanner.Err()
+}
+
+// copyRepoFromPeer copies repo from the gitserver at addr into the empty
+// directory tmp with the RepoPack RPC. The pack is indexed into tmp as it is
+// received rather than staged in a file first. Progress is reported in the lock
+// status and in the transfer progress of repo.
+func (s *Server) copyRepoFromPeer(ctx context.Context, logger log.Logger, repo api.RepoName, addr string, lock *RepositoryLock, tmp common.GitDir) (err error) {
+	defer func() {
+		repoCopiedCounter.WithLabelValues(fmt.Sprint(err == nil)).Inc()
+	}()
+
+	conn, err := defaults.DialContext(ctx, addr, logger)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := proto.NewGitserverServiceClient(conn).RepoPack(ctx, &proto.RepoPackRequest{Repo: string(repo)})
+	if err != nil {
+		return err
+	}
+
+	// All refs are sent before the pack.
+	var head string
+	var promisor bool
+	var refs []*proto.RepoPackRef
+	var data []byte
+	for first := true; len(data) == 0; first = false {
+		resp, err := stream.Recv()
+		if err != nil {
+			return errors.Wrap(err, "receiving refs")
+		}
+		if first {
+			head, promisor = resp.GetHead(), resp.GetPromisor()
+		}
+		refs = append(refs, resp.GetRefs()...)
+		data = resp.GetData()
+	}
+
+	if err := os.MkdirAll(string(tmp), os.ModePerm); err != nil {
+		return err
+	}
+	if err := runGitCommand(ctx, tmp, nil, "init", "--bare", "."); err != nil {
+		return err
+	}
+
+	copied := int64(len(data))
+	if err := s.DB.GitserverRepos().SetTransferProgress(ctx, repo, s.Hostname, copied); err != nil {
+		logger.Error("error updating transfer progress in the db", log.Error(err))
+	}
+	dbWritesLimiter := rate.NewLimiter(rate.Limit(1.0), 1)
+	pack := io.MultiReader(bytes.NewReader(data), streamio.NewReader(func() ([]byte, error) {
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		copied += int64(len(resp.GetData()))
+		lock.SetStatus(fmt.Sprintf("copying from %s: %d bytes", addr, copied))
+		if dbWritesLimiter.Allow() {
+			if err := s.DB.GitserverRepos().SetTransferProgress(ctx, repo, s.Hostname, copied); err != nil {
+				logger.Error("error updating transfer progress in the db", log.Error(err))
+			}
+		}
+		return resp.GetData(), nil
+	}))
+
+	indexPack := []string{"index-pack", "--stdin"}
+	if promisor {
+		indexPack = append(indexPack, "--promisor")
+	}
+	if err := runGitCommand(ctx, tmp, pack, indexPack...); err != nil {
+		return errors.Wrap(err, "receiving pack")
+	}
+
+	var updates strings.Builder
+	for _, ref := range refs {
+		fmt.Fprintf(&updates, "create %s %s\n", ref.GetName(), ref.GetOid())
+	}
+	if err := runGitCommand(ctx, tmp, strings.NewReader(updates.String()), "update-ref", "--stdin"); err != nil {
+		return err
+	}
+	return runGitCommand(ctx, tmp, nil, "symbolic-ref", "HEAD", head)
+}
+
+// runGitCommand runs git with args in dir, reading stdin if it is not nil.
+func runGitCommand(ctx context.Context, dir common.GitDir, stdin io.Reader, args ...string) error {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	cmd.Stdin = stdin
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return errors.Wrapf(err, "git %s failed. Output: %s", args[0], string(out))
+	}
+	return nil
+}
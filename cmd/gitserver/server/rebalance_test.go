@@ -0,0 +1,133 @@
+package server
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	internalgrpc "github.com/sourcegraph/sourcegraph/internal/grpc"
+	"github.com/sourcegraph/sourcegraph/internal/grpc/defaults"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestPeerAddr(t *testing.T) {
+	addrs := []string{
+		"gitserver-1:3178",
+		"gitserver-10.gitserver:3178",
+		"gitserver-2",
+		"gitserver-3.a:3178",
+		"gitserver-3.b:3178",
+		"gitserver-4.gitserver:3178",
+		"gitserver-4:3178",
+	}
+	for hostname, want := range map[string]string{
+		"gitserver-1":  "gitserver-1:3178",
+		"gitserver-10": "gitserver-10.gitserver:3178",
+		"gitserver-2":  "gitserver-2",
+		// The first label of more than one address matches.
+		"gitserver-3": "",
+		// A host which is the hostname wins over a first label.
+		"gitserver-4":            "gitserver-4:3178",
+		"gitserver-10.gitserver": "gitserver-10.gitserver:3178",
+		"gitserver":              "",
+		"gitserver-5":            "",
+		"":                       "",
+	} {
+		if got := peerAddr(hostname, addrs); got != want {
+			t.Errorf("peerAddr(%q) = %q, want %q", hostname, got, want)
+		}
+	}
+}
+
+func TestCloneRepo_Rebalance(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	remote := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	}
+	wantCommit := makeSingleCommitRepo(cmd)
+	cmd("git", "checkout", "-b", "trunk")
+
+	// The peer clones the repo from the code host.
+	peer := makeTestServer(ctx, t, t.TempDir(), remote, nil)
+	_, err := peer.cloneRepo(ctx, repoName, &cloneOptions{Block: true})
+	require.NoError(t, err)
+	// A ref which HEAD of the code host doesn't point to and an annotated tag
+	// are copied as well.
+	peerDir := filepath.Dir(string(peer.dir(repoName)))
+	runCmd(t, peerDir, "git", "update-ref", "refs/heads/other", strings.TrimSpace(wantCommit))
+	runCmd(t, peerDir, "git", "tag", "-a", "-m", "release", "v1", strings.TrimSpace(wantCommit))
+	grpcServer := defaults.NewServer(logtest.Scoped(t))
+	proto.RegisterGitserverServiceServer(grpcServer, &GRPCServer{Server: peer})
+	srv := httptest.NewServer(internalgrpc.MultiplexHandlers(grpcServer, peer.Handler()))
+	defer srv.Close()
+
+	// The peer still owns the repo.
+	gitserverRepos := database.NewMockGitserverRepoStore()
+	gitserverRepos.GetByNameFunc.SetDefaultReturn(&types.GitserverRepo{
+		RepoID:      1,
+		ShardID:     "127.0.0.1",
+		CloneStatus: types.CloneStatusCloned,
+	}, nil)
+	db := database.NewMockDB()
+	db.GitserverReposFunc.SetDefaultReturn(gitserverRepos)
+	repos := database.NewMockRepoStore()
+	repos.GetByNameFunc.SetDefaultReturn(nil, &database.RepoNotFoundErr{})
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.RepoPathsFunc.SetDefaultReturn(database.NewMockRepoPathStore())
+	db.FeatureFlagsFunc.SetDefaultReturn(database.NewMockFeatureFlagStore())
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{ExperimentalFeatures: &schema.ExperimentalFeatures{}},
+		ServiceConnectionConfig: conftypes.ServiceConnections{
+			GitServers: []string{strings.TrimPrefix(srv.URL, "http://")},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	// The code host is not reachable from the new owner, so it can only get
+	// the repo from the peer.
+	s := makeTestServer(ctx, t, t.TempDir(), filepath.Join(remote, "missing"), db)
+	s.Hostname = "gitserver-new"
+	_, err = s.cloneRepo(ctx, repoName, &cloneOptions{Block: true})
+	require.NoError(t, err)
+
+	dir := filepath.Dir(string(s.dir(repoName)))
+	require.Equal(t, wantCommit, runCmd(t, dir, "git", "rev-parse", "HEAD"))
+	require.Equal(t, "refs/heads/trunk", strings.TrimSpace(runCmd(t, dir, "git", "symbolic-ref", "HEAD")))
+	require.Equal(t, wantCommit, runCmd(t, dir, "git", "rev-parse", "master"))
+	require.Equal(t, wantCommit, runCmd(t, dir, "git", "rev-parse", "other"))
+	require.Equal(t, wantCommit, runCmd(t, dir, "git", "rev-parse", "v1^{commit}"))
+	require.Equal(t, "tag\n", runCmd(t, dir, "git", "cat-file", "-t", "v1"))
+	runCmd(t, dir, "git", "fsck", "--strict")
+
+	// The progress of the copy is recorded until the copy is complete, which
+	// makes us the owner.
+	require.NotEmpty(t, gitserverRepos.SetTransferProgressFunc.History())
+	for _, call := range gitserverRepos.SetTransferProgressFunc.History() {
+		require.Equal(t, "gitserver-new", call.Arg2)
+	}
+	endTransfers := gitserverRepos.EndTransferFunc.History()
+	require.Len(t, endTransfers, 1)
+	require.Equal(t, "gitserver-new", endTransfers[0].Arg2)
+	require.True(t, endTransfers[0].Arg3)
+
+	// We don't take over the repo before the clone is complete.
+	for _, call := range gitserverRepos.SetCloneStatusFunc.History() {
+		require.NotEqual(t, types.CloneStatusCloning, call.Arg2)
+	}
+}
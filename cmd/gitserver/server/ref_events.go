@@ -0,0 +1,91 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server/common"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// How long we keep the events recorded for the refs moved by repo updates.
+// Subscribers which fall further behind miss events.
+var refEventsRetention = env.MustGetDuration("SRC_REF_EVENTS_RETENTION", 7*24*time.Hour, "how long to keep the events recorded for the refs moved by repo updates")
+
+// refTips returns the commit each ref of the repository in dir points to.
+func refTips(ctx context.Context, dir common.GitDir) (map[string]api.CommitID, error) {
+	cmd := exec.CommandContext(ctx, "git", "for-each-ref", "--format=%(objectname) %(refname)")
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(wrapCmdError(cmd, err), "git for-each-ref")
+	}
+
+	tips := make(map[string]api.CommitID)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		commit, ref, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		tips[ref] = api.CommitID(commit)
+	}
+	return tips, scanner.Err()
+}
+
+// diffRefTips returns the changes between the ref tips before and after,
+// ordered by ref name.
+func diffRefTips(before, after map[string]api.CommitID) []database.RefChange {
+	var changes []database.RefChange
+	for ref, commit := range after {
+		if before[ref] != commit {
+			changes = append(changes, database.RefChange{Name: ref, OldCommit: before[ref], NewCommit: commit})
+		}
+	}
+	for ref, commit := range before {
+		if _, ok := after[ref]; !ok {
+			changes = append(changes, database.RefChange{Name: ref, OldCommit: commit})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// recordRefChanges records an event for the changes fetching repo at
+// fetchedAt made to its refs, which were at before prior to the fetch. No
+// event is recorded if no ref moved. Only the primary gitserver of repo calls
+// it, since its replicas fetch the same changes.
+func (s *Server) recordRefChanges(ctx context.Context, repo api.RepoName, before map[string]api.CommitID, fetchedAt time.Time) error {
+	after, err := refTips(ctx, s.dir(repo))
+	if err != nil {
+		return err
+	}
+	changes := diffRefTips(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	r, err := s.DB.Repos().GetByName(ctx, repo)
+	if err != nil {
+		return err
+	}
+	return s.DB.GitserverRefEvents().Insert(ctx, r.ID, changes, fetchedAt)
+}
+
+// pruneRefEvents deletes the ref events of fetches older than
+// refEventsRetention. Every gitserver prunes, which is harmless since deleting
+// is idempotent.
+func (s *Server) pruneRefEvents(ctx context.Context) error {
+	_, err := s.DB.GitserverRefEvents().DeleteBefore(ctx, time.Now().Add(-refEventsRetention))
+	return err
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDiffRefTips(t *testing.T) {
+	before := map[string]api.CommitID{
+		"refs/heads/main":    "a",
+		"refs/heads/old":     "b",
+		"refs/tags/v1":       "c",
+		"refs/heads/feature": "d",
+	}
+	after := map[string]api.CommitID{
+		"refs/heads/main":    "e",
+		"refs/heads/new":     "f",
+		"refs/tags/v1":       "c",
+		"refs/heads/feature": "d",
+	}
+	require.Equal(t, []database.RefChange{
+		{Name: "refs/heads/main", OldCommit: "a", NewCommit: "e"},
+		{Name: "refs/heads/new", NewCommit: "f"},
+		{Name: "refs/heads/old", OldCommit: "b"},
+	}, diffRefTips(before, after))
+
+	require.Empty(t, diffRefTips(after, after))
+}
+
+func TestRecordRefChanges(t *testing.T) {
+	ctx := context.Background()
+	reposDir := t.TempDir()
+	repoName := api.RepoName("example.com/foo/bar")
+
+	repoDir := filepath.Join(reposDir, string(repoName))
+	require.NoError(t, os.MkdirAll(repoDir, os.ModePerm))
+	cmd := func(name string, arg ...string) string {
+		return runCmd(t, repoDir, name, arg...)
+	}
+	cmd("git", "init", ".")
+
+	repos := database.NewMockRepoStore()
+	repos.GetByNameFunc.SetDefaultReturn(&types.Repo{ID: 1, Name: repoName}, nil)
+	refEvents := database.NewMockGitserverRefEventStore()
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+	db.GitserverRefEventsFunc.SetDefaultReturn(refEvents)
+
+	s := &Server{ReposDir: reposDir, DB: db}
+	dir := s.dir(repoName)
+
+	before, err := refTips(ctx, dir)
+	require.NoError(t, err)
+	require.Empty(t, before)
+
+	cmd("git", "commit", "--allow-empty", "-m", "init")
+	head := api.CommitID(strings.TrimSpace(cmd("git", "rev-parse", "HEAD")))
+	branch := strings.TrimSpace(cmd("git", "symbolic-ref", "HEAD"))
+	cmd("git", "tag", "v1")
+
+	fetchedAt := time.Now()
+	require.NoError(t, s.recordRefChanges(ctx, repoName, before, fetchedAt))
+	history := refEvents.InsertFunc.History()
+	require.Len(t, history, 1)
+	require.Equal(t, api.RepoID(1), history[0].Arg1)
+	require.Equal(t, []database.RefChange{
+		{Name: branch, NewCommit: head},
+		{Name: "refs/tags/v1", NewCommit: head},
+	}, history[0].Arg2)
+	require.Equal(t, fetchedAt, history[0].Arg3)
+
+	// No event is recorded if no ref moved.
+	before, err = refTips(ctx, dir)
+	require.NoError(t, err)
+	require.NoError(t, s.recordRefChanges(ctx, repoName, before, time.Now()))
+	require.Len(t, refEvents.InsertFunc.History(), 1)
+
+	cmd("git", "tag", "-d", "v1")
+	require.NoError(t, s.recordRefChanges(ctx, repoName, before, time.Now()))
+	history = refEvents.InsertFunc.History()
+	require.Len(t, history, 2)
+	require.Equal(t, []database.RefChange{{Name: "refs/tags/v1", OldCommit: head}}, history[1].Arg2)
+}
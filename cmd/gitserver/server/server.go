@@ -235,6 +235,12 @@ type Server struct {
 	// GetRemoteURLFunc being nil.
 	GetRemoteURLFunc func(context.Context, api.RepoName) (string, error)
 
+	// GetMirrorURLFunc is a function which returns the URL of the secondary
+	// remote to push all refs of a repository to after each update, or "" if
+	// the repository isn't mirrored. It may be nil, in which case no
+	// repository is mirrored.
+	GetMirrorURLFunc func(context.Context, api.RepoName) (string, error)
+
 	// GetVCSSyncer is a function which returns the VCS syncer for a repository.
 	// This is used when cloning or fetching a repository. In production this will
 	// speak to the database to determine the code host type. In tests this is
@@ -279,6 +285,24 @@ type Server struct {
 	repoUpdateLocksMu sync.Mutex // protects the map below and also updates to locks.once
 	repoUpdateLocks   map[api.RepoName]*locks
 
+	// healthChecks holds the repos the janitor found due for a health check
+	// until a worker started by StartHealthChecker checks them.
+	healthChecksMu      sync.Mutex // protects the fields below
+	healthChecks        chan common.GitDir
+	healthChecksPending map[common.GitDir]struct{} // repos in healthChecks or being checked
+
+	// commitIndexBuilds holds the repos without a commit index until a worker
+	// started by StartCommitIndexer builds it.
+	commitIndexBuildsMu      sync.Mutex // protects the fields below
+	commitIndexBuilds        chan api.RepoName
+	commitIndexBuildsPending map[api.RepoName]struct{} // repos in commitIndexBuilds or being indexed
+
+	// pathIndexUpdates holds the repos whose path index is out of date until
+	// a worker started by StartPathIndexer updates it.
+	pathIndexUpdatesMu      sync.Mutex // protects the fields below
+	pathIndexUpdates        chan api.RepoName
+	pathIndexUpdatesPending map[api.RepoName]struct{} // repos in pathIndexUpdates
+
 	// GlobalBatchLogSemaphore is a semaphore shared between all requests to ensure that a
 	// maximum number of Git subprocesses are active for all /batch-log requests combined.
 	GlobalBatchLogSemaphore *semaphore.Weighted
@@ -505,6 +529,7 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/delete", trace.WithRouteName("delete", s.handleRepoDelete))
 	mux.HandleFunc("/repo-update", trace.WithRouteName("repo-update", s.handleRepoUpdate))
 	mux.HandleFunc("/repo-clone", trace.WithRouteName("repo-clone", s.handleRepoClone))
+	mux.HandleFunc("/lfs-object", trace.WithRouteName("lfs-object", s.handleReadLFSObject))
 	mux.HandleFunc("/create-commit-from-patch-binary", trace.WithRouteName("create-commit-from-patch-binary", s.handleCreateCommitFromPatchBinary))
 	mux.HandleFunc("/ping", trace.WithRouteName("ping", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
@@ -561,6 +586,18 @@ func (s *Server) Janitor(ctx context.Context, interval time.Duration) {
 	for {
 		gitserverAddrs := gitserver.NewGitserverAddressesFromConf(conf.Get())
 		s.cleanupRepos(actor.WithInternalActor(ctx), gitserverAddrs)
+		if err := s.pruneRefEvents(ctx); err != nil {
+			s.Logger.Error("pruning ref events", log.Error(err))
+		}
+		if err := s.retryMirrors(ctx); err != nil {
+			s.Logger.Error("retrying pushes to mirrors", log.Error(err))
+		}
+		if err := s.cleanupLFSCache(); err != nil {
+			s.Logger.Error("cleaning up LFS cache", log.Error(err))
+		}
+		if err := s.cleanupBlameCache(); err != nil {
+			s.Logger.Error("cleaning up blame cache", log.Error(err))
+		}
 		time.Sleep(interval)
 	}
 }
@@ -604,6 +641,36 @@ func (s *Server) addrForRepo(repoName api.RepoName, gitServerAddrs gitserver.Git
 	return gitServerAddrs.AddrForRepo(filepath.Base(os.Args[0]), repoName)
 }
 
+// holdsRepo returns true if this gitserver is the primary gitserver of the
+// repo or one of its replicas.
+func (s *Server) holdsRepo(repoName api.RepoName, gitServerAddrs gitserver.GitserverAddresses) bool {
+	for _, addr := range gitServerAddrs.AddrsForRepo(filepath.Base(os.Args[0]), repoName) {
+		if s.hostnameMatch(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// gitserverAddresses returns the gitserver addresses of the current site
+// configuration.
+var gitserverAddresses = conf.Cached(func() gitserver.GitserverAddresses {
+	return gitserver.NewGitserverAddressesFromConf(conf.Get())
+})
+
+// isReplica returns true if this gitserver holds the repo as a replica of its
+// primary gitserver. The state of the repo in the database is the state on its
+// primary gitserver, so replicas don't record it.
+func (s *Server) isReplica(repoName api.RepoName) bool {
+	addrs := gitserverAddresses().AddrsForRepo(filepath.Base(os.Args[0]), repoName)
+	for i, addr := range addrs {
+		if i > 0 && s.hostnameMatch(addr) {
+			return true
+		}
+	}
+	return false
+}
+
 // StartClonePipeline clones repos asynchronously. It creates a producer-consumer
 // pipeline.
 func (s *Server) StartClonePipeline(ctx context.Context) {
@@ -823,6 +890,18 @@ func (s *Server) syncRepoState(gitServerAddrs gitserver.GitserverAddresses, batc
 			cloned := repoCloned(dir)
 			_, cloning := s.locker.Status(dir)
 
+			// A repo which moved to this gitserver stays owned by the
+			// gitserver which owned it before until we cloned it from there.
+			if !cloned && repo.ShardID != s.Hostname && repo.CloneStatus == types.CloneStatusCloned && peerAddr(repo.ShardID, addrs) != "" {
+				repoSyncStateCounter.WithLabelValues("rebalance").Inc()
+				if !cloning {
+					if _, err := s.cloneRepo(ctx, repo.Name, nil); err != nil {
+						s.Logger.Warn("failed to copy repo from previous gitserver", log.String("repo", string(repo.Name)), log.Error(err))
+					}
+				}
+				continue
+			}
+
 			var shouldUpdate bool
 			if repo.ShardID != s.Hostname {
 				repo.ShardID = s.Hostname
@@ -918,6 +997,18 @@ func (s *Server) getRemoteURL(ctx context.Context, name api.RepoName) (*vcs.URL,
 	return vcs.ParseURL(remoteURL)
 }
 
+// partialCloneRemoteURL returns the URL of the code host the partial clone of
+// repo fetches the objects it is missing from, or nil if it can't be
+// determined. Without it, the objects are fetched without credentials.
+func (s *Server) partialCloneRemoteURL(ctx context.Context, repo api.RepoName) *vcs.URL {
+	remoteURL, err := s.getRemoteURL(actor.WithInternalActor(ctx), repo)
+	if err != nil {
+		s.Logger.Warn("failed to get remote URL of partial clone", log.String("repo", string(repo)), log.Error(err))
+		return nil
+	}
+	return remoteURL
+}
+
 // acquireCloneLimiter() acquires a cancellable context associated with the
 // clone limiter.
 func (s *Server) acquireCloneLimiter(ctx context.Context) (context.Context, context.CancelFunc, error) {
@@ -958,12 +1049,13 @@ func tempDir(reposDir, prefix string) (name string, err error) {
 }
 
 func (s *Server) ignorePath(path string) bool {
-	// We ignore any path which starts with .tmp or .p4home in ReposDir
+	// We ignore any path which starts with .tmp, .p4home, .lfs-cache or
+	// .blame-cache in ReposDir
 	if filepath.Dir(path) != s.ReposDir {
 		return false
 	}
 	base := filepath.Base(path)
-	return strings.HasPrefix(base, tempDirName) || strings.HasPrefix(base, P4HomeName)
+	return strings.HasPrefix(base, tempDirName) || strings.HasPrefix(base, P4HomeName) || strings.HasPrefix(base, lfsCacheDirName) || strings.HasPrefix(base, blameCacheDirName)
 }
 
 func (s *Server) handleIsRepoCloneable(w http.ResponseWriter, r *http.Request) {
@@ -1419,6 +1511,7 @@ func (s *Server) search(ctx context.Context, args *protocol.SearchRequest, onMat
 		Query:                mt,
 		IncludeDiff:          args.IncludeDiff,
 		IncludeModifiedFiles: args.IncludeModifiedFiles || hasDiffModifiesFile,
+		UseCommitIndex:       enableCommitIndex,
 	}
 
 	return hitLimit.Load(), searcher.Search(ctx, limitedOnMatch)
@@ -1785,6 +1878,19 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 		}
 	}
 
+	// Partial clones fetch the objects commands read from their promisor
+	// remote.
+	partialClone := isPartialClone(dir)
+	var remoteURL *vcs.URL
+	if partialClone {
+		remoteURL = s.partialCloneRemoteURL(ctx, req.Repo)
+	}
+	if partialClone && len(req.Args) > 0 && req.Args[0] == "archive" {
+		if err := fetchMissingArchiveObjects(ctx, dir, remoteURL, req.Args); err != nil {
+			logger.Warn("failed to fetch missing objects of archive", log.Error(err))
+		}
+	}
+
 	var stderrBuf bytes.Buffer
 	stdoutW := &writeCounter{w: w}
 	stderrW := &writeCounter{w: &limitWriter{W: &stderrBuf, N: 1024}}
@@ -1792,6 +1898,9 @@ func (s *Server) exec(ctx context.Context, logger log.Logger, req *protocol.Exec
 	cmdStart = time.Now()
 	cmd := s.recordingCommandFactory.Command(ctx, s.Logger, "git", req.Args...)
 	dir.Set(cmd.Unwrap())
+	if partialClone {
+		configurePromisorGitCommand(cmd.Unwrap(), remoteURL)
+	}
 	cmd.Unwrap().Stdout = stdoutW
 	cmd.Unwrap().Stderr = stderrW
 	cmd.Unwrap().Stdin = bytes.NewReader(req.Stdin)
@@ -2035,6 +2144,10 @@ func (s *Server) p4Exec(ctx context.Context, logger log.Logger, req *protocol.P4
 }
 
 func (s *Server) setLastFetched(ctx context.Context, name api.RepoName) error {
+	if s.isReplica(name) {
+		return nil
+	}
+
 	dir := s.dir(name)
 
 	lastFetched, err := repoLastFetched(dir)
@@ -2056,6 +2169,10 @@ func (s *Server) setLastFetched(ctx context.Context, name api.RepoName) error {
 
 // setLastErrorNonFatal will set the last_error column for the repo in the gitserver table.
 func (s *Server) setLastErrorNonFatal(ctx context.Context, name api.RepoName, err error) {
+	if s.isReplica(name) {
+		return
+	}
+
 	var errString string
 	if err != nil {
 		errString = err.Error()
@@ -2067,6 +2184,9 @@ func (s *Server) setLastErrorNonFatal(ctx context.Context, name api.RepoName, er
 }
 
 func (s *Server) setCloneStatus(ctx context.Context, name api.RepoName, status types.CloneStatus) (err error) {
+	if s.isReplica(name) {
+		return nil
+	}
 	return s.DB.GitserverRepos().SetCloneStatus(ctx, name, status, s.Hostname)
 }
 
@@ -2079,6 +2199,9 @@ func (s *Server) setCloneStatusNonFatal(ctx context.Context, name api.RepoName,
 
 // setRepoSize calculates the size of the repo and stores it in the database.
 func (s *Server) setRepoSize(ctx context.Context, name api.RepoName) error {
+	if s.isReplica(name) {
+		return nil
+	}
 	return s.DB.GitserverRepos().SetRepoSize(ctx, name, dirSize(s.dir(name).Path(".")), s.Hostname)
 }
 
@@ -2132,6 +2255,13 @@ type cloneOptions struct {
 	// repository. If this is a non-zero string, then gitserver will attempt to clone the repo from
 	// that gitserver instance instead of the upstream repo URL of the external service.
 	CloneFromShard string
+
+	// CopyFromPeer is the address of the gitserver which owned the repository
+	// before it moved to this gitserver. If it is set, the repository is copied
+	// from that gitserver and only cloned from the code host if that fails.
+	// That gitserver stays the owner of the repository until the copy is
+	// complete.
+	CopyFromPeer string
 }
 
 // cloneRepo performs a clone operation for the given repository. It is
@@ -2178,6 +2308,17 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 		if err != nil {
 			return "", err
 		}
+
+		// If the repo moved to this gitserver, copy it from the gitserver
+		// which owned it before rather than cloning it from the code host.
+		if peer := s.rebalanceSource(ctx, repo, syncer); peer != "" {
+			o := cloneOptions{}
+			if opts != nil {
+				o = *opts
+			}
+			o.CopyFromPeer = peer
+			opts = &o
+		}
 	}
 
 	// isCloneable causes a network request, so we limit the number that can
@@ -2195,9 +2336,13 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 		return "", err
 	}
 
-	if err := syncer.IsCloneable(ctx, remoteURL); err != nil {
-		redactedErr := newURLRedactor(remoteURL).redact(err.Error())
-		return "", errors.Errorf("error cloning repo: repo %s not cloneable: %s", repo, redactedErr)
+	// The gitserver we copy from already cloned the repo, so we don't need to
+	// check with the code host.
+	if opts == nil || opts.CopyFromPeer == "" {
+		if err := syncer.IsCloneable(ctx, remoteURL); err != nil {
+			redactedErr := newURLRedactor(remoteURL).redact(err.Error())
+			return "", errors.Errorf("error cloning repo: repo %s not cloneable: %s", repo, redactedErr)
+		}
 	}
 
 	// Mark this repo as currently being cloned. We have to check again if someone else isn't already
@@ -2283,8 +2428,29 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir common.GitD
 	tmpPath = filepath.Join(tmpPath, ".git")
 	tmp := common.GitDir(tmpPath)
 
+	// A repo we copy from another gitserver stays owned by it until the copy
+	// is complete, so we only mark it as cloning if we clone it ourselves.
+	copied := false
+	if opts != nil && opts.CopyFromPeer != "" {
+		logger.Info("copying repo", log.String("peer", opts.CopyFromPeer), log.String("tmp", tmpPath), log.String("dst", dstPath))
+		if err := s.copyRepoFromPeer(ctx, logger, repo, opts.CopyFromPeer, lock, tmp); err != nil {
+			logger.Warn("failed to copy repo, cloning it from the code host", log.String("peer", opts.CopyFromPeer), log.Error(err))
+			if err := os.RemoveAll(tmpPath); err != nil {
+				return err
+			}
+		} else {
+			copied = true
+		}
+		defer func() {
+			// Use a background context to ensure we still update the DB even if we time out
+			if err := s.DB.GitserverRepos().EndTransfer(context.Background(), repo, s.Hostname, repoCloned(dir)); err != nil {
+				logger.Warn("failed to end transfer", log.Error(err))
+			}
+		}()
+	}
+
 	// It may already be cloned
-	if !repoCloned(dir) {
+	if !copied && !repoCloned(dir) {
 		s.setCloneStatusNonFatal(ctx, repo, types.CloneStatusCloning)
 	}
 	defer func() {
@@ -2292,25 +2458,27 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir common.GitD
 		s.setCloneStatusNonFatal(context.Background(), repo, cloneStatus(repoCloned(dir), false))
 	}()
 
-	cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
-	if err != nil {
-		return errors.Wrap(err, "get clone command")
-	}
-	if cmd.Env == nil {
-		cmd.Env = os.Environ()
-	}
+	if !copied {
+		cmd, err := syncer.CloneCommand(ctx, remoteURL, tmpPath)
+		if err != nil {
+			return errors.Wrap(err, "get clone command")
+		}
+		if cmd.Env == nil {
+			cmd.Env = os.Environ()
+		}
 
-	// see issue #7322: skip LFS content in repositories with Git LFS configured
-	cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
-	logger.Info("cloning repo", log.String("tmp", tmpPath), log.String("dst", dstPath))
+		// see issue #7322: skip LFS content in repositories with Git LFS configured
+		cmd.Env = append(cmd.Env, "GIT_LFS_SKIP_SMUDGE=1")
+		logger.Info("cloning repo", log.String("tmp", tmpPath), log.String("dst", dstPath))
 
-	pr, pw := io.Pipe()
-	defer pw.Close()
+		pr, pw := io.Pipe()
+		defer pw.Close()
 
-	go readCloneProgress(s.DB, logger, newURLRedactor(remoteURL), lock, pr, repo)
+		go readCloneProgress(s.DB, logger, newURLRedactor(remoteURL), lock, pr, repo)
 
-	if output, err := runRemoteGitCommand(ctx, s.recordingCommandFactory.Wrap(ctx, s.Logger, cmd), true, pw); err != nil {
-		return errors.Wrapf(err, "clone failed. Output: %s", string(output))
+		if output, err := runRemoteGitCommand(ctx, s.recordingCommandFactory.Wrap(ctx, s.Logger, cmd), true, pw); err != nil {
+			return errors.Wrapf(err, "clone failed. Output: %s", string(output))
+		}
 	}
 
 	if testRepoCorrupter != nil {
@@ -2319,9 +2487,36 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir common.GitD
 
 	removeBadRefs(ctx, tmp)
 
-	if err := setHEAD(ctx, logger, s.recordingCommandFactory, tmp, syncer, remoteURL); err != nil {
-		logger.Warn("Failed to ensure HEAD exists", log.Error(err))
-		return errors.Wrap(err, "failed to ensure HEAD exists")
+	// A copied repo already has the HEAD of the gitserver we copied it from.
+	if !copied {
+		if err := setHEAD(ctx, logger, s.recordingCommandFactory, tmp, syncer, remoteURL); err != nil {
+			logger.Warn("Failed to ensure HEAD exists", log.Error(err))
+			return errors.Wrap(err, "failed to ensure HEAD exists")
+		}
+	}
+
+	if gs, ok := syncer.(*GitRepoSyncer); ok {
+		// A repo copied or cloned from another gitserver must fetch the
+		// objects it is missing from the code host rather than from that
+		// gitserver.
+		promisorURL := remoteURL
+		if copied || (opts != nil && opts.CloneFromShard != "") {
+			promisorURL, err = s.getRemoteURL(actor.WithInternalActor(ctx), repo)
+			if err != nil {
+				return errors.Wrap(err, "get remote URL of code host")
+			}
+			if err := gs.configurePromisorRemote(ctx, tmp, promisorURL); err != nil {
+				return errors.Wrap(err, "failed to configure promisor remote")
+			}
+		}
+
+		// Partial clones fetch the files of their sparse paths of HEAD. A
+		// copied repo already has them.
+		if !copied {
+			if err := gs.fetchSparsePaths(ctx, tmp, promisorURL); err != nil {
+				return errors.Wrap(err, "failed to fetch sparse paths")
+			}
+		}
 	}
 
 	if err := setRepositoryType(tmp, syncer.Type()); err != nil {
@@ -2369,6 +2564,20 @@ func (s *Server) doClone(ctx context.Context, repo api.RepoName, dir common.GitD
 		logger.Warn("failed setting repo size", log.Error(err))
 	}
 
+	// Successfully updated, best-effort update of the path index.
+	s.queuePathIndexUpdate(repo)
+
+	// Successfully updated, best-effort update of the commit index.
+	if err := s.updateCommitIndex(ctx, repo); err != nil {
+		logger.Warn("failed updating commit index", log.Error(err))
+	}
+
+	// Successfully cloned, best-effort push to the secondary remote. Failed
+	// pushes are retried by the janitor or on later updates.
+	if err := s.mirrorRepo(ctx, repo); err != nil {
+		logger.Warn("failed pushing to mirror", log.Error(err))
+	}
+
 	logger.Info("repo cloned")
 	repoClonedCounter.Inc()
 
@@ -2597,14 +2806,7 @@ func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec st
 	defer tr.FinishWithErr(&err)
 
 	s.repoUpdateLocksMu.Lock()
-	l, ok := s.repoUpdateLocks[repo]
-	if !ok {
-		l = &locks{
-			once: new(sync.Once),
-			mu:   new(sync.Mutex),
-		}
-		s.repoUpdateLocks[repo] = l
-	}
+	l := s.repoUpdateLocksLocked(repo)
 	once := l.once
 	mu := l.mu
 	s.repoUpdateLocksMu.Unlock()
@@ -2650,6 +2852,33 @@ func (s *Server) doRepoUpdate(ctx context.Context, repo api.RepoName, revspec st
 	}
 }
 
+// repoUpdateLocksLocked returns the update locks of repo. s.repoUpdateLocksMu
+// must be held.
+func (s *Server) repoUpdateLocksLocked(repo api.RepoName) *locks {
+	l, ok := s.repoUpdateLocks[repo]
+	if !ok {
+		l = &locks{
+			once: new(sync.Once),
+			mu:   new(sync.Mutex),
+		}
+		s.repoUpdateLocks[repo] = l
+	}
+	return l
+}
+
+// tryLockRepoUpdate prevents updates of repo until unlock is called. ok is
+// false if repo is being updated.
+func (s *Server) tryLockRepoUpdate(repo api.RepoName) (unlock func(), ok bool) {
+	s.repoUpdateLocksMu.Lock()
+	mu := s.repoUpdateLocksLocked(repo).mu
+	s.repoUpdateLocksMu.Unlock()
+
+	if !mu.TryLock() {
+		return nil, false
+	}
+	return mu.Unlock, true
+}
+
 var doBackgroundRepoUpdateMock func(api.RepoName) error
 
 func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error {
@@ -2698,7 +2927,19 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 	// when the cleanup happens, just that it does.
 	defer s.cleanTmpFiles(dir)
 
+	// Remember where the refs pointed before fetching, so that we can record
+	// which refs the fetch moved. Only the primary gitserver of repo records
+	// them.
+	var refsBefore map[string]api.CommitID
+	if !s.isReplica(repo) {
+		refsBefore, err = refTips(ctx, dir)
+		if err != nil {
+			logger.Warn("failed to list refs before fetch", log.Error(err))
+		}
+	}
+
 	output, err := syncer.Fetch(ctx, remoteURL, dir, revspec)
+	fetchedAt := time.Now()
 	if err != nil {
 		if output != nil {
 			return errors.Wrapf(err, "failed to fetch repo %q with output %q", repo, newURLRedactor(remoteURL).redact(string(output)))
@@ -2733,6 +2974,29 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName, revspec string) error
 		logger.Warn("failed to set repo size", log.Error(err))
 	}
 
+	// Successfully updated, best-effort update of the path index.
+	s.queuePathIndexUpdate(repo)
+
+	// Successfully updated, best-effort update of the commit index.
+	if err := s.updateCommitIndex(ctx, repo); err != nil {
+		logger.Warn("failed to update commit index", log.Error(err))
+	}
+
+	// Successfully updated, best-effort push to the secondary remote. Failed
+	// pushes are retried by the janitor or on later updates.
+	if err := s.mirrorRepo(ctx, repo); err != nil {
+		logger.Warn("failed to push to mirror", log.Error(err))
+	}
+
+	// Successfully updated, best-effort recording of the refs the fetch moved.
+	// We can't tell which refs moved if listing them before the fetch failed,
+	// and replicas don't list them.
+	if refsBefore != nil {
+		if err := s.recordRefChanges(ctx, repo, refsBefore, fetchedAt); err != nil {
+			logger.Warn("failed to record ref changes", log.Error(err))
+		}
+	}
+
 	return nil
 }
 
@@ -139,6 +139,136 @@ func (gs *GRPCServer) Archive(req *proto.ArchiveRequest, ss proto.GitserverServi
 	return gs.doExec(ss.Context(), gs.Server.Logger, execReq, "unknown-grpc-client", w)
 }
 
+func (gs *GRPCServer) BatchReadFiles(req *proto.BatchReadFilesRequest, ss proto.GitserverService_BatchReadFilesServer) error {
+	// Log which which actor is accessing the repo.
+	accesslog.Record(ss.Context(), req.GetRepo(),
+		log.String("commit", req.GetCommit()),
+		log.Int("paths", len(req.GetPaths())),
+	)
+
+	if req.GetRepo() == "" {
+		return status.Error(codes.InvalidArgument, "empty repo")
+	}
+	if !isAbsoluteRevision(req.GetCommit()) {
+		return status.Error(codes.InvalidArgument, "commit must be a 40-character commit ID")
+	}
+	for _, path := range req.GetPaths() {
+		if strings.Contains(path, "\n") {
+			return status.Errorf(codes.InvalidArgument, "invalid path %q", path)
+		}
+	}
+
+	repo := protocol.NormalizeRepo(api.RepoName(req.GetRepo()))
+	if notFound, cloned := gs.Server.maybeStartClone(ss.Context(), gs.Server.Logger, repo); !cloned {
+		s, err := status.New(codes.NotFound, "repo not found").WithDetails(&proto.NotFoundPayload{
+			Repo:            string(repo),
+			CloneInProgress: notFound.CloneInProgress,
+			CloneProgress:   notFound.CloneProgress,
+		})
+		if err != nil {
+			gs.Server.Logger.Error("failed to marshal status", log.Error(err))
+			return err
+		}
+		return s.Err()
+	}
+
+	err := gs.Server.batchReadFiles(ss.Context(), gs.Server.Logger, repo, req.GetCommit(), req.GetPaths(), ss.Send)
+	if errors.Is(err, errRevisionNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+func (gs *GRPCServer) ReadLFSObject(req *proto.ReadLFSObjectRequest, ss proto.GitserverService_ReadLFSObjectServer) error {
+	// Log which which actor is accessing the repo.
+	accesslog.Record(ss.Context(), req.GetRepo(), log.String("oid", req.GetOid()))
+
+	var r protocol.ReadLFSObjectRequest
+	r.FromProto(req)
+	r.Repo = protocol.NormalizeRepo(r.Repo)
+	if r.Repo == "" {
+		return status.Error(codes.InvalidArgument, "empty repo")
+	}
+	if !gitdomain.IsValidLFSOID(r.OID) {
+		return status.Errorf(codes.InvalidArgument, "invalid LFS object ID %q", r.OID)
+	}
+
+	w := streamio.NewWriter(func(p []byte) error {
+		return ss.Send(&proto.ReadLFSObjectResponse{
+			Data: p,
+		})
+	})
+	err := gs.Server.readLFSObject(ss.Context(), &r, w)
+	if errors.Is(err, errLFSObjectTooLarge) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return err
+}
+
+func (gs *GRPCServer) Blame(req *proto.BlameRequest, ss proto.GitserverService_BlameServer) error {
+	// Log which which actor is accessing the repo.
+	accesslog.Record(ss.Context(), req.GetRepo(),
+		log.String("commit", req.GetCommit()),
+		log.String("path", req.GetPath()),
+	)
+
+	if req.GetRepo() == "" {
+		return status.Error(codes.InvalidArgument, "empty repo")
+	}
+	if !isAbsoluteRevision(req.GetCommit()) {
+		return status.Error(codes.InvalidArgument, "commit must be a 40-character commit ID")
+	}
+	if req.GetPath() == "" {
+		return status.Error(codes.InvalidArgument, "empty path")
+	}
+
+	repo := protocol.NormalizeRepo(api.RepoName(req.GetRepo()))
+	if notFound, cloned := gs.Server.maybeStartClone(ss.Context(), gs.Server.Logger, repo); !cloned {
+		s, err := status.New(codes.NotFound, "repo not found").WithDetails(&proto.NotFoundPayload{
+			Repo:            string(repo),
+			CloneInProgress: notFound.CloneInProgress,
+			CloneProgress:   notFound.CloneProgress,
+		})
+		if err != nil {
+			gs.Server.Logger.Error("failed to marshal status", log.Error(err))
+			return err
+		}
+		return s.Err()
+	}
+
+	err := gs.Server.blame(ss.Context(), gs.Server.Logger, repo, req.GetCommit(), req.GetPath(), int(req.GetStartLine()), int(req.GetEndLine()), func(h *proto.BlameHunk) error {
+		return ss.Send(&proto.BlameResponse{Hunk: h})
+	})
+	if errors.Is(err, errRevisionNotFound) {
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return err
+}
+
+func (gs *GRPCServer) RepoPack(req *proto.RepoPackRequest, ss proto.GitserverService_RepoPackServer) error {
+	// Log which which actor is accessing the repo.
+	accesslog.Record(ss.Context(), req.GetRepo())
+
+	if req.GetRepo() == "" {
+		return status.Error(codes.InvalidArgument, "empty repo")
+	}
+
+	repo := protocol.NormalizeRepo(api.RepoName(req.GetRepo()))
+	dir := gs.Server.dir(repo)
+	if !repoCloned(dir) {
+		s, err := status.New(codes.NotFound, "repo not found").WithDetails(&proto.NotFoundPayload{
+			Repo: string(repo),
+		})
+		if err != nil {
+			gs.Server.Logger.Error("failed to marshal status", log.Error(err))
+			return err
+		}
+		return s.Err()
+	}
+
+	return packRepo(ss.Context(), dir, ss.Send)
+}
+
 // doExec executes the given git command and streams the output to the given writer.
 //
 // Note: This function wraps the underlying exec implementation and returns grpc specific error handling.
@@ -572,6 +572,7 @@ func makeTestServer(ctx context.Context, t *testing.T, repoDir, remote string, d
 		CloneQueue:              NewCloneQueue(obctx, list.New()),
 		ctx:                     ctx,
 		locker:                  &RepositoryLocker{},
+		repoUpdateLocks:         make(map[api.RepoName]*locks),
 		cloneLimiter:            limiter.NewMutable(1),
 		cloneableLimiter:        limiter.NewMutable(1),
 		rpsLimiter:              ratelimit.NewInstrumentedLimiter("GitserverTest", rate.NewLimiter(rate.Inf, 10)),
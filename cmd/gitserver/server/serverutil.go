@@ -11,6 +11,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -320,3 +321,58 @@ func bestEffortWalk(root string, walkFn func(path string, entry fs.DirEntry) err
 		return walkFn(path, d)
 	})
 }
+
+// trimCache deletes the least recently modified files under the cache
+// directory dir until the files under it are no larger than limit bytes in
+// total. Caches mark the files they read as recently used by updating their
+// mtime.
+func (s *Server) trimCache(dir string, limit int64) error {
+	type file struct {
+		path    string
+		size    int64
+		modTime time.Time
+	}
+
+	var (
+		files []file
+		total int64
+	)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		fi, err := d.Info()
+		if err != nil {
+			return err
+		}
+		files = append(files, file{path: path, size: fi.Size(), modTime: fi.ModTime()})
+		total += fi.Size()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for _, f := range files {
+		if total <= limit {
+			break
+		}
+		if err := os.Remove(f.path); err != nil {
+			return err
+		}
+		// Remove the directory of the file if it is now empty.
+		_ = os.Remove(filepath.Dir(f.path))
+		total -= f.size
+		s.Logger.Debug("evicted cached file", log.String("path", f.path))
+	}
+	return nil
+}
@@ -52,7 +52,14 @@ git reflog expire --all
 # instances. Restricting the memory consumption by setting pack.windowMemory,
 # pack.deltaCacheSize and pack.threads in addition to --geometric=2 seemed to
 # have no effect.
-git repack -d -l -A --write-bitmap-index --window-memory 100m --unpack-unreachable=now
+#
+# Partial clones (SG_PARTIAL_CLONE is set) are missing objects, so we can't
+# write a bitmap index for them.
+if [ -n "${SG_PARTIAL_CLONE:-}" ]; then
+  git repack -d -l -A --window-memory 100m --unpack-unreachable=now
+else
+  git repack -d -l -A --write-bitmap-index --window-memory 100m --unpack-unreachable=now
+fi
 
 # With the --changed-paths option, compute and write information about the
 # paths changed between a commit and its first parent. This operation can take
@@ -1,9 +1,14 @@
 package server
 
 import (
+	"bufio"
+	"bytes"
 	"context"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/sourcegraph/log"
 
@@ -14,7 +19,28 @@ import (
 )
 
 // GitRepoSyncer is a syncer for Git repositories.
-type GitRepoSyncer struct{}
+type GitRepoSyncer struct {
+	// CloneStrategy is how the repository is cloned if it is too large to
+	// clone in full. Repositories are cloned in full if it is nil.
+	CloneStrategy *CloneStrategy
+}
+
+// CloneStrategy describes a partial clone of a repository. Objects which are
+// left out of a partial clone are fetched from the code host when they are
+// read.
+type CloneStrategy struct {
+	// Filter is the filter for the objects to clone, as passed to
+	// git fetch --filter. For example, "blob:none" clones commits and trees.
+	Filter string
+
+	// Depth is the number of commits of history to clone. The full history
+	// is cloned if it is 0.
+	Depth int
+
+	// SparsePaths are the directories whose files are fetched together with
+	// the commits of HEAD rather than when they are read.
+	SparsePaths []string
+}
 
 func (s *GitRepoSyncer) Type() string {
 	return "git"
@@ -59,6 +85,10 @@ func (s *GitRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tm
 		return nil, errors.Wrapf(&common.GitCommandError{Err: err}, "clone setup failed")
 	}
 
+	if err := s.configurePromisorRemote(ctx, common.GitDir(tmpPath), remoteURL); err != nil {
+		return nil, errors.Wrap(err, "clone failed to configure promisor remote")
+	}
+
 	cmd, _ = s.fetchCommand(ctx, remoteURL)
 	cmd.Dir = tmpPath
 	return cmd, nil
@@ -71,9 +101,65 @@ func (s *GitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir commo
 	if output, err := runRemoteGitCommand(ctx, wrexec.Wrap(ctx, log.NoOp(), cmd), configRemoteOpts, nil); err != nil {
 		return nil, &common.GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(output))}
 	}
+	if err := s.fetchSparsePaths(ctx, dir, remoteURL); err != nil {
+		return nil, err
+	}
 	return nil, nil
 }
 
+// Refetch fetches all objects of a Git repository from the remote again,
+// rather than only the objects of the refs which moved. It repairs
+// repositories which are missing objects.
+func (s *GitRepoSyncer) Refetch(ctx context.Context, remoteURL *vcs.URL, dir common.GitDir) error {
+	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL)
+	if len(cmd.Args) < 2 || filepath.Base(cmd.Args[0]) != "git" || cmd.Args[1] != "fetch" {
+		return errors.New("refetching is not supported with a custom fetch command")
+	}
+	cmd.Args = append([]string{cmd.Args[0], "fetch", "--refetch"}, cmd.Args[2:]...)
+	dir.Set(cmd)
+	if output, err := runRemoteGitCommand(ctx, wrexec.Wrap(ctx, log.NoOp(), cmd), configRemoteOpts, nil); err != nil {
+		return &common.GitCommandError{Err: err, Output: newURLRedactor(remoteURL).redact(string(output))}
+	}
+	return nil
+}
+
+// fetchSparsePaths fetches the files of the sparse paths of HEAD which the
+// partial clone in dir is missing from remoteURL.
+func (s *GitRepoSyncer) fetchSparsePaths(ctx context.Context, dir common.GitDir, remoteURL *vcs.URL) error {
+	if s.CloneStrategy == nil || len(s.CloneStrategy.SparsePaths) == 0 {
+		return nil
+	}
+	return fetchMissingObjects(ctx, dir, remoteURL, "HEAD", s.CloneStrategy.SparsePaths)
+}
+
+// configurePromisorRemote configures remoteURL as the remote the clone in dir
+// fetches from, which partial clones also lazily fetch missing objects from.
+// It is called once when the repository is cloned. Only the URL without its
+// credentials is stored in the config of the repository. Commands which fetch
+// from the remote get the credentials with promisorRemoteEnv instead.
+func (s *GitRepoSyncer) configurePromisorRemote(ctx context.Context, dir common.GitDir, remoteURL *vcs.URL) error {
+	if s.CloneStrategy == nil {
+		return nil
+	}
+
+	config := [][2]string{
+		{"remote." + promisorRemote + ".url", promisorRemoteURL(remoteURL).String()},
+	}
+	if s.CloneStrategy.Filter != "" {
+		config = append(config,
+			[2]string{"remote." + promisorRemote + ".promisor", "true"},
+			[2]string{"remote." + promisorRemote + ".partialclonefilter", s.CloneStrategy.Filter})
+	}
+	for _, kv := range config {
+		cmd := exec.CommandContext(ctx, "git", "config", "--replace-all", kv[0], kv[1])
+		dir.Set(cmd)
+		if err := cmd.Run(); err != nil {
+			return errors.Wrapf(wrapCmdError(cmd, err), "set %s", kv[0])
+		}
+	}
+	return nil
+}
+
 // RemoteShowCommand returns the command to be executed for showing remote of a Git repository.
 func (s *GitRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
 	return exec.CommandContext(ctx, "git", "remote", "show", remoteURL.String()), nil
@@ -84,6 +170,9 @@ func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (c
 	if customCmd := customFetchCmd(ctx, remoteURL); customCmd != nil {
 		cmd = customCmd
 		configRemoteOpts = false
+	} else if s.CloneStrategy != nil {
+		cmd = s.CloneStrategy.fetchCommand(ctx, remoteURL)
+		configRemoteOpts = false
 	} else if useRefspecOverrides() {
 		cmd = refspecOverridesFetchCmd(ctx, remoteURL)
 	} else {
@@ -104,3 +193,151 @@ func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (c
 	}
 	return cmd, configRemoteOpts
 }
+
+// fetchCommand returns the command fetching the partial clone from remoteURL.
+// It fetches from the promisor remote, which is the remote objects missing
+// from the clone are fetched from. See configurePromisorRemote.
+func (c *CloneStrategy) fetchCommand(ctx context.Context, remoteURL *vcs.URL) *exec.Cmd {
+	args := []string{"fetch", "--progress", "--prune"}
+	if c.Filter != "" {
+		args = append(args, "--filter="+c.Filter)
+	}
+	if c.Depth > 0 {
+		args = append(args, "--depth="+strconv.Itoa(c.Depth))
+	}
+	args = append(args, promisorRemote,
+		"+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*",
+		"+refs/pull/*:refs/pull/*",
+		"+refs/merge-requests/*:refs/merge-requests/*",
+		"+refs/pull-requests/*:refs/pull-requests/*",
+		"+refs/changes/*:refs/changes/*",
+		"+refs/sourcegraph/*:refs/sourcegraph/*")
+	cmd := exec.CommandContext(ctx, "git", args...)
+	configurePromisorGitCommand(cmd, remoteURL)
+	return cmd
+}
+
+// promisorRemote is the name of the remote partial clones fetch from.
+const promisorRemote = "origin"
+
+// promisorRemoteURL returns remoteURL without the credentials of HTTP URLs,
+// which is the URL of the promisor remote stored in a partial clone.
+func promisorRemoteURL(remoteURL *vcs.URL) *vcs.URL {
+	u := *remoteURL
+	if u.Scheme == "http" || u.Scheme == "https" {
+		u.User = nil
+	}
+	return &u
+}
+
+// promisorRemoteEnv returns the environment of a git command which may fetch
+// objects from the promisor remote of a partial clone, like
+// remoteGitCommandEnv. The command rewrites the URL of the promisor remote to
+// remoteURL, which passes the credentials of the remote to it like the remote
+// URL passes them to other fetches. remoteURL may be nil if it is unknown.
+func promisorRemoteEnv(remoteURL *vcs.URL) []string {
+	env := remoteGitCommandEnv(tlsExternal())
+	if remoteURL == nil {
+		return env
+	}
+	if u := promisorRemoteURL(remoteURL); u.String() != remoteURL.String() {
+		env = append(env,
+			"GIT_CONFIG_COUNT=1",
+			"GIT_CONFIG_KEY_0=url."+remoteURL.String()+".insteadOf",
+			"GIT_CONFIG_VALUE_0="+u.String(),
+		)
+	}
+	return env
+}
+
+// configurePromisorGitCommand configures cmd, which may fetch objects from the
+// promisor remote of a partial clone at remoteURL. See promisorRemoteEnv.
+func configurePromisorGitCommand(cmd *exec.Cmd, remoteURL *vcs.URL) {
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, promisorRemoteEnv(remoteURL)...)
+}
+
+// partialCloneMaintenanceEnv returns the environment of the maintenance
+// commands run in dir, or nil if it is not a partial clone. git repack keeps
+// the objects of promisor packs in promisor packs, but it can't write bitmaps
+// for a partial clone since it is missing objects. The maintenance commands
+// must not fetch the objects a partial clone is missing either.
+func partialCloneMaintenanceEnv(dir common.GitDir) []string {
+	if !isPartialClone(dir) {
+		return nil
+	}
+	return append(os.Environ(),
+		"GIT_NO_LAZY_FETCH=1",
+		"GIT_TERMINAL_PROMPT=0",
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=repack.writeBitmaps",
+		"GIT_CONFIG_VALUE_0=false",
+		// Read by sg_maintenance.sh.
+		"SG_PARTIAL_CLONE=1",
+	)
+}
+
+// isPartialClone returns true if the repository in dir is a partial clone.
+// Packs fetched from a promisor remote have an accompanying .promisor file.
+func isPartialClone(dir common.GitDir) bool {
+	promisors, _ := filepath.Glob(dir.Path("objects", "pack", "*.promisor"))
+	return len(promisors) > 0
+}
+
+// fetchMissingObjects fetches the objects of the tree of rev under paths which
+// the partial clone in dir is missing from remoteURL. It fetches them at once
+// rather than letting git fetch them one by one as they are read.
+func fetchMissingObjects(ctx context.Context, dir common.GitDir, remoteURL *vcs.URL, rev string, paths []string) error {
+	args := append([]string{"rev-list", "--objects", "--missing=print", "--no-walk", rev, "--"}, paths...)
+	cmd := exec.CommandContext(ctx, "git", args...)
+	dir.Set(cmd)
+	out, err := cmd.Output()
+	if err != nil {
+		return errors.Wrap(wrapCmdError(cmd, err), "list missing objects")
+	}
+
+	var missing bytes.Buffer
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.HasPrefix(line, "?") {
+			missing.WriteString(line[1:] + "\n")
+		}
+	}
+	if missing.Len() == 0 {
+		return nil
+	}
+
+	// These are the arguments git uses to fetch missing objects itself.
+	cmd = exec.CommandContext(ctx, "git", "-c", "fetch.negotiationAlgorithm=noop", "fetch", promisorRemote,
+		"--no-tags", "--no-write-fetch-head", "--recurse-submodules=no", "--filter=blob:none", "--stdin")
+	configurePromisorGitCommand(cmd, remoteURL)
+	dir.Set(cmd)
+	cmd.Stdin = &missing
+	if output, err := runRemoteGitCommand(ctx, wrexec.Wrap(ctx, log.NoOp(), cmd), false, nil); err != nil {
+		if remoteURL != nil {
+			output = []byte(newURLRedactor(remoteURL).redact(string(output)))
+		}
+		return &common.GitCommandError{Err: err, Output: string(output)}
+	}
+	return nil
+}
+
+// fetchMissingArchiveObjects fetches the objects the git archive command with
+// args reads which the partial clone in dir is missing from remoteURL.
+func fetchMissingArchiveObjects(ctx context.Context, dir common.GitDir, remoteURL *vcs.URL, args []string) error {
+	// The tree-ish is the first argument which is not a flag, followed by the
+	// paths to archive.
+	for i, arg := range args[1:] {
+		if strings.HasPrefix(arg, "-") {
+			continue
+		}
+		paths := args[i+2:]
+		if len(paths) > 0 && paths[0] == "--" {
+			paths = paths[1:]
+		}
+		return fetchMissingObjects(ctx, dir, remoteURL, arg, paths)
+	}
+	return nil
+}
@@ -10,9 +10,11 @@ import (
 	"os"
 	"os/signal"
 	"path/filepath"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/grafana/regexp"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/sourcegraph/log"
 	"golang.org/x/sync/semaphore"
@@ -142,6 +144,9 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 		GetRemoteURLFunc: func(ctx context.Context, repo api.RepoName) (string, error) {
 			return getRemoteURLFunc(ctx, externalServiceStore, repoStore, repo)
 		},
+		GetMirrorURLFunc: func(ctx context.Context, repo api.RepoName) (string, error) {
+			return getMirrorURL(ctx, externalServiceStore, repoStore, repo)
+		},
 		GetVCSSyncer: func(ctx context.Context, repo api.RepoName) (server.VCSSyncer, error) {
 			return getVCSSyncer(ctx, externalServiceStore, repoStore, dependenciesSvc, repo, config.ReposDir, config.CoursierCacheDir)
 		},
@@ -209,6 +214,9 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 	ready()
 
 	go syncRateLimiters(ctx, logger, externalServiceStore, rateLimitSyncerLimitPerSecond)
+	gitserver.StartHealthChecker(actor.WithInternalActor(ctx))
+	gitserver.StartCommitIndexer(actor.WithInternalActor(ctx))
+	gitserver.StartPathIndexer(actor.WithInternalActor(ctx))
 	go gitserver.Janitor(actor.WithInternalActor(ctx), janitorInterval)
 	go gitserver.SyncRepoState(syncRepoStateInterval, syncRepoStateBatchSize, syncRepoStateUpdatePerSecond)
 
@@ -362,6 +370,59 @@ func getRemoteURLFunc(
 	return "", errors.Errorf("no sources for %q", repo)
 }
 
+// getMirrorURL returns the URL of the secondary remote which the first code
+// host connection of repo that configures "mirrorTo" pushes repo to, or "" if
+// none of them does.
+func getMirrorURL(
+	ctx context.Context,
+	externalServiceStore database.ExternalServiceStore,
+	repoStore database.RepoStore,
+	repo api.RepoName,
+) (string, error) {
+	r, err := repoStore.GetByName(actor.WithInternalActor(ctx), repo)
+	if err != nil {
+		return "", errors.Wrap(err, "get repository")
+	}
+
+	for _, info := range r.Sources {
+		svc, err := externalServiceStore.GetByID(ctx, info.ExternalServiceID())
+		if err != nil {
+			return "", errors.Wrap(err, "get external service")
+		}
+		switch svc.Kind {
+		case extsvc.KindGitHub, extsvc.KindGitLab, extsvc.KindBitbucketServer, extsvc.KindOther:
+		default:
+			continue
+		}
+		rawConfig, err := svc.Config.Decrypt(ctx)
+		if err != nil {
+			return "", err
+		}
+		normalized, err := jsonc.Parse(rawConfig)
+		if err != nil {
+			return "", errors.Wrap(err, "normalize JSON")
+		}
+		// The mirror settings of all of these code host connections have the
+		// same shape.
+		var c struct {
+			MirrorTo *schema.OtherMirrorTo `json:"mirrorTo"`
+		}
+		if err := jsoniter.Unmarshal(normalized, &c); err != nil {
+			return "", errors.Wrap(err, "unmarshal JSON")
+		}
+		if c.MirrorTo != nil && c.MirrorTo.Url != "" {
+			return mirrorURL(c.MirrorTo.Url, repo), nil
+		}
+	}
+	return "", nil
+}
+
+// mirrorURL returns the URL of the remote repo is pushed to for the "url" of
+// a "mirrorTo" setting.
+func mirrorURL(pattern string, repo api.RepoName) string {
+	return strings.ReplaceAll(pattern, "{repo}", string(repo))
+}
+
 func getVCSSyncer(
 	ctx context.Context,
 	externalServiceStore database.ExternalServiceStore,
@@ -478,10 +539,43 @@ func getVCSSyncer(
 			return nil, err
 		}
 		return server.NewRubyPackagesSyncer(&c, depsSvc, cli), nil
+	case extsvc.TypeGitHub, extsvc.TypeGitLab, extsvc.TypeBitbucketServer, extsvc.TypeOther:
+		// The clone strategies of all of these code host connections have the
+		// same shape.
+		var c struct {
+			CloneStrategies []*schema.OtherCloneStrategy `json:"cloneStrategies"`
+		}
+		if _, err := extractOptions(&c); err != nil {
+			return nil, err
+		}
+		strategy, err := cloneStrategy(repo, c.CloneStrategies)
+		if err != nil {
+			return nil, err
+		}
+		return &server.GitRepoSyncer{CloneStrategy: strategy}, nil
 	}
 	return &server.GitRepoSyncer{}, nil
 }
 
+// cloneStrategy returns the first of strategies whose pattern matches repo, or
+// nil if repo should be cloned in full.
+func cloneStrategy(repo api.RepoName, strategies []*schema.OtherCloneStrategy) (*server.CloneStrategy, error) {
+	for _, s := range strategies {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid clone strategy pattern %q", s.Pattern)
+		}
+		if re.MatchString(string(repo)) {
+			return &server.CloneStrategy{
+				Filter:      s.Filter,
+				Depth:       s.Depth,
+				SparsePaths: s.SparsePaths,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
 func syncExternalServiceRateLimiters(ctx context.Context, store database.ExternalServiceStore) error {
 	svcs, err := store.List(ctx, database.ExternalServicesListOptions{})
 	if err != nil {
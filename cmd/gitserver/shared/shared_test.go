@@ -113,6 +113,90 @@ func TestGetVCSSyncer(t *testing.T) {
 	if !ok {
 		t.Fatalf("Want *server.PerforceDepotSyncer, got %T", s)
 	}
+
+	repoStore.GetByNameFunc.SetDefaultHook(func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{
+			ExternalRepo: api.ExternalRepoSpec{
+				ServiceType: extsvc.TypeGitHub,
+			},
+			Sources: map[string]*types.SourceInfo{
+				"a": {
+					ID:       "abc",
+					CloneURL: "example.com",
+				},
+			},
+		}, nil
+	})
+
+	extsvcStore.GetByIDFunc.SetDefaultHook(func(ctx context.Context, i int64) (*types.ExternalService, error) {
+		return &types.ExternalService{
+			ID:          1,
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "test",
+			Config: extsvc.NewUnencryptedConfig(`{
+				"cloneStrategies": [
+					{"pattern": "^foo/baz$", "depth": 1},
+					{"pattern": "^foo/", "filter": "blob:none", "sparsePaths": ["src"]}
+				]
+			}`),
+		}, nil
+	})
+
+	s, err = getVCSSyncer(context.Background(), extsvcStore, repoStore, depsSvc, repo, tempReposDir, tempCoursierCacheDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gs, ok := s.(*server.GitRepoSyncer)
+	if !ok {
+		t.Fatalf("Want *server.GitRepoSyncer, got %T", s)
+	}
+	want := &server.CloneStrategy{Filter: "blob:none", SparsePaths: []string{"src"}}
+	if diff := cmp.Diff(want, gs.CloneStrategy); diff != "" {
+		t.Fatalf("unexpected clone strategy (-want +got):\n%s", diff)
+	}
+}
+
+func TestGetMirrorURL(t *testing.T) {
+	repo := api.RepoName("github.com/foo/bar")
+	repoStore := database.NewMockRepoStore()
+	repoStore.GetByNameFunc.SetDefaultReturn(&types.Repo{
+		Name: repo,
+		Sources: map[string]*types.SourceInfo{
+			"a": {ID: "extsvc:github:1"},
+		},
+	}, nil)
+
+	config := `{"url": "https://github.com"}`
+	extsvcStore := database.NewMockExternalServiceStore()
+	extsvcStore.GetByIDFunc.SetDefaultHook(func(ctx context.Context, i int64) (*types.ExternalService, error) {
+		return &types.ExternalService{
+			ID:     1,
+			Kind:   extsvc.KindGitHub,
+			Config: extsvc.NewUnencryptedConfig(config),
+		}, nil
+	})
+
+	got, err := getMirrorURL(context.Background(), extsvcStore, repoStore, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != "" {
+		t.Fatalf("want no mirror URL without mirrorTo, got %q", got)
+	}
+
+	config = `{
+		// Comments are allowed.
+		"url": "https://github.com",
+		"mirrorTo": {"url": "ssh://git@backup.example.com/{repo}.git"}
+	}`
+	got, err = getMirrorURL(context.Background(), extsvcStore, repoStore, repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "ssh://git@backup.example.com/github.com/foo/bar.git"; got != want {
+		t.Fatalf("want mirror URL %q, got %q", want, got)
+	}
 }
 
 func TestMethodSpecificStreamInterceptor(t *testing.T) {
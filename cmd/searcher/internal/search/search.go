@@ -203,7 +203,16 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender matchS
 	if p.IsStructuralPat && p.Indexed {
 		// Execute the new structural search path that directly calls Zoekt.
 		// TODO use limit in indexed structural search
-		return structuralSearchWithZoekt(ctx, s.Log, s.Indexed, p, sender)
+		err := structuralSearchWithZoekt(ctx, s.Log, s.Indexed, p, sender)
+		if err == nil || ctx.Err() != nil || errors.Is(err, errNoResultsInTimeout) || errors.HasType(err, badRequestError{}) || sender.SentCount() > 0 {
+			return err
+		}
+		// Zoekt failed before we found anything, so we can still search
+		// the archive instead.
+		logWithTrace(ctx, s.Log).Warn("indexed structural search failed, falling back to searching the archive",
+			log.String("repo", string(p.Repo)),
+			log.String("commit", string(p.Commit)),
+			log.Error(err))
 	}
 
 	// Compile pattern before fetching from store incase it is bad.
@@ -528,11 +528,20 @@ func TestBuildQuery(t *testing.T) {
 	pattern := ":[x~*]"
 	want := "error parsing regexp: missing argument to repetition operator: `*`"
 	t.Run("build query", func(t *testing.T) {
-		_, err := buildQuery(&search.TextPatternInfo{Pattern: pattern}, nil, nil, false)
+		_, err := buildQuery(&search.TextPatternInfo{Pattern: pattern}, nil, nil)
 		if diff := cmp.Diff(err.Error(), want); diff != "" {
 			t.Error(diff)
 		}
 	})
+
+	t.Run("literal fragments", func(t *testing.T) {
+		args := &search.TextPatternInfo{Pattern: "ParseInt(:[args]) if err != nil"}
+		filePathPatterns, err := handleFilePathPatterns(args)
+		require.NoError(t, err)
+		q, err := buildQuery(args, nil, filePathPatterns)
+		require.NoError(t, err)
+		require.Equal(t, `(and (branchesrepos) (and ) case_content_substr:"ParseInt(" case_content_substr:"err" case_content_substr:"nil")`, q.String())
+	})
 }
 
 func Test_chunkRanges(t *testing.T) {
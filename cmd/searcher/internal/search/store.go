@@ -25,6 +25,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf/deploy"
 	"github.com/sourcegraph/sourcegraph/internal/diskcache"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/limiter"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -70,6 +71,11 @@ type Store struct {
 	// FilterTar returns a FilterFunc that filters out files we don't want to write to disk
 	FilterTar func(ctx context.Context, client gitserver.Client, repo api.RepoName, commit api.CommitID) (FilterFunc, error)
 
+	// ReadLFSObject returns the contents of the Git LFS object pointer points
+	// to in repo. If set, files which are LFS pointers are stored with the
+	// contents of the object they point to instead.
+	ReadLFSObject func(ctx context.Context, repo api.RepoName, pointer gitdomain.LFSPointer) ([]byte, error)
+
 	// Path is the directory to store the cache
 	Path string
 
@@ -171,6 +177,9 @@ func (s *Store) PrepareZipPaths(ctx context.Context, repo api.RepoName, commit a
 	h := sha256.New()
 	_, _ = fmt.Fprintf(h, "%q %q", repo, commit)
 	filter.HashKey(h)
+	if s.ReadLFSObject != nil {
+		_, _ = io.WriteString(h, "\x00LFS")
+	}
 	_, _ = io.WriteString(h, "\x00Paths")
 	for _, p := range paths {
 		_, _ = h.Write([]byte{0})
@@ -286,6 +295,20 @@ func (s *Store) fetch(ctx context.Context, repo api.RepoName, commit api.CommitI
 		}
 	}
 
+	var resolveLFS func(gitdomain.LFSPointer) ([]byte, error)
+	if s.ReadLFSObject != nil {
+		resolveLFS = func(p gitdomain.LFSPointer) ([]byte, error) {
+			data, err := s.ReadLFSObject(ctx, repo, p)
+			if err != nil {
+				s.Log.Warn("failed to read LFS object, searching the pointer instead",
+					log.String("repo", string(repo)),
+					log.String("oid", p.OID),
+					log.Error(err))
+			}
+			return data, err
+		}
+	}
+
 	pr, pw := io.Pipe()
 
 	// After this point we are not allowed to return an error. Instead we can
@@ -298,7 +321,7 @@ func (s *Store) fetch(ctx context.Context, repo api.RepoName, commit api.CommitI
 		defer r.Close()
 		tr := tar.NewReader(r)
 		zw := zip.NewWriter(pw)
-		err := copySearchable(tr, zw, filter)
+		err := copySearchable(tr, zw, filter, resolveLFS)
 		if err1 := zw.Close(); err == nil {
 			err = err1
 		}
@@ -312,7 +335,11 @@ func (s *Store) fetch(ctx context.Context, repo api.RepoName, commit api.CommitI
 
 // copySearchable copies searchable files from tr to zw. A searchable file is
 // any file that is under size limit, non-binary, and not matching the filter.
-func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter) error {
+//
+// If resolveLFS is non-nil, the contents of files which are Git LFS pointers
+// are replaced with the contents of the object they point to. Pointers which
+// fail to resolve are copied as is.
+func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter, resolveLFS func(gitdomain.LFSPointer) ([]byte, error)) error {
 	// 32*1024 is the same size used by io.Copy
 	buf := make([]byte, 32*1024)
 	for {
@@ -353,6 +380,36 @@ func copySearchable(tr *tar.Reader, zw *zip.Writer, filter *searchableFilter) er
 				continue
 			}
 
+			if resolveLFS != nil && hdr.Size <= gitdomain.LFSPointerMaxSize {
+				data, err := io.ReadAll(tr)
+				if err != nil {
+					return err
+				}
+				if p, ok := gitdomain.ParseLFSPointer(data); ok {
+					// The object may be too large to search, in which
+					// case we do not fetch it.
+					if filter.SkipContent(&tar.Header{Name: hdr.Name, Size: p.Size}) {
+						continue
+					}
+					if obj, err := resolveLFS(p); err == nil {
+						data = obj
+					}
+				}
+
+				// Same binary heuristic as below.
+				head := data
+				if len(head) > len(buf) {
+					head = head[:len(buf)]
+				}
+				if bytes.IndexByte(head, 0x00) >= 0 {
+					continue
+				}
+				if _, err := w.Write(data); err != nil {
+					return err
+				}
+				continue
+			}
+
 			n, err := tr.Read(buf)
 			switch err {
 			case io.EOF:
@@ -5,6 +5,7 @@ import (
 	"archive/zip"
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net"
 	"os"
@@ -15,10 +16,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/sourcegraph/log/logtest"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -217,7 +220,7 @@ func TestSymlink(t *testing.T) {
 	filter.CommitIgnore = func(hdr *tar.Header) bool {
 		return false
 	}
-	if err := copySearchable(tarReader, zw, filter); err != nil {
+	if err := copySearchable(tarReader, zw, filter, nil); err != nil {
 		t.Fatal(err)
 	}
 	zw.Close()
@@ -255,6 +258,88 @@ func TestSymlink(t *testing.T) {
 	}
 }
 
+func TestCopySearchableLFS(t *testing.T) {
+	const (
+		resolvedOID = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+		missingOID  = "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+	)
+	pointer := func(oid string, size int) string {
+		return fmt.Sprintf("version https://git-lfs.github.com/spec/v1\noid sha256:%s\nsize %d\n", oid, size)
+	}
+	files := map[string]string{
+		"resolved.txt": pointer(resolvedOID, 12),
+		"missing.txt":  pointer(missingOID, 3),
+		"large.bin":    pointer(resolvedOID, maxFileSize+1),
+		"plain.txt":    "plain content\n",
+	}
+
+	var b bytes.Buffer
+	tw := tar.NewWriter(&b)
+	for _, name := range []string{"resolved.txt", "missing.txt", "large.bin", "plain.txt"} {
+		content := files[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var fetched []string
+	resolveLFS := func(p gitdomain.LFSPointer) ([]byte, error) {
+		fetched = append(fetched, p.OID)
+		if p.OID == resolvedOID {
+			return []byte("lfs content\n"), nil
+		}
+		return nil, errors.New("not found")
+	}
+
+	var zb bytes.Buffer
+	zw := zip.NewWriter(&zb)
+	filter := newSearchableFilter(&schema.SiteConfiguration{})
+	filter.CommitIgnore = func(hdr *tar.Header) bool { return false }
+	if err := copySearchable(tar.NewReader(&b), zw, filter, resolveLFS); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(zb.Bytes()), int64(zb.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got[f.Name] = string(data)
+	}
+
+	want := map[string]string{
+		"resolved.txt": "lfs content\n",
+		"missing.txt":  files["missing.txt"],
+		"large.bin":    "",
+		"plain.txt":    "plain content\n",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected zip contents (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff([]string{resolvedOID, missingOID}, fetched); diff != "" {
+		t.Errorf("unexpected fetched objects (-want +got):\n%s", diff)
+	}
+}
+
 func createSymlinkRepo(dir string) error {
 	if err := os.MkdirAll(dir, 0o755); err != nil {
 		return err
@@ -44,24 +44,30 @@ func handleFilePathPatterns(query *search.TextPatternInfo) (zoektquery.Q, error)
 	return zoektquery.NewAnd(and...), nil
 }
 
-func buildQuery(args *search.TextPatternInfo, branchRepos []zoektquery.BranchRepos, filePathPatterns zoektquery.Q, shortcircuit bool) (zoektquery.Q, error) {
-	regexString := comby.StructuralPatToRegexpQuery(args.Pattern, shortcircuit)
-	if len(regexString) == 0 {
-		return &zoektquery.Const{Value: true}, nil
+// buildQuery returns the query for the files which may match the structural
+// pattern of args. Rather than the regular expression approximating the
+// pattern, which Zoekt has to evaluate on the content of every candidate, we
+// look for the literal fragments every match contains. Comby does the rest.
+func buildQuery(args *search.TextPatternInfo, branchRepos []zoektquery.BranchRepos, filePathPatterns zoektquery.Q) (zoektquery.Q, error) {
+	// Report invalid regular expression holes before searching, like the
+	// unindexed search does.
+	regexString := comby.StructuralPatToRegexpQuery(args.Pattern, false)
+	if _, err := syntax.Parse(regexString, syntax.ClassNL|syntax.PerlX|syntax.UnicodeGroups); err != nil {
+		return nil, badRequestError{err.Error()}
 	}
-	re, err := syntax.Parse(regexString, syntax.ClassNL|syntax.PerlX|syntax.UnicodeGroups)
-	if err != nil {
-		return nil, err
-	}
-	return zoektquery.NewAnd(
+
+	and := []zoektquery.Q{
 		&zoektquery.BranchesRepos{List: branchRepos},
 		filePathPatterns,
-		&zoektquery.Regexp{
-			Regexp:        re,
+	}
+	for _, fragment := range comby.StructuralPatToFragments(args.Pattern) {
+		and = append(and, &zoektquery.Substring{
+			Pattern:       fragment,
 			CaseSensitive: true,
 			Content:       true,
-		},
-	), nil
+		})
+	}
+	return zoektquery.NewAnd(and...), nil
 }
 
 // zoektSearch searches repositories using zoekt, returning file contents for
@@ -86,7 +92,7 @@ func zoektSearch(ctx context.Context, logger log.Logger, client zoekt.Streamer,
 	}
 
 	t0 := time.Now()
-	q, err := buildQuery(args, branchRepos, filePathPatterns, false)
+	q, err := buildQuery(args, branchRepos, filePathPatterns)
 	if err != nil {
 		return err
 	}
@@ -153,6 +153,10 @@ func Start(ctx context.Context, observationCtx *observation.Context, ready servi
 					Pathspecs: pathspecs,
 				})
 			},
+			ReadLFSObject: func(ctx context.Context, repo api.RepoName, pointer gitdomain.LFSPointer) ([]byte, error) {
+				ctx = actor.WithInternalActor(ctx)
+				return git.ReadLFSObject(ctx, repo, pointer)
+			},
 			FilterTar:         search.NewFilter,
 			Path:              filepath.Join(cacheDir, "searcher-archives"),
 			MaxCacheSizeBytes: cacheSizeBytes,
@@ -59,7 +59,7 @@ func TestContextResolver(t *testing.T) {
 	mockGitserver.StatFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, repo api.RepoName, _ api.CommitID, fileName string) (fs.FileInfo, error) {
 		return fakeFileInfo{path: fileName}, nil
 	})
-	mockGitserver.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, repo api.RepoName, _ api.CommitID, fileName string) ([]byte, error) {
+	mockGitserver.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, repo api.RepoName, _ api.CommitID, fileName string, _ gitserver.ReadFileOptions) ([]byte, error) {
 		if content, ok := files[repo][fileName]; ok {
 			return content, nil
 		}
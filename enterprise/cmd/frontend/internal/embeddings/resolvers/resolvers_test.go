@@ -41,7 +41,7 @@ func TestEmbeddingSearchResolver(t *testing.T) {
 	mockDB.ReposFunc.SetDefaultReturn(mockRepos)
 
 	mockGitserver := gitserver.NewMockClient()
-	mockGitserver.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, fileName string) ([]byte, error) {
+	mockGitserver.ReadFileFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, fileName string, _ gitserver.ReadFileOptions) ([]byte, error) {
 		if fileName == "testfile" {
 			return []byte("test\nfirst\nfour\nlines\nplus\nsome\nmore"), nil
 		}
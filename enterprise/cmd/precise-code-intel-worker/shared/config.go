@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/lsifuploadstore"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -11,11 +12,12 @@ import (
 type Config struct {
 	env.BaseConfig
 
-	WorkerPollInterval    time.Duration
-	WorkerConcurrency     int
-	WorkerBudget          int64
-	MaximumRuntimePerJob  time.Duration
-	LSIFUploadStoreConfig *lsifuploadstore.Config
+	WorkerPollInterval     time.Duration
+	WorkerConcurrency      int
+	WorkerBudget           int64
+	MaximumRuntimePerJob   time.Duration
+	LSIFUploadStoreConfig  *lsifuploadstore.Config
+	UploadValidationConfig uploadsshared.UploadValidationConfig
 }
 
 func (c *Config) Load() {
@@ -26,6 +28,13 @@ func (c *Config) Load() {
 	c.WorkerConcurrency = c.GetInt("PRECISE_CODE_INTEL_WORKER_CONCURRENCY", "1", "The maximum number of indexes that can be processed concurrently.")
 	c.WorkerBudget = int64(c.GetInt("PRECISE_CODE_INTEL_WORKER_BUDGET", "0", "The amount of compressed input data (in bytes) a worker can process concurrently. Zero acts as an infinite budget."))
 	c.MaximumRuntimePerJob = c.GetInterval("PRECISE_CODE_INTEL_WORKER_MAXIMUM_RUNTIME_PER_JOB", "25m", "The maximum time a single LSIF processing job can take.")
+
+	c.UploadValidationConfig.Strict = c.GetBool("PRECISE_CODE_INTEL_UPLOAD_VALIDATION_STRICT", "false", "Whether or not to reject uploads whose SCIP index exceeds any of the validation thresholds.")
+	c.UploadValidationConfig.MaximumUnresolvedReferencesPercent = c.GetInt("PRECISE_CODE_INTEL_UPLOAD_VALIDATION_MAX_UNRESOLVED_REFERENCES_PERCENT", "50", "The maximum percentage of references without a definition in an index. A negative value disables this check.")
+	c.UploadValidationConfig.MaximumDocumentsWithoutOccurrences = c.GetInt("PRECISE_CODE_INTEL_UPLOAD_VALIDATION_MAX_DOCUMENTS_WITHOUT_OCCURRENCES", "-1", "The maximum number of documents without occurrences in an index. A negative value disables this check.")
+	c.UploadValidationConfig.MaximumPathsNotInRepository = c.GetInt("PRECISE_CODE_INTEL_UPLOAD_VALIDATION_MAX_PATHS_NOT_IN_REPOSITORY", "0", "The maximum number of document paths in an index that do not exist in the repository. A negative value disables this check.")
+	c.UploadValidationConfig.MaximumDuplicateSymbols = c.GetInt("PRECISE_CODE_INTEL_UPLOAD_VALIDATION_MAX_DUPLICATE_SYMBOLS", "-1", "The maximum number of symbols defined in more than one document of an index. A negative value disables this check.")
+	c.UploadValidationConfig.MaximumDocumentsWithOverlappingOccurrences = c.GetInt("PRECISE_CODE_INTEL_UPLOAD_VALIDATION_MAX_DOCUMENTS_WITH_OVERLAPPING_OCCURRENCES", "0", "The maximum number of documents of an index with occurrences whose ranges partially overlap. A negative value disables this check.")
 }
 
 func (c *Config) Validate() error {
@@ -89,6 +89,7 @@ func Main(ctx context.Context, observationCtx *observation.Context, ready servic
 		config.WorkerBudget,
 		config.WorkerPollInterval,
 		config.MaximumRuntimePerJob,
+		config.UploadValidationConfig,
 	)
 
 	// Initialize health server
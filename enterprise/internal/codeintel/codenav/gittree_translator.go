@@ -235,6 +235,9 @@ func translateLineNumbers(hunks []*diff.Hunk, line int) (int, bool) {
 	targetOffset := int(hunk.NewStartLine)
 
 	for _, deltaLine := range strings.Split(string(hunk.Body), "\n") {
+		if isNoNewlineMarker(deltaLine) {
+			continue
+		}
 		isAdded := strings.HasPrefix(deltaLine, "+")
 		isRemoved := strings.HasPrefix(deltaLine, "-")
 
@@ -273,6 +276,40 @@ func translateLineNumbers(hunks []*diff.Hunk, line int) (int, bool) {
 	panic("Malformed hunk body")
 }
 
+// changedSourceLines returns the set of (zero-indexed) lines of the source file that are edited
+// or removed by the given hunks.
+func changedSourceLines(hunks []*diff.Hunk) map[int]struct{} {
+	lines := map[int]struct{}{}
+	for _, hunk := range hunks {
+		sourceOffset := int(hunk.OrigStartLine)
+
+		for _, deltaLine := range strings.Split(string(hunk.Body), "\n") {
+			if isNoNewlineMarker(deltaLine) {
+				// Not a line of either file
+				continue
+			}
+			if strings.HasPrefix(deltaLine, "+") {
+				// Added lines don't exist in the source file
+				continue
+			}
+			if strings.HasPrefix(deltaLine, "-") {
+				// Translate from git diff one-index to bundle/lsp zero-index
+				lines[sourceOffset-1] = struct{}{}
+			}
+
+			sourceOffset++
+		}
+	}
+
+	return lines
+}
+
+// isNoNewlineMarker returns true if the given line of a hunk body is the "\ No newline at end of file"
+// marker git adds after the last line of a file that doesn't end in a newline.
+func isNoNewlineMarker(deltaLine string) bool {
+	return strings.HasPrefix(deltaLine, "\\")
+}
+
 func makeKey(parts ...string) string {
 	return strings.Join(parts, ":")
 }
@@ -399,3 +399,52 @@ func TestRawGetTargetCommitPositionFromSourcePosition(t *testing.T) {
 		})
 	}
 }
+
+func TestChangedSourceLines(t *testing.T) {
+	for _, testCase := range append(append([]gitTreeTranslatorTestCase(nil), hugoTestCases...), prometheusTestCases...) {
+		name := fmt.Sprintf("%s : %s", testCase.diffName, testCase.description)
+
+		t.Run(name, func(t *testing.T) {
+			diff, err := godiff.NewFileDiffReader(bytes.NewReader([]byte(testCase.diff))).Read()
+			if err != nil {
+				t.Fatalf("unexpected error reading file diff: %s", err)
+			}
+
+			// Lines that cannot be translated are exactly the lines that were changed
+			if _, changed := changedSourceLines(diff.Hunks)[testCase.line-1]; changed == testCase.expectedOk {
+				t.Errorf("unexpected changed flag. want=%v have=%v", !testCase.expectedOk, changed)
+			}
+		})
+	}
+}
+
+func TestChangedSourceLinesNoNewlineAtEndOfFile(t *testing.T) {
+	const noNewlineDiff = `
+diff --git a/a.go b/a.go
+index 0c0a5e1..7fe1b2c 100644
+--- a/a.go
++++ b/a.go
+@@ -1,3 +1,3 @@
+-a
++x
+ b
+ c
+\ No newline at end of file
+@@ -10,2 +10,3 @@
+ j
+-k
+\ No newline at end of file
++k
++l
+`
+
+	diff, err := godiff.NewFileDiffReader(bytes.NewReader([]byte(noNewlineDiff))).Read()
+	if err != nil {
+		t.Fatalf("unexpected error reading file diff: %s", err)
+	}
+
+	expected := map[int]struct{}{0: {}, 10: {}}
+	if diff := cmp.Diff(expected, changedSourceLines(diff.Hunks)); diff != "" {
+		t.Errorf("unexpected changed lines (-want +got):\n%s", diff)
+	}
+}
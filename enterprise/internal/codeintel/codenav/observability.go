@@ -13,7 +13,11 @@ import (
 
 type operations struct {
 	getReferences          *observation.Operation
+	getImpactedReferences  *observation.Operation
 	getImplementations     *observation.Operation
+	getIncomingCalls       *observation.Operation
+	getOutgoingCalls       *observation.Operation
+	getTypeHierarchy       *observation.Operation
 	getDiagnostics         *observation.Operation
 	getHover               *observation.Operation
 	getDefinitions         *observation.Operation
@@ -46,7 +50,11 @@ func newOperations(observationCtx *observation.Context) *operations {
 
 	return &operations{
 		getReferences:          op("getReferences"),
+		getImpactedReferences:  op("getImpactedReferences"),
 		getImplementations:     op("getImplementations"),
+		getIncomingCalls:       op("getIncomingCalls"),
+		getOutgoingCalls:       op("getOutgoingCalls"),
+		getTypeHierarchy:       op("getTypeHierarchy"),
 		getDiagnostics:         op("getDiagnostics"),
 		getHover:               op("getHover"),
 		getDefinitions:         op("getDefinitions"),
@@ -186,6 +186,26 @@ func (s *Service) GetReferences(ctx context.Context, args RequestArgs, requestSt
 	}})
 	defer endObservation()
 
+	locations, cursor, err := s.getReferenceLocations(ctx, args, requestState, cursor, trace)
+	if err != nil {
+		return nil, cursor, err
+	}
+
+	// Adjust the locations back to the appropriate range in the target commits. This adjusts
+	// locations within the repository the user is browsing so that it appears all references
+	// are occurring at the same commit they are looking at.
+	referenceLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
+	if err != nil {
+		return nil, cursor, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numReferenceLocations", len(referenceLocations)))
+
+	return referenceLocations, cursor, nil
+}
+
+// getReferenceLocations returns the next page of locations (relative to the indexed commits) that
+// reference the symbol at the given position, along with the cursor used to fetch the following page.
+func (s *Service) getReferenceLocations(ctx context.Context, args RequestArgs, requestState RequestState, cursor ReferencesCursor, trace observation.TraceLogger) ([]shared.Location, ReferencesCursor, error) {
 	// Adjust the path and position for each visible upload based on its git difference to
 	// the target commit. This data may already be stashed in the cursor decoded above, in
 	// which case we don't need to hit the database.
@@ -276,16 +296,7 @@ func (s *Service) GetReferences(ctx context.Context, args RequestArgs, requestSt
 
 	trace.AddEvent("TODO Domain Owner", attribute.Int("numLocations", len(locations)))
 
-	// Adjust the locations back to the appropriate range in the target commits. This adjusts
-	// locations within the repository the user is browsing so that it appears all references
-	// are occurring at the same commit they are looking at.
-	referenceLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
-	if err != nil {
-		return nil, cursor, err
-	}
-	trace.AddEvent("TODO Domain Owner", attribute.Int("numReferenceLocations", len(referenceLocations)))
-
-	return referenceLocations, cursor, nil
+	return locations, cursor, nil
 }
 
 // getUploadsWithDefinitionsForMonikers returns the set of uploads that provide any of the given monikers.
@@ -842,6 +853,27 @@ func (s *Service) GetDefinitions(ctx context.Context, args RequestArgs, requestS
 		return nil, err
 	}
 
+	locations, err := s.getDefinitionLocations(ctx, visibleUploads, requestState, trace)
+	if err != nil {
+		return nil, err
+	}
+
+	// Adjust the locations back to the appropriate range in the target commits. This adjusts
+	// locations within the repository the user is browsing so that it appears all definitions
+	// are occurring at the same commit they are looking at.
+
+	adjustedLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numAdjustedLocations", len(adjustedLocations)))
+
+	return adjustedLocations, nil
+}
+
+// getDefinitionLocations returns the set of locations (relative to the indexed commits) defining the
+// symbol at the target position of the given visible uploads.
+func (s *Service) getDefinitionLocations(ctx context.Context, visibleUploads []visibleUpload, requestState RequestState, trace observation.TraceLogger) ([]shared.Location, error) {
 	// Gather the "local" reference locations that are reachable via a referenceResult vertex.
 	// If the definition exists within the index, it should be reachable via an LSIF graph
 	// traversal and should not require an additional moniker search in the same index.
@@ -862,7 +894,7 @@ func (s *Service) GetDefinitions(ctx context.Context, args RequestArgs, requestS
 		}
 		if len(locations) > 0 {
 			// If we have a local definition, we won't find a better one and can exit early
-			return s.getUploadLocations(ctx, args, requestState, locations, true)
+			return locations, nil
 		}
 	}
 
@@ -893,17 +925,7 @@ func (s *Service) GetDefinitions(ctx context.Context, args RequestArgs, requestS
 	}
 	trace.AddEvent("TODO Domain Owner", attribute.Int("numXrepoLocations", len(locations)))
 
-	// Adjust the locations back to the appropriate range in the target commits. This adjusts
-	// locations within the repository the user is browsing so that it appears all definitions
-	// are occurring at the same commit they are looking at.
-
-	adjustedLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
-	if err != nil {
-		return nil, err
-	}
-	trace.AddEvent("TODO Domain Owner", attribute.Int("numAdjustedXrepoLocations", len(adjustedLocations)))
-
-	return adjustedLocations, nil
+	return locations, nil
 }
 
 func (s *Service) GetDiagnostics(ctx context.Context, args RequestArgs, requestState RequestState) (diagnosticsAtUploads []DiagnosticAtUpload, _ int, err error) {
@@ -1400,7 +1422,7 @@ func (s *Service) SnapshotForDocument(ctx context.Context, repositoryID int, com
 		return nil, err
 	}
 
-	file, err := s.gitserver.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, api.RepoName(dump.RepositoryName), api.CommitID(dump.Commit), path)
+	file, err := s.gitserver.ReadFile(ctx, authz.DefaultSubRepoPermsChecker, api.RepoName(dump.RepositoryName), api.CommitID(dump.Commit), path, gitserver.ReadFileOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,369 @@
+package codenav
+
+import (
+	"context"
+	"sort"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// defaultCallHierarchyLimit is the number of calls returned for requests without a positive limit.
+const defaultCallHierarchyLimit = 100
+
+// GetIncomingCalls returns the calls of the function or method at the given position, grouped by the
+// function or method containing them. Call sites are the references of the symbol, so calls from other
+// repositories are found via moniker search just like references. At most args.Limit calls are returned.
+func (s *Service) GetIncomingCalls(ctx context.Context, args RequestArgs, requestState RequestState) (_ []CallHierarchyCall, err error) {
+	if args.Limit <= 0 {
+		args.Limit = defaultCallHierarchyLimit
+	}
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getIncomingCalls, serviceObserverThreshold, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", args.RepositoryID),
+		attribute.String("commit", args.Commit),
+		attribute.String("path", args.Path),
+		attribute.Int("numUploads", len(requestState.GetCacheUploads())),
+		attribute.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+		attribute.Int("line", args.Line),
+		attribute.Int("character", args.Character),
+		attribute.Int("limit", args.Limit),
+	}})
+	defer endObservation()
+
+	documents := newDocumentCache(s)
+
+	var calls []locationCall
+	callIndexes := map[callerKey]int{}
+
+	// Group the references of the symbol by their enclosing function until we have enough callers or
+	// run out of references. Later pages may add call sites to callers we already know about.
+	cursor := ReferencesCursor{Phase: "local"}
+	for cursor.Phase != "done" && len(calls) < args.Limit {
+		var locations []shared.Location
+		locations, cursor, err = s.getReferenceLocations(ctx, args, requestState, cursor, trace)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, location := range locations {
+			document, err := documents.get(ctx, location.DumpID, location.Path)
+			if err != nil {
+				return nil, err
+			}
+			caller, ok := enclosingCallable(document, location.Range)
+			if !ok {
+				continue
+			}
+
+			key := callerKey{dumpID: location.DumpID, path: location.Path, symbol: caller.Symbol}
+			i, ok := callIndexes[key]
+			if !ok {
+				i = len(calls)
+				callIndexes[key] = i
+				calls = append(calls, locationCall{
+					symbol: caller.Symbol,
+					definitions: []shared.Location{{
+						DumpID: location.DumpID,
+						Path:   location.Path,
+						Range:  convertSCIPRange(caller.Range),
+					}},
+				})
+			}
+			calls[i].callSites = append(calls[i].callSites, location)
+		}
+	}
+	if len(calls) > args.Limit {
+		calls = calls[:args.Limit]
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numCalls", len(calls)))
+
+	return s.getUploadCalls(ctx, args, requestState, calls)
+}
+
+// GetOutgoingCalls returns the calls made by the function or method at the given position, grouped by the
+// function or method being called. The calls are read from the documents that define the symbol, which may
+// belong to another repository, and the definitions of the called functions are resolved like definitions
+// of any other symbol. At most args.Limit calls are returned.
+func (s *Service) GetOutgoingCalls(ctx context.Context, args RequestArgs, requestState RequestState) (_ []CallHierarchyCall, err error) {
+	if args.Limit <= 0 {
+		args.Limit = defaultCallHierarchyLimit
+	}
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getOutgoingCalls, serviceObserverThreshold, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", args.RepositoryID),
+		attribute.String("commit", args.Commit),
+		attribute.String("path", args.Path),
+		attribute.Int("numUploads", len(requestState.GetCacheUploads())),
+		attribute.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+		attribute.Int("line", args.Line),
+		attribute.Int("character", args.Character),
+		attribute.Int("limit", args.Limit),
+	}})
+	defer endObservation()
+
+	visibleUploads, err := s.getVisibleUploads(ctx, args.Line, args.Character, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	definitions, err := s.getDefinitionLocations(ctx, visibleUploads, requestState, trace)
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numDefinitions", len(definitions)))
+
+	documents := newDocumentCache(s)
+
+	var calls []locationCall
+	callIndexes := map[string]int{}
+	seenDefinitions := map[shared.Location]struct{}{}
+
+	for _, definition := range definitions {
+		if _, ok := seenDefinitions[definition]; ok {
+			continue
+		}
+		seenDefinitions[definition] = struct{}{}
+
+		document, err := documents.get(ctx, definition.DumpID, definition.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, occurrence := range calledOccurrences(document, definition.Range) {
+			location := shared.Location{
+				DumpID: definition.DumpID,
+				Path:   definition.Path,
+				Range:  convertSCIPRange(occurrence.Range),
+			}
+
+			i, ok := callIndexes[occurrence.Symbol]
+			if !ok {
+				if len(calls) >= args.Limit {
+					continue
+				}
+
+				calleeDefinitions, err := s.getCalleeDefinitions(ctx, location, requestState, trace)
+				if err != nil {
+					return nil, err
+				}
+
+				i = len(calls)
+				callIndexes[occurrence.Symbol] = i
+				calls = append(calls, locationCall{symbol: occurrence.Symbol, definitions: calleeDefinitions})
+			}
+			calls[i].callSites = append(calls[i].callSites, location)
+		}
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numCalls", len(calls)))
+
+	return s.getUploadCalls(ctx, args, requestState, calls)
+}
+
+// getCalleeDefinitions returns the locations (relative to the indexed commits) defining the symbol called
+// at the given location.
+func (s *Service) getCalleeDefinitions(ctx context.Context, location shared.Location, requestState RequestState, trace observation.TraceLogger) ([]shared.Location, error) {
+	upload, ok := requestState.dataLoader.GetUploadFromCacheMap(location.DumpID)
+	if !ok {
+		return nil, nil
+	}
+
+	return s.getDefinitionLocations(ctx, []visibleUpload{{
+		Upload:                upload,
+		TargetPath:            upload.Root + location.Path,
+		TargetPosition:        location.Range.Start,
+		TargetPathWithoutRoot: location.Path,
+	}}, requestState, trace)
+}
+
+// locationCall is a call hierarchy call whose locations are relative to the indexed commits.
+type locationCall struct {
+	symbol      string
+	definitions []shared.Location
+	callSites   []shared.Location
+}
+
+type callerKey struct {
+	dumpID int
+	path   string
+	symbol string
+}
+
+// getUploadCalls translates the locations of the given calls into equivalent locations in the requested
+// commit. Calls without a visible call site are dropped.
+func (s *Service) getUploadCalls(ctx context.Context, args RequestArgs, requestState RequestState, calls []locationCall) ([]CallHierarchyCall, error) {
+	uploadCalls := make([]CallHierarchyCall, 0, len(calls))
+	for _, call := range calls {
+		definitions, err := s.getUploadLocations(ctx, args, requestState, call.definitions, true)
+		if err != nil {
+			return nil, err
+		}
+		callSites, err := s.getUploadLocations(ctx, args, requestState, call.callSites, true)
+		if err != nil {
+			return nil, err
+		}
+		if len(callSites) == 0 {
+			continue
+		}
+
+		uploadCalls = append(uploadCalls, CallHierarchyCall{
+			Symbol:      call.symbol,
+			Definitions: definitions,
+			CallSites:   callSites,
+		})
+	}
+
+	return uploadCalls, nil
+}
+
+// documentCache memoizes the SCIP documents read while resolving a single call hierarchy request.
+type documentCache struct {
+	s         *Service
+	documents map[documentKey]*scip.Document
+}
+
+type documentKey struct {
+	dumpID int
+	path   string
+}
+
+func newDocumentCache(s *Service) *documentCache {
+	return &documentCache{
+		s:         s,
+		documents: map[documentKey]*scip.Document{},
+	}
+}
+
+// get returns the document with the given path (relative to the upload root) in the given upload. A missing
+// document is returned as an empty document.
+func (c *documentCache) get(ctx context.Context, dumpID int, path string) (*scip.Document, error) {
+	key := documentKey{dumpID: dumpID, path: path}
+	if document, ok := c.documents[key]; ok {
+		return document, nil
+	}
+
+	document, err := c.s.lsifstore.SCIPDocument(ctx, dumpID, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "lsifStore.SCIPDocument")
+	}
+	if document == nil {
+		document = &scip.Document{}
+	}
+	c.documents[key] = document
+
+	return document, nil
+}
+
+// enclosingCallable returns the definition occurrence of the function or method whose body contains the
+// given reference range. If the range is itself a definition, or it lies outside of any function, a false
+// flag is returned.
+//
+// SCIP documents don't record the extent of a definition. We assume that the body of a function extends
+// from its definition up to the definition of the next global symbol in the document. Parameters and other
+// symbols declared within function bodies are local, so they don't end it early.
+func enclosingCallable(document *scip.Document, rn shared.Range) (*scip.Occurrence, bool) {
+	var enclosing *scip.Occurrence
+	var enclosingStart shared.Position
+	for _, occurrence := range document.Occurrences {
+		if !isGlobalDefinition(occurrence) {
+			continue
+		}
+
+		occurrenceRange := convertSCIPRange(occurrence.Range)
+		if occurrenceRange == rn {
+			return nil, false
+		}
+		if comparePositions(occurrenceRange.Start, rn.Start) > 0 {
+			continue
+		}
+		if enclosing == nil || comparePositions(occurrenceRange.Start, enclosingStart) > 0 {
+			enclosing = occurrence
+			enclosingStart = occurrenceRange.Start
+		}
+	}
+
+	if enclosing == nil || !isCallableSymbol(enclosing.Symbol) {
+		return nil, false
+	}
+	return enclosing, true
+}
+
+// calledOccurrences returns the references to functions and methods, in document order, within the body of
+// the function or method defined at the given range. The body is delimited as in enclosingCallable.
+func calledOccurrences(document *scip.Document, definitionRange shared.Range) []*scip.Occurrence {
+	var bodyEnd *shared.Position
+	isCallable := false
+	for _, occurrence := range document.Occurrences {
+		if !isGlobalDefinition(occurrence) {
+			continue
+		}
+
+		occurrenceRange := convertSCIPRange(occurrence.Range)
+		if occurrenceRange == definitionRange {
+			isCallable = isCallable || isCallableSymbol(occurrence.Symbol)
+			continue
+		}
+		if comparePositions(occurrenceRange.Start, definitionRange.Start) > 0 && (bodyEnd == nil || comparePositions(occurrenceRange.Start, *bodyEnd) < 0) {
+			bodyEnd = &occurrenceRange.Start
+		}
+	}
+	if !isCallable {
+		return nil
+	}
+
+	var occurrences []*scip.Occurrence
+	for _, occurrence := range document.Occurrences {
+		if occurrence.SymbolRoles&int32(scip.SymbolRole_Definition) != 0 || scip.IsLocalSymbol(occurrence.Symbol) || !isCallableSymbol(occurrence.Symbol) {
+			continue
+		}
+
+		start := convertSCIPRange(occurrence.Range).Start
+		if comparePositions(start, definitionRange.End) < 0 || (bodyEnd != nil && comparePositions(start, *bodyEnd) >= 0) {
+			continue
+		}
+		occurrences = append(occurrences, occurrence)
+	}
+
+	sort.SliceStable(occurrences, func(i, j int) bool {
+		return comparePositions(convertSCIPRange(occurrences[i].Range).Start, convertSCIPRange(occurrences[j].Range).Start) < 0
+	})
+
+	return occurrences
+}
+
+// isGlobalDefinition returns true if the given occurrence defines a non-local symbol.
+func isGlobalDefinition(occurrence *scip.Occurrence) bool {
+	return occurrence.SymbolRoles&int32(scip.SymbolRole_Definition) != 0 && occurrence.Symbol != "" && !scip.IsLocalSymbol(occurrence.Symbol)
+}
+
+// isCallableSymbol returns true if the given symbol names a function or method.
+func isCallableSymbol(symbol string) bool {
+	parsed, err := scip.ParseSymbol(symbol)
+	if err != nil || len(parsed.Descriptors) == 0 {
+		return false
+	}
+
+	return parsed.Descriptors[len(parsed.Descriptors)-1].Suffix == scip.Descriptor_Method
+}
+
+// convertSCIPRange converts a SCIP occurrence range into a range.
+func convertSCIPRange(rn []int32) shared.Range {
+	r := scip.NewRange(rn)
+
+	return shared.Range{
+		Start: shared.Position{Line: int(r.Start.Line), Character: int(r.Start.Character)},
+		End:   shared.Position{Line: int(r.End.Line), Character: int(r.End.Character)},
+	}
+}
+
+// comparePositions returns a negative number if a precedes b, a positive number if b precedes a, and zero
+// if the positions are equal.
+func comparePositions(a, b shared.Position) int {
+	if a.Line != b.Line {
+		return a.Line - b.Line
+	}
+
+	return a.Character - b.Character
+}
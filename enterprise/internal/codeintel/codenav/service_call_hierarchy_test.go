@@ -0,0 +1,208 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const (
+	callerSymbol = "scip-go gomod example v1 example/caller()."
+	targetSymbol = "scip-go gomod example v1 example/target()."
+	otherSymbol  = "scip-go gomod example v1 example/other()."
+	typeSymbol   = "scip-go gomod example v1 example/T#"
+)
+
+var (
+	callerDefinitionRange = shared.Range{Start: shared.Position{Line: 0, Character: 5}, End: shared.Position{Line: 0, Character: 11}}
+	targetCallRange       = shared.Range{Start: shared.Position{Line: 1, Character: 1}, End: shared.Position{Line: 1, Character: 7}}
+	otherCallRange        = shared.Range{Start: shared.Position{Line: 2, Character: 1}, End: shared.Position{Line: 2, Character: 6}}
+	typeDefinitionRange   = shared.Range{Start: shared.Position{Line: 4, Character: 5}, End: shared.Position{Line: 4, Character: 6}}
+	varCallRange          = shared.Range{Start: shared.Position{Line: 5, Character: 8}, End: shared.Position{Line: 5, Character: 14}}
+	targetDefinitionRange = shared.Range{Start: shared.Position{Line: 6, Character: 5}, End: shared.Position{Line: 6, Character: 11}}
+)
+
+// callHierarchyDocument is the SCIP document of the following file:
+//
+//	func caller() {
+//		target()
+//		other()
+//	}
+//	type T struct{}
+//	var x = target()
+//	func target() {}
+var callHierarchyDocument = &scip.Document{
+	RelativePath: "a.go",
+	Occurrences: []*scip.Occurrence{
+		{Range: []int32{6, 5, 11}, Symbol: targetSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{0, 5, 11}, Symbol: callerSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{2, 1, 6}, Symbol: otherSymbol},
+		{Range: []int32{1, 1, 7}, Symbol: targetSymbol},
+		{Range: []int32{4, 5, 6}, Symbol: typeSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{5, 4, 5}, Symbol: "local 0", SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{5, 8, 14}, Symbol: targetSymbol},
+	},
+}
+
+func TestIncomingCalls(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []uploadsshared.Dump{
+		{ID: 50, Commit: mockCommit, Root: "sub1/"},
+		{ID: 51, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	// Empty result set (prevents nil pointer as scanner is always non-nil)
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.PushReturn([]int{}, 0, 0, nil)
+
+	locations := []shared.Location{
+		{DumpID: 51, Path: "a.go", Range: targetCallRange},
+		{DumpID: 51, Path: "a.go", Range: varCallRange},
+		{DumpID: 51, Path: "a.go", Range: targetDefinitionRange},
+	}
+	mockLsifStore.GetReferenceLocationsFunc.PushReturn(nil, 0, nil)
+	mockLsifStore.GetReferenceLocationsFunc.PushReturn(locations, len(locations), nil)
+	mockLsifStore.SCIPDocumentFunc.SetDefaultReturn(callHierarchyDocument, nil)
+
+	mockRequest := RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         mockPath,
+		Line:         6,
+		Character:    7,
+		Limit:        50,
+	}
+	calls, err := svc.GetIncomingCalls(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+
+	expectedCalls := []CallHierarchyCall{
+		{
+			Symbol:      callerSymbol,
+			Definitions: []shared.UploadLocation{{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: callerDefinitionRange}},
+			CallSites:   []shared.UploadLocation{{Dump: uploads[1], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: targetCallRange}},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	if history := mockLsifStore.SCIPDocumentFunc.History(); len(history) != 1 {
+		t.Errorf("unexpected number of document reads. want=%d have=%d", 1, len(history))
+	}
+
+	// Requests without a positive limit use the default limit
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.PushReturn([]int{}, 0, 0, nil)
+	mockLsifStore.GetReferenceLocationsFunc.PushReturn(nil, 0, nil)
+	mockLsifStore.GetReferenceLocationsFunc.PushReturn(locations, len(locations), nil)
+	mockRequest.Limit = 0
+	calls, err = svc.GetIncomingCalls(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying incoming calls: %s", err)
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+}
+
+func TestOutgoingCalls(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []uploadsshared.Dump{
+		{ID: 51, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	mockLsifStore.GetDefinitionLocationsFunc.SetDefaultHook(func(_ context.Context, uploadID int, path string, line, _, _, _ int) ([]shared.Location, int, error) {
+		switch line {
+		case callerDefinitionRange.Start.Line:
+			return []shared.Location{{DumpID: uploadID, Path: "a.go", Range: callerDefinitionRange}}, 1, nil
+		case targetCallRange.Start.Line:
+			return []shared.Location{{DumpID: uploadID, Path: "a.go", Range: targetDefinitionRange}}, 1, nil
+		}
+		return nil, 0, nil
+	})
+	mockLsifStore.SCIPDocumentFunc.SetDefaultReturn(callHierarchyDocument, nil)
+
+	mockRequest := RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         "sub2/a.go",
+		Line:         0,
+		Character:    7,
+		Limit:        50,
+	}
+	calls, err := svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+
+	expectedCalls := []CallHierarchyCall{
+		{
+			Symbol:      targetSymbol,
+			Definitions: []shared.UploadLocation{{Dump: uploads[0], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: targetDefinitionRange}},
+			CallSites:   []shared.UploadLocation{{Dump: uploads[0], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: targetCallRange}},
+		},
+		{
+			Symbol:      otherSymbol,
+			Definitions: []shared.UploadLocation{},
+			CallSites:   []shared.UploadLocation{{Dump: uploads[0], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: otherCallRange}},
+		},
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	mockRequest.Limit = 1
+	calls, err = svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+	if diff := cmp.Diff(expectedCalls[:1], calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	// Requests without a positive limit use the default limit
+	mockRequest.Limit = 0
+	calls, err = svc.GetOutgoingCalls(context.Background(), mockRequest, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error querying outgoing calls: %s", err)
+	}
+	if diff := cmp.Diff(expectedCalls, calls); diff != "" {
+		t.Errorf("unexpected calls (-want +got):\n%s", diff)
+	}
+}
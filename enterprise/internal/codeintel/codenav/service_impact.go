@@ -0,0 +1,266 @@
+package codenav
+
+import (
+	"context"
+	"io"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// GetImpactedReferences returns the symbols whose definitions are changed between the base and head
+// commits of the given range, along with the references to those symbols in all indexed repositories.
+// Definitions are read from the uploads closest to the base commit and mapped onto the base commit
+// before being compared with the diff. The given request state must be for the base commit.
+func (s *Service) GetImpactedReferences(ctx context.Context, args ImpactArgs, requestState RequestState) (_ ImpactAnalysis, err error) {
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getImpactedReferences, serviceObserverThreshold, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", args.RepositoryID),
+		attribute.String("baseCommit", args.BaseCommit),
+		attribute.String("headCommit", args.HeadCommit),
+		attribute.Int("limit", args.Limit),
+	}})
+	defer endObservation()
+
+	changedLines, err := s.getChangedLines(ctx, args, requestState)
+	if err != nil {
+		return ImpactAnalysis{}, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numChangedPaths", len(changedLines)))
+
+	symbols, err := s.getChangedSymbols(ctx, args, requestState, changedLines)
+	if err != nil {
+		return ImpactAnalysis{}, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numChangedSymbols", len(symbols)))
+
+	// Locations are adjusted to fit the base commit of the range
+	requestArgs := RequestArgs{RepositoryID: args.RepositoryID, Commit: args.BaseCommit}
+
+	var analysis ImpactAnalysis
+	repositoryIndexes := map[int]int{}
+	for _, symbol := range symbols {
+		definitions, err := s.getUploadLocations(ctx, requestArgs, requestState, symbol.definitions, true)
+		if err != nil {
+			return ImpactAnalysis{}, err
+		}
+		if len(definitions) == 0 {
+			// The symbol is only defined in files the user can't see
+			continue
+		}
+		analysis.Symbols = append(analysis.Symbols, ImpactedSymbol{Symbol: symbol.symbol, Definitions: definitions})
+
+		locations, err := s.getImpactedReferenceLocations(ctx, args, requestState, symbol)
+		if err != nil {
+			return ImpactAnalysis{}, err
+		}
+		references, err := s.getUploadLocations(ctx, requestArgs, requestState, locations, true)
+		if err != nil {
+			return ImpactAnalysis{}, err
+		}
+
+		for _, reference := range references {
+			i, ok := repositoryIndexes[reference.Dump.RepositoryID]
+			if !ok {
+				i = len(analysis.Repositories)
+				repositoryIndexes[reference.Dump.RepositoryID] = i
+				analysis.Repositories = append(analysis.Repositories, ImpactedRepository{
+					RepositoryID:   reference.Dump.RepositoryID,
+					RepositoryName: reference.Dump.RepositoryName,
+				})
+			}
+
+			analysis.Repositories[i].References = append(analysis.Repositories[i].References, ImpactedReference{
+				Symbol:   symbol.symbol,
+				Location: reference,
+			})
+		}
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numRepositories", len(analysis.Repositories)))
+
+	return analysis, nil
+}
+
+// getChangedLines returns the lines of each file of the base commit that are edited or removed between
+// the base and head commits, keyed by path.
+func (s *Service) getChangedLines(ctx context.Context, args ImpactArgs, requestState RequestState) (map[string]map[int]struct{}, error) {
+	repo, err := s.repoStore.Get(ctx, api.RepoID(args.RepositoryID))
+	if err != nil {
+		return nil, errors.Wrap(err, "repoStore.Get")
+	}
+
+	iterator, err := s.gitserver.Diff(ctx, requestState.authChecker, gitserver.DiffOptions{
+		Repo:      repo.Name,
+		Base:      args.BaseCommit,
+		Head:      args.HeadCommit,
+		RangeType: "..",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "gitserver.Diff")
+	}
+	defer iterator.Close()
+
+	changedLines := map[string]map[int]struct{}{}
+	for {
+		fileDiff, err := iterator.Next()
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "gitserver.Diff")
+		}
+
+		if fileDiff.OrigName == "/dev/null" {
+			// Added files contain no existing definitions
+			continue
+		}
+
+		if lines := changedSourceLines(fileDiff.Hunks); len(lines) > 0 {
+			changedLines[fileDiff.OrigName] = lines
+		}
+	}
+
+	return changedLines, nil
+}
+
+// changedSymbol is a symbol with a definition intersecting the changed lines of a diff.
+type changedSymbol struct {
+	symbol      string
+	definitions []shared.Location
+}
+
+// getChangedSymbols returns the global symbols defined by the uploads closest to the base commit whose
+// definition ranges intersect the given changed lines. Definition ranges are mapped from the indexed
+// commit onto the base commit; definitions on lines that changed since the indexed commit are ignored.
+func (s *Service) getChangedSymbols(ctx context.Context, args ImpactArgs, requestState RequestState, changedLines map[string]map[int]struct{}) ([]changedSymbol, error) {
+	paths := make([]string, 0, len(changedLines))
+	for path := range changedLines {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	requestArgs := RequestArgs{RepositoryID: args.RepositoryID, Commit: args.BaseCommit}
+	documents := newDocumentCache(s)
+
+	var symbols []changedSymbol
+	symbolIndexes := map[string]int{}
+	for _, path := range paths {
+		uploads, err := s.GetClosestDumpsForBlob(ctx, args.RepositoryID, args.BaseCommit, path, true, "")
+		if err != nil {
+			return nil, err
+		}
+		requestState.dataLoader.SetUploadInCacheMap(uploads)
+
+		for _, upload := range uploads {
+			pathWithoutRoot := strings.TrimPrefix(path, upload.Root)
+
+			document, err := documents.get(ctx, upload.ID, pathWithoutRoot)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, occurrence := range document.Occurrences {
+				if !isGlobalDefinition(occurrence) {
+					continue
+				}
+
+				rn := convertSCIPRange(occurrence.Range)
+				_, baseRange, ok, err := s.getSourceRange(ctx, requestArgs, requestState, upload.RepositoryID, upload.Commit, path, rn)
+				if err != nil {
+					return nil, err
+				}
+				if !ok || !rangeIntersectsLines(baseRange, changedLines[path]) {
+					continue
+				}
+
+				i, ok := symbolIndexes[occurrence.Symbol]
+				if !ok {
+					i = len(symbols)
+					symbolIndexes[occurrence.Symbol] = i
+					symbols = append(symbols, changedSymbol{symbol: occurrence.Symbol})
+				}
+				symbols[i].definitions = append(symbols[i].definitions, shared.Location{
+					DumpID: upload.ID,
+					Path:   pathWithoutRoot,
+					Range:  rn,
+				})
+			}
+		}
+	}
+
+	return symbols, nil
+}
+
+// getImpactedReferenceLocations returns up to args.Limit references to the given symbol. References are
+// searched for in the uploads defining the symbol first, then in batches of the uploads referencing the
+// package of the symbol.
+func (s *Service) getImpactedReferenceLocations(ctx context.Context, args ImpactArgs, requestState RequestState, symbol changedSymbol) ([]shared.Location, error) {
+	moniker, err := symbolToQualifiedMoniker(symbol.symbol, "export")
+	if err != nil {
+		// Symbols without a package cannot be referenced from other uploads
+		return nil, nil
+	}
+	monikers := []precise.QualifiedMonikerData{moniker}
+
+	definitionIDs := dedupeIDs(locationDumpIDs(symbol.definitions))
+	definitionUploads, err := s.getUploadsByIDs(ctx, definitionIDs, requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, _, err := s.getBulkMonikerLocations(ctx, definitionUploads, monikers, "references", args.Limit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for offset := 0; len(locations) < args.Limit; {
+		referenceIDs, recordsScanned, totalRecords, err := s.uploadSvc.GetUploadIDsWithReferences(
+			ctx,
+			monikers,
+			definitionIDs,
+			args.RepositoryID,
+			args.BaseCommit,
+			requestState.maximumIndexesPerMonikerSearch,
+			offset,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		referenceUploads, err := s.getUploadsByIDs(ctx, referenceIDs, requestState)
+		if err != nil {
+			return nil, err
+		}
+
+		batchLocations, _, err := s.getBulkMonikerLocations(ctx, referenceUploads, monikers, "references", args.Limit-len(locations), 0)
+		if err != nil {
+			return nil, err
+		}
+		locations = append(locations, batchLocations...)
+
+		offset += recordsScanned
+		if recordsScanned == 0 || offset >= totalRecords {
+			break
+		}
+	}
+
+	return locations, nil
+}
+
+// rangeIntersectsLines returns true if any line spanned by the given range is in the given set.
+func rangeIntersectsLines(rn shared.Range, lines map[int]struct{}) bool {
+	for line := rn.Start.Line; line <= rn.End.Line; line++ {
+		if _, ok := lines[line]; ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,138 @@
+package codenav
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// impactDiff changes the definition of target in callHierarchyDocument.
+const impactDiff = `diff --git a.go a.go
+index 1111111111111111111111111111111111111111..2222222222222222222222222222222222222222 100644
+--- a.go
++++ a.go
+@@ -5,3 +5,3 @@
+ type T struct{}
+ var x = target()
+-func target() {}
++func target() int { return 0 }
+diff --git b.go b.go
+new file mode 100644
+index 0000000000000000000000000000000000000000..3333333333333333333333333333333333333333
+--- /dev/null
++++ b.go
+@@ -0,0 +1 @@
++func other() {}
+`
+
+func TestImpactedReferences(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{ID: 42}, mockCommit, "", hunkCache)
+	mockRequestState.SetUploadsDataLoader(nil)
+	mockRequestState.SetMaximumIndexesPerMonikerSearch(50)
+
+	uploads := []uploadsshared.Dump{
+		{ID: 50, RepositoryID: 42, RepositoryName: "r42", Commit: mockCommit},
+		{ID: 60, RepositoryID: 43, RepositoryName: "r43", Commit: "cafebabe"},
+	}
+
+	mockRepoStore.GetFunc.SetDefaultReturn(&sgtypes.Repo{ID: 42, Name: "r42"}, nil)
+	mockGitserverClient.DiffFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, opts gitserver.DiffOptions) (*gitserver.DiffFileIterator, error) {
+		if opts.Base != mockCommit || opts.Head != "c0ffee" {
+			t.Errorf("unexpected diff range %s..%s", opts.Base, opts.Head)
+		}
+		return gitserver.NewDiffFileIterator(io.NopCloser(strings.NewReader(impactDiff))), nil
+	})
+	mockGitserverClient.CommitsExistFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, rcs []api.RepoCommit) (exists []bool, _ error) {
+		for range rcs {
+			exists = append(exists, true)
+		}
+		return exists, nil
+	})
+	mockUploadSvc.InferClosestUploadsFunc.SetDefaultReturn(uploads[:1], nil)
+	mockUploadSvc.GetUploadIDsWithReferencesFunc.SetDefaultReturn([]int{60}, 1, 1, nil)
+	mockUploadSvc.GetDumpsByIDsFunc.SetDefaultReturn(uploads[1:], nil)
+	mockLsifStore.GetPathExistsFunc.SetDefaultReturn(true, nil)
+	mockLsifStore.SCIPDocumentFunc.SetDefaultReturn(callHierarchyDocument, nil)
+
+	referenceRange := shared.Range{Start: shared.Position{Line: 3, Character: 2}, End: shared.Position{Line: 3, Character: 8}}
+	mockLsifStore.GetBulkMonikerLocationsFunc.SetDefaultHook(func(_ context.Context, tableName string, uploadIDs []int, monikers []precise.MonikerData, _, _ int) ([]shared.Location, int, error) {
+		if tableName != "references" || len(monikers) != 1 || monikers[0].Identifier != targetSymbol {
+			t.Errorf("unexpected moniker search of %s for %v", tableName, monikers)
+		}
+
+		switch uploadIDs[0] {
+		case 50:
+			return []shared.Location{
+				{DumpID: 50, Path: "a.go", Range: targetCallRange},
+				{DumpID: 50, Path: "a.go", Range: varCallRange},
+			}, 2, nil
+		case 60:
+			return []shared.Location{{DumpID: 60, Path: "c.go", Range: referenceRange}}, 1, nil
+		}
+		return nil, 0, nil
+	})
+
+	analysis, err := svc.GetImpactedReferences(context.Background(), ImpactArgs{
+		RepositoryID: 42,
+		BaseCommit:   mockCommit,
+		HeadCommit:   "c0ffee",
+		Limit:        50,
+	}, mockRequestState)
+	if err != nil {
+		t.Fatalf("unexpected error analyzing impact: %s", err)
+	}
+
+	uploadLocation := func(dump uploadsshared.Dump, path string, rn shared.Range) shared.UploadLocation {
+		return shared.UploadLocation{Dump: dump, Path: path, TargetCommit: dump.Commit, TargetRange: rn}
+	}
+	expectedAnalysis := ImpactAnalysis{
+		Symbols: []ImpactedSymbol{
+			{Symbol: targetSymbol, Definitions: []shared.UploadLocation{uploadLocation(uploads[0], "a.go", targetDefinitionRange)}},
+		},
+		Repositories: []ImpactedRepository{
+			{
+				RepositoryID:   42,
+				RepositoryName: "r42",
+				References: []ImpactedReference{
+					{Symbol: targetSymbol, Location: uploadLocation(uploads[0], "a.go", targetCallRange)},
+					{Symbol: targetSymbol, Location: uploadLocation(uploads[0], "a.go", varCallRange)},
+				},
+			},
+			{
+				RepositoryID:   43,
+				RepositoryName: "r43",
+				References: []ImpactedReference{
+					{Symbol: targetSymbol, Location: uploadLocation(uploads[1], "c.go", referenceRange)},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedAnalysis, analysis); diff != "" {
+		t.Errorf("unexpected analysis (-want +got):\n%s", diff)
+	}
+}
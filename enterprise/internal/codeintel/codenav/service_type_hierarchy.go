@@ -0,0 +1,438 @@
+package codenav
+
+import (
+	"context"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// The directions in which a type hierarchy can be walked.
+const (
+	TypeHierarchySupertypes = "supertypes"
+	TypeHierarchySubtypes   = "subtypes"
+)
+
+// typeHierarchyLocationsLimit is the maximum number of subtype definitions read when expanding a
+// single type of a hierarchy.
+const typeHierarchyLocationsLimit = 500
+
+// maxTypeHierarchyNodes is the maximum number of types of a type hierarchy, which bounds the size of
+// its cursor.
+const maxTypeHierarchyNodes = 500
+
+// defaultTypeHierarchyLimit is the number of types returned for requests without a positive limit.
+const defaultTypeHierarchyLimit = 100
+
+// GetTypeHierarchy returns the next page of the type hierarchy rooted at the type at the given position.
+// The hierarchy is walked breadth-first in the given direction by following the implementation
+// relationships of SCIP symbols transitively, across uploads and repositories. Each node refers to its
+// parent by ID. A type that already occurs in the hierarchy is returned again but is not expanded any
+// further, and it is marked as a cycle if it occurs on the path from the root.
+func (s *Service) GetTypeHierarchy(ctx context.Context, args RequestArgs, requestState RequestState, direction string, cursor TypeHierarchyCursor) (_ []TypeHierarchyNode, _ TypeHierarchyCursor, err error) {
+	if args.Limit <= 0 {
+		args.Limit = defaultTypeHierarchyLimit
+	}
+	ctx, trace, endObservation := observeResolver(ctx, &err, s.operations.getTypeHierarchy, serviceObserverThreshold, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("repositoryID", args.RepositoryID),
+		attribute.String("commit", args.Commit),
+		attribute.String("path", args.Path),
+		attribute.Int("numUploads", len(requestState.GetCacheUploads())),
+		attribute.String("uploads", uploadIDsToString(requestState.GetCacheUploads())),
+		attribute.Int("line", args.Line),
+		attribute.Int("character", args.Character),
+		attribute.String("direction", direction),
+		attribute.String("phase", cursor.Phase),
+	}})
+	defer endObservation()
+
+	if direction != TypeHierarchySupertypes && direction != TypeHierarchySubtypes {
+		return nil, cursor, errors.Newf("unknown type hierarchy direction %q", direction)
+	}
+
+	documents := newDocumentCache(s)
+	// The definitions of the types found by this request, by type ID. The definitions of the types found
+	// by previous requests are looked up again when needed.
+	definitions := map[int][]shared.Location{}
+
+	if cursor.Phase == "start" {
+		root, rootDefinitions, ok, err := s.getTypeHierarchyRoot(ctx, args, requestState, documents, trace)
+		if err != nil {
+			return nil, cursor, err
+		}
+		if ok {
+			cursor.Nodes = []TypeHierarchyCursorNode{root}
+			definitions[1] = rootDefinitions
+		}
+		cursor.Phase = "walk"
+	}
+
+	visited := make(map[string]struct{}, len(cursor.Nodes))
+	for _, node := range cursor.Nodes {
+		visited[node.Symbol] = struct{}{}
+	}
+
+	var nodes []TypeHierarchyNode
+	for cursor.Phase == "walk" && len(nodes) < args.Limit {
+		// Return the types we've found before looking for more
+		if cursor.Returned < len(cursor.Nodes) {
+			id := cursor.Returned + 1
+			node := &cursor.Nodes[id-1]
+			cursor.Returned++
+
+			locations, err := s.getTypeDefinitions(ctx, args, requestState, documents, trace, id, *node, definitions)
+			if err != nil {
+				return nil, cursor, err
+			}
+			uploadLocations, err := s.getUploadLocations(ctx, args, requestState, locations, true)
+			if err != nil {
+				return nil, cursor, err
+			}
+			if len(locations) > 0 && len(uploadLocations) == 0 {
+				// The type is only defined in files the user can't see
+				node.Leaf = true
+				continue
+			}
+
+			depth, cycle := typeHierarchyPath(cursor.Nodes, id)
+			nodes = append(nodes, TypeHierarchyNode{
+				ID:          id,
+				ParentID:    node.ParentID,
+				Depth:       depth,
+				Symbol:      node.Symbol,
+				Definitions: uploadLocations,
+				Cycle:       cycle,
+			})
+			continue
+		}
+
+		if cursor.Expanded == cursor.Returned {
+			cursor.Phase = "done"
+			break
+		}
+		id := cursor.Expanded + 1
+		node := cursor.Nodes[id-1]
+		cursor.Expanded++
+		if node.Leaf {
+			continue
+		}
+
+		locations, err := s.getTypeDefinitions(ctx, args, requestState, documents, trace, id, node, definitions)
+		if err != nil {
+			return nil, cursor, err
+		}
+		var related []relatedType
+		if direction == TypeHierarchySupertypes {
+			related, err = s.getSupertypes(ctx, node.Symbol, locations, requestState, documents)
+		} else {
+			related, err = s.getSubtypes(ctx, args, node.Symbol, locations, requestState, documents, trace)
+		}
+		if err != nil {
+			return nil, cursor, err
+		}
+
+		for _, r := range related {
+			if len(cursor.Nodes) >= maxTypeHierarchyNodes {
+				trace.AddEvent("TODO Domain Owner", attribute.Bool("truncated", true))
+				break
+			}
+
+			_, seen := visited[r.symbol]
+			visited[r.symbol] = struct{}{}
+			cursor.Nodes = append(cursor.Nodes, TypeHierarchyCursorNode{
+				Symbol:    r.symbol,
+				ParentID:  id,
+				UploadIDs: dedupeIDs(locationDumpIDs(r.definitions)),
+				Leaf:      seen,
+			})
+			definitions[len(cursor.Nodes)] = r.definitions
+		}
+	}
+	if cursor.Returned == len(cursor.Nodes) && cursor.Expanded == cursor.Returned {
+		cursor.Phase = "done"
+	}
+	trace.AddEvent("TODO Domain Owner",
+		attribute.Int("numNodes", len(nodes)),
+		attribute.Int("numFound", len(cursor.Nodes)),
+		attribute.Int("numExpanded", cursor.Expanded))
+
+	return nodes, cursor, nil
+}
+
+// typeHierarchyPath returns the depth of the type with the given ID and whether its symbol occurs on the
+// path from the root to it.
+func typeHierarchyPath(nodes []TypeHierarchyCursorNode, id int) (depth int, cycle bool) {
+	symbol := nodes[id-1].Symbol
+	for parentID := nodes[id-1].ParentID; parentID != 0; parentID = nodes[parentID-1].ParentID {
+		depth++
+		if nodes[parentID-1].Symbol == symbol {
+			cycle = true
+		}
+	}
+
+	return depth, cycle
+}
+
+// getTypeHierarchyRoot returns the root of the type hierarchy: the symbol at the given position and its
+// definitions. If there is no symbol at the given position, a false-valued flag is returned.
+func (s *Service) getTypeHierarchyRoot(ctx context.Context, args RequestArgs, requestState RequestState, documents *documentCache, trace observation.TraceLogger) (TypeHierarchyCursorNode, []shared.Location, bool, error) {
+	visibleUploads, err := s.getVisibleUploads(ctx, args.Line, args.Character, requestState)
+	if err != nil {
+		return TypeHierarchyCursorNode{}, nil, false, err
+	}
+
+	for _, upload := range visibleUploads {
+		document, err := documents.get(ctx, upload.Upload.ID, upload.TargetPathWithoutRoot)
+		if err != nil {
+			return TypeHierarchyCursorNode{}, nil, false, err
+		}
+
+		for _, occurrence := range scip.FindOccurrences(document.Occurrences, int32(upload.TargetPosition.Line), int32(upload.TargetPosition.Character)) {
+			if occurrence.Symbol == "" || scip.IsLocalSymbol(occurrence.Symbol) {
+				continue
+			}
+
+			definitions, err := s.getDefinitionLocations(ctx, []visibleUpload{upload}, requestState, trace)
+			if err != nil {
+				return TypeHierarchyCursorNode{}, nil, false, err
+			}
+
+			return TypeHierarchyCursorNode{
+				Symbol:    occurrence.Symbol,
+				UploadIDs: dedupeIDs(locationDumpIDs(definitions)),
+			}, definitions, true, nil
+		}
+	}
+
+	return TypeHierarchyCursorNode{}, nil, false, nil
+}
+
+// getTypeDefinitions returns the definitions of the type with the given ID. The definitions of a type found
+// by a previous request are looked up again in the uploads that defined it, which also inserts those uploads
+// into the request's upload cache so that the definitions can be adjusted to the requested commit.
+func (s *Service) getTypeDefinitions(ctx context.Context, args RequestArgs, requestState RequestState, documents *documentCache, trace observation.TraceLogger, id int, node TypeHierarchyCursorNode, definitions map[int][]shared.Location) ([]shared.Location, error) {
+	if locations, ok := definitions[id]; ok {
+		return locations, nil
+	}
+
+	var locations []shared.Location
+	if id == 1 {
+		_, rootDefinitions, _, err := s.getTypeHierarchyRoot(ctx, args, requestState, documents, trace)
+		if err != nil {
+			return nil, err
+		}
+		locations = rootDefinitions
+	} else if moniker, err := symbolToQualifiedMoniker(node.Symbol, "export"); err == nil && len(node.UploadIDs) > 0 {
+		uploads, err := s.getUploadsByIDs(ctx, node.UploadIDs, requestState)
+		if err != nil {
+			return nil, err
+		}
+		locations, _, err = s.getBulkMonikerLocations(ctx, uploads, []precise.QualifiedMonikerData{moniker}, "definitions", DefinitionsLimit, 0)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	definitions[id] = locations
+	return locations, nil
+}
+
+// relatedType is a supertype or subtype of a type in a type hierarchy.
+type relatedType struct {
+	symbol      string
+	definitions []shared.Location
+}
+
+// getSupertypes returns the types the given type implements or extends, as recorded in the symbol
+// information of the documents defining it.
+func (s *Service) getSupertypes(ctx context.Context, typeSymbol string, typeDefinitions []shared.Location, requestState RequestState, documents *documentCache) ([]relatedType, error) {
+	var symbols []string
+	for _, definition := range typeDefinitions {
+		document, err := documents.get(ctx, definition.DumpID, definition.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if symbol := scip.FindSymbol(document, typeSymbol); symbol != nil {
+			for _, relationship := range symbol.Relationships {
+				if relationship.IsImplementation && !sliceContains(symbols, relationship.Symbol) {
+					symbols = append(symbols, relationship.Symbol)
+				}
+			}
+		}
+	}
+
+	if len(symbols) == 0 {
+		return nil, nil
+	}
+
+	// Supertypes are either defined next to the type or in an upload providing their package
+	nearbyUploads, err := s.getUploadsByIDs(ctx, dedupeIDs(locationDumpIDs(typeDefinitions)), requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	related := make([]relatedType, 0, len(symbols))
+	for _, symbol := range symbols {
+		moniker, err := symbolToQualifiedMoniker(symbol, "import")
+		if err != nil {
+			// Skip malformed symbols emitted by the indexer
+			continue
+		}
+
+		uploads := append([]uploadsshared.Dump(nil), nearbyUploads...)
+		definitionUploads, err := s.getUploadsWithDefinitionsForMonikers(ctx, []precise.QualifiedMonikerData{moniker}, requestState)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[int]struct{}, len(uploads))
+		for _, upload := range uploads {
+			seen[upload.ID] = struct{}{}
+		}
+		for _, upload := range definitionUploads {
+			if _, ok := seen[upload.ID]; !ok {
+				uploads = append(uploads, upload)
+			}
+		}
+
+		definitions, _, err := s.getBulkMonikerLocations(ctx, uploads, []precise.QualifiedMonikerData{moniker}, "definitions", DefinitionsLimit, 0)
+		if err != nil {
+			return nil, err
+		}
+
+		related = append(related, relatedType{symbol: symbol, definitions: definitions})
+	}
+
+	return related, nil
+}
+
+// getSubtypes returns the types implementing or extending the given type. Subtypes are searched for in
+// the uploads defining the type as well as in one batch of the uploads referencing it.
+func (s *Service) getSubtypes(ctx context.Context, args RequestArgs, typeSymbol string, typeDefinitions []shared.Location, requestState RequestState, documents *documentCache, trace observation.TraceLogger) ([]relatedType, error) {
+	moniker, err := symbolToQualifiedMoniker(typeSymbol, "export")
+	if err != nil {
+		return nil, nil
+	}
+	monikers := []precise.QualifiedMonikerData{moniker}
+
+	ids := dedupeIDs(locationDumpIDs(typeDefinitions))
+	referenceIDs, _, _, err := s.uploadSvc.GetUploadIDsWithReferences(
+		ctx,
+		monikers,
+		ids,
+		args.RepositoryID,
+		args.Commit,
+		requestState.maximumIndexesPerMonikerSearch,
+		0,
+	)
+	if err != nil {
+		return nil, err
+	}
+	trace.AddEvent("TODO Domain Owner", attribute.Int("numReferenceUploads", len(referenceIDs)))
+
+	uploads, err := s.getUploadsByIDs(ctx, dedupeIDs(append(ids, referenceIDs...)), requestState)
+	if err != nil {
+		return nil, err
+	}
+
+	locations, _, err := s.getBulkMonikerLocations(ctx, uploads, monikers, "implementations", typeHierarchyLocationsLimit, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var related []relatedType
+	relatedIndexes := map[string]int{}
+	for _, location := range locations {
+		document, err := documents.get(ctx, location.DumpID, location.Path)
+		if err != nil {
+			return nil, err
+		}
+		symbol, ok := implementingSymbol(document, location.Range, typeSymbol)
+		if !ok {
+			continue
+		}
+
+		i, ok := relatedIndexes[symbol]
+		if !ok {
+			i = len(related)
+			relatedIndexes[symbol] = i
+			related = append(related, relatedType{symbol: symbol})
+		}
+		related[i].definitions = append(related[i].definitions, location)
+	}
+
+	return related, nil
+}
+
+// implementingSymbol returns the symbol defined at the given range of the document that has an
+// implementation relationship to the given symbol.
+func implementingSymbol(document *scip.Document, rn shared.Range, symbol string) (string, bool) {
+	for _, occurrence := range document.Occurrences {
+		if !isGlobalDefinition(occurrence) || convertSCIPRange(occurrence.Range) != rn {
+			continue
+		}
+
+		if info := scip.FindSymbol(document, occurrence.Symbol); info != nil {
+			for _, relationship := range info.Relationships {
+				if relationship.IsImplementation && relationship.Symbol == symbol {
+					return occurrence.Symbol, true
+				}
+			}
+		}
+	}
+
+	return "", false
+}
+
+// symbolToQualifiedMoniker returns the moniker of the given global SCIP symbol, qualified by the package
+// encoded in the symbol.
+func symbolToQualifiedMoniker(symbol, kind string) (precise.QualifiedMonikerData, error) {
+	parsed, err := scip.ParseSymbol(symbol)
+	if err != nil {
+		return precise.QualifiedMonikerData{}, err
+	}
+	if parsed.Package == nil {
+		return precise.QualifiedMonikerData{}, errors.Newf("symbol %q has no package", symbol)
+	}
+
+	return precise.QualifiedMonikerData{
+		MonikerData: precise.MonikerData{
+			Kind:       kind,
+			Scheme:     parsed.Scheme,
+			Identifier: symbol,
+		},
+		PackageInformationData: precise.PackageInformationData{
+			Manager: parsed.Package.Manager,
+			Name:    parsed.Package.Name,
+			Version: parsed.Package.Version,
+		},
+	}, nil
+}
+
+func locationDumpIDs(locations []shared.Location) []int {
+	ids := make([]int, 0, len(locations))
+	for _, location := range locations {
+		ids = append(ids, location.DumpID)
+	}
+
+	return ids
+}
+
+func dedupeIDs(ids []int) []int {
+	seen := make(map[int]struct{}, len(ids))
+	deduped := ids[:0]
+	for _, id := range ids {
+		if _, ok := seen[id]; !ok {
+			seen[id] = struct{}{}
+			deduped = append(deduped, id)
+		}
+	}
+
+	return deduped
+}
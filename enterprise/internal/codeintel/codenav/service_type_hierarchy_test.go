@@ -0,0 +1,285 @@
+package codenav
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav/shared"
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	sgtypes "github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+const (
+	animalSymbol = "scip-go gomod example v1 example/Animal#"
+	dogSymbol    = "scip-go gomod example v1 example/Dog#"
+	puppySymbol  = "scip-go gomod example v1 example/Puppy#"
+)
+
+var (
+	animalDefinitionRange = shared.Range{Start: shared.Position{Line: 0, Character: 5}, End: shared.Position{Line: 0, Character: 11}}
+	dogDefinitionRange    = shared.Range{Start: shared.Position{Line: 1, Character: 5}, End: shared.Position{Line: 1, Character: 8}}
+	puppyDefinitionRange  = shared.Range{Start: shared.Position{Line: 2, Character: 5}, End: shared.Position{Line: 2, Character: 10}}
+)
+
+// typeHierarchyDocument is the SCIP document of a file defining three types, each of which
+// implements the next one: Puppy implements Dog, Dog implements Animal, and Animal implements
+// Puppy (forming a cycle).
+var typeHierarchyDocument = &scip.Document{
+	RelativePath: "a.go",
+	Occurrences: []*scip.Occurrence{
+		{Range: []int32{0, 5, 11}, Symbol: animalSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{1, 5, 8}, Symbol: dogSymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+		{Range: []int32{2, 5, 10}, Symbol: puppySymbol, SymbolRoles: int32(scip.SymbolRole_Definition)},
+	},
+	Symbols: []*scip.SymbolInformation{
+		{Symbol: animalSymbol, Relationships: []*scip.Relationship{{Symbol: puppySymbol, IsImplementation: true}}},
+		{Symbol: dogSymbol, Relationships: []*scip.Relationship{{Symbol: animalSymbol, IsImplementation: true}}},
+		{Symbol: puppySymbol, Relationships: []*scip.Relationship{{Symbol: dogSymbol, IsImplementation: true}}},
+	},
+}
+
+func TestTypeHierarchy(t *testing.T) {
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	uploads := []uploadsshared.Dump{
+		{ID: 51, Commit: mockCommit, Root: "sub2/"},
+	}
+	mockRequestState.SetUploadsDataLoader(uploads)
+
+	definitionRanges := map[string]shared.Range{
+		animalSymbol: animalDefinitionRange,
+		dogSymbol:    dogDefinitionRange,
+		puppySymbol:  puppyDefinitionRange,
+	}
+	subtypes := map[string]string{
+		animalSymbol: dogSymbol,
+		dogSymbol:    puppySymbol,
+		puppySymbol:  animalSymbol,
+	}
+
+	mockLsifStore.SCIPDocumentFunc.SetDefaultReturn(typeHierarchyDocument, nil)
+	mockLsifStore.GetDefinitionLocationsFunc.SetDefaultHook(func(_ context.Context, uploadID int, _ string, line, _, _, _ int) ([]shared.Location, int, error) {
+		for _, rn := range definitionRanges {
+			if rn.Start.Line == line {
+				return []shared.Location{{DumpID: uploadID, Path: "a.go", Range: rn}}, 1, nil
+			}
+		}
+		return nil, 0, nil
+	})
+	mockLsifStore.GetBulkMonikerLocationsFunc.SetDefaultHook(func(_ context.Context, tableName string, uploadIDs []int, monikers []precise.MonikerData, _, _ int) ([]shared.Location, int, error) {
+		symbol := monikers[0].Identifier
+		if tableName == "implementations" {
+			symbol = subtypes[symbol]
+		}
+		return []shared.Location{{DumpID: uploadIDs[0], Path: "a.go", Range: definitionRanges[symbol]}}, 1, nil
+	})
+
+	definitions := func(rn shared.Range) []shared.UploadLocation {
+		return []shared.UploadLocation{{Dump: uploads[0], Path: "sub2/a.go", TargetCommit: mockCommit, TargetRange: rn}}
+	}
+
+	testCases := []struct {
+		name      string
+		direction string
+		line      int
+		expected  []TypeHierarchyNode
+	}{
+		{
+			name:      "supertypes",
+			direction: TypeHierarchySupertypes,
+			line:      puppyDefinitionRange.Start.Line,
+			expected: []TypeHierarchyNode{
+				{ID: 1, Symbol: puppySymbol, Definitions: definitions(puppyDefinitionRange)},
+				{ID: 2, ParentID: 1, Depth: 1, Symbol: dogSymbol, Definitions: definitions(dogDefinitionRange)},
+				{ID: 3, ParentID: 2, Depth: 2, Symbol: animalSymbol, Definitions: definitions(animalDefinitionRange)},
+				{ID: 4, ParentID: 3, Depth: 3, Symbol: puppySymbol, Definitions: definitions(puppyDefinitionRange), Cycle: true},
+			},
+		},
+		{
+			name:      "subtypes",
+			direction: TypeHierarchySubtypes,
+			line:      animalDefinitionRange.Start.Line,
+			expected: []TypeHierarchyNode{
+				{ID: 1, Symbol: animalSymbol, Definitions: definitions(animalDefinitionRange)},
+				{ID: 2, ParentID: 1, Depth: 1, Symbol: dogSymbol, Definitions: definitions(dogDefinitionRange)},
+				{ID: 3, ParentID: 2, Depth: 2, Symbol: puppySymbol, Definitions: definitions(puppyDefinitionRange)},
+				{ID: 4, ParentID: 3, Depth: 3, Symbol: animalSymbol, Definitions: definitions(animalDefinitionRange), Cycle: true},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockRequest := RequestArgs{
+				RepositoryID: 42,
+				Commit:       mockCommit,
+				Path:         "sub2/a.go",
+				Line:         testCase.line,
+				Character:    6,
+				Limit:        3,
+			}
+
+			var nodes []TypeHierarchyNode
+			cursor := TypeHierarchyCursor{Phase: "start"}
+			for pages := 0; cursor.Phase != "done"; pages++ {
+				if pages == 3 {
+					t.Fatalf("type hierarchy did not terminate")
+				}
+
+				page, nextCursor, err := svc.GetTypeHierarchy(context.Background(), mockRequest, mockRequestState, testCase.direction, cursor)
+				if err != nil {
+					t.Fatalf("unexpected error querying type hierarchy: %s", err)
+				}
+				if len(page) > mockRequest.Limit {
+					t.Fatalf("unexpected page size. want<=%d have=%d", mockRequest.Limit, len(page))
+				}
+
+				nodes = append(nodes, page...)
+				cursor = nextCursor
+			}
+
+			if diff := cmp.Diff(testCase.expected, nodes); diff != "" {
+				t.Errorf("unexpected nodes (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("default limit", func(t *testing.T) {
+		mockRequest := RequestArgs{
+			RepositoryID: 42,
+			Commit:       mockCommit,
+			Path:         "sub2/a.go",
+			Line:         puppyDefinitionRange.Start.Line,
+			Character:    6,
+		}
+
+		nodes, cursor, err := svc.GetTypeHierarchy(context.Background(), mockRequest, mockRequestState, TypeHierarchySupertypes, TypeHierarchyCursor{Phase: "start"})
+		if err != nil {
+			t.Fatalf("unexpected error querying type hierarchy: %s", err)
+		}
+		if cursor.Phase != "done" {
+			t.Errorf("unexpected cursor phase. want=%q have=%q", "done", cursor.Phase)
+		}
+		if diff := cmp.Diff(testCases[0].expected, nodes); diff != "" {
+			t.Errorf("unexpected nodes (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestTypeHierarchyDiamond(t *testing.T) {
+	// Bottom implements Left and Right, which both implement Base, which implements Top.
+	symbols := []string{
+		"scip-go gomod example v1 example/Bottom#",
+		"scip-go gomod example v1 example/Left#",
+		"scip-go gomod example v1 example/Right#",
+		"scip-go gomod example v1 example/Base#",
+		"scip-go gomod example v1 example/Top#",
+	}
+	bottom, left, right, base, top := symbols[0], symbols[1], symbols[2], symbols[3], symbols[4]
+	supertypes := map[string][]string{bottom: {left, right}, left: {base}, right: {base}, base: {top}}
+
+	document := &scip.Document{RelativePath: "a.go"}
+	definitionRanges := map[string]shared.Range{}
+	for i, symbol := range symbols {
+		definitionRanges[symbol] = shared.Range{Start: shared.Position{Line: i, Character: 5}, End: shared.Position{Line: i, Character: 10}}
+		document.Occurrences = append(document.Occurrences, &scip.Occurrence{Range: []int32{int32(i), 5, 10}, Symbol: symbol, SymbolRoles: int32(scip.SymbolRole_Definition)})
+
+		info := &scip.SymbolInformation{Symbol: symbol}
+		for _, supertype := range supertypes[symbol] {
+			info.Relationships = append(info.Relationships, &scip.Relationship{Symbol: supertype, IsImplementation: true})
+		}
+		document.Symbols = append(document.Symbols, info)
+	}
+
+	// Set up mocks
+	mockRepoStore := defaultMockRepoStore()
+	mockLsifStore := NewMockLsifStore()
+	mockUploadSvc := NewMockUploadService()
+	mockGitserverClient := gitserver.NewMockClient()
+	hunkCache, _ := NewHunkCache(50)
+
+	// Init service
+	svc := newService(&observation.TestContext, mockRepoStore, mockLsifStore, mockUploadSvc, mockGitserverClient)
+
+	// Set up request state
+	mockRequestState := RequestState{}
+	mockRequestState.SetLocalCommitCache(mockRepoStore, mockGitserverClient)
+	mockRequestState.SetLocalGitTreeTranslator(mockGitserverClient, &sgtypes.Repo{}, mockCommit, mockPath, hunkCache)
+	mockRequestState.SetUploadsDataLoader([]uploadsshared.Dump{{ID: 51, Commit: mockCommit, Root: "sub2/"}})
+
+	mockLsifStore.SCIPDocumentFunc.SetDefaultReturn(document, nil)
+	mockLsifStore.GetDefinitionLocationsFunc.SetDefaultHook(func(_ context.Context, uploadID int, _ string, line, _, _, _ int) ([]shared.Location, int, error) {
+		return []shared.Location{{DumpID: uploadID, Path: "a.go", Range: definitionRanges[symbols[line]]}}, 1, nil
+	})
+	mockLsifStore.GetBulkMonikerLocationsFunc.SetDefaultHook(func(_ context.Context, _ string, uploadIDs []int, monikers []precise.MonikerData, _, _ int) ([]shared.Location, int, error) {
+		return []shared.Location{{DumpID: uploadIDs[0], Path: "a.go", Range: definitionRanges[monikers[0].Identifier]}}, 1, nil
+	})
+
+	mockRequest := RequestArgs{
+		RepositoryID: 42,
+		Commit:       mockCommit,
+		Path:         "sub2/a.go",
+		Line:         0,
+		Character:    6,
+		Limit:        2,
+	}
+
+	type node struct {
+		ID, ParentID, Depth int
+		Symbol              string
+		Cycle               bool
+	}
+	var nodes []node
+	cursor := TypeHierarchyCursor{Phase: "start"}
+	for pages := 0; cursor.Phase != "done"; pages++ {
+		if pages == 5 {
+			t.Fatalf("type hierarchy did not terminate")
+		}
+
+		page, nextCursor, err := svc.GetTypeHierarchy(context.Background(), mockRequest, mockRequestState, TypeHierarchySupertypes, cursor)
+		if err != nil {
+			t.Fatalf("unexpected error querying type hierarchy: %s", err)
+		}
+		for _, n := range page {
+			if len(n.Definitions) != 1 {
+				t.Fatalf("unexpected definitions of %s: %v", n.Symbol, n.Definitions)
+			}
+			nodes = append(nodes, node{n.ID, n.ParentID, n.Depth, n.Symbol, n.Cycle})
+		}
+		cursor = nextCursor
+	}
+
+	// Base is reached twice, but only expanded once.
+	expected := []node{
+		{1, 0, 0, bottom, false},
+		{2, 1, 1, left, false},
+		{3, 1, 1, right, false},
+		{4, 2, 2, base, false},
+		{5, 3, 2, base, false},
+		{6, 4, 3, top, false},
+	}
+	if diff := cmp.Diff(expected, nodes); diff != "" {
+		t.Errorf("unexpected nodes (-want +got):\n%s", diff)
+	}
+	if len(cursor.Nodes) != len(expected) {
+		t.Errorf("unexpected number of cursor nodes. want=%d have=%d", len(expected), len(cursor.Nodes))
+	}
+}
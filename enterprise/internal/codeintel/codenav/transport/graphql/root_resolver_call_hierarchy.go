@@ -0,0 +1,84 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers/gitresolvers"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultCallHierarchyPageSize is the number of calls returned when no limit is supplied.
+const DefaultCallHierarchyPageSize = 100
+
+// IncomingCalls returns the calls of the function or method at the given position, grouped by calling function.
+func (r *gitBlobLSIFDataResolver) IncomingCalls(ctx context.Context, args *resolverstubs.LSIFCallHierarchyArgs) (_ resolverstubs.CallHierarchyCallConnectionResolver, err error) {
+	limit := int(resolverstubs.Deref(args.First, DefaultCallHierarchyPageSize))
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	requestArgs := codenav.RequestArgs{RepositoryID: r.requestState.RepositoryID, Commit: r.requestState.Commit, Path: r.requestState.Path, Line: int(args.Line), Character: int(args.Character), Limit: limit}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.incomingCalls, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	calls, err := r.codeNavSvc.GetIncomingCalls(ctx, requestArgs, r.requestState)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetIncomingCalls")
+	}
+
+	return newCallHierarchyCallConnectionResolver(calls, r.locationResolver), nil
+}
+
+// OutgoingCalls returns the calls made by the function or method at the given position, grouped by called function.
+func (r *gitBlobLSIFDataResolver) OutgoingCalls(ctx context.Context, args *resolverstubs.LSIFCallHierarchyArgs) (_ resolverstubs.CallHierarchyCallConnectionResolver, err error) {
+	limit := int(resolverstubs.Deref(args.First, DefaultCallHierarchyPageSize))
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	requestArgs := codenav.RequestArgs{RepositoryID: r.requestState.RepositoryID, Commit: r.requestState.Commit, Path: r.requestState.Path, Line: int(args.Line), Character: int(args.Character), Limit: limit}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.outgoingCalls, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	calls, err := r.codeNavSvc.GetOutgoingCalls(ctx, requestArgs, r.requestState)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetOutgoingCalls")
+	}
+
+	return newCallHierarchyCallConnectionResolver(calls, r.locationResolver), nil
+}
+
+func newCallHierarchyCallConnectionResolver(calls []codenav.CallHierarchyCall, locationResolver *gitresolvers.CachedLocationResolver) resolverstubs.CallHierarchyCallConnectionResolver {
+	resolvers := make([]resolverstubs.CallHierarchyCallResolver, 0, len(calls))
+	for _, call := range calls {
+		resolvers = append(resolvers, &callHierarchyCallResolver{
+			call:             call,
+			locationResolver: locationResolver,
+		})
+	}
+
+	return resolverstubs.NewConnectionResolver(resolvers)
+}
+
+//
+//
+
+type callHierarchyCallResolver struct {
+	call             codenav.CallHierarchyCall
+	locationResolver *gitresolvers.CachedLocationResolver
+}
+
+func (r *callHierarchyCallResolver) Symbol() string {
+	return r.call.Symbol
+}
+
+func (r *callHierarchyCallResolver) Definitions(ctx context.Context) (resolverstubs.LocationConnectionResolver, error) {
+	return newLocationConnectionResolver(r.call.Definitions, nil, r.locationResolver), nil
+}
+
+func (r *callHierarchyCallResolver) CallSites(ctx context.Context) (resolverstubs.LocationConnectionResolver, error) {
+	return newLocationConnectionResolver(r.call.CallSites, nil, r.locationResolver), nil
+}
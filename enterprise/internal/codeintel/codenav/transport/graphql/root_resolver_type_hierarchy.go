@@ -0,0 +1,123 @@
+package graphql
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/codenav"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/resolvers/gitresolvers"
+	resolverstubs "github.com/sourcegraph/sourcegraph/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// DefaultTypeHierarchyPageSize is the number of types returned when no limit is supplied.
+const DefaultTypeHierarchyPageSize = 100
+
+// TypeHierarchy returns the supertypes or subtypes of the type at the given position, transitively.
+func (r *gitBlobLSIFDataResolver) TypeHierarchy(ctx context.Context, args *resolverstubs.LSIFTypeHierarchyArgs) (_ resolverstubs.TypeHierarchyNodeConnectionResolver, err error) {
+	limit := int(resolverstubs.Deref(args.First, DefaultTypeHierarchyPageSize))
+	if limit <= 0 {
+		return nil, ErrIllegalLimit
+	}
+
+	rawCursor, err := decodeCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+
+	requestArgs := codenav.RequestArgs{RepositoryID: r.requestState.RepositoryID, Commit: r.requestState.Commit, Path: r.requestState.Path, Line: int(args.Line), Character: int(args.Character), Limit: limit, RawCursor: rawCursor}
+	ctx, _, endObservation := observeResolver(ctx, &err, r.operations.typeHierarchy, time.Second, getObservationArgs(requestArgs))
+	defer endObservation()
+
+	// Decode cursor given from previous response or create a new one with default values.
+	// The cursor holds the types found so far and how many of them have been returned and
+	// expanded.
+	var nextCursor string
+	cursor, err := decodeTypeHierarchyCursor(rawCursor)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("invalid cursor: %q", rawCursor))
+	}
+
+	nodes, hierarchyCursor, err := r.codeNavSvc.GetTypeHierarchy(ctx, requestArgs, r.requestState, strings.ToLower(args.Direction), cursor)
+	if err != nil {
+		return nil, errors.Wrap(err, "codeNavSvc.GetTypeHierarchy")
+	}
+
+	if hierarchyCursor.Phase != "done" {
+		nextCursor = encodeTypeHierarchyCursor(hierarchyCursor)
+	}
+
+	resolvers := make([]resolverstubs.TypeHierarchyNodeResolver, 0, len(nodes))
+	for _, node := range nodes {
+		resolvers = append(resolvers, &typeHierarchyNodeResolver{
+			node:             node,
+			locationResolver: r.locationResolver,
+		})
+	}
+
+	return resolverstubs.NewCursorConnectionResolver(resolvers, encodeCursor(resolverstubs.NonZeroPtr(nextCursor))), nil
+}
+
+// decodeTypeHierarchyCursor is the inverse of encodeTypeHierarchyCursor. If the given encoded
+// string is empty, then a fresh cursor is returned.
+func decodeTypeHierarchyCursor(rawEncoded string) (codenav.TypeHierarchyCursor, error) {
+	if rawEncoded == "" {
+		return codenav.TypeHierarchyCursor{Phase: "start"}, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(rawEncoded)
+	if err != nil {
+		return codenav.TypeHierarchyCursor{}, err
+	}
+
+	var cursor codenav.TypeHierarchyCursor
+	err = json.Unmarshal(raw, &cursor)
+	return cursor, err
+}
+
+// encodeTypeHierarchyCursor returns an encoding of the given cursor suitable for a URL or a GraphQL token.
+func encodeTypeHierarchyCursor(cursor codenav.TypeHierarchyCursor) string {
+	rawEncoded, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(rawEncoded)
+}
+
+//
+//
+
+type typeHierarchyNodeResolver struct {
+	node             codenav.TypeHierarchyNode
+	locationResolver *gitresolvers.CachedLocationResolver
+}
+
+func (r *typeHierarchyNodeResolver) Index() int32 {
+	return int32(r.node.ID)
+}
+
+func (r *typeHierarchyNodeResolver) ParentIndex() *int32 {
+	if r.node.ParentID == 0 {
+		return nil
+	}
+
+	parentID := int32(r.node.ParentID)
+	return &parentID
+}
+
+func (r *typeHierarchyNodeResolver) Depth() int32 {
+	return int32(r.node.Depth)
+}
+
+func (r *typeHierarchyNodeResolver) Symbol() string {
+	return r.node.Symbol
+}
+
+func (r *typeHierarchyNodeResolver) Definitions(ctx context.Context) (resolverstubs.LocationConnectionResolver, error) {
+	return newLocationConnectionResolver(r.node.Definitions, nil, r.locationResolver), nil
+}
+
+func (r *typeHierarchyNodeResolver) Cycle() bool {
+	return r.node.Cycle
+}
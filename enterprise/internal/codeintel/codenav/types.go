@@ -57,6 +57,15 @@ type RequestArgs struct {
 	RawCursor    string
 }
 
+// ImpactArgs describes a range of commits of a repository whose changed definitions are analyzed.
+// Limit is the maximum number of references returned for each changed symbol.
+type ImpactArgs struct {
+	RepositoryID int
+	BaseCommit   string
+	HeadCommit   string
+	Limit        int
+}
+
 // DiagnosticAtUpload is a diagnostic from within a particular upload. The adjusted commit denotes
 // the target commit for which the location was adjusted (the originally requested commit).
 type DiagnosticAtUpload struct {
@@ -77,6 +86,56 @@ type AdjustedCodeIntelligenceRange struct {
 	HoverText       string
 }
 
+// CallHierarchyCall is a call between two functions or methods. For incoming calls, the symbol and
+// definitions describe the calling function; for outgoing calls, they describe the called function.
+// In both cases, the call sites are the ranges within the calling function at which the call occurs.
+// All locations have been adjusted to fit the target (originally requested) commit.
+type CallHierarchyCall struct {
+	Symbol      string
+	Definitions []shared.UploadLocation
+	CallSites   []shared.UploadLocation
+}
+
+// ImpactAnalysis is the set of symbols whose definitions changed within a range of commits, along
+// with the references to those symbols grouped by the repository they occur in. All locations have
+// been adjusted to fit the base commit of the range where possible.
+type ImpactAnalysis struct {
+	Symbols      []ImpactedSymbol
+	Repositories []ImpactedRepository
+}
+
+// ImpactedSymbol is a symbol with a definition intersecting the changes of a range of commits.
+type ImpactedSymbol struct {
+	Symbol      string
+	Definitions []shared.UploadLocation
+}
+
+// ImpactedRepository is a repository referencing symbols whose definitions changed.
+type ImpactedRepository struct {
+	RepositoryID   int
+	RepositoryName string
+	References     []ImpactedReference
+}
+
+// ImpactedReference is a reference to a symbol whose definition changed.
+type ImpactedReference struct {
+	Symbol   string
+	Location shared.UploadLocation
+}
+
+// TypeHierarchyNode is a type in a type hierarchy. Nodes refer to their parent by ID; the root of
+// the hierarchy has no parent. A node that repeats a type on the path from the root is marked as
+// a cycle and has no children. All definitions have been adjusted to fit the target (originally
+// requested) commit.
+type TypeHierarchyNode struct {
+	ID          int
+	ParentID    int
+	Depth       int
+	Symbol      string
+	Definitions []shared.UploadLocation
+	Cycle       bool
+}
+
 // referencesCursor stores (enough of) the state of a previous References request used to
 // calculate the offset into the result set to be returned by the current request.
 type ReferencesCursor struct {
@@ -98,6 +157,26 @@ type ImplementationsCursor struct {
 	RemoteCursor                  RemoteCursor                   `json:"remoteCursor"`
 }
 
+// TypeHierarchyCursor stores the state of a previous TypeHierarchy request: the types found so far in
+// breadth-first order, the number of them that have been returned and the number of them whose supertypes
+// or subtypes have been searched. The ID of a type is its position in Nodes plus one.
+type TypeHierarchyCursor struct {
+	Phase    string                    `json:"phase"`
+	Nodes    []TypeHierarchyCursorNode `json:"nodes"`
+	Returned int                       `json:"returned"`
+	Expanded int                       `json:"expanded"`
+}
+
+// TypeHierarchyCursorNode is a type of a type hierarchy. Its definitions are looked up again in the uploads
+// with the given IDs when a later request needs them. Leaf types are not expanded because they occur earlier
+// in the hierarchy or their definitions are not visible.
+type TypeHierarchyCursorNode struct {
+	Symbol    string `json:"symbol"`
+	ParentID  int    `json:"parentID"`
+	UploadIDs []int  `json:"uploadIDs"`
+	Leaf      bool   `json:"leaf,omitempty"`
+}
+
 // cursorAdjustedUpload
 type CursorToVisibleUpload struct {
 	DumpID                int             `json:"dumpID"`
@@ -58,6 +58,7 @@ func (r *treeEntryResolver) Content(ctx context.Context, args *resolvers.GitTree
 		api.RepoName(r.commit.Repository().Name()), // repository name
 		api.CommitID(r.commit.OID()),               // commit oid
 		r.path,                                     // path
+		gitserver.ReadFileOptions{ResolveLFS: true},
 	)
 	if err != nil {
 		return "", err
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/background/processor"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/lsifstore"
 	uploadsstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/internal/store"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
@@ -64,11 +65,13 @@ func NewUploadProcessorJob(
 	workerBudget int64,
 	workerPollInterval time.Duration,
 	maximumRuntimePerJob time.Duration,
+	validationConfig shared.UploadValidationConfig,
 ) []goroutine.BackgroundRoutine {
 	ProcessorConfigInst.WorkerConcurrency = workerConcurrency
 	ProcessorConfigInst.WorkerBudget = workerBudget
 	ProcessorConfigInst.WorkerPollInterval = workerPollInterval
 	ProcessorConfigInst.MaximumRuntimePerJob = maximumRuntimePerJob
+	ProcessorConfigInst.Validation = validationConfig
 
 	return background.NewUploadProcessorJob(
 		scopedContext("processor", observationCtx),
@@ -134,6 +134,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// GetUploadsByIDsAllowDeleted.
 	GetUploadsByIDsAllowDeletedFunc *StoreGetUploadsByIDsAllowDeletedFunc
+	// GetValidationReportFunc is an instance of a mock function object
+	// controlling the behavior of the method GetValidationReport.
+	GetValidationReportFunc *StoreGetValidationReportFunc
 	// GetVisibleUploadsMatchingMonikersFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// GetVisibleUploadsMatchingMonikers.
@@ -229,6 +232,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// UpdateUploadsVisibleToCommits.
 	UpdateUploadsVisibleToCommitsFunc *StoreUpdateUploadsVisibleToCommitsFunc
+	// UpdateValidationReportFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateValidationReport.
+	UpdateValidationReportFunc *StoreUpdateValidationReportFunc
 	// WithTransactionFunc is an instance of a mock function object
 	// controlling the behavior of the method WithTransaction.
 	WithTransactionFunc *StoreWithTransactionFunc
@@ -406,6 +412,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetValidationReportFunc: &StoreGetValidationReportFunc{
+			defaultHook: func(context.Context, int) (r0 *shared.UploadValidationReport, r1 error) {
+				return
+			},
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (r0 shared.PackageReferenceScanner, r1 int, r2 error) {
 				return
@@ -551,6 +562,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		UpdateValidationReportFunc: &StoreUpdateValidationReportFunc{
+			defaultHook: func(context.Context, int, shared.UploadValidationReport) (r0 error) {
+				return
+			},
+		},
 		WithTransactionFunc: &StoreWithTransactionFunc{
 			defaultHook: func(context.Context, func(s store.Store) error) (r0 error) {
 				return
@@ -733,6 +749,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetUploadsByIDsAllowDeleted")
 			},
 		},
+		GetValidationReportFunc: &StoreGetValidationReportFunc{
+			defaultHook: func(context.Context, int) (*shared.UploadValidationReport, error) {
+				panic("unexpected invocation of MockStore.GetValidationReport")
+			},
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared.PackageReferenceScanner, int, error) {
 				panic("unexpected invocation of MockStore.GetVisibleUploadsMatchingMonikers")
@@ -878,6 +899,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.UpdateUploadsVisibleToCommits")
 			},
 		},
+		UpdateValidationReportFunc: &StoreUpdateValidationReportFunc{
+			defaultHook: func(context.Context, int, shared.UploadValidationReport) error {
+				panic("unexpected invocation of MockStore.UpdateValidationReport")
+			},
+		},
 		WithTransactionFunc: &StoreWithTransactionFunc{
 			defaultHook: func(context.Context, func(s store.Store) error) error {
 				panic("unexpected invocation of MockStore.WithTransaction")
@@ -994,6 +1020,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetUploadsByIDsAllowDeletedFunc: &StoreGetUploadsByIDsAllowDeletedFunc{
 			defaultHook: i.GetUploadsByIDsAllowDeleted,
 		},
+		GetValidationReportFunc: &StoreGetValidationReportFunc{
+			defaultHook: i.GetValidationReport,
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: i.GetVisibleUploadsMatchingMonikers,
 		},
@@ -1081,6 +1110,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		UpdateUploadsVisibleToCommitsFunc: &StoreUpdateUploadsVisibleToCommitsFunc{
 			defaultHook: i.UpdateUploadsVisibleToCommits,
 		},
+		UpdateValidationReportFunc: &StoreUpdateValidationReportFunc{
+			defaultHook: i.UpdateValidationReport,
+		},
 		WithTransactionFunc: &StoreWithTransactionFunc{
 			defaultHook: i.WithTransaction,
 		},
@@ -4799,6 +4831,114 @@ func (c StoreGetUploadsByIDsAllowDeletedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetValidationReportFunc describes the behavior when the
+// GetValidationReport method of the parent MockStore instance is invoked.
+type StoreGetValidationReportFunc struct {
+	defaultHook func(context.Context, int) (*shared.UploadValidationReport, error)
+	hooks       []func(context.Context, int) (*shared.UploadValidationReport, error)
+	history     []StoreGetValidationReportFuncCall
+	mutex       sync.Mutex
+}
+
+// GetValidationReport delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetValidationReport(v0 context.Context, v1 int) (*shared.UploadValidationReport, error) {
+	r0, r1 := m.GetValidationReportFunc.nextHook()(v0, v1)
+	m.GetValidationReportFunc.appendCall(StoreGetValidationReportFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetValidationReport
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetValidationReportFunc) SetDefaultHook(hook func(context.Context, int) (*shared.UploadValidationReport, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetValidationReport method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetValidationReportFunc) PushHook(hook func(context.Context, int) (*shared.UploadValidationReport, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetValidationReportFunc) SetDefaultReturn(r0 *shared.UploadValidationReport, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (*shared.UploadValidationReport, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetValidationReportFunc) PushReturn(r0 *shared.UploadValidationReport, r1 error) {
+	f.PushHook(func(context.Context, int) (*shared.UploadValidationReport, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetValidationReportFunc) nextHook() func(context.Context, int) (*shared.UploadValidationReport, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetValidationReportFunc) appendCall(r0 StoreGetValidationReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetValidationReportFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetValidationReportFunc) History() []StoreGetValidationReportFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetValidationReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetValidationReportFuncCall is an object that describes an
+// invocation of method GetValidationReport on an instance of MockStore.
+type StoreGetValidationReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *shared.UploadValidationReport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetValidationReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetValidationReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetVisibleUploadsMatchingMonikersFunc describes the behavior when
 // the GetVisibleUploadsMatchingMonikers method of the parent MockStore
 // instance is invoked.
@@ -8016,6 +8156,115 @@ func (c StoreUpdateUploadsVisibleToCommitsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// StoreUpdateValidationReportFunc describes the behavior when the
+// UpdateValidationReport method of the parent MockStore instance is
+// invoked.
+type StoreUpdateValidationReportFunc struct {
+	defaultHook func(context.Context, int, shared.UploadValidationReport) error
+	hooks       []func(context.Context, int, shared.UploadValidationReport) error
+	history     []StoreUpdateValidationReportFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateValidationReport delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateValidationReport(v0 context.Context, v1 int, v2 shared.UploadValidationReport) error {
+	r0 := m.UpdateValidationReportFunc.nextHook()(v0, v1, v2)
+	m.UpdateValidationReportFunc.appendCall(StoreUpdateValidationReportFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateValidationReport method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreUpdateValidationReportFunc) SetDefaultHook(hook func(context.Context, int, shared.UploadValidationReport) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateValidationReport method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreUpdateValidationReportFunc) PushHook(hook func(context.Context, int, shared.UploadValidationReport) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreUpdateValidationReportFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, shared.UploadValidationReport) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreUpdateValidationReportFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, shared.UploadValidationReport) error {
+		return r0
+	})
+}
+
+func (f *StoreUpdateValidationReportFunc) nextHook() func(context.Context, int, shared.UploadValidationReport) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateValidationReportFunc) appendCall(r0 StoreUpdateValidationReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreUpdateValidationReportFuncCall objects
+// describing the invocations of this function.
+func (f *StoreUpdateValidationReportFunc) History() []StoreUpdateValidationReportFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateValidationReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateValidationReportFuncCall is an object that describes an
+// invocation of method UpdateValidationReport on an instance of MockStore.
+type StoreUpdateValidationReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 shared.UploadValidationReport
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateValidationReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateValidationReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreWithTransactionFunc describes the behavior when the WithTransaction
 // method of the parent MockStore instance is invoked.
 type StoreWithTransactionFunc struct {
@@ -2,6 +2,8 @@ package processor
 
 import (
 	"time"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 )
 
 type Config struct {
@@ -10,4 +12,5 @@ type Config struct {
 	WorkerBudget         int64
 	WorkerPollInterval   time.Duration
 	MaximumRuntimePerJob time.Duration
+	Validation           shared.UploadValidationConfig
 }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 	"sync/atomic"
 	"time"
 
@@ -20,6 +21,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -52,6 +54,7 @@ func NewUploadProcessorWorker(
 		workerStore,
 		uploadStore,
 		config.WorkerBudget,
+		config.Validation,
 	)
 
 	metrics := workerutil.NewMetrics(observationCtx, "codeintel_upload_processor", workerutil.WithSampler(func(job workerutil.Record) bool { return true }))
@@ -68,16 +71,17 @@ func NewUploadProcessorWorker(
 }
 
 type handler struct {
-	store           store.Store
-	lsifStore       lsifstore.Store
-	gitserverClient gitserver.Client
-	repoStore       RepoStore
-	workerStore     dbworkerstore.Store[uploadsshared.Upload]
-	uploadStore     uploadstore.Store
-	handleOp        *observation.Operation
-	budgetRemaining int64
-	enableBudget    bool
-	uploadSizeGauge prometheus.Gauge
+	store            store.Store
+	lsifStore        lsifstore.Store
+	gitserverClient  gitserver.Client
+	repoStore        RepoStore
+	workerStore      dbworkerstore.Store[uploadsshared.Upload]
+	uploadStore      uploadstore.Store
+	handleOp         *observation.Operation
+	budgetRemaining  int64
+	enableBudget     bool
+	uploadSizeGauge  prometheus.Gauge
+	validationConfig uploadsshared.UploadValidationConfig
 }
 
 var (
@@ -95,20 +99,22 @@ func NewUploadProcessorHandler(
 	workerStore dbworkerstore.Store[uploadsshared.Upload],
 	uploadStore uploadstore.Store,
 	budgetMax int64,
+	validationConfig uploadsshared.UploadValidationConfig,
 ) workerutil.Handler[uploadsshared.Upload] {
 	operations := newWorkerOperations(observationCtx)
 
 	return &handler{
-		store:           store,
-		lsifStore:       lsifStore,
-		gitserverClient: gitserverClient,
-		repoStore:       repoStore,
-		workerStore:     workerStore,
-		uploadStore:     uploadStore,
-		handleOp:        operations.uploadProcessor,
-		budgetRemaining: budgetMax,
-		enableBudget:    budgetMax > 0,
-		uploadSizeGauge: operations.uploadSizeGauge,
+		store:            store,
+		lsifStore:        lsifStore,
+		gitserverClient:  gitserverClient,
+		repoStore:        repoStore,
+		workerStore:      workerStore,
+		uploadStore:      uploadStore,
+		handleOp:         operations.uploadProcessor,
+		budgetRemaining:  budgetMax,
+		enableBudget:     budgetMax > 0,
+		uploadSizeGauge:  operations.uploadSizeGauge,
+		validationConfig: validationConfig,
 	}
 }
 
@@ -278,11 +284,28 @@ func (h *handler) HandleRawUpload(ctx context.Context, logger log.Logger, upload
 			rSize = *upload.UncompressedSize
 		}
 
-		correlatedSCIPData, err := correlateSCIP(ctx, r, rSize, upload.Root, getChildren)
+		// Stop processing the index in the background if we bail out before consuming it
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		correlatedSCIPData, validationReport, err := correlateSCIP(ctx, r, rSize, upload.Root, getChildren)
 		if err != nil {
 			return errors.Wrap(err, "conversion.Correlate")
 		}
 
+		// Store the quality report of the index regardless of whether or not the upload is accepted
+		// so that users can see why an upload was rejected.
+		validationReport.Violations = h.validationConfig.Violations(validationReport)
+		if err := h.store.UpdateValidationReport(ctx, upload.ID, validationReport); err != nil {
+			return errors.Wrap(err, "store.UpdateValidationReport")
+		}
+		trace.AddEvent("TODO Domain Owner", attribute.Int("validationViolations", len(validationReport.Violations)))
+
+		if h.validationConfig.Strict && len(validationReport.Violations) > 0 {
+			// Retrying will not fix a broken index
+			return errcode.MakeNonRetryable(errors.Newf("SCIP index failed validation: %s", strings.Join(validationReport.Violations, "; ")))
+		}
+
 		// Note: this is writing to a different database than the block below, so we need to use a
 		// different transaction context (managed by the writeData function).
 		if err := writeSCIPData(ctx, h.lsifStore, upload, correlatedSCIPData, trace); err != nil {
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -324,6 +325,72 @@ func TestHandleError(t *testing.T) {
 	}
 }
 
+func TestHandleStrictValidation(t *testing.T) {
+	setupRepoMocks(t)
+
+	upload := shared.Upload{
+		ID:           42,
+		Root:         "root/",
+		Commit:       "deadbeef",
+		RepositoryID: 50,
+		Indexer:      "lsif-go",
+		ContentType:  "application/x-protobuf+scip",
+	}
+
+	mockWorkerStore := NewMockWorkerStore[shared.Upload]()
+	mockDBStore := NewMockStore()
+	mockRepoStore := defaultMockRepoStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockUploadStore := uploadstoremocks.NewMockStore()
+	gitserverClient := gitserver.NewMockClient()
+
+	// Give correlation package a valid input dump
+	mockUploadStore.GetFunc.SetDefaultHook(copyTestDumpScip)
+
+	// Supply non-nil commit date
+	gitserverClient.CommitDateFunc.SetDefaultReturn("deadbeef", time.Now(), true, nil)
+
+	// Note: no directory children, so no document of the dump exists in the repository
+
+	svc := &handler{
+		store:           mockDBStore,
+		lsifStore:       mockLSIFStore,
+		gitserverClient: gitserverClient,
+		repoStore:       mockRepoStore,
+		workerStore:     mockWorkerStore,
+		validationConfig: shared.UploadValidationConfig{
+			Strict:                                     true,
+			MaximumUnresolvedReferencesPercent:         -1,
+			MaximumDocumentsWithoutOccurrences:         -1,
+			MaximumPathsNotInRepository:                0,
+			MaximumDuplicateSymbols:                    -1,
+			MaximumDocumentsWithOverlappingOccurrences: -1,
+		},
+	}
+
+	requeued, err := svc.HandleRawUpload(context.Background(), logtest.Scoped(t), upload, mockUploadStore, observation.TestTraceLogger(logtest.Scoped(t)))
+	if err == nil {
+		t.Fatalf("unexpected nil error handling upload")
+	} else if !errcode.IsNonRetryable(err) {
+		t.Fatalf("expected non-retryable error, got %s", err)
+	} else if requeued {
+		t.Errorf("unexpected requeue")
+	}
+
+	if history := mockDBStore.UpdateValidationReportFunc.History(); len(history) != 1 {
+		t.Fatalf("unexpected number of UpdateValidationReport calls. want=%d have=%d", 1, len(history))
+	} else if report := history[0].Arg2; report.NumPathsNotInRepository != 68 || len(report.Violations) != 1 {
+		t.Errorf("unexpected validation report: %+v", report)
+	}
+
+	if len(mockLSIFStore.InsertMetadataFunc.History()) != 0 {
+		t.Errorf("unexpected number of InsertMetadata calls. want=%d have=%d", 0, len(mockLSIFStore.InsertMetadataFunc.History()))
+	}
+	if len(mockUploadStore.DeleteFunc.History()) != 0 {
+		t.Errorf("unexpected number of Delete calls. want=%d have=%d", 0, len(mockUploadStore.DeleteFunc.History()))
+	}
+}
+
 func TestHandleCloneInProgress(t *testing.T) {
 	upload := shared.Upload{
 		ID:           42,
@@ -19,6 +19,7 @@ import (
 
 // correlateSCIP reads the content of the given reader as a SCIP index object. The index is processed in
 // the background, and processed documents are emitted on a channel to be persisted to the database.
+// A quality report of the index (see validateSCIP) is computed before processing begins.
 //
 // **NOTE TO CONSUMERS OF THIS FUNCTION** (see `readPackageAndPackageReferences` for a concrete impl):
 //
@@ -33,17 +34,20 @@ func correlateSCIP(
 	rSize int64,
 	root string,
 	getChildren pathexistence.GetChildrenFunc,
-) (lsifstore.ProcessedSCIPData, error) {
+) (lsifstore.ProcessedSCIPData, shared.UploadValidationReport, error) {
 	index, err := readIndex(r, rSize)
 	if err != nil {
-		return lsifstore.ProcessedSCIPData{}, err
+		return lsifstore.ProcessedSCIPData{}, shared.UploadValidationReport{}, err
 	}
 
 	ignorePaths, err := ignorePaths(ctx, index.Documents, root, getChildren)
 	if err != nil {
-		return lsifstore.ProcessedSCIPData{}, err
+		return lsifstore.ProcessedSCIPData{}, shared.UploadValidationReport{}, err
 	}
 
+	// Validate before the documents are canonicalized (and mutated) below
+	validationReport := validateSCIP(index, ignorePaths)
+
 	var (
 		documents             = make(chan lsifstore.ProcessedSCIPDocument)
 		packages              = make(chan precise.Package)
@@ -133,7 +137,7 @@ func correlateSCIP(
 		Documents:         documents,
 		Packages:          packages,
 		PackageReferences: packageReferences,
-	}, nil
+	}, validationReport, nil
 }
 
 // readPackageAndPackageReferences reads content from the package and package reference channels of
@@ -38,7 +38,7 @@ func TestCorrelateSCIP(t *testing.T) {
 	n := int64(len(content))
 
 	// Correlate and consume channels from returned object
-	correlatedSCIPData, err := correlateSCIP(ctx, testReader(), n, "", func(ctx context.Context, dirnames []string) (map[string][]string, error) {
+	correlatedSCIPData, _, err := correlateSCIP(ctx, testReader(), n, "", func(ctx context.Context, dirnames []string) (map[string][]string, error) {
 		return scipDirectoryChildren, nil
 	})
 	if err != nil {
@@ -0,0 +1,212 @@
+package processor
+
+import (
+	"sort"
+
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/precise"
+)
+
+// maxValidationSamples is the maximum number of paths or symbols stored for each check of
+// a validation report.
+const maxValidationSamples = 10
+
+// validateSCIP computes a quality report for the given index. The given set of ignored paths
+// holds the relative paths of documents that do not exist in the repository (see ignorePaths).
+// This function must be called before the documents of the index are canonicalized.
+//
+// Two occurrences of a document overlap if their ranges intersect but neither range contains the
+// other. Nested and identical ranges are common (e.g., a definition that is also a reference to the
+// symbol it implements) and are not considered overlapping.
+//
+// A reference to a local symbol is unresolved if the local is not defined in the same document.
+// A reference to a global symbol is unresolved if the symbol belongs to a package defined by the
+// index but has no definition within it. References to symbols of other packages are resolved via
+// other indexes and are not considered unresolved.
+func validateSCIP(index *scip.Index, ignorePaths map[string]struct{}) shared.UploadValidationReport {
+	var (
+		numOccurrencesByPath = map[string]int{}
+		overlappingPaths     = map[string]struct{}{}
+		definitionPaths      = map[string]map[string]struct{}{}
+		globalReferences     = map[string]int{}
+		unresolvedSymbols    = map[string]struct{}{}
+		report               shared.UploadValidationReport
+	)
+
+	for _, document := range index.Documents {
+		numOccurrencesByPath[document.RelativePath] += len(document.Occurrences)
+		if hasOverlappingOccurrences(document.Occurrences) {
+			overlappingPaths[document.RelativePath] = struct{}{}
+		}
+
+		localDefinitions := map[string]struct{}{}
+		for _, occurrence := range document.Occurrences {
+			if occurrence.Symbol == "" || !scip.SymbolRole_Definition.Matches(occurrence) {
+				continue
+			}
+
+			if scip.IsLocalSymbol(occurrence.Symbol) {
+				localDefinitions[occurrence.Symbol] = struct{}{}
+				continue
+			}
+
+			if _, ok := definitionPaths[occurrence.Symbol]; !ok {
+				definitionPaths[occurrence.Symbol] = map[string]struct{}{}
+			}
+			definitionPaths[occurrence.Symbol][document.RelativePath] = struct{}{}
+		}
+
+		for _, occurrence := range document.Occurrences {
+			if occurrence.Symbol == "" || scip.SymbolRole_Definition.Matches(occurrence) {
+				continue
+			}
+
+			report.NumReferences++
+
+			if !scip.IsLocalSymbol(occurrence.Symbol) {
+				globalReferences[occurrence.Symbol]++
+			} else if _, ok := localDefinitions[occurrence.Symbol]; !ok {
+				report.NumUnresolvedReferences++
+				unresolvedSymbols[document.RelativePath+": "+occurrence.Symbol] = struct{}{}
+			}
+		}
+	}
+
+	// Determine the set of packages defined by this index so that we can distinguish missing
+	// definitions from references to symbols of other indexes.
+	definedPackages := map[precise.Package]struct{}{}
+	for symbol := range definitionPaths {
+		if pkg, ok := packageFromSymbol(symbol); ok {
+			definedPackages[pkg] = struct{}{}
+		}
+	}
+
+	for symbol, count := range globalReferences {
+		if _, ok := definitionPaths[symbol]; ok {
+			continue
+		}
+
+		pkg, ok := packageFromSymbol(symbol)
+		if !ok {
+			continue
+		}
+		if _, ok := definedPackages[pkg]; ok {
+			report.NumUnresolvedReferences += count
+			unresolvedSymbols[symbol] = struct{}{}
+		}
+	}
+
+	var duplicateSymbols []string
+	for symbol, paths := range definitionPaths {
+		if len(paths) > 1 && !isNamespaceSymbol(symbol) {
+			duplicateSymbols = append(duplicateSymbols, symbol)
+		}
+	}
+
+	var documentsWithoutOccurrences []string
+	for path, numOccurrences := range numOccurrencesByPath {
+		if numOccurrences == 0 {
+			documentsWithoutOccurrences = append(documentsWithoutOccurrences, path)
+		}
+	}
+
+	pathsNotInRepository := make([]string, 0, len(ignorePaths))
+	for path := range ignorePaths {
+		pathsNotInRepository = append(pathsNotInRepository, path)
+	}
+
+	report.NumDocuments = len(numOccurrencesByPath)
+	report.NumDocumentsWithoutOccurrences = len(documentsWithoutOccurrences)
+	report.DocumentsWithoutOccurrences = validationSamples(documentsWithoutOccurrences)
+	report.UnresolvedSymbols = validationSamples(keys(unresolvedSymbols))
+	report.NumPathsNotInRepository = len(pathsNotInRepository)
+	report.PathsNotInRepository = validationSamples(pathsNotInRepository)
+	report.NumDuplicateSymbols = len(duplicateSymbols)
+	report.DuplicateSymbols = validationSamples(duplicateSymbols)
+	report.NumDocumentsWithOverlappingOccurrences = len(overlappingPaths)
+	report.DocumentsWithOverlappingOccurrences = validationSamples(keys(overlappingPaths))
+	return report
+}
+
+// hasOverlappingOccurrences returns true if the ranges of any two of the given occurrences
+// intersect without one containing the other. Occurrences with malformed ranges are ignored.
+func hasOverlappingOccurrences(occurrences []*scip.Occurrence) bool {
+	ranges := make([]*scip.Range, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		if len(occurrence.Range) == 3 || len(occurrence.Range) == 4 {
+			ranges = append(ranges, scip.NewRange(occurrence.Range))
+		}
+	}
+
+	// Order ranges by start position and, for ranges starting at the same position, with the
+	// enclosing range first. Each range then either follows, nests within, or overlaps the ranges
+	// that are still open when it starts.
+	sort.Slice(ranges, func(i, j int) bool {
+		if ranges[i].Start != ranges[j].Start {
+			return comparePositions(ranges[i].Start, ranges[j].Start) < 0
+		}
+		return comparePositions(ranges[i].End, ranges[j].End) > 0
+	})
+
+	// Open ranges form a chain in which each range contains the next, so a range overlaps one
+	// of them only if it overlaps the innermost.
+	var open []*scip.Range
+	for _, r := range ranges {
+		for len(open) > 0 && comparePositions(open[len(open)-1].End, r.Start) <= 0 {
+			open = open[:len(open)-1]
+		}
+		if len(open) > 0 && comparePositions(open[len(open)-1].End, r.End) < 0 {
+			return true
+		}
+
+		open = append(open, r)
+	}
+
+	return false
+}
+
+// comparePositions returns a negative number if a precedes b, a positive number if a follows b,
+// and zero if the positions are equal.
+func comparePositions(a, b scip.Position) int {
+	if a.Line != b.Line {
+		return int(a.Line - b.Line)
+	}
+
+	return int(a.Character - b.Character)
+}
+
+// isNamespaceSymbol returns true if the given symbol names a namespace (e.g., a package). Many
+// indexers define namespaces once in every file that declares them, so these are not duplicates.
+func isNamespaceSymbol(symbolName string) bool {
+	symbol, err := scip.ParseSymbol(symbolName)
+	if err != nil || len(symbol.Descriptors) == 0 {
+		return false
+	}
+
+	return symbol.Descriptors[len(symbol.Descriptors)-1].Suffix == scip.Descriptor_Namespace
+}
+
+// validationSamples returns a deterministic subset of the given values of bounded size.
+func validationSamples(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	sort.Strings(values)
+	if len(values) > maxValidationSamples {
+		values = values[:maxValidationSamples]
+	}
+
+	return values
+}
+
+func keys(m map[string]struct{}) []string {
+	values := make([]string, 0, len(m))
+	for value := range m {
+		values = append(values, value)
+	}
+
+	return values
+}
@@ -0,0 +1,170 @@
+package processor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+)
+
+func TestValidateSCIP(t *testing.T) {
+	const (
+		pkg       = "scip-go gomod example v1 `example`/"
+		formatter = "scip-go gomod example v1 `example`/Formatter#"
+		format    = "scip-go gomod example v1 `example`/Format()."
+		missing   = "scip-go gomod example v1 `example`/Missing#"
+		external  = "scip-go gomod github.com/other/lib v2 `github.com/other/lib`/Client#"
+	)
+
+	definition := func(symbol string) *scip.Occurrence {
+		return &scip.Occurrence{Symbol: symbol, SymbolRoles: int32(scip.SymbolRole_Definition)}
+	}
+	reference := func(symbol string) *scip.Occurrence {
+		return &scip.Occurrence{Symbol: symbol}
+	}
+
+	index := &scip.Index{
+		Documents: []*scip.Document{
+			{
+				RelativePath: "format.go",
+				Occurrences: []*scip.Occurrence{
+					definition(pkg),
+					definition(formatter),
+					definition(format),
+					definition("local 1"),
+					reference("local 1"),
+					reference("local 2"),
+				},
+			},
+			{
+				RelativePath: "main.go",
+				Occurrences: []*scip.Occurrence{
+					definition(pkg),
+					definition(format),
+					reference(formatter),
+					reference(missing),
+					reference(missing),
+					reference(external),
+				},
+			},
+			{RelativePath: "empty.go"},
+			{RelativePath: "generated/main.go", Occurrences: []*scip.Occurrence{reference(format)}},
+			{
+				RelativePath: "overlap.go",
+				Occurrences: []*scip.Occurrence{
+					{Range: []int32{1, 0, 10}, Symbol: "local 1", SymbolRoles: int32(scip.SymbolRole_Definition)},
+					{Range: []int32{1, 5, 15}, Symbol: "local 1"},
+				},
+			},
+		},
+	}
+
+	report := validateSCIP(index, map[string]struct{}{"generated/main.go": {}})
+
+	expected := shared.UploadValidationReport{
+		NumDocuments:                           5,
+		NumDocumentsWithoutOccurrences:         1,
+		DocumentsWithoutOccurrences:            []string{"empty.go"},
+		NumReferences:                          8,
+		NumUnresolvedReferences:                3,
+		UnresolvedSymbols:                      []string{"format.go: local 2", missing},
+		NumPathsNotInRepository:                1,
+		PathsNotInRepository:                   []string{"generated/main.go"},
+		NumDuplicateSymbols:                    1,
+		DuplicateSymbols:                       []string{format},
+		NumDocumentsWithOverlappingOccurrences: 1,
+		DocumentsWithOverlappingOccurrences:    []string{"overlap.go"},
+	}
+	if diff := cmp.Diff(expected, report); diff != "" {
+		t.Errorf("unexpected report (-want +got):\n%s", diff)
+	}
+}
+
+func TestHasOverlappingOccurrences(t *testing.T) {
+	testCases := []struct {
+		name     string
+		ranges   [][]int32
+		expected bool
+	}{
+		{name: "disjoint", ranges: [][]int32{{1, 0, 5}, {1, 5, 10}, {2, 0, 5}}, expected: false},
+		{name: "identical", ranges: [][]int32{{1, 0, 5}, {1, 0, 5}}, expected: false},
+		{name: "nested", ranges: [][]int32{{1, 0, 4, 1}, {2, 0, 10}, {2, 2, 4}, {3, 0, 5}}, expected: false},
+		{name: "same start", ranges: [][]int32{{1, 0, 5}, {1, 0, 10}}, expected: false},
+		{name: "crossing", ranges: [][]int32{{1, 0, 10}, {1, 5, 15}}, expected: true},
+		{name: "crossing lines", ranges: [][]int32{{1, 0, 3, 5}, {2, 0, 4, 0}}, expected: true},
+		{name: "crossing after nested", ranges: [][]int32{{1, 0, 5, 0}, {2, 0, 10}, {4, 0, 6, 0}}, expected: true},
+		{name: "malformed", ranges: [][]int32{{1, 0, 10}, {1}, {1, 5}}, expected: false},
+	}
+
+	for _, testCase := range testCases {
+		var occurrences []*scip.Occurrence
+		for _, r := range testCase.ranges {
+			occurrences = append(occurrences, &scip.Occurrence{Range: r})
+		}
+
+		if overlapping := hasOverlappingOccurrences(occurrences); overlapping != testCase.expected {
+			t.Errorf("unexpected result for %s. want=%v have=%v", testCase.name, testCase.expected, overlapping)
+		}
+	}
+}
+
+func TestValidationViolations(t *testing.T) {
+	report := shared.UploadValidationReport{
+		NumDocumentsWithoutOccurrences:         3,
+		NumReferences:                          10,
+		NumUnresolvedReferences:                6,
+		NumPathsNotInRepository:                1,
+		NumDuplicateSymbols:                    2,
+		NumDocumentsWithOverlappingOccurrences: 1,
+	}
+
+	testCases := []struct {
+		config   shared.UploadValidationConfig
+		expected []string
+	}{
+		{
+			config: shared.UploadValidationConfig{
+				MaximumUnresolvedReferencesPercent:         -1,
+				MaximumDocumentsWithoutOccurrences:         -1,
+				MaximumPathsNotInRepository:                -1,
+				MaximumDuplicateSymbols:                    -1,
+				MaximumDocumentsWithOverlappingOccurrences: -1,
+			},
+			expected: nil,
+		},
+		{
+			config: shared.UploadValidationConfig{
+				MaximumUnresolvedReferencesPercent:         60,
+				MaximumDocumentsWithoutOccurrences:         3,
+				MaximumPathsNotInRepository:                1,
+				MaximumDuplicateSymbols:                    2,
+				MaximumDocumentsWithOverlappingOccurrences: 1,
+			},
+			expected: nil,
+		},
+		{
+			config: shared.UploadValidationConfig{
+				MaximumUnresolvedReferencesPercent:         50,
+				MaximumDocumentsWithoutOccurrences:         2,
+				MaximumPathsNotInRepository:                0,
+				MaximumDuplicateSymbols:                    1,
+				MaximumDocumentsWithOverlappingOccurrences: 0,
+			},
+			expected: []string{
+				"60.0% of references are unresolved (maximum 50%)",
+				"3 documents have no occurrences (maximum 2)",
+				"1 document paths do not exist in the repository (maximum 0)",
+				"2 symbols are defined in more than one document (maximum 1)",
+				"1 documents have overlapping occurrences (maximum 0)",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		if diff := cmp.Diff(testCase.expected, testCase.config.Violations(report)); diff != "" {
+			t.Errorf("unexpected violations (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -15,6 +15,8 @@ type operations struct {
 	deleteLsifDataByUploadIds                 *observation.Operation
 	deleteUnreferencedDocuments               *observation.Operation
 	insertDefinitionsAndReferencesForDocument *observation.Operation
+	searchSymbolDefinitions                   *observation.Operation
+	getDocumentPaths                          *observation.Operation
 }
 
 var m = new(metrics.SingletonREDMetrics)
@@ -45,5 +47,7 @@ func newOperations(observationCtx *observation.Context) *operations {
 		deleteLsifDataByUploadIds:                 op("DeleteLsifDataByUploadIds"),
 		deleteUnreferencedDocuments:               op("DeleteUnreferencedDocuments"),
 		insertDefinitionsAndReferencesForDocument: op("InsertDefinitionsAndReferencesForDocument"),
+		searchSymbolDefinitions:                   op("SearchSymbolDefinitions"),
+		getDocumentPaths:                          op("GetDocumentPaths"),
 	}
 }
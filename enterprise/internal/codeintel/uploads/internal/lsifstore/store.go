@@ -26,6 +26,10 @@ type Store interface {
 	DeleteLsifDataByUploadIds(ctx context.Context, bundleIDs ...int) (err error)
 	DeleteUnreferencedDocuments(ctx context.Context, batchSize int, maxAge time.Duration, now time.Time) (numScanned, numDeleted int, err error)
 
+	// Symbols
+	SearchSymbolDefinitions(ctx context.Context, uploadID int, search string, after, limit int) ([]shared.SymbolDefinition, int, error)
+	GetDocumentPaths(ctx context.Context, uploadID int, paths []string) ([]string, error)
+
 	// Scan/export document data
 	InsertDefinitionsAndReferencesForDocument(ctx context.Context, upload shared.ExportedUpload, rankingGraphKey string, rankingBatchSize int, f func(ctx context.Context, upload shared.ExportedUpload, rankingBatchSize int, rankingGraphKey, path string, document *scip.Document) error) (err error)
 }
@@ -0,0 +1,177 @@
+package lsifstore
+
+import (
+	"context"
+	"strings"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared/ranges"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// SearchSymbolDefinitions returns the symbols defined in the given upload whose descriptor names contain
+// the given search text (ignoring case), along with the path and ranges of each definition. An empty
+// search text matches all defined symbols. Local symbols are never returned.
+//
+// Defined symbols are read in pages of at most limit symbols, ordered by their identifier within the
+// upload. Each call reads the page following the given cursor (zero for the first page) and returns the
+// cursor of the next page, which is zero once all defined symbols have been read. A page may contain
+// fewer matching definitions than limit, or none at all.
+func (s *store) SearchSymbolDefinitions(ctx context.Context, uploadID int, search string, after, limit int) (_ []shared.SymbolDefinition, next int, err error) {
+	ctx, _, endObservation := s.operations.searchSymbolDefinitions.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+		attribute.String("search", search),
+		attribute.Int("after", after),
+		attribute.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	definitions, err := scanSymbolDefinitions(s.db.Query(ctx, sqlf.Sprintf(
+		searchSymbolDefinitionsQuery,
+		uploadID,
+		after,
+		limit,
+		uploadID,
+		uploadID,
+		uploadID,
+	)))
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var (
+		numSymbols int
+		lastID     int
+		matches    []shared.SymbolDefinition
+	)
+	search = strings.ToLower(search)
+	for _, definition := range definitions {
+		if definition.symbolID != lastID {
+			numSymbols++
+			lastID = definition.symbolID
+		}
+
+		if name, ok := descriptorName(definition.Symbol); ok && strings.Contains(strings.ToLower(name), search) {
+			matches = append(matches, definition.SymbolDefinition)
+		}
+	}
+	if numSymbols == limit {
+		next = lastID
+	}
+
+	return matches, next, nil
+}
+
+const searchSymbolDefinitionsQuery = `
+WITH RECURSIVE
+page AS (
+	SELECT DISTINCT ss.symbol_id
+	FROM codeintel_scip_symbols ss
+	WHERE
+		ss.upload_id = %s AND
+		ss.symbol_id > %s AND
+		ss.definition_ranges IS NOT NULL
+	ORDER BY ss.symbol_id
+	LIMIT %s
+),
+defined_symbols AS (
+	SELECT ss.symbol_id, sid.document_path, ss.definition_ranges
+	FROM codeintel_scip_symbols ss
+	JOIN codeintel_scip_document_lookup sid ON sid.id = ss.document_lookup_id
+	WHERE
+		ss.upload_id = %s AND
+		ss.symbol_id IN (SELECT symbol_id FROM page) AND
+		ss.definition_ranges IS NOT NULL
+),
+
+-- Reconstruct the names of the symbols in the page by walking the trie of name segments
+-- from each terminal segment up to its root, prepending each prefix as we go.
+symbol_names(symbol_id, prefix_id, name) AS (
+	(
+		SELECT ssn.id, ssn.prefix_id, ssn.name_segment
+		FROM codeintel_scip_symbol_names ssn
+		WHERE
+			ssn.upload_id = %s AND
+			ssn.id IN (SELECT symbol_id FROM page)
+	) UNION ALL (
+		SELECT sn.symbol_id, ssn.prefix_id, ssn.name_segment || sn.name
+		FROM symbol_names sn
+		JOIN codeintel_scip_symbol_names ssn ON
+			ssn.upload_id = %s AND
+			ssn.id = sn.prefix_id
+	)
+)
+SELECT sn.symbol_id, sn.name, ds.document_path, ds.definition_ranges
+FROM symbol_names sn
+JOIN defined_symbols ds ON ds.symbol_id = sn.symbol_id
+WHERE sn.prefix_id IS NULL
+ORDER BY sn.symbol_id, ds.document_path
+`
+
+// descriptorName returns the name of the last descriptor of the given symbol. Local symbols and
+// symbols which cannot be parsed have no descriptor name.
+func descriptorName(symbol string) (string, bool) {
+	if scip.IsLocalSymbol(symbol) {
+		return "", false
+	}
+
+	parsed, err := scip.ParseSymbol(symbol)
+	if err != nil || len(parsed.Descriptors) == 0 {
+		return "", false
+	}
+
+	return parsed.Descriptors[len(parsed.Descriptors)-1].Name, true
+}
+
+// pagedSymbolDefinition is a symbol definition along with the identifier of its symbol within the
+// upload, which orders the pages read by SearchSymbolDefinitions.
+type pagedSymbolDefinition struct {
+	shared.SymbolDefinition
+	symbolID int
+}
+
+var scanSymbolDefinitions = basestore.NewSliceScanner(func(s dbutil.Scanner) (pagedSymbolDefinition, error) {
+	var (
+		definition    pagedSymbolDefinition
+		encodedRanges []byte
+	)
+	if err := s.Scan(&definition.symbolID, &definition.Symbol, &definition.DocumentPath, &encodedRanges); err != nil {
+		return pagedSymbolDefinition{}, err
+	}
+
+	definitionRanges, err := ranges.DecodeRanges(encodedRanges)
+	if err != nil {
+		return pagedSymbolDefinition{}, err
+	}
+	definition.Ranges = definitionRanges
+
+	return definition, nil
+})
+
+// GetDocumentPaths returns the subset of the given paths, relative to the root of the given upload,
+// for which the upload has a document.
+func (s *store) GetDocumentPaths(ctx context.Context, uploadID int, paths []string) (_ []string, err error) {
+	ctx, _, endObservation := s.operations.getDocumentPaths.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("uploadID", uploadID),
+		attribute.Int("numPaths", len(paths)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return basestore.ScanStrings(s.db.Query(ctx, sqlf.Sprintf(getDocumentPathsQuery, uploadID, pq.Array(paths))))
+}
+
+const getDocumentPathsQuery = `
+SELECT sid.document_path
+FROM codeintel_scip_document_lookup sid
+WHERE
+	sid.upload_id = %s AND
+	sid.document_path = ANY(%s)
+ORDER BY sid.document_path
+`
@@ -0,0 +1,138 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+
+	codeintelshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/shared"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestSearchSymbolDefinitions(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, codeIntelDB)
+	ctx := context.Background()
+
+	documents := map[string]*scip.Document{
+		"cmd/main.go": {
+			Occurrences: []*scip.Occurrence{
+				{Range: []int32{3, 5, 9}, Symbol: "scip-go gomod example v1 `example/cmd`/main().", SymbolRoles: int32(scip.SymbolRole_Definition)},
+				{Range: []int32{4, 1, 10}, Symbol: "scip-go gomod example v1 `example/util`/Format().", SymbolRoles: 0},
+			},
+		},
+		"util/format.go": {
+			Occurrences: []*scip.Occurrence{
+				{Range: []int32{10, 5, 11}, Symbol: "scip-go gomod example v1 `example/util`/Format().", SymbolRoles: int32(scip.SymbolRole_Definition)},
+				{Range: []int32{20, 5, 17}, Symbol: "scip-go gomod example v1 `example/util`/FormatPercent().", SymbolRoles: int32(scip.SymbolRole_Definition)},
+				{Range: []int32{30, 5, 12}, Symbol: "scip-go gomod example v1 `example/util`/Parse_Ok().", SymbolRoles: int32(scip.SymbolRole_Definition)},
+			},
+		},
+	}
+
+	if err := store.WithTransaction(ctx, func(tx Store) error {
+		scipWriter, err := tx.NewSCIPWriter(ctx, 42)
+		if err != nil {
+			return err
+		}
+		for path, document := range documents {
+			if err := scipWriter.InsertDocument(ctx, path, document); err != nil {
+				return err
+			}
+		}
+		_, err = scipWriter.Flush(ctx)
+		return err
+	}); err != nil {
+		t.Fatalf("failed to write SCIP data: %s", err)
+	}
+
+	format := shared.SymbolDefinition{Symbol: "scip-go gomod example v1 `example/util`/Format().", DocumentPath: "util/format.go", Ranges: []*scip.Range{{Start: scip.Position{Line: 10, Character: 5}, End: scip.Position{Line: 10, Character: 11}}}}
+	formatPercent := shared.SymbolDefinition{Symbol: "scip-go gomod example v1 `example/util`/FormatPercent().", DocumentPath: "util/format.go", Ranges: []*scip.Range{{Start: scip.Position{Line: 20, Character: 5}, End: scip.Position{Line: 20, Character: 17}}}}
+	parseOk := shared.SymbolDefinition{Symbol: "scip-go gomod example v1 `example/util`/Parse_Ok().", DocumentPath: "util/format.go", Ranges: []*scip.Range{{Start: scip.Position{Line: 30, Character: 5}, End: scip.Position{Line: 30, Character: 12}}}}
+
+	testCases := []struct {
+		search   string
+		expected []shared.SymbolDefinition
+	}{
+		{search: "format", expected: []shared.SymbolDefinition{format, formatPercent}},
+		{search: "e_o", expected: []shared.SymbolDefinition{parseOk}},
+		// Only the descriptor name is matched, not the package or namespace
+		{search: "example", expected: nil},
+		{search: "gomod", expected: nil},
+		// Underscores do not match arbitrary characters
+		{search: "Format_ercent", expected: nil},
+	}
+
+	for _, testCase := range testCases {
+		for _, limit := range []int{1, 2, 10} {
+			var definitions []shared.SymbolDefinition
+			for after, numPages := 0, 0; ; numPages++ {
+				if numPages > 10 {
+					t.Fatalf("too many pages for %q with limit %d", testCase.search, limit)
+				}
+
+				page, next, err := store.SearchSymbolDefinitions(ctx, 42, testCase.search, after, limit)
+				if err != nil {
+					t.Fatalf("unexpected error searching symbol definitions: %s", err)
+				}
+				definitions = append(definitions, page...)
+
+				if next == 0 {
+					break
+				}
+				after = next
+			}
+
+			sort.Slice(definitions, func(i, j int) bool { return definitions[i].Symbol < definitions[j].Symbol })
+			if diff := cmp.Diff(testCase.expected, definitions); diff != "" {
+				t.Errorf("unexpected definitions for %q with limit %d (-want +got):\n%s", testCase.search, limit, diff)
+			}
+		}
+	}
+}
+
+func TestGetDocumentPaths(t *testing.T) {
+	logger := logtest.Scoped(t)
+	codeIntelDB := codeintelshared.NewCodeIntelDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, codeIntelDB)
+	ctx := context.Background()
+
+	if err := store.WithTransaction(ctx, func(tx Store) error {
+		scipWriter, err := tx.NewSCIPWriter(ctx, 42)
+		if err != nil {
+			return err
+		}
+		for _, path := range []string{"cmd/main.go", "util/format.go"} {
+			if err := scipWriter.InsertDocument(ctx, path, &scip.Document{}); err != nil {
+				return err
+			}
+		}
+		_, err = scipWriter.Flush(ctx)
+		return err
+	}); err != nil {
+		t.Fatalf("failed to write SCIP data: %s", err)
+	}
+
+	paths, err := store.GetDocumentPaths(ctx, 42, []string{"util/format.go", "util/format_test.go", "README.md"})
+	if err != nil {
+		t.Fatalf("unexpected error getting document paths: %s", err)
+	}
+	if diff := cmp.Diff([]string{"util/format.go"}, paths); diff != "" {
+		t.Errorf("unexpected paths (-want +got):\n%s", diff)
+	}
+
+	paths, err = store.GetDocumentPaths(ctx, 43, []string{"util/format.go"})
+	if err != nil {
+		t.Fatalf("unexpected error getting document paths: %s", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("unexpected paths for unknown upload: %v", paths)
+	}
+}
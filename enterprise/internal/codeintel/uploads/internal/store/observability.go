@@ -59,6 +59,8 @@ type operations struct {
 	addUploadPart                        *observation.Operation
 	markQueued                           *observation.Operation
 	markFailed                           *observation.Operation
+	updateValidationReport               *observation.Operation
+	getValidationReport                  *observation.Operation
 	deleteUploads                        *observation.Operation
 
 	// Dumps
@@ -161,6 +163,8 @@ func newOperations(observationCtx *observation.Context) *operations {
 		addUploadPart:                        op("AddUploadPart"),
 		markQueued:                           op("MarkQueued"),
 		markFailed:                           op("MarkFailed"),
+		updateValidationReport:               op("UpdateValidationReport"),
+		getValidationReport:                  op("GetValidationReport"),
 		deleteUploads:                        op("DeleteUploads"),
 
 		writeVisibleUploads:        op("writeVisibleUploads"),
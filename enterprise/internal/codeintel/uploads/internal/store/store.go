@@ -49,6 +49,8 @@ type Store interface {
 	AddUploadPart(ctx context.Context, uploadID, partIndex int) error
 	MarkQueued(ctx context.Context, id int, uploadSize *int64) error
 	MarkFailed(ctx context.Context, id int, reason string) error
+	UpdateValidationReport(ctx context.Context, id int, report shared.UploadValidationReport) error
+	GetValidationReport(ctx context.Context, id int) (*shared.UploadValidationReport, error)
 	DeleteOverlappingDumps(ctx context.Context, repositoryID int, commit, root, indexer string) error
 	WorkerutilStore(observationCtx *observation.Context) dbworkerstore.Store[shared.Upload]
 
@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/keegancsmith/sqlf"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// UpdateValidationReport stores the quality report computed while processing the given upload.
+func (s *store) UpdateValidationReport(ctx context.Context, id int, report shared.UploadValidationReport) (err error) {
+	ctx, _, endObservation := s.operations.updateValidationReport.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+		attribute.Int("numViolations", len(report.Violations)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rawReport, err := json.Marshal(report)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal validation_report")
+	}
+
+	return s.db.Exec(ctx, sqlf.Sprintf(updateValidationReportQuery, rawReport, id))
+}
+
+const updateValidationReportQuery = `
+UPDATE lsif_uploads SET validation_report = %s WHERE id = %s
+`
+
+// GetValidationReport returns the quality report of the given upload. A nil report is returned
+// for uploads that have not (yet) been validated.
+func (s *store) GetValidationReport(ctx context.Context, id int) (_ *shared.UploadValidationReport, err error) {
+	ctx, _, endObservation := s.operations.getValidationReport.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	authzConds, err := database.AuthzQueryConds(ctx, database.NewDBWith(s.logger, s.db))
+	if err != nil {
+		return nil, err
+	}
+
+	report, _, err := scanFirstValidationReport(s.db.Query(ctx, sqlf.Sprintf(getValidationReportQuery, id, authzConds)))
+	return report, err
+}
+
+const getValidationReportQuery = `
+SELECT u.validation_report
+FROM lsif_uploads u
+JOIN repo ON repo.id = u.repository_id
+WHERE u.id = %s AND %s
+`
+
+func scanValidationReport(s dbutil.Scanner) (*shared.UploadValidationReport, error) {
+	var rawReport []byte
+	if err := s.Scan(&rawReport); err != nil {
+		return nil, err
+	}
+	if rawReport == nil {
+		return nil, nil
+	}
+
+	var report shared.UploadValidationReport
+	if err := json.Unmarshal(rawReport, &report); err != nil {
+		return nil, errors.Wrap(err, "unmarshal of validation_report failed")
+	}
+
+	return &report, nil
+}
+
+var scanFirstValidationReport = basestore.NewFirstScanner(scanValidationReport)
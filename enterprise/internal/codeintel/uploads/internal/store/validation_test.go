@@ -0,0 +1,52 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/log/logtest"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestValidationReport(t *testing.T) {
+	logger := logtest.Scoped(t)
+	db := database.NewDB(logger, dbtest.NewDB(logger, t))
+	store := New(&observation.TestContext, db)
+	ctx := context.Background()
+
+	insertUploads(t, db, shared.Upload{ID: 1, State: "processing"})
+
+	if report, err := store.GetValidationReport(ctx, 1); err != nil {
+		t.Fatalf("unexpected error getting validation report: %s", err)
+	} else if report != nil {
+		t.Fatalf("unexpected validation report before validation: %v", report)
+	}
+
+	expected := shared.UploadValidationReport{
+		NumDocuments:            12,
+		NumReferences:           100,
+		NumUnresolvedReferences: 40,
+		UnresolvedSymbols:       []string{"scip-go gomod example v1 `example`/Missing#"},
+		NumPathsNotInRepository: 1,
+		PathsNotInRepository:    []string{"generated/main.go"},
+		Violations:              []string{"1 document paths do not exist in the repository (maximum 0)"},
+	}
+	if err := store.UpdateValidationReport(ctx, 1, expected); err != nil {
+		t.Fatalf("unexpected error updating validation report: %s", err)
+	}
+
+	report, err := store.GetValidationReport(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error getting validation report: %s", err)
+	} else if report == nil {
+		t.Fatal("expected validation report")
+	}
+	if diff := cmp.Diff(expected, *report); diff != "" {
+		t.Errorf("unexpected validation report (-want +got):\n%s", diff)
+	}
+}
@@ -142,6 +142,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// GetUploadsByIDsAllowDeleted.
 	GetUploadsByIDsAllowDeletedFunc *StoreGetUploadsByIDsAllowDeletedFunc
+	// GetValidationReportFunc is an instance of a mock function object
+	// controlling the behavior of the method GetValidationReport.
+	GetValidationReportFunc *StoreGetValidationReportFunc
 	// GetVisibleUploadsMatchingMonikersFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// GetVisibleUploadsMatchingMonikers.
@@ -237,6 +240,9 @@ type MockStore struct {
 	// object controlling the behavior of the method
 	// UpdateUploadsVisibleToCommits.
 	UpdateUploadsVisibleToCommitsFunc *StoreUpdateUploadsVisibleToCommitsFunc
+	// UpdateValidationReportFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateValidationReport.
+	UpdateValidationReportFunc *StoreUpdateValidationReportFunc
 	// WithTransactionFunc is an instance of a mock function object
 	// controlling the behavior of the method WithTransaction.
 	WithTransactionFunc *StoreWithTransactionFunc
@@ -414,6 +420,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		GetValidationReportFunc: &StoreGetValidationReportFunc{
+			defaultHook: func(context.Context, int) (r0 *shared.UploadValidationReport, r1 error) {
+				return
+			},
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (r0 shared.PackageReferenceScanner, r1 int, r2 error) {
 				return
@@ -559,6 +570,11 @@ func NewMockStore() *MockStore {
 				return
 			},
 		},
+		UpdateValidationReportFunc: &StoreUpdateValidationReportFunc{
+			defaultHook: func(context.Context, int, shared.UploadValidationReport) (r0 error) {
+				return
+			},
+		},
 		WithTransactionFunc: &StoreWithTransactionFunc{
 			defaultHook: func(context.Context, func(s store.Store) error) (r0 error) {
 				return
@@ -741,6 +757,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.GetUploadsByIDsAllowDeleted")
 			},
 		},
+		GetValidationReportFunc: &StoreGetValidationReportFunc{
+			defaultHook: func(context.Context, int) (*shared.UploadValidationReport, error) {
+				panic("unexpected invocation of MockStore.GetValidationReport")
+			},
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: func(context.Context, int, string, []precise.QualifiedMonikerData, int, int) (shared.PackageReferenceScanner, int, error) {
 				panic("unexpected invocation of MockStore.GetVisibleUploadsMatchingMonikers")
@@ -886,6 +907,11 @@ func NewStrictMockStore() *MockStore {
 				panic("unexpected invocation of MockStore.UpdateUploadsVisibleToCommits")
 			},
 		},
+		UpdateValidationReportFunc: &StoreUpdateValidationReportFunc{
+			defaultHook: func(context.Context, int, shared.UploadValidationReport) error {
+				panic("unexpected invocation of MockStore.UpdateValidationReport")
+			},
+		},
 		WithTransactionFunc: &StoreWithTransactionFunc{
 			defaultHook: func(context.Context, func(s store.Store) error) error {
 				panic("unexpected invocation of MockStore.WithTransaction")
@@ -1002,6 +1028,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		GetUploadsByIDsAllowDeletedFunc: &StoreGetUploadsByIDsAllowDeletedFunc{
 			defaultHook: i.GetUploadsByIDsAllowDeleted,
 		},
+		GetValidationReportFunc: &StoreGetValidationReportFunc{
+			defaultHook: i.GetValidationReport,
+		},
 		GetVisibleUploadsMatchingMonikersFunc: &StoreGetVisibleUploadsMatchingMonikersFunc{
 			defaultHook: i.GetVisibleUploadsMatchingMonikers,
 		},
@@ -1089,6 +1118,9 @@ func NewMockStoreFrom(i store.Store) *MockStore {
 		UpdateUploadsVisibleToCommitsFunc: &StoreUpdateUploadsVisibleToCommitsFunc{
 			defaultHook: i.UpdateUploadsVisibleToCommits,
 		},
+		UpdateValidationReportFunc: &StoreUpdateValidationReportFunc{
+			defaultHook: i.UpdateValidationReport,
+		},
 		WithTransactionFunc: &StoreWithTransactionFunc{
 			defaultHook: i.WithTransaction,
 		},
@@ -4807,6 +4839,114 @@ func (c StoreGetUploadsByIDsAllowDeletedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// StoreGetValidationReportFunc describes the behavior when the
+// GetValidationReport method of the parent MockStore instance is invoked.
+type StoreGetValidationReportFunc struct {
+	defaultHook func(context.Context, int) (*shared.UploadValidationReport, error)
+	hooks       []func(context.Context, int) (*shared.UploadValidationReport, error)
+	history     []StoreGetValidationReportFuncCall
+	mutex       sync.Mutex
+}
+
+// GetValidationReport delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockStore) GetValidationReport(v0 context.Context, v1 int) (*shared.UploadValidationReport, error) {
+	r0, r1 := m.GetValidationReportFunc.nextHook()(v0, v1)
+	m.GetValidationReportFunc.appendCall(StoreGetValidationReportFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetValidationReport
+// method of the parent MockStore instance is invoked and the hook queue is
+// empty.
+func (f *StoreGetValidationReportFunc) SetDefaultHook(hook func(context.Context, int) (*shared.UploadValidationReport, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetValidationReport method of the parent MockStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *StoreGetValidationReportFunc) PushHook(hook func(context.Context, int) (*shared.UploadValidationReport, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreGetValidationReportFunc) SetDefaultReturn(r0 *shared.UploadValidationReport, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (*shared.UploadValidationReport, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreGetValidationReportFunc) PushReturn(r0 *shared.UploadValidationReport, r1 error) {
+	f.PushHook(func(context.Context, int) (*shared.UploadValidationReport, error) {
+		return r0, r1
+	})
+}
+
+func (f *StoreGetValidationReportFunc) nextHook() func(context.Context, int) (*shared.UploadValidationReport, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreGetValidationReportFunc) appendCall(r0 StoreGetValidationReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreGetValidationReportFuncCall objects
+// describing the invocations of this function.
+func (f *StoreGetValidationReportFunc) History() []StoreGetValidationReportFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreGetValidationReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreGetValidationReportFuncCall is an object that describes an
+// invocation of method GetValidationReport on an instance of MockStore.
+type StoreGetValidationReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *shared.UploadValidationReport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreGetValidationReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreGetValidationReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // StoreGetVisibleUploadsMatchingMonikersFunc describes the behavior when
 // the GetVisibleUploadsMatchingMonikers method of the parent MockStore
 // instance is invoked.
@@ -8024,6 +8164,115 @@ func (c StoreUpdateUploadsVisibleToCommitsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// StoreUpdateValidationReportFunc describes the behavior when the
+// UpdateValidationReport method of the parent MockStore instance is
+// invoked.
+type StoreUpdateValidationReportFunc struct {
+	defaultHook func(context.Context, int, shared.UploadValidationReport) error
+	hooks       []func(context.Context, int, shared.UploadValidationReport) error
+	history     []StoreUpdateValidationReportFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateValidationReport delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockStore) UpdateValidationReport(v0 context.Context, v1 int, v2 shared.UploadValidationReport) error {
+	r0 := m.UpdateValidationReportFunc.nextHook()(v0, v1, v2)
+	m.UpdateValidationReportFunc.appendCall(StoreUpdateValidationReportFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateValidationReport method of the parent MockStore instance is invoked
+// and the hook queue is empty.
+func (f *StoreUpdateValidationReportFunc) SetDefaultHook(hook func(context.Context, int, shared.UploadValidationReport) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateValidationReport method of the parent MockStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *StoreUpdateValidationReportFunc) PushHook(hook func(context.Context, int, shared.UploadValidationReport) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *StoreUpdateValidationReportFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, shared.UploadValidationReport) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *StoreUpdateValidationReportFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, shared.UploadValidationReport) error {
+		return r0
+	})
+}
+
+func (f *StoreUpdateValidationReportFunc) nextHook() func(context.Context, int, shared.UploadValidationReport) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *StoreUpdateValidationReportFunc) appendCall(r0 StoreUpdateValidationReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of StoreUpdateValidationReportFuncCall objects
+// describing the invocations of this function.
+func (f *StoreUpdateValidationReportFunc) History() []StoreUpdateValidationReportFuncCall {
+	f.mutex.Lock()
+	history := make([]StoreUpdateValidationReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// StoreUpdateValidationReportFuncCall is an object that describes an
+// invocation of method UpdateValidationReport on an instance of MockStore.
+type StoreUpdateValidationReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 shared.UploadValidationReport
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c StoreUpdateValidationReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c StoreUpdateValidationReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // StoreWithTransactionFunc describes the behavior when the WithTransaction
 // method of the parent MockStore instance is invoked.
 type StoreWithTransactionFunc struct {
@@ -8395,6 +8644,9 @@ type MockLSIFStore struct {
 	// object controlling the behavior of the method
 	// DeleteUnreferencedDocuments.
 	DeleteUnreferencedDocumentsFunc *LSIFStoreDeleteUnreferencedDocumentsFunc
+	// GetDocumentPathsFunc is an instance of a mock function object
+	// controlling the behavior of the method GetDocumentPaths.
+	GetDocumentPathsFunc *LSIFStoreGetDocumentPathsFunc
 	// IDsWithMetaFunc is an instance of a mock function object controlling
 	// the behavior of the method IDsWithMeta.
 	IDsWithMetaFunc *LSIFStoreIDsWithMetaFunc
@@ -8415,6 +8667,9 @@ type MockLSIFStore struct {
 	// object controlling the behavior of the method
 	// ReconcileCandidatesWithTime.
 	ReconcileCandidatesWithTimeFunc *LSIFStoreReconcileCandidatesWithTimeFunc
+	// SearchSymbolDefinitionsFunc is an instance of a mock function object
+	// controlling the behavior of the method SearchSymbolDefinitions.
+	SearchSymbolDefinitionsFunc *LSIFStoreSearchSymbolDefinitionsFunc
 	// WithTransactionFunc is an instance of a mock function object
 	// controlling the behavior of the method WithTransaction.
 	WithTransactionFunc *LSIFStoreWithTransactionFunc
@@ -8434,6 +8689,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return
 			},
 		},
+		GetDocumentPathsFunc: &LSIFStoreGetDocumentPathsFunc{
+			defaultHook: func(context.Context, int, []string) (r0 []string, r1 error) {
+				return
+			},
+		},
 		IDsWithMetaFunc: &LSIFStoreIDsWithMetaFunc{
 			defaultHook: func(context.Context, []int) (r0 []int, r1 error) {
 				return
@@ -8464,6 +8724,11 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return
 			},
 		},
+		SearchSymbolDefinitionsFunc: &LSIFStoreSearchSymbolDefinitionsFunc{
+			defaultHook: func(context.Context, int, string, int, int) (r0 []shared.SymbolDefinition, r1 int, r2 error) {
+				return
+			},
+		},
 		WithTransactionFunc: &LSIFStoreWithTransactionFunc{
 			defaultHook: func(context.Context, func(s lsifstore.Store) error) (r0 error) {
 				return
@@ -8486,6 +8751,11 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.DeleteUnreferencedDocuments")
 			},
 		},
+		GetDocumentPathsFunc: &LSIFStoreGetDocumentPathsFunc{
+			defaultHook: func(context.Context, int, []string) ([]string, error) {
+				panic("unexpected invocation of MockLSIFStore.GetDocumentPaths")
+			},
+		},
 		IDsWithMetaFunc: &LSIFStoreIDsWithMetaFunc{
 			defaultHook: func(context.Context, []int) ([]int, error) {
 				panic("unexpected invocation of MockLSIFStore.IDsWithMeta")
@@ -8516,6 +8786,11 @@ func NewStrictMockLSIFStore() *MockLSIFStore {
 				panic("unexpected invocation of MockLSIFStore.ReconcileCandidatesWithTime")
 			},
 		},
+		SearchSymbolDefinitionsFunc: &LSIFStoreSearchSymbolDefinitionsFunc{
+			defaultHook: func(context.Context, int, string, int, int) ([]shared.SymbolDefinition, int, error) {
+				panic("unexpected invocation of MockLSIFStore.SearchSymbolDefinitions")
+			},
+		},
 		WithTransactionFunc: &LSIFStoreWithTransactionFunc{
 			defaultHook: func(context.Context, func(s lsifstore.Store) error) error {
 				panic("unexpected invocation of MockLSIFStore.WithTransaction")
@@ -8534,6 +8809,9 @@ func NewMockLSIFStoreFrom(i lsifstore.Store) *MockLSIFStore {
 		DeleteUnreferencedDocumentsFunc: &LSIFStoreDeleteUnreferencedDocumentsFunc{
 			defaultHook: i.DeleteUnreferencedDocuments,
 		},
+		GetDocumentPathsFunc: &LSIFStoreGetDocumentPathsFunc{
+			defaultHook: i.GetDocumentPaths,
+		},
 		IDsWithMetaFunc: &LSIFStoreIDsWithMetaFunc{
 			defaultHook: i.IDsWithMeta,
 		},
@@ -8552,6 +8830,9 @@ func NewMockLSIFStoreFrom(i lsifstore.Store) *MockLSIFStore {
 		ReconcileCandidatesWithTimeFunc: &LSIFStoreReconcileCandidatesWithTimeFunc{
 			defaultHook: i.ReconcileCandidatesWithTime,
 		},
+		SearchSymbolDefinitionsFunc: &LSIFStoreSearchSymbolDefinitionsFunc{
+			defaultHook: i.SearchSymbolDefinitions,
+		},
 		WithTransactionFunc: &LSIFStoreWithTransactionFunc{
 			defaultHook: i.WithTransaction,
 		},
@@ -8794,6 +9075,117 @@ func (c LSIFStoreDeleteUnreferencedDocumentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// LSIFStoreGetDocumentPathsFunc describes the behavior when the
+// GetDocumentPaths method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreGetDocumentPathsFunc struct {
+	defaultHook func(context.Context, int, []string) ([]string, error)
+	hooks       []func(context.Context, int, []string) ([]string, error)
+	history     []LSIFStoreGetDocumentPathsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetDocumentPaths delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) GetDocumentPaths(v0 context.Context, v1 int, v2 []string) ([]string, error) {
+	r0, r1 := m.GetDocumentPathsFunc.nextHook()(v0, v1, v2)
+	m.GetDocumentPathsFunc.appendCall(LSIFStoreGetDocumentPathsFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetDocumentPaths
+// method of the parent MockLSIFStore instance is invoked and the hook queue
+// is empty.
+func (f *LSIFStoreGetDocumentPathsFunc) SetDefaultHook(hook func(context.Context, int, []string) ([]string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetDocumentPaths method of the parent MockLSIFStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *LSIFStoreGetDocumentPathsFunc) PushHook(hook func(context.Context, int, []string) ([]string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreGetDocumentPathsFunc) SetDefaultReturn(r0 []string, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, []string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreGetDocumentPathsFunc) PushReturn(r0 []string, r1 error) {
+	f.PushHook(func(context.Context, int, []string) ([]string, error) {
+		return r0, r1
+	})
+}
+
+func (f *LSIFStoreGetDocumentPathsFunc) nextHook() func(context.Context, int, []string) ([]string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreGetDocumentPathsFunc) appendCall(r0 LSIFStoreGetDocumentPathsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreGetDocumentPathsFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreGetDocumentPathsFunc) History() []LSIFStoreGetDocumentPathsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreGetDocumentPathsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreGetDocumentPathsFuncCall is an object that describes an
+// invocation of method GetDocumentPaths on an instance of MockLSIFStore.
+type LSIFStoreGetDocumentPathsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []string
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreGetDocumentPathsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreGetDocumentPathsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // LSIFStoreIDsWithMetaFunc describes the behavior when the IDsWithMeta
 // method of the parent MockLSIFStore instance is invoked.
 type LSIFStoreIDsWithMetaFunc struct {
@@ -9462,6 +9854,129 @@ func (c LSIFStoreReconcileCandidatesWithTimeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// LSIFStoreSearchSymbolDefinitionsFunc describes the behavior when the
+// SearchSymbolDefinitions method of the parent MockLSIFStore instance is
+// invoked.
+type LSIFStoreSearchSymbolDefinitionsFunc struct {
+	defaultHook func(context.Context, int, string, int, int) ([]shared.SymbolDefinition, int, error)
+	hooks       []func(context.Context, int, string, int, int) ([]shared.SymbolDefinition, int, error)
+	history     []LSIFStoreSearchSymbolDefinitionsFuncCall
+	mutex       sync.Mutex
+}
+
+// SearchSymbolDefinitions delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockLSIFStore) SearchSymbolDefinitions(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([]shared.SymbolDefinition, int, error) {
+	r0, r1, r2 := m.SearchSymbolDefinitionsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.SearchSymbolDefinitionsFunc.appendCall(LSIFStoreSearchSymbolDefinitionsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// SearchSymbolDefinitions method of the parent MockLSIFStore instance is
+// invoked and the hook queue is empty.
+func (f *LSIFStoreSearchSymbolDefinitionsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([]shared.SymbolDefinition, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SearchSymbolDefinitions method of the parent MockLSIFStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *LSIFStoreSearchSymbolDefinitionsFunc) PushHook(hook func(context.Context, int, string, int, int) ([]shared.SymbolDefinition, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *LSIFStoreSearchSymbolDefinitionsFunc) SetDefaultReturn(r0 []shared.SymbolDefinition, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, int, int) ([]shared.SymbolDefinition, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *LSIFStoreSearchSymbolDefinitionsFunc) PushReturn(r0 []shared.SymbolDefinition, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, int, int) ([]shared.SymbolDefinition, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *LSIFStoreSearchSymbolDefinitionsFunc) nextHook() func(context.Context, int, string, int, int) ([]shared.SymbolDefinition, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreSearchSymbolDefinitionsFunc) appendCall(r0 LSIFStoreSearchSymbolDefinitionsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreSearchSymbolDefinitionsFuncCall
+// objects describing the invocations of this function.
+func (f *LSIFStoreSearchSymbolDefinitionsFunc) History() []LSIFStoreSearchSymbolDefinitionsFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreSearchSymbolDefinitionsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreSearchSymbolDefinitionsFuncCall is an object that describes an
+// invocation of method SearchSymbolDefinitions on an instance of
+// MockLSIFStore.
+type LSIFStoreSearchSymbolDefinitionsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []shared.SymbolDefinition
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreSearchSymbolDefinitionsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreSearchSymbolDefinitionsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // LSIFStoreWithTransactionFunc describes the behavior when the
 // WithTransaction method of the parent MockLSIFStore instance is invoked.
 type LSIFStoreWithTransactionFunc struct {
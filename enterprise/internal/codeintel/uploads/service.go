@@ -180,10 +180,22 @@ func (s *Service) ReferencesForUpload(ctx context.Context, uploadID int) (shared
 	return s.store.ReferencesForUpload(ctx, uploadID)
 }
 
+func (s *Service) SearchSymbolDefinitions(ctx context.Context, uploadID int, search string, after, limit int) ([]shared.SymbolDefinition, int, error) {
+	return s.lsifstore.SearchSymbolDefinitions(ctx, uploadID, search, after, limit)
+}
+
+func (s *Service) GetDocumentPaths(ctx context.Context, uploadID int, paths []string) ([]string, error) {
+	return s.lsifstore.GetDocumentPaths(ctx, uploadID, paths)
+}
+
 func (s *Service) GetAuditLogsForUpload(ctx context.Context, uploadID int) ([]shared.UploadLog, error) {
 	return s.store.GetAuditLogsForUpload(ctx, uploadID)
 }
 
+func (s *Service) GetValidationReport(ctx context.Context, uploadID int) (*shared.UploadValidationReport, error) {
+	return s.store.GetValidationReport(ctx, uploadID)
+}
+
 // func (s *Service) GetUploadDocumentsForPath(ctx context.Context, bundleID int, pathPattern string) ([]string, int, error) {
 // 	return s.lsifstore.GetUploadDocumentsForPath(ctx, bundleID, pathPattern)
 // }
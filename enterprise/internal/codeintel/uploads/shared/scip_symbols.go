@@ -6,6 +6,14 @@ import (
 	"github.com/sourcegraph/scip/bindings/go/scip"
 )
 
+// SymbolDefinition is a symbol defined within a document of an upload, along with the
+// ranges of its definitions within that document.
+type SymbolDefinition struct {
+	Symbol       string
+	DocumentPath string
+	Ranges       []*scip.Range
+}
+
 type InvertedRangeIndex struct {
 	SymbolName           string
 	DefinitionRanges     []int32
@@ -0,0 +1,76 @@
+package shared
+
+import (
+	"fmt"
+)
+
+// UploadValidationReport summarizes the quality of the SCIP index of an upload. It is computed
+// by the upload processor before the index is written to the codeintel database. Lists of paths
+// and symbols are samples and hold at most a handful of entries; the counts are exact.
+type UploadValidationReport struct {
+	NumDocuments                           int      `json:"numDocuments"`
+	NumDocumentsWithoutOccurrences         int      `json:"numDocumentsWithoutOccurrences"`
+	DocumentsWithoutOccurrences            []string `json:"documentsWithoutOccurrences"`
+	NumReferences                          int      `json:"numReferences"`
+	NumUnresolvedReferences                int      `json:"numUnresolvedReferences"`
+	UnresolvedSymbols                      []string `json:"unresolvedSymbols"`
+	NumPathsNotInRepository                int      `json:"numPathsNotInRepository"`
+	PathsNotInRepository                   []string `json:"pathsNotInRepository"`
+	NumDuplicateSymbols                    int      `json:"numDuplicateSymbols"`
+	DuplicateSymbols                       []string `json:"duplicateSymbols"`
+	NumDocumentsWithOverlappingOccurrences int      `json:"numDocumentsWithOverlappingOccurrences"`
+	DocumentsWithOverlappingOccurrences    []string `json:"documentsWithOverlappingOccurrences"`
+
+	// Violations describes each threshold of the processor's validation config the index
+	// exceeded. When strict validation is enabled, an upload with violations is rejected.
+	Violations []string `json:"violations"`
+}
+
+// UnresolvedReferenceRatio returns the fraction of reference occurrences in the index whose
+// symbol has no definition.
+func (r UploadValidationReport) UnresolvedReferenceRatio() float64 {
+	if r.NumReferences == 0 {
+		return 0
+	}
+
+	return float64(r.NumUnresolvedReferences) / float64(r.NumReferences)
+}
+
+// UploadValidationConfig configures the validation stage of the upload processor. A negative
+// maximum disables the corresponding check.
+type UploadValidationConfig struct {
+	// Strict rejects uploads that exceed any of the thresholds below.
+	Strict                                     bool
+	MaximumUnresolvedReferencesPercent         int
+	MaximumDocumentsWithoutOccurrences         int
+	MaximumPathsNotInRepository                int
+	MaximumDuplicateSymbols                    int
+	MaximumDocumentsWithOverlappingOccurrences int
+}
+
+// Violations returns a description of each threshold the given report exceeds.
+func (c UploadValidationConfig) Violations(report UploadValidationReport) (violations []string) {
+	if c.MaximumUnresolvedReferencesPercent >= 0 {
+		if percent := report.UnresolvedReferenceRatio() * 100; percent > float64(c.MaximumUnresolvedReferencesPercent) {
+			violations = append(violations, fmt.Sprintf("%.1f%% of references are unresolved (maximum %d%%)", percent, c.MaximumUnresolvedReferencesPercent))
+		}
+	}
+
+	if c.MaximumDocumentsWithoutOccurrences >= 0 && report.NumDocumentsWithoutOccurrences > c.MaximumDocumentsWithoutOccurrences {
+		violations = append(violations, fmt.Sprintf("%d documents have no occurrences (maximum %d)", report.NumDocumentsWithoutOccurrences, c.MaximumDocumentsWithoutOccurrences))
+	}
+
+	if c.MaximumPathsNotInRepository >= 0 && report.NumPathsNotInRepository > c.MaximumPathsNotInRepository {
+		violations = append(violations, fmt.Sprintf("%d document paths do not exist in the repository (maximum %d)", report.NumPathsNotInRepository, c.MaximumPathsNotInRepository))
+	}
+
+	if c.MaximumDuplicateSymbols >= 0 && report.NumDuplicateSymbols > c.MaximumDuplicateSymbols {
+		violations = append(violations, fmt.Sprintf("%d symbols are defined in more than one document (maximum %d)", report.NumDuplicateSymbols, c.MaximumDuplicateSymbols))
+	}
+
+	if c.MaximumDocumentsWithOverlappingOccurrences >= 0 && report.NumDocumentsWithOverlappingOccurrences > c.MaximumDocumentsWithOverlappingOccurrences {
+		violations = append(violations, fmt.Sprintf("%d documents have overlapping occurrences (maximum %d)", report.NumDocumentsWithOverlappingOccurrences, c.MaximumDocumentsWithOverlappingOccurrences))
+	}
+
+	return violations
+}
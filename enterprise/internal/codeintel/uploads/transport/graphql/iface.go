@@ -17,6 +17,7 @@ type UploadsService interface {
 	GetIndexes(ctx context.Context, opts uploadshared.GetIndexesOptions) (_ []uploadsshared.Index, _ int, err error)
 	GetUploads(ctx context.Context, opts uploadshared.GetUploadsOptions) (uploads []shared.Upload, totalCount int, err error)
 	GetAuditLogsForUpload(ctx context.Context, uploadID int) (_ []shared.UploadLog, err error)
+	GetValidationReport(ctx context.Context, uploadID int) (*shared.UploadValidationReport, error)
 	GetIndexByID(ctx context.Context, id int) (_ uploadsshared.Index, _ bool, err error)
 	DeleteIndexByID(ctx context.Context, id int) (_ bool, err error)
 	DeleteIndexes(ctx context.Context, opts uploadshared.DeleteIndexesOptions) (err error)
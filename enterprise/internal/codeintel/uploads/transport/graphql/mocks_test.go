@@ -68,6 +68,9 @@ type MockUploadsService struct {
 	// GetUploadsByIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadsByIDs.
 	GetUploadsByIDsFunc *UploadsServiceGetUploadsByIDsFunc
+	// GetValidationReportFunc is an instance of a mock function object
+	// controlling the behavior of the method GetValidationReport.
+	GetValidationReportFunc *UploadsServiceGetValidationReportFunc
 	// NumRepositoriesWithCodeIntelligenceFunc is an instance of a mock
 	// function object controlling the behavior of the method
 	// NumRepositoriesWithCodeIntelligence.
@@ -173,6 +176,11 @@ func NewMockUploadsService() *MockUploadsService {
 				return
 			},
 		},
+		GetValidationReportFunc: &UploadsServiceGetValidationReportFunc{
+			defaultHook: func(context.Context, int) (r0 *shared.UploadValidationReport, r1 error) {
+				return
+			},
+		},
 		NumRepositoriesWithCodeIntelligenceFunc: &UploadsServiceNumRepositoriesWithCodeIntelligenceFunc{
 			defaultHook: func(context.Context) (r0 int, r1 error) {
 				return
@@ -290,6 +298,11 @@ func NewStrictMockUploadsService() *MockUploadsService {
 				panic("unexpected invocation of MockUploadsService.GetUploadsByIDs")
 			},
 		},
+		GetValidationReportFunc: &UploadsServiceGetValidationReportFunc{
+			defaultHook: func(context.Context, int) (*shared.UploadValidationReport, error) {
+				panic("unexpected invocation of MockUploadsService.GetValidationReport")
+			},
+		},
 		NumRepositoriesWithCodeIntelligenceFunc: &UploadsServiceNumRepositoriesWithCodeIntelligenceFunc{
 			defaultHook: func(context.Context) (int, error) {
 				panic("unexpected invocation of MockUploadsService.NumRepositoriesWithCodeIntelligence")
@@ -376,6 +389,9 @@ func NewMockUploadsServiceFrom(i UploadsService) *MockUploadsService {
 		GetUploadsByIDsFunc: &UploadsServiceGetUploadsByIDsFunc{
 			defaultHook: i.GetUploadsByIDs,
 		},
+		GetValidationReportFunc: &UploadsServiceGetValidationReportFunc{
+			defaultHook: i.GetValidationReport,
+		},
 		NumRepositoriesWithCodeIntelligenceFunc: &UploadsServiceNumRepositoriesWithCodeIntelligenceFunc{
 			defaultHook: i.NumRepositoriesWithCodeIntelligence,
 		},
@@ -2180,6 +2196,117 @@ func (c UploadsServiceGetUploadsByIDsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// UploadsServiceGetValidationReportFunc describes the behavior when the
+// GetValidationReport method of the parent MockUploadsService instance is
+// invoked.
+type UploadsServiceGetValidationReportFunc struct {
+	defaultHook func(context.Context, int) (*shared.UploadValidationReport, error)
+	hooks       []func(context.Context, int) (*shared.UploadValidationReport, error)
+	history     []UploadsServiceGetValidationReportFuncCall
+	mutex       sync.Mutex
+}
+
+// GetValidationReport delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockUploadsService) GetValidationReport(v0 context.Context, v1 int) (*shared.UploadValidationReport, error) {
+	r0, r1 := m.GetValidationReportFunc.nextHook()(v0, v1)
+	m.GetValidationReportFunc.appendCall(UploadsServiceGetValidationReportFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetValidationReport
+// method of the parent MockUploadsService instance is invoked and the hook
+// queue is empty.
+func (f *UploadsServiceGetValidationReportFunc) SetDefaultHook(hook func(context.Context, int) (*shared.UploadValidationReport, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetValidationReport method of the parent MockUploadsService instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *UploadsServiceGetValidationReportFunc) PushHook(hook func(context.Context, int) (*shared.UploadValidationReport, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *UploadsServiceGetValidationReportFunc) SetDefaultReturn(r0 *shared.UploadValidationReport, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (*shared.UploadValidationReport, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *UploadsServiceGetValidationReportFunc) PushReturn(r0 *shared.UploadValidationReport, r1 error) {
+	f.PushHook(func(context.Context, int) (*shared.UploadValidationReport, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadsServiceGetValidationReportFunc) nextHook() func(context.Context, int) (*shared.UploadValidationReport, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadsServiceGetValidationReportFunc) appendCall(r0 UploadsServiceGetValidationReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadsServiceGetValidationReportFuncCall
+// objects describing the invocations of this function.
+func (f *UploadsServiceGetValidationReportFunc) History() []UploadsServiceGetValidationReportFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadsServiceGetValidationReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadsServiceGetValidationReportFuncCall is an object that describes an
+// invocation of method GetValidationReport on an instance of
+// MockUploadsService.
+type UploadsServiceGetValidationReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 *shared.UploadValidationReport
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadsServiceGetValidationReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadsServiceGetValidationReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // UploadsServiceNumRepositoriesWithCodeIntelligenceFunc describes the
 // behavior when the NumRepositoriesWithCodeIntelligence method of the
 // parent MockUploadsService instance is invoked.
@@ -393,6 +393,19 @@ func (r *preciseIndexResolver) AuditLogs(ctx context.Context) (*[]resolverstubs.
 	return &resolvers, nil
 }
 
+func (r *preciseIndexResolver) ValidationReport(ctx context.Context) (resolverstubs.PreciseIndexValidationReportResolver, error) {
+	if r.upload == nil {
+		return nil, nil
+	}
+
+	report, err := r.uploadsSvc.GetValidationReport(ctx, r.upload.ID)
+	if err != nil || report == nil {
+		return nil, err
+	}
+
+	return newPreciseIndexValidationReportResolver(*report), nil
+}
+
 //
 //
 
@@ -487,3 +500,79 @@ func (r *auditLogColumnChangeResolver) Old() *string {
 func (r *auditLogColumnChangeResolver) New() *string {
 	return r.columnTransition["new"]
 }
+
+//
+//
+
+type preciseIndexValidationReportResolver struct {
+	report shared.UploadValidationReport
+}
+
+func newPreciseIndexValidationReportResolver(report shared.UploadValidationReport) resolverstubs.PreciseIndexValidationReportResolver {
+	return &preciseIndexValidationReportResolver{report: report}
+}
+
+func (r *preciseIndexValidationReportResolver) DocumentCount() int32 {
+	return int32(r.report.NumDocuments)
+}
+
+func (r *preciseIndexValidationReportResolver) DocumentsWithoutOccurrencesCount() int32 {
+	return int32(r.report.NumDocumentsWithoutOccurrences)
+}
+
+func (r *preciseIndexValidationReportResolver) DocumentsWithoutOccurrences() []string {
+	return nonNilStrings(r.report.DocumentsWithoutOccurrences)
+}
+
+func (r *preciseIndexValidationReportResolver) ReferenceCount() int32 {
+	return int32(r.report.NumReferences)
+}
+
+func (r *preciseIndexValidationReportResolver) UnresolvedReferenceCount() int32 {
+	return int32(r.report.NumUnresolvedReferences)
+}
+
+func (r *preciseIndexValidationReportResolver) UnresolvedReferenceRatio() float64 {
+	return r.report.UnresolvedReferenceRatio()
+}
+
+func (r *preciseIndexValidationReportResolver) UnresolvedSymbols() []string {
+	return nonNilStrings(r.report.UnresolvedSymbols)
+}
+
+func (r *preciseIndexValidationReportResolver) PathsNotInRepositoryCount() int32 {
+	return int32(r.report.NumPathsNotInRepository)
+}
+
+func (r *preciseIndexValidationReportResolver) PathsNotInRepository() []string {
+	return nonNilStrings(r.report.PathsNotInRepository)
+}
+
+func (r *preciseIndexValidationReportResolver) DuplicateSymbolCount() int32 {
+	return int32(r.report.NumDuplicateSymbols)
+}
+
+func (r *preciseIndexValidationReportResolver) DuplicateSymbols() []string {
+	return nonNilStrings(r.report.DuplicateSymbols)
+}
+
+func (r *preciseIndexValidationReportResolver) DocumentsWithOverlappingOccurrencesCount() int32 {
+	return int32(r.report.NumDocumentsWithOverlappingOccurrences)
+}
+
+func (r *preciseIndexValidationReportResolver) DocumentsWithOverlappingOccurrences() []string {
+	return nonNilStrings(r.report.DocumentsWithOverlappingOccurrences)
+}
+
+func (r *preciseIndexValidationReportResolver) Violations() []string {
+	return nonNilStrings(r.report.Violations)
+}
+
+// nonNilStrings returns an empty slice in place of nil so that list fields serialize as [].
+func nonNilStrings(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+
+	return values
+}
@@ -7896,6 +7896,9 @@ type MockEnterpriseDB struct {
 	// GitserverLocalCloneFunc is an instance of a mock function object
 	// controlling the behavior of the method GitserverLocalClone.
 	GitserverLocalCloneFunc *EnterpriseDBGitserverLocalCloneFunc
+	// GitserverRefEventsFunc is an instance of a mock function object
+	// controlling the behavior of the method GitserverRefEvents.
+	GitserverRefEventsFunc *EnterpriseDBGitserverRefEventsFunc
 	// GitserverReposFunc is an instance of a mock function object
 	// controlling the behavior of the method GitserverRepos.
 	GitserverReposFunc *EnterpriseDBGitserverReposFunc
@@ -8133,6 +8136,11 @@ func NewMockEnterpriseDB() *MockEnterpriseDB {
 				return
 			},
 		},
+		GitserverRefEventsFunc: &EnterpriseDBGitserverRefEventsFunc{
+			defaultHook: func() (r0 database.GitserverRefEventStore) {
+				return
+			},
+		},
 		GitserverReposFunc: &EnterpriseDBGitserverReposFunc{
 			defaultHook: func() (r0 database.GitserverRepoStore) {
 				return
@@ -8460,6 +8468,11 @@ func NewStrictMockEnterpriseDB() *MockEnterpriseDB {
 				panic("unexpected invocation of MockEnterpriseDB.GitserverLocalClone")
 			},
 		},
+		GitserverRefEventsFunc: &EnterpriseDBGitserverRefEventsFunc{
+			defaultHook: func() database.GitserverRefEventStore {
+				panic("unexpected invocation of MockEnterpriseDB.GitserverRefEvents")
+			},
+		},
 		GitserverReposFunc: &EnterpriseDBGitserverReposFunc{
 			defaultHook: func() database.GitserverRepoStore {
 				panic("unexpected invocation of MockEnterpriseDB.GitserverRepos")
@@ -8750,6 +8763,9 @@ func NewMockEnterpriseDBFrom(i EnterpriseDB) *MockEnterpriseDB {
 		GitserverLocalCloneFunc: &EnterpriseDBGitserverLocalCloneFunc{
 			defaultHook: i.GitserverLocalClone,
 		},
+		GitserverRefEventsFunc: &EnterpriseDBGitserverRefEventsFunc{
+			defaultHook: i.GitserverRefEvents,
+		},
 		GitserverReposFunc: &EnterpriseDBGitserverReposFunc{
 			defaultHook: i.GitserverRepos,
 		},
@@ -10805,6 +10821,107 @@ func (c EnterpriseDBGitserverLocalCloneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// EnterpriseDBGitserverRefEventsFunc describes the behavior when the
+// GitserverRefEvents method of the parent MockEnterpriseDB instance is
+// invoked.
+type EnterpriseDBGitserverRefEventsFunc struct {
+	defaultHook func() database.GitserverRefEventStore
+	hooks       []func() database.GitserverRefEventStore
+	history     []EnterpriseDBGitserverRefEventsFuncCall
+	mutex       sync.Mutex
+}
+
+// GitserverRefEvents delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEnterpriseDB) GitserverRefEvents() database.GitserverRefEventStore {
+	r0 := m.GitserverRefEventsFunc.nextHook()()
+	m.GitserverRefEventsFunc.appendCall(EnterpriseDBGitserverRefEventsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the GitserverRefEvents
+// method of the parent MockEnterpriseDB instance is invoked and the hook
+// queue is empty.
+func (f *EnterpriseDBGitserverRefEventsFunc) SetDefaultHook(hook func() database.GitserverRefEventStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GitserverRefEvents method of the parent MockEnterpriseDB instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *EnterpriseDBGitserverRefEventsFunc) PushHook(hook func() database.GitserverRefEventStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *EnterpriseDBGitserverRefEventsFunc) SetDefaultReturn(r0 database.GitserverRefEventStore) {
+	f.SetDefaultHook(func() database.GitserverRefEventStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *EnterpriseDBGitserverRefEventsFunc) PushReturn(r0 database.GitserverRefEventStore) {
+	f.PushHook(func() database.GitserverRefEventStore {
+		return r0
+	})
+}
+
+func (f *EnterpriseDBGitserverRefEventsFunc) nextHook() func() database.GitserverRefEventStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnterpriseDBGitserverRefEventsFunc) appendCall(r0 EnterpriseDBGitserverRefEventsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnterpriseDBGitserverRefEventsFuncCall
+// objects describing the invocations of this function.
+func (f *EnterpriseDBGitserverRefEventsFunc) History() []EnterpriseDBGitserverRefEventsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnterpriseDBGitserverRefEventsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnterpriseDBGitserverRefEventsFuncCall is an object that describes an
+// invocation of method GitserverRefEvents on an instance of
+// MockEnterpriseDB.
+type EnterpriseDBGitserverRefEventsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 database.GitserverRefEventStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnterpriseDBGitserverRefEventsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnterpriseDBGitserverRefEventsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // EnterpriseDBGitserverReposFunc describes the behavior when the
 // GitserverRepos method of the parent MockEnterpriseDB instance is invoked.
 type EnterpriseDBGitserverReposFunc struct {
@@ -133,6 +133,7 @@ func (s *service) RulesetForRepo(ctx context.Context, repoName api.RepoName, rep
 			repoName,
 			commitID,
 			path,
+			gitserver.ReadFileOptions{},
 		)
 		if content != nil && err == nil {
 			pbfile, err := codeowners.Parse(bytes.NewReader(content))
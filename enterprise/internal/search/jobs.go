@@ -2,16 +2,22 @@ package search
 
 import (
 	ownsearch "github.com/sourcegraph/sourcegraph/enterprise/internal/own/search"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/search/symbol"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/job"
 	"github.com/sourcegraph/sourcegraph/internal/search/job/jobutil"
 )
 
-func NewEnterpriseSearchJobs() jobutil.EnterpriseJobs {
-	return &enterpriseJobs{}
+// NewEnterpriseSearchJobs returns the enterprise implementations of search jobs. Symbol
+// searches are answered from precise code intelligence data by the given upload service
+// where possible; a nil service disables this.
+func NewEnterpriseSearchJobs(uploadSvc symbol.UploadService) jobutil.EnterpriseJobs {
+	return &enterpriseJobs{uploadSvc: uploadSvc}
 }
 
-type enterpriseJobs struct{}
+type enterpriseJobs struct {
+	uploadSvc symbol.UploadService
+}
 
 func (e *enterpriseJobs) FileHasOwnerJob(child job.Job, features *search.Features, includeOwners, excludeOwners []string) job.Job {
 	return ownsearch.NewFileHasOwnersJob(child, features, includeOwners, excludeOwners)
@@ -20,3 +26,10 @@ func (e *enterpriseJobs) FileHasOwnerJob(child job.Job, features *search.Feature
 func (e *enterpriseJobs) SelectFileOwnerJob(child job.Job, features *search.Features) job.Job {
 	return ownsearch.NewSelectOwnersJob(child, features)
 }
+
+func (e *enterpriseJobs) PreciseSymbolSearchJob(fallback job.Job, patternInfo *search.TextPatternInfo, limit int, features *search.Features) job.Job {
+	if e.uploadSvc == nil {
+		return fallback
+	}
+	return symbol.NewPreciseSymbolSearchJob(e.uploadSvc, fallback, patternInfo, limit, features)
+}
@@ -0,0 +1,570 @@
+package symbol
+
+import (
+	"context"
+	"regexp/syntax"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/conc/pool"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"go.opentelemetry.io/otel/attribute"
+
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/search/symbol"
+	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// UploadService is the subset of the code intelligence uploads service used to
+// answer symbol searches from precise code intelligence data.
+type UploadService interface {
+	InferClosestUploads(ctx context.Context, repositoryID int, commit, path string, exactPath bool, indexer string) ([]uploadsshared.Dump, error)
+	SearchSymbolDefinitions(ctx context.Context, uploadID int, search string, after, limit int) ([]uploadsshared.SymbolDefinition, int, error)
+	GetDocumentPaths(ctx context.Context, uploadID int, paths []string) ([]string, error)
+}
+
+// preciseSymbolSearchPageSize is the number of defined symbols read from an
+// upload at a time.
+const preciseSymbolSearchPageSize = 500
+
+// preciseSymbolSearchMaxPages is the number of pages of defined symbols read
+// from an upload at most. Patterns without a literal every matching name
+// contains read every defined symbol, so searches of larger uploads report
+// that they hit the limit instead.
+const preciseSymbolSearchMaxPages = 100
+
+// NewPreciseSymbolSearchJob creates a job which searches the symbols defined by
+// the SCIP uploads visible from the searched commit of each repository, rather
+// than the symbols extracted by ctags. Uploads are used when they were made for
+// the searched commit, or for any visible commit when the `symbol.precise`
+// feature is enabled. Repositories without such uploads, and the files of other
+// repositories which their uploads do not cover, are searched by fallback,
+// which is an unindexed or a Zoekt symbol search with the given pattern and
+// limit.
+func NewPreciseSymbolSearchJob(uploadSvc UploadService, fallback job.Job, patternInfo *search.TextPatternInfo, limit int, features *search.Features) job.Job {
+	return &preciseSymbolSearchJob{
+		uploadSvc:   uploadSvc,
+		fallback:    fallback,
+		patternInfo: patternInfo,
+		limit:       limit,
+		features:    features,
+	}
+}
+
+type preciseSymbolSearchJob struct {
+	uploadSvc   UploadService
+	fallback    job.Job
+	patternInfo *search.TextPatternInfo
+	limit       int
+	features    *search.Features
+}
+
+func (j *preciseSymbolSearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	tr, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	repos := fallbackRepos(j.fallback)
+	if len(repos) == 0 {
+		return nil, nil
+	}
+
+	match, err := compileSymbolMatcher(j.patternInfo)
+	if err != nil {
+		return nil, err
+	}
+
+	// The uploads used for a repository are determined once, by whichever
+	// of the precise search of the repository and the fallback needs them
+	// first. The fallback needs them to tell which of its results the
+	// precise search answers.
+	type repoUploads struct {
+		once    sync.Once
+		uploads []uploadsshared.Dump
+	}
+	uploadsByRepo := make(map[api.RepoID]*repoUploads, len(repos))
+	reposByID := make(map[api.RepoID]*search.RepositoryRevisions, len(repos))
+	for _, repo := range repos {
+		uploadsByRepo[repo.Repo.ID] = &repoUploads{}
+		reposByID[repo.Repo.ID] = repo
+	}
+	var preciseRepos atomic.Int64
+	getUploads := func(repoID api.RepoID) []uploadsshared.Dump {
+		u, ok := uploadsByRepo[repoID]
+		if !ok {
+			return nil
+		}
+		u.once.Do(func() {
+			u.uploads = j.preciseUploads(ctx, clients.Gitserver, reposByID[repoID])
+			if len(u.uploads) > 0 {
+				preciseRepos.Add(1)
+			}
+		})
+		return u.uploads
+	}
+	defer func() {
+		tr.SetAttributes(
+			attribute.Int64("preciseRepos", preciseRepos.Load()),
+			attribute.Int64("fallbackRepos", int64(len(repos))-preciseRepos.Load()))
+	}()
+
+	var (
+		p          = pool.New().WithContext(ctx).WithMaxGoroutines(conf.SearchSymbolsParallelism())
+		maxAlerter search.MaxAlerter
+	)
+
+	// The fallback searches every repository, as uploads need not cover all of
+	// the files of a repository. Its results for files that are covered are
+	// dropped in favor of the precise results.
+	p.Go(func(ctx context.Context) error {
+		alert, err := runFallback(ctx, j.fallback, clients, streaming.StreamFunc(func(event streaming.SearchEvent) {
+			event.Results = j.dropCoveredFiles(ctx, event.Results, getUploads)
+			stream.Send(event)
+		}))
+		maxAlerter.Add(alert)
+		return err
+	})
+	for _, repo := range repos {
+		repo := repo
+		p.Go(func(ctx context.Context) error {
+			repoUploads := getUploads(repo.Repo.ID)
+			if len(repoUploads) == 0 {
+				return nil
+			}
+			matches, limitHit, err := j.searchRepo(ctx, repo, repoUploads, match)
+			status, limitHit, err := search.HandleRepoSearchResult(repo.Repo.ID, repo.Revs, limitHit, false, err)
+			stream.Send(streaming.SearchEvent{
+				Results: matches,
+				Stats: streaming.Stats{
+					Status:     status,
+					IsLimitHit: limitHit,
+				},
+			})
+			return err
+		})
+	}
+	return maxAlerter.Alert, p.Wait()
+}
+
+// runFallback runs the given fallback job. It is replaced in tests, which
+// cannot search with ctags or Zoekt.
+var runFallback = func(ctx context.Context, fallback job.Job, clients job.RuntimeClients, stream streaming.Sender) (*search.Alert, error) {
+	return fallback.Run(ctx, clients, stream)
+}
+
+// fallbackRepos returns the repositories searched by the given fallback job,
+// or nil if it is not a symbol search the precise job can stand in for.
+func fallbackRepos(fallback job.Job) []*search.RepositoryRevisions {
+	switch f := fallback.(type) {
+	case *searcher.SymbolSearchJob:
+		return f.Repos
+	case *zoekt.SymbolSearchJob:
+		if f.Repos == nil {
+			return nil
+		}
+		repos := make([]*search.RepositoryRevisions, 0, len(f.Repos.RepoRevs))
+		for _, repo := range f.Repos.RepoRevs {
+			repos = append(repos, repo)
+		}
+		sort.Slice(repos, func(i, k int) bool { return repos[i].Repo.ID < repos[k].Repo.ID })
+		return repos
+	}
+	return nil
+}
+
+// dropCoveredFiles removes the file matches of the given fallback results which
+// are in a document of one of the uploads used for their repository. If the
+// documents of an upload cannot be read, its files are treated as not covered
+// so that their symbols are still returned.
+func (j *preciseSymbolSearchJob) dropCoveredFiles(ctx context.Context, matches result.Matches, getUploads func(api.RepoID) []uploadsshared.Dump) result.Matches {
+	type uploadKey struct {
+		repoID   api.RepoID
+		uploadID int
+		root     string
+	}
+
+	// Group the paths of the matches by the uploads whose root contains them
+	pathsByUpload := map[uploadKey][]string{}
+	for _, match := range matches {
+		fileMatch, ok := match.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		for _, upload := range getUploads(fileMatch.Repo.ID) {
+			if strings.HasPrefix(fileMatch.Path, upload.Root) {
+				key := uploadKey{fileMatch.Repo.ID, upload.ID, upload.Root}
+				pathsByUpload[key] = append(pathsByUpload[key], strings.TrimPrefix(fileMatch.Path, upload.Root))
+			}
+		}
+	}
+	if len(pathsByUpload) == 0 {
+		return matches
+	}
+
+	type fileKey struct {
+		repoID api.RepoID
+		path   string
+	}
+	covered := map[fileKey]struct{}{}
+	for key, paths := range pathsByUpload {
+		documentPaths, err := j.uploadSvc.GetDocumentPaths(ctx, key.uploadID, paths)
+		if err != nil {
+			continue
+		}
+		for _, path := range documentPaths {
+			covered[fileKey{key.repoID, key.root + path}] = struct{}{}
+		}
+	}
+
+	filtered := matches[:0]
+	for _, match := range matches {
+		if fileMatch, ok := match.(*result.FileMatch); ok {
+			if _, ok := covered[fileKey{fileMatch.Repo.ID, fileMatch.Path}]; ok {
+				continue
+			}
+		}
+		filtered = append(filtered, match)
+	}
+	return filtered
+}
+
+// preciseUploads returns the uploads which can answer a symbol search of the
+// given repository, or nil if the repository should only be searched by
+// fallback.
+// Failures are not reported here: the fallback surfaces them when it searches
+// the same revision.
+func (j *preciseSymbolSearchJob) preciseUploads(ctx context.Context, gitserverClient gitserver.Client, repo *search.RepositoryRevisions) []uploadsshared.Dump {
+	if j.uploadSvc == nil || len(repo.Revs) == 0 {
+		return nil
+	}
+
+	commitID, err := gitserverClient.ResolveRevision(ctx, repo.GitserverRepo(), repo.Revs[0], gitserver.ResolveRevisionOptions{})
+	if err != nil {
+		return nil
+	}
+
+	uploads, err := j.uploadSvc.InferClosestUploads(ctx, int(repo.Repo.ID), string(commitID), "", false, "")
+	if err != nil {
+		return nil
+	}
+	if j.features != nil && j.features.PreciseSymbolSearch {
+		return uploads
+	}
+
+	upToDate := uploads[:0]
+	for _, upload := range uploads {
+		if upload.Commit == string(commitID) {
+			upToDate = append(upToDate, upload)
+		}
+	}
+	return upToDate
+}
+
+// searchRepo searches the symbols defined by the given uploads of a repository
+// and returns them grouped into file matches. It also returns true if there are
+// more matching symbols than the limit of the search.
+func (j *preciseSymbolSearchJob) searchRepo(ctx context.Context, repo *search.RepositoryRevisions, uploads []uploadsshared.Dump, match *symbolMatcher) (_ result.Matches, limitHit bool, err error) {
+	inputRev := repo.Revs[0]
+	tr, ctx := trace.New(ctx, "symbols", "searchPreciseInRepo",
+		attribute.String("repo", string(repo.Repo.Name)),
+		attribute.String("rev", inputRev))
+	defer tr.FinishWithErr(&err)
+
+	// The database narrows definitions by a substring of their names; the
+	// matcher applies the exact semantics of the pattern to each name.
+	hint := j.patternInfo.Pattern
+	if j.patternInfo.IsRegExp {
+		hint = requiredLiteral(j.patternInfo.Pattern)
+	}
+
+	limit := j.limit
+	var symbolMatches []*result.SymbolMatch
+
+outer:
+	for _, upload := range uploads {
+		file := func(path string) *result.File {
+			return &result.File{
+				Path:     path,
+				Repo:     repo.Repo,
+				CommitID: api.CommitID(upload.Commit),
+				InputRev: &inputRev,
+			}
+		}
+
+		for page, after := 0, 0; ; page++ {
+			if page == preciseSymbolSearchMaxPages {
+				limitHit = true
+				break
+			}
+			definitions, next, err := j.uploadSvc.SearchSymbolDefinitions(ctx, upload.ID, hint, after, preciseSymbolSearchPageSize)
+			if err != nil {
+				return nil, false, err
+			}
+
+			for _, definition := range definitions {
+				path := upload.Root + definition.DocumentPath
+				sym, ok := toSymbol(definition.Symbol)
+				if !ok || !match.matchName(sym.Name) || !match.matchPath(path) {
+					continue
+				}
+				sym.Path = path
+
+				for _, r := range definition.Ranges {
+					if len(symbolMatches) == limit {
+						limitHit = true
+						break outer
+					}
+
+					sym.Line = int(r.Start.Line) + 1
+					sym.Character = int(r.Start.Character)
+					symbolMatches = append(symbolMatches, &result.SymbolMatch{
+						File:   file(path),
+						Symbol: sym,
+					})
+				}
+			}
+
+			if next == 0 {
+				break
+			}
+			after = next
+		}
+	}
+	tr.SetAttributes(attribute.Int("numSymbols", len(symbolMatches)))
+
+	symbolMatches, err = symbol.FilterZoektResults(ctx, authz.DefaultSubRepoPermsChecker, repo.Repo.Name, symbolMatches)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return symbolMatchesToFileMatches(symbolMatches), limitHit, nil
+}
+
+// requiredLiteral returns the longest literal which every string matched by
+// the regular expression pattern contains, ignoring case, or an empty string
+// if there is none or pattern is invalid. Definitions are narrowed by hints
+// ignoring case, so literals of case insensitive patterns are hints as well.
+func requiredLiteral(pattern string) string {
+	re, err := syntax.Parse(pattern, syntax.Perl)
+	if err != nil {
+		return ""
+	}
+	return requiredLiteralOf(re.Simplify())
+}
+
+func requiredLiteralOf(re *syntax.Regexp) string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return string(re.Rune)
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiteralOf(re.Sub[0])
+	case syntax.OpConcat:
+		var longest string
+		for _, sub := range re.Sub {
+			if literal := requiredLiteralOf(sub); len(literal) > len(longest) {
+				longest = literal
+			}
+		}
+		return longest
+	}
+	return ""
+}
+
+// symbolMatchesToFileMatches groups the given symbol matches by file.
+func symbolMatchesToFileMatches(symbolMatches []*result.SymbolMatch) result.Matches {
+	type fileKey struct {
+		commitID api.CommitID
+		path     string
+	}
+
+	var (
+		matches     result.Matches
+		fileMatches = map[fileKey]*result.FileMatch{}
+	)
+	for _, symbolMatch := range symbolMatches {
+		key := fileKey{symbolMatch.File.CommitID, symbolMatch.File.Path}
+
+		fileMatch, ok := fileMatches[key]
+		if !ok {
+			fileMatch = &result.FileMatch{File: *symbolMatch.File}
+			fileMatches[key] = fileMatch
+			matches = append(matches, fileMatch)
+		}
+		symbolMatch.File = &fileMatch.File
+		fileMatch.Symbols = append(fileMatch.Symbols, symbolMatch)
+	}
+
+	// Make the results deterministic
+	sort.Sort(matches)
+	return matches
+}
+
+// toSymbol converts the given SCIP symbol into a search symbol, using its last
+// descriptor as the name and the descriptor before it as the parent. The kinds
+// of the symbol and its parent are inferred from the descriptor suffixes, as
+// SCIP symbols do not carry a kind of their own. Local symbols are not
+// converted.
+func toSymbol(scipSymbol string) (result.Symbol, bool) {
+	if scip.IsLocalSymbol(scipSymbol) {
+		return result.Symbol{}, false
+	}
+
+	parsed, err := scip.ParseSymbol(scipSymbol)
+	if err != nil || len(parsed.Descriptors) == 0 {
+		return result.Symbol{}, false
+	}
+	descriptors := parsed.Descriptors
+
+	sym := result.Symbol{
+		Name: descriptors[len(descriptors)-1].Name,
+		Kind: descriptorKind(descriptors, len(descriptors)-1),
+	}
+	if len(descriptors) > 1 {
+		sym.Parent = descriptors[len(descriptors)-2].Name
+		sym.ParentKind = descriptorKind(descriptors, len(descriptors)-2)
+	}
+
+	return sym, true
+}
+
+// descriptorKind returns the ctags-style kind of the ith descriptor. Methods and
+// terms are distinguished from functions and variables by whether they are
+// nested in a type.
+func descriptorKind(descriptors []*scip.Descriptor, i int) string {
+	inType := i > 0 && descriptors[i-1].Suffix == scip.Descriptor_Type
+
+	switch descriptors[i].Suffix {
+	case scip.Descriptor_Namespace:
+		return "package"
+	case scip.Descriptor_Type:
+		return "class"
+	case scip.Descriptor_Method:
+		if inType {
+			return "method"
+		}
+		return "function"
+	case scip.Descriptor_Term:
+		if inType {
+			return "field"
+		}
+		return "variable"
+	case scip.Descriptor_TypeParameter:
+		return "type parameter"
+	case scip.Descriptor_Macro:
+		return "macro"
+	}
+
+	return ""
+}
+
+// symbolMatcher implements the name and path semantics of a symbol search on the
+// symbols read from precise code intelligence data.
+type symbolMatcher struct {
+	name    *regexp.Regexp
+	include []*regexp.Regexp
+	exclude *regexp.Regexp
+}
+
+func compileSymbolMatcher(patternInfo *search.TextPatternInfo) (*symbolMatcher, error) {
+	compile := func(expr string, caseSensitive bool) (*regexp.Regexp, error) {
+		if !caseSensitive {
+			expr = "(?i:" + expr + ")"
+		}
+		return regexp.Compile(expr)
+	}
+
+	var m symbolMatcher
+
+	if patternInfo.Pattern != "" {
+		expr := patternInfo.Pattern
+		if !patternInfo.IsRegExp {
+			expr = regexp.QuoteMeta(expr)
+		}
+		var err error
+		if m.name, err = compile(expr, patternInfo.IsCaseSensitive); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, p := range patternInfo.IncludePatterns {
+		re, err := compile(p, patternInfo.PathPatternsAreCaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		m.include = append(m.include, re)
+	}
+
+	if patternInfo.ExcludePattern != "" {
+		var err error
+		if m.exclude, err = compile(patternInfo.ExcludePattern, patternInfo.PathPatternsAreCaseSensitive); err != nil {
+			return nil, err
+		}
+	}
+
+	return &m, nil
+}
+
+func (m *symbolMatcher) matchName(name string) bool {
+	return m.name == nil || m.name.MatchString(name)
+}
+
+func (m *symbolMatcher) matchPath(path string) bool {
+	for _, re := range m.include {
+		if !re.MatchString(path) {
+			return false
+		}
+	}
+	return m.exclude == nil || !m.exclude.MatchString(path)
+}
+
+func (j *preciseSymbolSearchJob) Name() string {
+	return "PreciseSymbolSearchJob"
+}
+
+func (j *preciseSymbolSearchJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		res = append(res,
+			attribute.Int("numRepos", len(fallbackRepos(j.fallback))),
+		)
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Scoped("patternInfo", j.patternInfo.Fields()...)...,
+		)
+		res = append(res,
+			attribute.Int("limit", j.limit),
+		)
+	}
+	return res
+}
+
+func (j *preciseSymbolSearchJob) Children() []job.Describer {
+	return []job.Describer{j.fallback}
+}
+
+func (j *preciseSymbolSearchJob) MapChildren(fn job.MapFunc) job.Job {
+	mapped := job.Map(j.fallback, fn)
+	switch mapped.(type) {
+	case *searcher.SymbolSearchJob, *zoekt.SymbolSearchJob:
+	default:
+		// The fallback was replaced with a job this job cannot stand in for
+		return mapped
+	}
+
+	cp := *j
+	cp.fallback = mapped
+	return &cp
+}
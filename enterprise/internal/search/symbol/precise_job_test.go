@@ -0,0 +1,383 @@
+package symbol
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sourcegraph/scip/bindings/go/scip"
+	"github.com/stretchr/testify/require"
+
+	uploadsshared "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/uploads/shared"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const (
+	testCommit      = "deadbeef01deadbeef02deadbeef03deadbeef04"
+	testStaleCommit = "deadbeef05deadbeef06deadbeef07deadbeef08"
+)
+
+var testDefinitions = []uploadsshared.SymbolDefinition{
+	{
+		Symbol:       "scip-go gomod example v1 `example/util`/Formatter#",
+		DocumentPath: "util/format.go",
+		Ranges:       []*scip.Range{{Start: scip.Position{Line: 9, Character: 5}}},
+	},
+	{
+		Symbol:       "scip-go gomod example v1 `example/util`/Formatter#Format().",
+		DocumentPath: "util/format.go",
+		Ranges:       []*scip.Range{{Start: scip.Position{Line: 14, Character: 20}}},
+	},
+	{
+		Symbol:       "scip-go gomod example v1 `example/util`/FormatPercent().",
+		DocumentPath: "util/percent.go",
+		Ranges:       []*scip.Range{{Start: scip.Position{Line: 3, Character: 5}}},
+	},
+	{
+		Symbol:       "scip-go gomod example v1 `example/util`/Formatter#Reformat().",
+		DocumentPath: "util/format_test.go",
+		Ranges:       []*scip.Range{{Start: scip.Position{Line: 30, Character: 20}}},
+	},
+}
+
+func TestPreciseSymbolSearchJob(t *testing.T) {
+	uploadSvc := NewMockUploadService()
+	uploadSvc.InferClosestUploadsFunc.SetDefaultHook(func(_ context.Context, repositoryID int, _, _ string, _ bool, _ string) ([]uploadsshared.Dump, error) {
+		switch repositoryID {
+		case 1:
+			return []uploadsshared.Dump{{ID: 10, Commit: testCommit, Root: "lib/"}}, nil
+		case 2:
+			return []uploadsshared.Dump{{ID: 20, Commit: testStaleCommit}}, nil
+		}
+		return nil, nil
+	})
+	uploadSvc.SearchSymbolDefinitionsFunc.SetDefaultHook(func(_ context.Context, _ int, _ string, after, limit int) ([]uploadsshared.SymbolDefinition, int, error) {
+		// Use the index of the next definition as the cursor
+		if after+limit >= len(testDefinitions) {
+			return testDefinitions[after:], 0, nil
+		}
+		return testDefinitions[after : after+limit], after + limit, nil
+	})
+	uploadSvc.GetDocumentPathsFunc.SetDefaultHook(func(_ context.Context, _ int, paths []string) ([]string, error) {
+		var documentPaths []string
+		for _, path := range paths {
+			for _, definition := range testDefinitions {
+				if definition.DocumentPath == path {
+					documentPaths = append(documentPaths, path)
+					break
+				}
+			}
+		}
+		return documentPaths, nil
+	})
+
+	// The fallback finds symbols both in files the uploads cover and in files
+	// they do not
+	ctagsSymbols := map[api.RepoID][]result.Symbol{
+		1: {
+			{Name: "Formatter", Kind: "struct", Path: "lib/util/format.go", Line: 10},
+			{Name: "format_all", Kind: "function", Path: "lib/scripts/format.sh", Line: 3},
+		},
+		2: {
+			{Name: "Format", Kind: "method", Path: "util/format.go", Line: 15},
+		},
+		3: {
+			{Name: "Format", Kind: "function", Path: "util/format.go", Line: 7},
+		},
+	}
+	origRunFallback := runFallback
+	t.Cleanup(func() { runFallback = origRunFallback })
+	runFallback = func(_ context.Context, fallback job.Job, _ job.RuntimeClients, stream streaming.Sender) (*search.Alert, error) {
+		for _, repo := range fallbackRepos(fallback) {
+			var matches result.Matches
+			for _, sym := range ctagsSymbols[repo.Repo.ID] {
+				sym := sym
+				fileMatch := &result.FileMatch{File: result.File{Path: sym.Path, Repo: repo.Repo, CommitID: testCommit}}
+				fileMatch.Symbols = []*result.SymbolMatch{{File: &fileMatch.File, Symbol: sym}}
+				matches = append(matches, fileMatch)
+			}
+			stream.Send(streaming.SearchEvent{Results: matches})
+		}
+		return nil, nil
+	}
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ResolveRevisionFunc.SetDefaultReturn(testCommit, nil)
+	clients := job.RuntimeClients{Gitserver: gitserverClient}
+
+	repos := []*search.RepositoryRevisions{
+		{Repo: types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/up-to-date"}, Revs: []string{""}},
+		{Repo: types.MinimalRepo{ID: 2, Name: "github.com/sourcegraph/stale"}, Revs: []string{""}},
+		{Repo: types.MinimalRepo{ID: 3, Name: "github.com/sourcegraph/unindexed"}, Revs: []string{""}},
+	}
+
+	t.Run("up-to-date uploads", func(t *testing.T) {
+		j := newSearcherFallbackJob(uploadSvc, &searcher.SymbolSearchJob{
+			PatternInfo: &search.TextPatternInfo{Pattern: "format", ExcludePattern: `_test\.go$`},
+			Repos:       repos[:1],
+			Limit:       10,
+		}, &search.Features{})
+
+		stream := streaming.NewAggregatingStream()
+		_, err := j.Run(context.Background(), clients, stream)
+		require.NoError(t, err)
+
+		expected := map[string][]string{
+			"lib/scripts/format.sh": {"format_all:function:::3:0"},
+			"lib/util/format.go":    {"Formatter:class:example/util:package:10:5", "Format:method:Formatter:class:15:20"},
+			"lib/util/percent.go":   {"FormatPercent:function:example/util:package:4:5"},
+		}
+		if diff := cmp.Diff(expected, summarizeMatches(stream.Results)); diff != "" {
+			t.Errorf("unexpected matches (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("stale uploads with precise symbol search", func(t *testing.T) {
+		j := newSearcherFallbackJob(uploadSvc, &searcher.SymbolSearchJob{
+			PatternInfo: &search.TextPatternInfo{Pattern: "^Format$", IsRegExp: true, IsCaseSensitive: true},
+			Repos:       repos[1:2],
+			Limit:       10,
+		}, &search.Features{PreciseSymbolSearch: true})
+
+		stream := streaming.NewAggregatingStream()
+		_, err := j.Run(context.Background(), clients, stream)
+		require.NoError(t, err)
+
+		expected := map[string][]string{
+			"util/format.go": {"Format:method:Formatter:class:15:20"},
+		}
+		if diff := cmp.Diff(expected, summarizeMatches(stream.Results)); diff != "" {
+			t.Errorf("unexpected matches (-want +got):\n%s", diff)
+		}
+		for _, match := range stream.Results {
+			if commitID := match.(*result.FileMatch).CommitID; commitID != testStaleCommit {
+				t.Errorf("unexpected commit. want=%s have=%s", testStaleCommit, commitID)
+			}
+		}
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		j := newSearcherFallbackJob(uploadSvc, &searcher.SymbolSearchJob{
+			PatternInfo: &search.TextPatternInfo{Pattern: "format"},
+			Repos:       repos[:1],
+			Limit:       2,
+		}, &search.Features{})
+
+		stream := streaming.NewAggregatingStream()
+		_, err := j.Run(context.Background(), clients, stream)
+		require.NoError(t, err)
+
+		expected := map[string][]string{
+			"lib/scripts/format.sh": {"format_all:function:::3:0"},
+			"lib/util/format.go":    {"Formatter:class:example/util:package:10:5", "Format:method:Formatter:class:15:20"},
+		}
+		if diff := cmp.Diff(expected, summarizeMatches(stream.Results)); diff != "" {
+			t.Errorf("unexpected matches (-want +got):\n%s", diff)
+		}
+		require.True(t, stream.Stats.IsLimitHit)
+	})
+
+	t.Run("zoekt fallback", func(t *testing.T) {
+		patternInfo := &search.TextPatternInfo{Pattern: "format", ExcludePattern: `_test\.go$`}
+		j := NewPreciseSymbolSearchJob(uploadSvc, &zoekt.SymbolSearchJob{
+			Repos: &zoekt.IndexedRepoRevs{RepoRevs: map[api.RepoID]*search.RepositoryRevisions{
+				1: repos[0],
+				3: repos[2],
+			}},
+		}, patternInfo, 10, &search.Features{})
+
+		stream := streaming.NewAggregatingStream()
+		_, err := j.Run(context.Background(), clients, stream)
+		require.NoError(t, err)
+
+		expected := map[string][]string{
+			"lib/scripts/format.sh": {"format_all:function:::3:0"},
+			"lib/util/format.go":    {"Formatter:class:example/util:package:10:5", "Format:method:Formatter:class:15:20"},
+			"lib/util/percent.go":   {"FormatPercent:function:example/util:package:4:5"},
+			"util/format.go":        {"Format:function:::7:0"},
+		}
+		if diff := cmp.Diff(expected, summarizeMatches(stream.Results)); diff != "" {
+			t.Errorf("unexpected matches (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("repositories without uploads", func(t *testing.T) {
+		j := newSearcherFallbackJob(uploadSvc, &searcher.SymbolSearchJob{
+			PatternInfo: &search.TextPatternInfo{Pattern: "format"},
+			Repos:       repos[2:],
+			Limit:       10,
+		}, &search.Features{})
+
+		stream := streaming.NewAggregatingStream()
+		_, err := j.Run(context.Background(), clients, stream)
+		require.NoError(t, err)
+
+		expected := map[string][]string{
+			"util/format.go": {"Format:function:::7:0"},
+		}
+		if diff := cmp.Diff(expected, summarizeMatches(stream.Results)); diff != "" {
+			t.Errorf("unexpected matches (-want +got):\n%s", diff)
+		}
+	})
+}
+
+func TestPreciseSymbolSearchJobScanLimit(t *testing.T) {
+	uploadSvc := NewMockUploadService()
+	uploadSvc.InferClosestUploadsFunc.SetDefaultReturn([]uploadsshared.Dump{{ID: 10, Commit: testCommit}}, nil)
+	// Every page is followed by another one without matching definitions
+	uploadSvc.SearchSymbolDefinitionsFunc.SetDefaultHook(func(_ context.Context, _ int, _ string, after, _ int) ([]uploadsshared.SymbolDefinition, int, error) {
+		return nil, after + 1, nil
+	})
+
+	origRunFallback := runFallback
+	t.Cleanup(func() { runFallback = origRunFallback })
+	runFallback = func(context.Context, job.Job, job.RuntimeClients, streaming.Sender) (*search.Alert, error) {
+		return nil, nil
+	}
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ResolveRevisionFunc.SetDefaultReturn(testCommit, nil)
+
+	j := newSearcherFallbackJob(uploadSvc, &searcher.SymbolSearchJob{
+		PatternInfo: &search.TextPatternInfo{Pattern: "^[A-Z]", IsRegExp: true},
+		Repos:       []*search.RepositoryRevisions{{Repo: types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}, Revs: []string{""}}},
+		Limit:       10,
+	}, &search.Features{})
+
+	stream := streaming.NewAggregatingStream()
+	_, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gitserverClient}, stream)
+	require.NoError(t, err)
+
+	require.True(t, stream.Stats.IsLimitHit)
+	require.Len(t, uploadSvc.SearchSymbolDefinitionsFunc.History(), preciseSymbolSearchMaxPages)
+}
+
+func TestRequiredLiteral(t *testing.T) {
+	for pattern, expected := range map[string]string{
+		"Format":                  "Format",
+		"^Format$":                "Format",
+		"(?i)format":              "FORMAT",
+		"^New[A-Z]\\w*Formatter$": "Formatter",
+		"Format(ter|Percent)":     "Format",
+		"(Format)+er":             "Format",
+		"Format|Parse":            "",
+		"F?ormat":                 "ormat",
+		"[":                       "",
+	} {
+		if actual := requiredLiteral(pattern); actual != expected {
+			t.Errorf("unexpected literal for %q. want=%q have=%q", pattern, expected, actual)
+		}
+	}
+}
+
+func TestPreciseUploads(t *testing.T) {
+	uploadSvc := NewMockUploadService()
+	uploadSvc.InferClosestUploadsFunc.SetDefaultReturn([]uploadsshared.Dump{
+		{ID: 10, Commit: testCommit},
+		{ID: 11, Commit: testStaleCommit},
+	}, nil)
+
+	gitserverClient := gitserver.NewMockClient()
+	gitserverClient.ResolveRevisionFunc.SetDefaultReturn(api.CommitID(testCommit), nil)
+
+	repo := &search.RepositoryRevisions{Repo: types.MinimalRepo{ID: 1, Name: "github.com/sourcegraph/sourcegraph"}, Revs: []string{"main"}}
+
+	for _, testCase := range []struct {
+		features    *search.Features
+		expectedIDs []int
+	}{
+		{features: nil, expectedIDs: []int{10}},
+		{features: &search.Features{PreciseSymbolSearch: true}, expectedIDs: []int{10, 11}},
+	} {
+		j := newSearcherFallbackJob(uploadSvc, &searcher.SymbolSearchJob{}, testCase.features).(*preciseSymbolSearchJob)
+
+		var ids []int
+		for _, upload := range j.preciseUploads(context.Background(), gitserverClient, repo) {
+			ids = append(ids, upload.ID)
+		}
+		if diff := cmp.Diff(testCase.expectedIDs, ids); diff != "" {
+			t.Errorf("unexpected uploads (-want +got):\n%s", diff)
+		}
+	}
+}
+
+func TestToSymbol(t *testing.T) {
+	testCases := []struct {
+		symbol   string
+		expected result.Symbol
+		ok       bool
+	}{
+		{
+			symbol:   "scip-go gomod example v1 `example/util`/",
+			expected: result.Symbol{Name: "example/util", Kind: "package"},
+			ok:       true,
+		},
+		{
+			symbol:   "scip-go gomod example v1 `example/util`/Formatter#",
+			expected: result.Symbol{Name: "Formatter", Kind: "class", Parent: "example/util", ParentKind: "package"},
+			ok:       true,
+		},
+		{
+			symbol:   "scip-go gomod example v1 `example/util`/Formatter#Format().",
+			expected: result.Symbol{Name: "Format", Kind: "method", Parent: "Formatter", ParentKind: "class"},
+			ok:       true,
+		},
+		{
+			symbol:   "scip-go gomod example v1 `example/util`/Formatter#width.",
+			expected: result.Symbol{Name: "width", Kind: "field", Parent: "Formatter", ParentKind: "class"},
+			ok:       true,
+		},
+		{
+			symbol:   "scip-go gomod example v1 `example/util`/defaultWidth.",
+			expected: result.Symbol{Name: "defaultWidth", Kind: "variable", Parent: "example/util", ParentKind: "package"},
+			ok:       true,
+		},
+		{
+			symbol:   "scip-typescript npm example 1.0.0 src/`box.ts`/Box#[T]",
+			expected: result.Symbol{Name: "T", Kind: "type parameter", Parent: "Box", ParentKind: "class"},
+			ok:       true,
+		},
+		{
+			symbol: "local 42",
+			ok:     false,
+		},
+	}
+
+	for _, testCase := range testCases {
+		sym, ok := toSymbol(testCase.symbol)
+		if ok != testCase.ok {
+			t.Errorf("unexpected ok for %q. want=%v have=%v", testCase.symbol, testCase.ok, ok)
+		}
+		if diff := cmp.Diff(testCase.expected, sym); diff != "" {
+			t.Errorf("unexpected symbol for %q (-want +got):\n%s", testCase.symbol, diff)
+		}
+	}
+}
+
+// summarizeMatches returns the symbols of each file match as name:kind:parent:parentKind:line:character.
+func summarizeMatches(matches []result.Match) map[string][]string {
+	summary := map[string][]string{}
+	for _, match := range matches {
+		fileMatch := match.(*result.FileMatch)
+		for _, symbolMatch := range fileMatch.Symbols {
+			s := symbolMatch.Symbol
+			summary[fileMatch.Path] = append(summary[fileMatch.Path], fmt.Sprintf("%s:%s:%s:%s:%d:%d", s.Name, s.Kind, s.Parent, s.ParentKind, s.Line, s.Character))
+		}
+	}
+	return summary
+}
+
+// newSearcherFallbackJob creates a precise symbol search job with the pattern
+// and limit of the given unindexed fallback.
+func newSearcherFallbackJob(uploadSvc UploadService, fallback *searcher.SymbolSearchJob, features *search.Features) job.Job {
+	return NewPreciseSymbolSearchJob(uploadSvc, fallback, fallback.PatternInfo, fallback.Limit, features)
+}
@@ -33,6 +33,9 @@ type GitBlobLSIFDataResolver interface {
 	References(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Implementations(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
 	Prototypes(ctx context.Context, args *LSIFPagedQueryPositionArgs) (LocationConnectionResolver, error)
+	IncomingCalls(ctx context.Context, args *LSIFCallHierarchyArgs) (CallHierarchyCallConnectionResolver, error)
+	OutgoingCalls(ctx context.Context, args *LSIFCallHierarchyArgs) (CallHierarchyCallConnectionResolver, error)
+	TypeHierarchy(ctx context.Context, args *LSIFTypeHierarchyArgs) (TypeHierarchyNodeConnectionResolver, error)
 	Hover(ctx context.Context, args *LSIFQueryPositionArgs) (HoverResolver, error)
 	VisibleIndexes(ctx context.Context) (_ *[]PreciseIndexResolver, err error)
 	Snapshot(ctx context.Context, args *struct{ IndexID graphql.ID }) (_ *[]SnapshotDataResolver, err error)
@@ -60,6 +63,41 @@ type LSIFPagedQueryPositionArgs struct {
 	Filter *string
 }
 
+type LSIFCallHierarchyArgs struct {
+	Line      int32
+	Character int32
+	First     *int32
+}
+
+type (
+	CallHierarchyCallConnectionResolver = ConnectionResolver[CallHierarchyCallResolver]
+)
+
+type CallHierarchyCallResolver interface {
+	Symbol() string
+	Definitions(ctx context.Context) (LocationConnectionResolver, error)
+	CallSites(ctx context.Context) (LocationConnectionResolver, error)
+}
+
+type LSIFTypeHierarchyArgs struct {
+	LSIFQueryPositionArgs
+	PagedConnectionArgs
+	Direction string
+}
+
+type (
+	TypeHierarchyNodeConnectionResolver = PagedConnectionResolver[TypeHierarchyNodeResolver]
+)
+
+type TypeHierarchyNodeResolver interface {
+	Index() int32
+	ParentIndex() *int32
+	Depth() int32
+	Symbol() string
+	Definitions(ctx context.Context) (LocationConnectionResolver, error)
+	Cycle() bool
+}
+
 type (
 	CodeIntelligenceRangeConnectionResolver = ConnectionResolver[CodeIntelligenceRangeResolver]
 )
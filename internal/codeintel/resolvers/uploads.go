@@ -90,6 +90,24 @@ type PreciseIndexResolver interface {
 	IsLatestForRepo() bool
 	RetentionPolicyOverview(ctx context.Context, args *LSIFUploadRetentionPolicyMatchesArgs) (CodeIntelligenceRetentionPolicyMatchesConnectionResolver, error)
 	AuditLogs(ctx context.Context) (*[]LSIFUploadsAuditLogsResolver, error)
+	ValidationReport(ctx context.Context) (PreciseIndexValidationReportResolver, error)
+}
+
+type PreciseIndexValidationReportResolver interface {
+	DocumentCount() int32
+	DocumentsWithoutOccurrencesCount() int32
+	DocumentsWithoutOccurrences() []string
+	ReferenceCount() int32
+	UnresolvedReferenceCount() int32
+	UnresolvedReferenceRatio() float64
+	UnresolvedSymbols() []string
+	PathsNotInRepositoryCount() int32
+	PathsNotInRepository() []string
+	DuplicateSymbolCount() int32
+	DuplicateSymbols() []string
+	DocumentsWithOverlappingOccurrencesCount() int32
+	DocumentsWithOverlappingOccurrences() []string
+	Violations() []string
 }
 
 type LSIFUploadRetentionPolicyMatchesArgs struct {
@@ -190,3 +190,34 @@ func StructuralPatToRegexpQuery(pattern string, shortcircuit bool) string {
 	}
 	return "(?:" + strings.Join(pieces, ")(?:.|\\s)*?(?:") + ")"
 }
+
+// minFragmentLength is the length of the shortest fragment returned by
+// StructuralPatToFragments. Zoekt indexes trigrams, so shorter fragments
+// hardly narrow down the files to search.
+const minFragmentLength = 3
+
+// StructuralPatToFragments returns the literal fragments of a comby pattern
+// which every file matching the pattern contains. Fragments never contain
+// holes or whitespace, since whitespace in the pattern matches any amount of
+// whitespace. An empty result means that any file may match.
+//
+// Example:
+// "ParseInt(:[args]) if err != nil" -> ["ParseInt(", "err", "nil"]
+func StructuralPatToFragments(pattern string) []string {
+	var fragments []string
+	for _, term := range parseTemplate([]byte(pattern)) {
+		literal, ok := term.(Literal)
+		if !ok {
+			continue
+		}
+		// Ellipses are holes, but parseTemplate leaves them in literals.
+		for _, part := range strings.Split(literal.String(), "...") {
+			for _, f := range strings.Fields(part) {
+				if len(f) >= minFragmentLength {
+					fragments = append(fragments, f)
+				}
+			}
+		}
+	}
+	return fragments
+}
@@ -119,3 +119,45 @@ func TestStructuralPatToRegexpQuery(t *testing.T) {
 		})
 	}
 }
+
+func TestStructuralPatToFragments(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Pattern string
+		Want    []string
+	}{
+		{
+			Name:    "Just a hole",
+			Pattern: ":[1]",
+			Want:    nil,
+		},
+		{
+			Name:    "Whitespace separates fragments",
+			Pattern: "ParseInt(:[args]) if err != nil",
+			Want:    []string{"ParseInt(", "err", "nil"},
+		},
+		{
+			Name:    "Ellipses are holes",
+			Pattern: "foo(...)...bar",
+			Want:    []string{"foo(", "bar"},
+		},
+		{
+			Name:    "Regexp holes are skipped",
+			Pattern: "strconv.:[fn~Parse\\w+](:[args])",
+			Want:    []string{"strconv."},
+		},
+		{
+			Name:    "Multiline",
+			Pattern: "func :[name]() {\n\treturn nil\n}",
+			Want:    []string{"func", "return", "nil"},
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			got := StructuralPatToFragments(tt.Pattern)
+			if diff := cmp.Diff(tt.Want, got); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
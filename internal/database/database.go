@@ -31,6 +31,7 @@ type DB interface {
 	FeatureFlags() FeatureFlagStore
 	GitserverRepos() GitserverRepoStore
 	GitserverLocalClone() GitserverLocalCloneStore
+	GitserverRefEvents() GitserverRefEventStore
 	GlobalState() GlobalStateStore
 	NamespacePermissions() NamespacePermissionStore
 	Namespaces() NamespaceStore
@@ -172,6 +173,10 @@ func (d *db) GitserverLocalClone() GitserverLocalCloneStore {
 	return GitserverLocalCloneStoreWith(d.Store)
 }
 
+func (d *db) GitserverRefEvents() GitserverRefEventStore {
+	return GitserverRefEventsWith(d.Store)
+}
+
 func (d *db) GlobalState() GlobalStateStore {
 	return GlobalStateWith(d.Store)
 }
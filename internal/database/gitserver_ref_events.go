@@ -0,0 +1,185 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// RefChange is a change of the commit a ref of a repository points to.
+type RefChange struct {
+	// Name is the full name of the ref, e.g. refs/heads/main.
+	Name string `json:"name"`
+	// OldCommit is empty if the ref was created.
+	OldCommit api.CommitID `json:"old,omitempty"`
+	// NewCommit is empty if the ref was deleted.
+	NewCommit api.CommitID `json:"new,omitempty"`
+}
+
+// GitserverRefEvent records the refs gitserver changed when fetching a
+// repository.
+type GitserverRefEvent struct {
+	ID int64
+	// TransactionID is the ID of the transaction which recorded the event.
+	TransactionID int64
+	RepoID        api.RepoID
+	RepoName      api.RepoName
+	Changes       []RefChange
+	FetchedAt     time.Time
+}
+
+// Position returns the position of the event in the stream of ref events.
+func (e *GitserverRefEvent) Position() GitserverRefEventPosition {
+	return GitserverRefEventPosition{TransactionID: e.TransactionID, EventID: e.ID}
+}
+
+// GitserverRefEventPosition is a position in the stream of ref events.
+//
+// Events are ordered by the ID of the transaction which recorded them rather
+// than by their ID alone. IDs are assigned when an event is inserted, but the
+// transaction which inserted it may commit after events with higher IDs are
+// visible, so a subscriber at a higher ID would miss it. Events are only listed
+// once every transaction with a lower transaction ID finished, and transactions
+// which start later get a higher transaction ID, so no event is recorded before
+// a position once a subscriber has seen it.
+type GitserverRefEventPosition struct {
+	TransactionID int64
+	EventID       int64
+}
+
+// GitserverRefEventStore stores the changes gitserver makes to the refs of
+// repositories, and the position of each subscriber in the stream of them.
+type GitserverRefEventStore interface {
+	// Insert records that fetching the given repository at fetchedAt made the
+	// given changes to its refs.
+	Insert(ctx context.Context, repoID api.RepoID, changes []RefChange, fetchedAt time.Time) error
+	// ListAfter returns up to limit events after the given position, in the
+	// order of their positions.
+	ListAfter(ctx context.Context, after GitserverRefEventPosition, limit int) ([]*GitserverRefEvent, error)
+	// DeleteBefore deletes the events of fetches before the given time and
+	// returns the number of deleted events.
+	DeleteBefore(ctx context.Context, before time.Time) (int, error)
+	// GetBookmark returns the position of the last event the given subscriber
+	// processed. The bookmark of a new subscriber is after the events recorded
+	// so far, so it only sees events recorded after it first subscribed.
+	GetBookmark(ctx context.Context, subscriber string) (GitserverRefEventPosition, error)
+	// UpdateBookmark sets the position of the last event the given subscriber
+	// processed.
+	UpdateBookmark(ctx context.Context, subscriber string, position GitserverRefEventPosition) error
+}
+
+var _ GitserverRefEventStore = &gitserverRefEventStore{}
+
+type gitserverRefEventStore struct {
+	*basestore.Store
+}
+
+func GitserverRefEventsWith(other basestore.ShareableStore) GitserverRefEventStore {
+	return &gitserverRefEventStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+const insertGitserverRefEventFmtstr = `
+	INSERT INTO gitserver_ref_events (repo_id, ref_changes, fetched_at)
+	VALUES (%s, %s, %s)
+`
+
+func (s *gitserverRefEventStore) Insert(ctx context.Context, repoID api.RepoID, changes []RefChange, fetchedAt time.Time) error {
+	data, err := json.Marshal(changes)
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(insertGitserverRefEventFmtstr, repoID, data, fetchedAt))
+}
+
+const listGitserverRefEventsFmtstr = `
+	SELECT e.id, e.transaction_id, e.repo_id, r.name, e.ref_changes, e.fetched_at
+	FROM gitserver_ref_events e
+	JOIN repo r ON r.id = e.repo_id
+	WHERE
+		(e.transaction_id, e.id) > (%s, %s) AND
+		e.transaction_id < txid_snapshot_xmin(txid_current_snapshot())
+	ORDER BY e.transaction_id, e.id
+	LIMIT %s
+`
+
+func (s *gitserverRefEventStore) ListAfter(ctx context.Context, after GitserverRefEventPosition, limit int) ([]*GitserverRefEvent, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listGitserverRefEventsFmtstr, after.TransactionID, after.EventID, limit))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*GitserverRefEvent
+	for rows.Next() {
+		var (
+			e       GitserverRefEvent
+			changes []byte
+		)
+		if err := rows.Scan(&e.ID, &e.TransactionID, &e.RepoID, &e.RepoName, &changes, &e.FetchedAt); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(changes, &e.Changes); err != nil {
+			return nil, err
+		}
+		events = append(events, &e)
+	}
+	return events, rows.Err()
+}
+
+func (s *gitserverRefEventStore) DeleteBefore(ctx context.Context, before time.Time) (int, error) {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf("DELETE FROM gitserver_ref_events WHERE fetched_at < %s", before))
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	return int(n), err
+}
+
+// The bookmark of a new subscriber is before the transactions which haven't
+// finished yet, whose events it sees. The insert and the select see the same
+// snapshot, so at most one of them returns the bookmark.
+const getOrInsertGitserverRefEventBookmarkFmtstr = `
+	WITH inserted AS (
+		INSERT INTO gitserver_ref_event_bookmarks (subscriber, transaction_id, event_id)
+		VALUES (%s, txid_snapshot_xmin(txid_current_snapshot()), 0)
+		ON CONFLICT (subscriber) DO NOTHING
+		RETURNING transaction_id, event_id
+	)
+	SELECT transaction_id, event_id FROM inserted
+	UNION ALL
+	SELECT transaction_id, event_id FROM gitserver_ref_event_bookmarks WHERE subscriber = %s
+`
+
+const getGitserverRefEventBookmarkFmtstr = `
+	SELECT transaction_id, event_id FROM gitserver_ref_event_bookmarks WHERE subscriber = %s
+`
+
+func (s *gitserverRefEventStore) GetBookmark(ctx context.Context, subscriber string) (GitserverRefEventPosition, error) {
+	var p GitserverRefEventPosition
+	err := s.QueryRow(ctx, sqlf.Sprintf(getOrInsertGitserverRefEventBookmarkFmtstr, subscriber, subscriber)).Scan(&p.TransactionID, &p.EventID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The bookmark was inserted concurrently by a transaction which
+		// committed after our snapshot was taken, so our insert did nothing
+		// and our select didn't see it. A new statement sees it.
+		err = s.QueryRow(ctx, sqlf.Sprintf(getGitserverRefEventBookmarkFmtstr, subscriber)).Scan(&p.TransactionID, &p.EventID)
+	}
+	return p, err
+}
+
+const updateGitserverRefEventBookmarkFmtstr = `
+	UPDATE gitserver_ref_event_bookmarks
+	SET transaction_id = %s, event_id = %s, updated_at = NOW()
+	WHERE subscriber = %s
+`
+
+func (s *gitserverRefEventStore) UpdateBookmark(ctx context.Context, subscriber string, position GitserverRefEventPosition) error {
+	return s.Exec(ctx, sqlf.Sprintf(updateGitserverRefEventBookmarkFmtstr, position.TransactionID, position.EventID, subscriber))
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/log/logtest"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGitserverRefEvents(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	store := db.GitserverRefEvents()
+
+	repo := mustCreate(ctx, t, db, &types.Repo{Name: "a/b"})
+
+	old := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+	err := store.Insert(ctx, repo.ID, []RefChange{{Name: "refs/heads/main", NewCommit: "a"}}, old)
+	require.NoError(t, err)
+
+	// A new subscriber starts after the existing events.
+	bookmark, err := store.GetBookmark(ctx, "test")
+	require.NoError(t, err)
+	events, err := store.ListAfter(ctx, bookmark, 10)
+	require.NoError(t, err)
+	require.Empty(t, events)
+
+	now := time.Now().Truncate(time.Microsecond)
+	changes := []RefChange{
+		{Name: "refs/heads/main", OldCommit: "a", NewCommit: "b"},
+		{Name: "refs/heads/old", OldCommit: "c"},
+	}
+	require.NoError(t, store.Insert(ctx, repo.ID, changes, now))
+
+	events, err = store.ListAfter(ctx, bookmark, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, repo.ID, events[0].RepoID)
+	require.Equal(t, api.RepoName("a/b"), events[0].RepoName)
+	require.Equal(t, changes, events[0].Changes)
+	require.True(t, now.Equal(events[0].FetchedAt))
+
+	// The bookmark of an existing subscriber is kept.
+	require.NoError(t, store.UpdateBookmark(ctx, "test", events[0].Position()))
+	bookmark, err = store.GetBookmark(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, events[0].Position(), bookmark)
+
+	// An event whose transaction commits after the transaction of a later
+	// event is not skipped.
+	tx, err := basestore.NewWithHandle(db.Handle()).Transact(ctx)
+	require.NoError(t, err)
+	late := []RefChange{{Name: "refs/heads/late", NewCommit: "d"}}
+	require.NoError(t, GitserverRefEventsWith(tx).Insert(ctx, repo.ID, late, now))
+	early := []RefChange{{Name: "refs/heads/early", NewCommit: "e"}}
+	require.NoError(t, store.Insert(ctx, repo.ID, early, now))
+	events, err = store.ListAfter(ctx, bookmark, 10)
+	require.NoError(t, err)
+	require.Empty(t, events)
+	require.NoError(t, tx.Done(nil))
+	events, err = store.ListAfter(ctx, bookmark, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	require.Equal(t, late, events[0].Changes)
+	require.Equal(t, early, events[1].Changes)
+
+	deleted, err := store.DeleteBefore(ctx, now.Add(-time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+	events, err = store.ListAfter(ctx, GitserverRefEventPosition{}, 10)
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+}
@@ -37,6 +37,26 @@ type GitserverRepoStore interface {
 	// LogCorruption sets the corrupted at value and logs the corruption reason. Reason will be truncated if it exceeds
 	// MaxReasonSizeInMB
 	LogCorruption(ctx context.Context, name api.RepoName, reason string, shardID string) error
+	// SetHealthReport stores the report of the last health check gitserver ran
+	// on the repo.
+	SetHealthReport(ctx context.Context, name api.RepoName, report *types.RepoHealthReport, shardID string) error
+	// SetLastMirrored records that gitserver pushed all refs of the repo to its
+	// secondary remote at mirroredAt, and clears the mirror error.
+	SetLastMirrored(ctx context.Context, name api.RepoName, mirroredAt time.Time, shardID string) error
+	// SetMirrorError records a failed push of the repo to its secondary remote,
+	// which gitserver retries no earlier than nextMirrorAt.
+	SetMirrorError(ctx context.Context, name api.RepoName, mirrorErr string, nextMirrorAt time.Time, shardID string) error
+	// ListReposDueForMirror returns up to limit repos of the shard whose last
+	// push to their secondary remote failed and whose next_mirror_at has
+	// passed, the longest overdue first.
+	ListReposDueForMirror(ctx context.Context, shardID string, limit int) ([]api.RepoName, error)
+	// SetTransferProgress records that the gitserver shardID has copied
+	// transferredBytes of the repo from the gitserver which owns it so far.
+	SetTransferProgress(ctx context.Context, name api.RepoName, shardID string, transferredBytes int64) error
+	// EndTransfer clears the transfer progress of the repo recorded by the
+	// gitserver shardID. If completed is true, shardID becomes the owner of
+	// the cloned repo.
+	EndTransfer(ctx context.Context, name api.RepoName, shardID string, completed bool) error
 	// SetCloneStatus will attempt to update ONLY the clone status of a
 	// GitServerRepo. If a matching row does not yet exist a new one will be created.
 	// If the status value hasn't changed, the row will not be updated.
@@ -311,7 +331,15 @@ SELECT
 	gr.repo_size_bytes,
 	gr.updated_at,
 	gr.corrupted_at,
-	gr.corruption_logs
+	gr.corruption_logs,
+	gr.health_report,
+	gr.last_mirrored_at,
+	gr.mirror_error,
+	gr.mirror_failures,
+	gr.next_mirror_at,
+	gr.transfer_shard_id,
+	gr.transfer_bytes,
+	gr.transfer_started_at
 FROM gitserver_repos gr
 JOIN repo ON gr.repo_id = repo.id
 WHERE %s
@@ -344,7 +372,15 @@ SELECT
 	repo_size_bytes,
 	updated_at,
 	corrupted_at,
-	corruption_logs
+	corruption_logs,
+	health_report,
+	last_mirrored_at,
+	mirror_error,
+	mirror_failures,
+	next_mirror_at,
+	transfer_shard_id,
+	transfer_bytes,
+	transfer_started_at
 FROM gitserver_repos
 WHERE repo_id = %s
 `
@@ -374,7 +410,15 @@ SELECT
 	gr.repo_size_bytes,
 	gr.updated_at,
 	gr.corrupted_at,
-	gr.corruption_logs
+	gr.corruption_logs,
+	gr.health_report,
+	gr.last_mirrored_at,
+	gr.mirror_error,
+	gr.mirror_failures,
+	gr.next_mirror_at,
+	gr.transfer_shard_id,
+	gr.transfer_bytes,
+	gr.transfer_started_at
 FROM gitserver_repos gr
 JOIN repo r ON r.id = gr.repo_id
 WHERE r.name = %s
@@ -398,7 +442,15 @@ SELECT
 	gr.repo_size_bytes,
 	gr.updated_at,
 	gr.corrupted_at,
-	gr.corruption_logs
+	gr.corruption_logs,
+	gr.health_report,
+	gr.last_mirrored_at,
+	gr.mirror_error,
+	gr.mirror_failures,
+	gr.next_mirror_at,
+	gr.transfer_shard_id,
+	gr.transfer_bytes,
+	gr.transfer_started_at
 FROM gitserver_repos gr
 JOIN repo r on r.id = gr.repo_id
 WHERE r.name = ANY (%s)
@@ -426,7 +478,7 @@ func (s *gitserverRepoStore) GetByNames(ctx context.Context, names ...api.RepoNa
 
 func scanGitserverRepo(scanner dbutil.Scanner) (*types.GitserverRepo, api.RepoName, error) {
 	var gr types.GitserverRepo
-	var rawLogs []byte
+	var rawLogs, rawHealthReport []byte
 	var cloneStatus string
 	var repoName api.RepoName
 	err := scanner.Scan(
@@ -442,6 +494,14 @@ func scanGitserverRepo(scanner dbutil.Scanner) (*types.GitserverRepo, api.RepoNa
 		&gr.UpdatedAt,
 		&dbutil.NullTime{Time: &gr.CorruptedAt},
 		&rawLogs,
+		&rawHealthReport,
+		&dbutil.NullTime{Time: &gr.LastMirroredAt},
+		&dbutil.NullString{S: &gr.MirrorError},
+		&gr.MirrorFailures,
+		&dbutil.NullTime{Time: &gr.NextMirrorAt},
+		&dbutil.NullString{S: &gr.TransferShardID},
+		&gr.TransferBytes,
+		&dbutil.NullTime{Time: &gr.TransferStartedAt},
 	)
 	if err != nil {
 		return nil, "", errors.Wrap(err, "scanning GitserverRepo")
@@ -452,6 +512,11 @@ func scanGitserverRepo(scanner dbutil.Scanner) (*types.GitserverRepo, api.RepoNa
 	if err != nil {
 		return nil, repoName, errors.Wrap(err, "unmarshal of corruption_logs failed")
 	}
+	if rawHealthReport != nil {
+		if err := json.Unmarshal(rawHealthReport, &gr.HealthReport); err != nil {
+			return nil, repoName, errors.Wrap(err, "unmarshal of health_report failed")
+		}
+	}
 	return &gr, repoName, nil
 }
 
@@ -557,6 +622,148 @@ AND
 	return nil
 }
 
+func (s *gitserverRepoStore) SetHealthReport(ctx context.Context, name api.RepoName, report *types.RepoHealthReport, shardID string) error {
+	rawReport, err := json.Marshal(report)
+	if err != nil {
+		return errors.Wrap(err, "could not marshal health_report")
+	}
+
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(`
+UPDATE gitserver_repos
+SET
+	health_report = %s,
+	shard_id = %s,
+	updated_at = NOW()
+WHERE repo_id = (SELECT id FROM repo WHERE name = %s)
+`, rawReport, shardID, name))
+	if err != nil {
+		return errors.Wrap(err, "setting health report")
+	}
+
+	if nrows, err := res.RowsAffected(); err != nil {
+		return errors.Wrap(err, "getting rows affected")
+	} else if nrows != 1 {
+		return errors.New("repo not found")
+	}
+	return nil
+}
+
+func (s *gitserverRepoStore) SetLastMirrored(ctx context.Context, name api.RepoName, mirroredAt time.Time, shardID string) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(`
+UPDATE gitserver_repos
+SET
+	last_mirrored_at = %s,
+	mirror_error = NULL,
+	mirror_failures = 0,
+	next_mirror_at = NULL,
+	shard_id = %s,
+	updated_at = NOW()
+WHERE repo_id = (SELECT id FROM repo WHERE name = %s)
+`, mirroredAt, shardID, name))
+	if err != nil {
+		return errors.Wrap(err, "setting last mirrored")
+	}
+
+	if nrows, err := res.RowsAffected(); err != nil {
+		return errors.Wrap(err, "getting rows affected")
+	} else if nrows != 1 {
+		return errors.New("repo not found")
+	}
+	return nil
+}
+
+func (s *gitserverRepoStore) SetTransferProgress(ctx context.Context, name api.RepoName, shardID string, transferredBytes int64) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(`
+UPDATE gitserver_repos
+SET
+	transfer_started_at = CASE WHEN transfer_shard_id IS DISTINCT FROM %s THEN NOW() ELSE transfer_started_at END,
+	transfer_shard_id = %s,
+	transfer_bytes = %s,
+	updated_at = NOW()
+WHERE repo_id = (SELECT id FROM repo WHERE name = %s)
+`, shardID, shardID, transferredBytes, name))
+	if err != nil {
+		return errors.Wrap(err, "setting transfer progress")
+	}
+
+	if nrows, err := res.RowsAffected(); err != nil {
+		return errors.Wrap(err, "getting rows affected")
+	} else if nrows != 1 {
+		return errors.New("repo not found")
+	}
+	return nil
+}
+
+func (s *gitserverRepoStore) EndTransfer(ctx context.Context, name api.RepoName, shardID string, completed bool) error {
+	err := s.Exec(ctx, sqlf.Sprintf(`
+UPDATE gitserver_repos
+SET
+	shard_id = CASE WHEN %s THEN transfer_shard_id ELSE shard_id END,
+	clone_status = CASE WHEN %s THEN 'cloned' ELSE clone_status END,
+	transfer_shard_id = NULL,
+	transfer_bytes = 0,
+	transfer_started_at = NULL,
+	updated_at = NOW()
+WHERE
+	repo_id = (SELECT id FROM repo WHERE name = %s)
+	AND
+	transfer_shard_id = %s
+`, completed, completed, name, shardID))
+	if err != nil {
+		return errors.Wrap(err, "ending transfer")
+	}
+
+	return nil
+}
+
+func (s *gitserverRepoStore) SetMirrorError(ctx context.Context, name api.RepoName, mirrorErr string, nextMirrorAt time.Time, shardID string) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(`
+UPDATE gitserver_repos
+SET
+	mirror_error = %s,
+	mirror_failures = mirror_failures + 1,
+	next_mirror_at = %s,
+	shard_id = %s,
+	updated_at = NOW()
+WHERE repo_id = (SELECT id FROM repo WHERE name = %s)
+`, sanitizeToUTF8(mirrorErr), nextMirrorAt, shardID, name))
+	if err != nil {
+		return errors.Wrap(err, "setting mirror error")
+	}
+
+	if nrows, err := res.RowsAffected(); err != nil {
+		return errors.Wrap(err, "getting rows affected")
+	} else if nrows != 1 {
+		return errors.New("repo not found")
+	}
+	return nil
+}
+
+func (s *gitserverRepoStore) ListReposDueForMirror(ctx context.Context, shardID string, limit int) ([]api.RepoName, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listReposDueForMirrorQuery, shardID, limit))
+	return scanRepoNames(rows, err)
+}
+
+var scanRepoNames = basestore.NewSliceScanner(func(s dbutil.Scanner) (name api.RepoName, err error) {
+	err = s.Scan(&name)
+	return name, err
+})
+
+const listReposDueForMirrorQuery = `
+SELECT
+	repo.name
+FROM gitserver_repos gr
+JOIN repo ON repo.id = gr.repo_id
+WHERE
+	gr.shard_id = %s
+	AND gr.mirror_error IS NOT NULL
+	AND gr.next_mirror_at <= NOW()
+	AND gr.clone_status = 'cloned'
+	AND repo.deleted_at IS NULL
+ORDER BY gr.next_mirror_at
+LIMIT %s
+`
+
 // GitserverFetchData is the metadata associated with a fetch operation on
 // gitserver.
 type GitserverFetchData struct {
@@ -703,6 +703,194 @@ func TestLogCorruption(t *testing.T) {
 	})
 }
 
+func TestSetHealthReport(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	repo, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{
+		Name:          "github.com/sourcegraph/repo1",
+		RepoSizeBytes: 100,
+		CloneStatus:   types.CloneStatusCloned,
+	})
+
+	fromDB, err := db.GitserverRepos().GetByID(ctx, repo.ID)
+	if err != nil {
+		t.Fatalf("failed to get repo by id: %s", err)
+	}
+	if fromDB.HealthReport != nil {
+		t.Fatalf("expected no health report before the first check, got %+v", fromDB.HealthReport)
+	}
+
+	report := &types.RepoHealthReport{
+		CheckedAt:      time.Now().UTC().Truncate(time.Second),
+		MissingObjects: []string{"e8960bf2b8d05ecd2435a205010631c7c5c47bf4"},
+		PackCount:      3,
+		Repairs:        []types.RepoRepair{{Action: "refetch"}},
+	}
+	if err := db.GitserverRepos().SetHealthReport(ctx, repo.Name, report, shardID); err != nil {
+		t.Fatal(err)
+	}
+
+	fromDB, err = db.GitserverRepos().GetByName(ctx, repo.Name)
+	if err != nil {
+		t.Fatalf("failed to get repo by name: %s", err)
+	}
+	if diff := cmp.Diff(report, fromDB.HealthReport); diff != "" {
+		t.Errorf("unexpected health report (-want +got):\n%s", diff)
+	}
+
+	if err := db.GitserverRepos().SetHealthReport(ctx, "github.com/sourcegraph/missing", report, shardID); err == nil {
+		t.Error("expected an error for a missing repo")
+	}
+}
+
+func TestSetMirrorState(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	repo, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{
+		Name:          "github.com/sourcegraph/repo1",
+		RepoSizeBytes: 100,
+		CloneStatus:   types.CloneStatusCloned,
+	})
+
+	now := time.Now().UTC().Truncate(time.Second)
+	for i := 1; i <= 2; i++ {
+		if err := db.GitserverRepos().SetMirrorError(ctx, repo.Name, "push failed", now.Add(time.Minute), shardID); err != nil {
+			t.Fatal(err)
+		}
+		fromDB, err := db.GitserverRepos().GetByID(ctx, repo.ID)
+		if err != nil {
+			t.Fatalf("failed to get repo by id: %s", err)
+		}
+		if fromDB.MirrorError != "push failed" || fromDB.MirrorFailures != i || !fromDB.NextMirrorAt.Equal(now.Add(time.Minute)) {
+			t.Fatalf("unexpected mirror state after %d failures: %+v", i, fromDB)
+		}
+	}
+
+	if err := db.GitserverRepos().SetLastMirrored(ctx, repo.Name, now, shardID); err != nil {
+		t.Fatal(err)
+	}
+	fromDB, err := db.GitserverRepos().GetByName(ctx, repo.Name)
+	if err != nil {
+		t.Fatalf("failed to get repo by name: %s", err)
+	}
+	if !fromDB.LastMirroredAt.Equal(now) || fromDB.MirrorError != "" || fromDB.MirrorFailures != 0 || !fromDB.NextMirrorAt.IsZero() {
+		t.Fatalf("unexpected mirror state after a successful push: %+v", fromDB)
+	}
+
+	if err := db.GitserverRepos().SetLastMirrored(ctx, "github.com/sourcegraph/missing", now, shardID); err == nil {
+		t.Error("expected an error for a missing repo")
+	}
+}
+
+func TestTransferProgress(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	repo, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{
+		Name:          "github.com/sourcegraph/repo1",
+		RepoSizeBytes: 100,
+		CloneStatus:   types.CloneStatusCloned,
+	})
+
+	for _, transferred := range []int64{0, 1024} {
+		if err := db.GitserverRepos().SetTransferProgress(ctx, repo.Name, "gitserver-new", transferred); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fromDB, err := db.GitserverRepos().GetByID(ctx, repo.ID)
+	if err != nil {
+		t.Fatalf("failed to get repo by id: %s", err)
+	}
+	if fromDB.TransferShardID != "gitserver-new" || fromDB.TransferBytes != 1024 || fromDB.TransferStartedAt.IsZero() || fromDB.ShardID != shardID {
+		t.Fatalf("unexpected transfer progress: %+v", fromDB)
+	}
+
+	// Only the gitserver which transfers the repo ends its transfer.
+	if err := db.GitserverRepos().EndTransfer(ctx, repo.Name, "other", true); err != nil {
+		t.Fatal(err)
+	}
+	if fromDB, err = db.GitserverRepos().GetByID(ctx, repo.ID); err != nil {
+		t.Fatalf("failed to get repo by id: %s", err)
+	}
+	if fromDB.TransferShardID != "gitserver-new" || fromDB.ShardID != shardID {
+		t.Fatalf("unexpected transfer progress: %+v", fromDB)
+	}
+
+	if err := db.GitserverRepos().EndTransfer(ctx, repo.Name, "gitserver-new", true); err != nil {
+		t.Fatal(err)
+	}
+	if fromDB, err = db.GitserverRepos().GetByID(ctx, repo.ID); err != nil {
+		t.Fatalf("failed to get repo by id: %s", err)
+	}
+	if fromDB.TransferShardID != "" || fromDB.TransferBytes != 0 || !fromDB.TransferStartedAt.IsZero() || fromDB.ShardID != "gitserver-new" || fromDB.CloneStatus != types.CloneStatusCloned {
+		t.Fatalf("unexpected state after a completed transfer: %+v", fromDB)
+	}
+
+	if err := db.GitserverRepos().SetTransferProgress(ctx, "github.com/sourcegraph/missing", "gitserver-new", 0); err == nil {
+		t.Error("expected an error for a missing repo")
+	}
+}
+
+func TestListReposDueForMirror(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	logger := logtest.Scoped(t)
+	db := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+
+	var names []api.RepoName
+	for _, name := range []api.RepoName{"github.com/sourcegraph/due", "github.com/sourcegraph/backoff", "github.com/sourcegraph/other-shard", "github.com/sourcegraph/mirrored"} {
+		repo, _ := createTestRepo(ctx, t, db, &createTestRepoPayload{
+			Name:        name,
+			CloneStatus: types.CloneStatusCloned,
+		})
+		names = append(names, repo.Name)
+	}
+
+	now := time.Now()
+	for name, next := range map[api.RepoName]time.Time{
+		names[0]: now.Add(-time.Minute),
+		names[1]: now.Add(time.Hour),
+	} {
+		if err := db.GitserverRepos().SetMirrorError(ctx, name, "push failed", next, shardID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := db.GitserverRepos().SetMirrorError(ctx, names[2], "push failed", now.Add(-time.Minute), "other"); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.GitserverRepos().SetLastMirrored(ctx, names[3], now, shardID); err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := db.GitserverRepos().ListReposDueForMirror(ctx, shardID, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]api.RepoName{names[0]}, have); diff != "" {
+		t.Fatalf("unexpected repos (-want +have):\n%s", diff)
+	}
+}
+
 func TestSetLastError(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
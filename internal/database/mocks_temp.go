@@ -5893,6 +5893,9 @@ type MockDB struct {
 	// GitserverLocalCloneFunc is an instance of a mock function object
 	// controlling the behavior of the method GitserverLocalClone.
 	GitserverLocalCloneFunc *DBGitserverLocalCloneFunc
+	// GitserverRefEventsFunc is an instance of a mock function object
+	// controlling the behavior of the method GitserverRefEvents.
+	GitserverRefEventsFunc *DBGitserverRefEventsFunc
 	// GitserverReposFunc is an instance of a mock function object
 	// controlling the behavior of the method GitserverRepos.
 	GitserverReposFunc *DBGitserverReposFunc
@@ -6109,6 +6112,11 @@ func NewMockDB() *MockDB {
 				return
 			},
 		},
+		GitserverRefEventsFunc: &DBGitserverRefEventsFunc{
+			defaultHook: func() (r0 GitserverRefEventStore) {
+				return
+			},
+		},
 		GitserverReposFunc: &DBGitserverReposFunc{
 			defaultHook: func() (r0 GitserverRepoStore) {
 				return
@@ -6411,6 +6419,11 @@ func NewStrictMockDB() *MockDB {
 				panic("unexpected invocation of MockDB.GitserverLocalClone")
 			},
 		},
+		GitserverRefEventsFunc: &DBGitserverRefEventsFunc{
+			defaultHook: func() GitserverRefEventStore {
+				panic("unexpected invocation of MockDB.GitserverRefEvents")
+			},
+		},
 		GitserverReposFunc: &DBGitserverReposFunc{
 			defaultHook: func() GitserverRepoStore {
 				panic("unexpected invocation of MockDB.GitserverRepos")
@@ -6681,6 +6694,9 @@ func NewMockDBFrom(i DB) *MockDB {
 		GitserverLocalCloneFunc: &DBGitserverLocalCloneFunc{
 			defaultHook: i.GitserverLocalClone,
 		},
+		GitserverRefEventsFunc: &DBGitserverRefEventsFunc{
+			defaultHook: i.GitserverRefEvents,
+		},
 		GitserverReposFunc: &DBGitserverReposFunc{
 			defaultHook: i.GitserverRepos,
 		},
@@ -8413,6 +8429,105 @@ func (c DBGitserverLocalCloneFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBGitserverRefEventsFunc describes the behavior when the
+// GitserverRefEvents method of the parent MockDB instance is invoked.
+type DBGitserverRefEventsFunc struct {
+	defaultHook func() GitserverRefEventStore
+	hooks       []func() GitserverRefEventStore
+	history     []DBGitserverRefEventsFuncCall
+	mutex       sync.Mutex
+}
+
+// GitserverRefEvents delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDB) GitserverRefEvents() GitserverRefEventStore {
+	r0 := m.GitserverRefEventsFunc.nextHook()()
+	m.GitserverRefEventsFunc.appendCall(DBGitserverRefEventsFuncCall{r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the GitserverRefEvents
+// method of the parent MockDB instance is invoked and the hook queue is
+// empty.
+func (f *DBGitserverRefEventsFunc) SetDefaultHook(hook func() GitserverRefEventStore) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GitserverRefEvents method of the parent MockDB instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBGitserverRefEventsFunc) PushHook(hook func() GitserverRefEventStore) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *DBGitserverRefEventsFunc) SetDefaultReturn(r0 GitserverRefEventStore) {
+	f.SetDefaultHook(func() GitserverRefEventStore {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *DBGitserverRefEventsFunc) PushReturn(r0 GitserverRefEventStore) {
+	f.PushHook(func() GitserverRefEventStore {
+		return r0
+	})
+}
+
+func (f *DBGitserverRefEventsFunc) nextHook() func() GitserverRefEventStore {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBGitserverRefEventsFunc) appendCall(r0 DBGitserverRefEventsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBGitserverRefEventsFuncCall objects
+// describing the invocations of this function.
+func (f *DBGitserverRefEventsFunc) History() []DBGitserverRefEventsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBGitserverRefEventsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBGitserverRefEventsFuncCall is an object that describes an invocation of
+// method GitserverRefEvents on an instance of MockDB.
+type DBGitserverRefEventsFuncCall struct {
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 GitserverRefEventStore
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBGitserverRefEventsFuncCall) Args() []interface{} {
+	return []interface{}{}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBGitserverRefEventsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBGitserverReposFunc describes the behavior when the GitserverRepos
 // method of the parent MockDB instance is invoked.
 type DBGitserverReposFunc struct {
@@ -27222,11 +27337,684 @@ func (c GitserverLocalCloneStoreWithFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// MockGitserverRefEventStore is a mock implementation of the
+// GitserverRefEventStore interface (from the package
+// github.com/sourcegraph/sourcegraph/internal/database) used for unit
+// testing.
+type MockGitserverRefEventStore struct {
+	// DeleteBeforeFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteBefore.
+	DeleteBeforeFunc *GitserverRefEventStoreDeleteBeforeFunc
+	// GetBookmarkFunc is an instance of a mock function object controlling
+	// the behavior of the method GetBookmark.
+	GetBookmarkFunc *GitserverRefEventStoreGetBookmarkFunc
+	// ListAfterFunc is an instance of a mock function object controlling
+	// the behavior of the method ListAfter.
+	ListAfterFunc *GitserverRefEventStoreListAfterFunc
+	// UpdateBookmarkFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdateBookmark.
+	UpdateBookmarkFunc *GitserverRefEventStoreUpdateBookmarkFunc
+	// InsertFunc is an instance of a mock function object controlling the
+	// behavior of the method Insert.
+	InsertFunc *GitserverRefEventStoreInsertFunc
+}
+
+// NewMockGitserverRefEventStore creates a new mock of the
+// GitserverRefEventStore interface. All methods return zero values for all
+// results, unless overwritten.
+func NewMockGitserverRefEventStore() *MockGitserverRefEventStore {
+	return &MockGitserverRefEventStore{
+		DeleteBeforeFunc: &GitserverRefEventStoreDeleteBeforeFunc{
+			defaultHook: func(context.Context, time.Time) (r0 int, r1 error) {
+				return
+			},
+		},
+		GetBookmarkFunc: &GitserverRefEventStoreGetBookmarkFunc{
+			defaultHook: func(context.Context, string) (r0 GitserverRefEventPosition, r1 error) {
+				return
+			},
+		},
+		ListAfterFunc: &GitserverRefEventStoreListAfterFunc{
+			defaultHook: func(context.Context, GitserverRefEventPosition, int) (r0 []*GitserverRefEvent, r1 error) {
+				return
+			},
+		},
+		UpdateBookmarkFunc: &GitserverRefEventStoreUpdateBookmarkFunc{
+			defaultHook: func(context.Context, string, GitserverRefEventPosition) (r0 error) {
+				return
+			},
+		},
+		InsertFunc: &GitserverRefEventStoreInsertFunc{
+			defaultHook: func(context.Context, api.RepoID, []RefChange, time.Time) (r0 error) {
+				return
+			},
+		},
+	}
+}
+
+// NewStrictMockGitserverRefEventStore creates a new mock of the
+// GitserverRefEventStore interface. All methods panic on invocation, unless
+// overwritten.
+func NewStrictMockGitserverRefEventStore() *MockGitserverRefEventStore {
+	return &MockGitserverRefEventStore{
+		DeleteBeforeFunc: &GitserverRefEventStoreDeleteBeforeFunc{
+			defaultHook: func(context.Context, time.Time) (int, error) {
+				panic("unexpected invocation of MockGitserverRefEventStore.DeleteBefore")
+			},
+		},
+		GetBookmarkFunc: &GitserverRefEventStoreGetBookmarkFunc{
+			defaultHook: func(context.Context, string) (GitserverRefEventPosition, error) {
+				panic("unexpected invocation of MockGitserverRefEventStore.GetBookmark")
+			},
+		},
+		ListAfterFunc: &GitserverRefEventStoreListAfterFunc{
+			defaultHook: func(context.Context, GitserverRefEventPosition, int) ([]*GitserverRefEvent, error) {
+				panic("unexpected invocation of MockGitserverRefEventStore.ListAfter")
+			},
+		},
+		UpdateBookmarkFunc: &GitserverRefEventStoreUpdateBookmarkFunc{
+			defaultHook: func(context.Context, string, GitserverRefEventPosition) error {
+				panic("unexpected invocation of MockGitserverRefEventStore.UpdateBookmark")
+			},
+		},
+		InsertFunc: &GitserverRefEventStoreInsertFunc{
+			defaultHook: func(context.Context, api.RepoID, []RefChange, time.Time) error {
+				panic("unexpected invocation of MockGitserverRefEventStore.Insert")
+			},
+		},
+	}
+}
+
+// NewMockGitserverRefEventStoreFrom creates a new mock of the
+// MockGitserverRefEventStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockGitserverRefEventStoreFrom(i GitserverRefEventStore) *MockGitserverRefEventStore {
+	return &MockGitserverRefEventStore{
+		DeleteBeforeFunc: &GitserverRefEventStoreDeleteBeforeFunc{
+			defaultHook: i.DeleteBefore,
+		},
+		GetBookmarkFunc: &GitserverRefEventStoreGetBookmarkFunc{
+			defaultHook: i.GetBookmark,
+		},
+		ListAfterFunc: &GitserverRefEventStoreListAfterFunc{
+			defaultHook: i.ListAfter,
+		},
+		UpdateBookmarkFunc: &GitserverRefEventStoreUpdateBookmarkFunc{
+			defaultHook: i.UpdateBookmark,
+		},
+		InsertFunc: &GitserverRefEventStoreInsertFunc{
+			defaultHook: i.Insert,
+		},
+	}
+}
+
+// GitserverRefEventStoreDeleteBeforeFunc describes the behavior when the
+// DeleteBefore method of the parent MockGitserverRefEventStore instance is
+// invoked.
+type GitserverRefEventStoreDeleteBeforeFunc struct {
+	defaultHook func(context.Context, time.Time) (int, error)
+	hooks       []func(context.Context, time.Time) (int, error)
+	history     []GitserverRefEventStoreDeleteBeforeFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteBefore delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverRefEventStore) DeleteBefore(v0 context.Context, v1 time.Time) (int, error) {
+	r0, r1 := m.DeleteBeforeFunc.nextHook()(v0, v1)
+	m.DeleteBeforeFunc.appendCall(GitserverRefEventStoreDeleteBeforeFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DeleteBefore method
+// of the parent MockGitserverRefEventStore instance is invoked and the hook
+// queue is empty.
+func (f *GitserverRefEventStoreDeleteBeforeFunc) SetDefaultHook(hook func(context.Context, time.Time) (int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteBefore method of the parent MockGitserverRefEventStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverRefEventStoreDeleteBeforeFunc) PushHook(hook func(context.Context, time.Time) (int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRefEventStoreDeleteBeforeFunc) SetDefaultReturn(r0 int, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRefEventStoreDeleteBeforeFunc) PushReturn(r0 int, r1 error) {
+	f.PushHook(func(context.Context, time.Time) (int, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverRefEventStoreDeleteBeforeFunc) nextHook() func(context.Context, time.Time) (int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRefEventStoreDeleteBeforeFunc) appendCall(r0 GitserverRefEventStoreDeleteBeforeFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverRefEventStoreDeleteBeforeFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverRefEventStoreDeleteBeforeFunc) History() []GitserverRefEventStoreDeleteBeforeFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRefEventStoreDeleteBeforeFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRefEventStoreDeleteBeforeFuncCall is an object that describes an
+// invocation of method DeleteBefore on an instance of
+// MockGitserverRefEventStore.
+type GitserverRefEventStoreDeleteBeforeFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRefEventStoreDeleteBeforeFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRefEventStoreDeleteBeforeFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GitserverRefEventStoreGetBookmarkFunc describes the behavior when the
+// GetBookmark method of the parent MockGitserverRefEventStore instance is
+// invoked.
+type GitserverRefEventStoreGetBookmarkFunc struct {
+	defaultHook func(context.Context, string) (GitserverRefEventPosition, error)
+	hooks       []func(context.Context, string) (GitserverRefEventPosition, error)
+	history     []GitserverRefEventStoreGetBookmarkFuncCall
+	mutex       sync.Mutex
+}
+
+// GetBookmark delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverRefEventStore) GetBookmark(v0 context.Context, v1 string) (GitserverRefEventPosition, error) {
+	r0, r1 := m.GetBookmarkFunc.nextHook()(v0, v1)
+	m.GetBookmarkFunc.appendCall(GitserverRefEventStoreGetBookmarkFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetBookmark method
+// of the parent MockGitserverRefEventStore instance is invoked and the hook
+// queue is empty.
+func (f *GitserverRefEventStoreGetBookmarkFunc) SetDefaultHook(hook func(context.Context, string) (GitserverRefEventPosition, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetBookmark method of the parent MockGitserverRefEventStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverRefEventStoreGetBookmarkFunc) PushHook(hook func(context.Context, string) (GitserverRefEventPosition, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRefEventStoreGetBookmarkFunc) SetDefaultReturn(r0 GitserverRefEventPosition, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (GitserverRefEventPosition, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRefEventStoreGetBookmarkFunc) PushReturn(r0 GitserverRefEventPosition, r1 error) {
+	f.PushHook(func(context.Context, string) (GitserverRefEventPosition, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverRefEventStoreGetBookmarkFunc) nextHook() func(context.Context, string) (GitserverRefEventPosition, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRefEventStoreGetBookmarkFunc) appendCall(r0 GitserverRefEventStoreGetBookmarkFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverRefEventStoreGetBookmarkFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverRefEventStoreGetBookmarkFunc) History() []GitserverRefEventStoreGetBookmarkFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRefEventStoreGetBookmarkFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRefEventStoreGetBookmarkFuncCall is an object that describes an
+// invocation of method GetBookmark on an instance of
+// MockGitserverRefEventStore.
+type GitserverRefEventStoreGetBookmarkFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 GitserverRefEventPosition
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRefEventStoreGetBookmarkFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRefEventStoreGetBookmarkFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GitserverRefEventStoreListAfterFunc describes the behavior when the
+// ListAfter method of the parent MockGitserverRefEventStore instance is
+// invoked.
+type GitserverRefEventStoreListAfterFunc struct {
+	defaultHook func(context.Context, GitserverRefEventPosition, int) ([]*GitserverRefEvent, error)
+	hooks       []func(context.Context, GitserverRefEventPosition, int) ([]*GitserverRefEvent, error)
+	history     []GitserverRefEventStoreListAfterFuncCall
+	mutex       sync.Mutex
+}
+
+// ListAfter delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGitserverRefEventStore) ListAfter(v0 context.Context, v1 GitserverRefEventPosition, v2 int) ([]*GitserverRefEvent, error) {
+	r0, r1 := m.ListAfterFunc.nextHook()(v0, v1, v2)
+	m.ListAfterFunc.appendCall(GitserverRefEventStoreListAfterFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ListAfter method of
+// the parent MockGitserverRefEventStore instance is invoked and the hook
+// queue is empty.
+func (f *GitserverRefEventStoreListAfterFunc) SetDefaultHook(hook func(context.Context, GitserverRefEventPosition, int) ([]*GitserverRefEvent, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListAfter method of the parent MockGitserverRefEventStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverRefEventStoreListAfterFunc) PushHook(hook func(context.Context, GitserverRefEventPosition, int) ([]*GitserverRefEvent, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRefEventStoreListAfterFunc) SetDefaultReturn(r0 []*GitserverRefEvent, r1 error) {
+	f.SetDefaultHook(func(context.Context, GitserverRefEventPosition, int) ([]*GitserverRefEvent, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRefEventStoreListAfterFunc) PushReturn(r0 []*GitserverRefEvent, r1 error) {
+	f.PushHook(func(context.Context, GitserverRefEventPosition, int) ([]*GitserverRefEvent, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverRefEventStoreListAfterFunc) nextHook() func(context.Context, GitserverRefEventPosition, int) ([]*GitserverRefEvent, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRefEventStoreListAfterFunc) appendCall(r0 GitserverRefEventStoreListAfterFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverRefEventStoreListAfterFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverRefEventStoreListAfterFunc) History() []GitserverRefEventStoreListAfterFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRefEventStoreListAfterFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRefEventStoreListAfterFuncCall is an object that describes an
+// invocation of method ListAfter on an instance of
+// MockGitserverRefEventStore.
+type GitserverRefEventStoreListAfterFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 GitserverRefEventPosition
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []*GitserverRefEvent
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRefEventStoreListAfterFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRefEventStoreListAfterFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// GitserverRefEventStoreUpdateBookmarkFunc describes the behavior when the
+// UpdateBookmark method of the parent MockGitserverRefEventStore instance
+// is invoked.
+type GitserverRefEventStoreUpdateBookmarkFunc struct {
+	defaultHook func(context.Context, string, GitserverRefEventPosition) error
+	hooks       []func(context.Context, string, GitserverRefEventPosition) error
+	history     []GitserverRefEventStoreUpdateBookmarkFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateBookmark delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverRefEventStore) UpdateBookmark(v0 context.Context, v1 string, v2 GitserverRefEventPosition) error {
+	r0 := m.UpdateBookmarkFunc.nextHook()(v0, v1, v2)
+	m.UpdateBookmarkFunc.appendCall(GitserverRefEventStoreUpdateBookmarkFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpdateBookmark
+// method of the parent MockGitserverRefEventStore instance is invoked and
+// the hook queue is empty.
+func (f *GitserverRefEventStoreUpdateBookmarkFunc) SetDefaultHook(hook func(context.Context, string, GitserverRefEventPosition) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateBookmark method of the parent MockGitserverRefEventStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverRefEventStoreUpdateBookmarkFunc) PushHook(hook func(context.Context, string, GitserverRefEventPosition) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRefEventStoreUpdateBookmarkFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string, GitserverRefEventPosition) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRefEventStoreUpdateBookmarkFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string, GitserverRefEventPosition) error {
+		return r0
+	})
+}
+
+func (f *GitserverRefEventStoreUpdateBookmarkFunc) nextHook() func(context.Context, string, GitserverRefEventPosition) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRefEventStoreUpdateBookmarkFunc) appendCall(r0 GitserverRefEventStoreUpdateBookmarkFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitserverRefEventStoreUpdateBookmarkFuncCall objects describing the
+// invocations of this function.
+func (f *GitserverRefEventStoreUpdateBookmarkFunc) History() []GitserverRefEventStoreUpdateBookmarkFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRefEventStoreUpdateBookmarkFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRefEventStoreUpdateBookmarkFuncCall is an object that describes
+// an invocation of method UpdateBookmark on an instance of
+// MockGitserverRefEventStore.
+type GitserverRefEventStoreUpdateBookmarkFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 GitserverRefEventPosition
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRefEventStoreUpdateBookmarkFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRefEventStoreUpdateBookmarkFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// GitserverRefEventStoreInsertFunc describes the behavior when the Insert
+// method of the parent MockGitserverRefEventStore instance is invoked.
+type GitserverRefEventStoreInsertFunc struct {
+	defaultHook func(context.Context, api.RepoID, []RefChange, time.Time) error
+	hooks       []func(context.Context, api.RepoID, []RefChange, time.Time) error
+	history     []GitserverRefEventStoreInsertFuncCall
+	mutex       sync.Mutex
+}
+
+// Insert delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGitserverRefEventStore) Insert(v0 context.Context, v1 api.RepoID, v2 []RefChange, v3 time.Time) error {
+	r0 := m.InsertFunc.nextHook()(v0, v1, v2, v3)
+	m.InsertFunc.appendCall(GitserverRefEventStoreInsertFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Insert method of the
+// parent MockGitserverRefEventStore instance is invoked and the hook queue
+// is empty.
+func (f *GitserverRefEventStoreInsertFunc) SetDefaultHook(hook func(context.Context, api.RepoID, []RefChange, time.Time) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Insert method of the parent MockGitserverRefEventStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverRefEventStoreInsertFunc) PushHook(hook func(context.Context, api.RepoID, []RefChange, time.Time) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRefEventStoreInsertFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, []RefChange, time.Time) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRefEventStoreInsertFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, []RefChange, time.Time) error {
+		return r0
+	})
+}
+
+func (f *GitserverRefEventStoreInsertFunc) nextHook() func(context.Context, api.RepoID, []RefChange, time.Time) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRefEventStoreInsertFunc) appendCall(r0 GitserverRefEventStoreInsertFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverRefEventStoreInsertFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverRefEventStoreInsertFunc) History() []GitserverRefEventStoreInsertFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRefEventStoreInsertFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRefEventStoreInsertFuncCall is an object that describes an
+// invocation of method Insert on an instance of MockGitserverRefEventStore.
+type GitserverRefEventStoreInsertFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []RefChange
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRefEventStoreInsertFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRefEventStoreInsertFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockGitserverRepoStore is a mock implementation of the GitserverRepoStore
 // interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/database) used for unit
 // testing.
 type MockGitserverRepoStore struct {
+	// EndTransferFunc is an instance of a mock function object controlling
+	// the behavior of the method EndTransfer.
+	EndTransferFunc *GitserverRepoStoreEndTransferFunc
 	// GetByIDFunc is an instance of a mock function object controlling the
 	// behavior of the method GetByID.
 	GetByIDFunc *GitserverRepoStoreGetByIDFunc
@@ -27246,6 +28034,9 @@ type MockGitserverRepoStore struct {
 	// object controlling the behavior of the method
 	// IterateRepoGitserverStatus.
 	IterateRepoGitserverStatusFunc *GitserverRepoStoreIterateRepoGitserverStatusFunc
+	// ListReposDueForMirrorFunc is an instance of a mock function object
+	// controlling the behavior of the method ListReposDueForMirror.
+	ListReposDueForMirrorFunc *GitserverRepoStoreListReposDueForMirrorFunc
 	// ListReposWithLastErrorFunc is an instance of a mock function object
 	// controlling the behavior of the method ListReposWithLastError.
 	ListReposWithLastErrorFunc *GitserverRepoStoreListReposWithLastErrorFunc
@@ -27261,15 +28052,27 @@ type MockGitserverRepoStore struct {
 	// SetCloningProgressFunc is an instance of a mock function object
 	// controlling the behavior of the method SetCloningProgress.
 	SetCloningProgressFunc *GitserverRepoStoreSetCloningProgressFunc
+	// SetHealthReportFunc is an instance of a mock function object
+	// controlling the behavior of the method SetHealthReport.
+	SetHealthReportFunc *GitserverRepoStoreSetHealthReportFunc
 	// SetLastErrorFunc is an instance of a mock function object controlling
 	// the behavior of the method SetLastError.
 	SetLastErrorFunc *GitserverRepoStoreSetLastErrorFunc
 	// SetLastFetchedFunc is an instance of a mock function object
 	// controlling the behavior of the method SetLastFetched.
 	SetLastFetchedFunc *GitserverRepoStoreSetLastFetchedFunc
+	// SetLastMirroredFunc is an instance of a mock function object
+	// controlling the behavior of the method SetLastMirrored.
+	SetLastMirroredFunc *GitserverRepoStoreSetLastMirroredFunc
+	// SetMirrorErrorFunc is an instance of a mock function object
+	// controlling the behavior of the method SetMirrorError.
+	SetMirrorErrorFunc *GitserverRepoStoreSetMirrorErrorFunc
 	// SetRepoSizeFunc is an instance of a mock function object controlling
 	// the behavior of the method SetRepoSize.
 	SetRepoSizeFunc *GitserverRepoStoreSetRepoSizeFunc
+	// SetTransferProgressFunc is an instance of a mock function object
+	// controlling the behavior of the method SetTransferProgress.
+	SetTransferProgressFunc *GitserverRepoStoreSetTransferProgressFunc
 	// TotalErroredCloudDefaultReposFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// TotalErroredCloudDefaultRepos.
@@ -27290,6 +28093,11 @@ type MockGitserverRepoStore struct {
 // overwritten.
 func NewMockGitserverRepoStore() *MockGitserverRepoStore {
 	return &MockGitserverRepoStore{
+		EndTransferFunc: &GitserverRepoStoreEndTransferFunc{
+			defaultHook: func(context.Context, api.RepoName, string, bool) (r0 error) {
+				return
+			},
+		},
 		GetByIDFunc: &GitserverRepoStoreGetByIDFunc{
 			defaultHook: func(context.Context, api.RepoID) (r0 *types.GitserverRepo, r1 error) {
 				return
@@ -27320,6 +28128,11 @@ func NewMockGitserverRepoStore() *MockGitserverRepoStore {
 				return
 			},
 		},
+		ListReposDueForMirrorFunc: &GitserverRepoStoreListReposDueForMirrorFunc{
+			defaultHook: func(context.Context, string, int) (r0 []api.RepoName, r1 error) {
+				return
+			},
+		},
 		ListReposWithLastErrorFunc: &GitserverRepoStoreListReposWithLastErrorFunc{
 			defaultHook: func(context.Context) (r0 []api.RepoName, r1 error) {
 				return
@@ -27345,6 +28158,11 @@ func NewMockGitserverRepoStore() *MockGitserverRepoStore {
 				return
 			},
 		},
+		SetHealthReportFunc: &GitserverRepoStoreSetHealthReportFunc{
+			defaultHook: func(context.Context, api.RepoName, *types.RepoHealthReport, string) (r0 error) {
+				return
+			},
+		},
 		SetLastErrorFunc: &GitserverRepoStoreSetLastErrorFunc{
 			defaultHook: func(context.Context, api.RepoName, string, string) (r0 error) {
 				return
@@ -27355,11 +28173,26 @@ func NewMockGitserverRepoStore() *MockGitserverRepoStore {
 				return
 			},
 		},
+		SetLastMirroredFunc: &GitserverRepoStoreSetLastMirroredFunc{
+			defaultHook: func(context.Context, api.RepoName, time.Time, string) (r0 error) {
+				return
+			},
+		},
+		SetMirrorErrorFunc: &GitserverRepoStoreSetMirrorErrorFunc{
+			defaultHook: func(context.Context, api.RepoName, string, time.Time, string) (r0 error) {
+				return
+			},
+		},
 		SetRepoSizeFunc: &GitserverRepoStoreSetRepoSizeFunc{
 			defaultHook: func(context.Context, api.RepoName, int64, string) (r0 error) {
 				return
 			},
 		},
+		SetTransferProgressFunc: &GitserverRepoStoreSetTransferProgressFunc{
+			defaultHook: func(context.Context, api.RepoName, string, int64) (r0 error) {
+				return
+			},
+		},
 		TotalErroredCloudDefaultReposFunc: &GitserverRepoStoreTotalErroredCloudDefaultReposFunc{
 			defaultHook: func(context.Context) (r0 int, r1 error) {
 				return
@@ -27388,6 +28221,11 @@ func NewMockGitserverRepoStore() *MockGitserverRepoStore {
 // overwritten.
 func NewStrictMockGitserverRepoStore() *MockGitserverRepoStore {
 	return &MockGitserverRepoStore{
+		EndTransferFunc: &GitserverRepoStoreEndTransferFunc{
+			defaultHook: func(context.Context, api.RepoName, string, bool) error {
+				panic("unexpected invocation of MockGitserverRepoStore.EndTransfer")
+			},
+		},
 		GetByIDFunc: &GitserverRepoStoreGetByIDFunc{
 			defaultHook: func(context.Context, api.RepoID) (*types.GitserverRepo, error) {
 				panic("unexpected invocation of MockGitserverRepoStore.GetByID")
@@ -27418,6 +28256,11 @@ func NewStrictMockGitserverRepoStore() *MockGitserverRepoStore {
 				panic("unexpected invocation of MockGitserverRepoStore.IterateRepoGitserverStatus")
 			},
 		},
+		ListReposDueForMirrorFunc: &GitserverRepoStoreListReposDueForMirrorFunc{
+			defaultHook: func(context.Context, string, int) ([]api.RepoName, error) {
+				panic("unexpected invocation of MockGitserverRepoStore.ListReposDueForMirror")
+			},
+		},
 		ListReposWithLastErrorFunc: &GitserverRepoStoreListReposWithLastErrorFunc{
 			defaultHook: func(context.Context) ([]api.RepoName, error) {
 				panic("unexpected invocation of MockGitserverRepoStore.ListReposWithLastError")
@@ -27443,6 +28286,11 @@ func NewStrictMockGitserverRepoStore() *MockGitserverRepoStore {
 				panic("unexpected invocation of MockGitserverRepoStore.SetCloningProgress")
 			},
 		},
+		SetHealthReportFunc: &GitserverRepoStoreSetHealthReportFunc{
+			defaultHook: func(context.Context, api.RepoName, *types.RepoHealthReport, string) error {
+				panic("unexpected invocation of MockGitserverRepoStore.SetHealthReport")
+			},
+		},
 		SetLastErrorFunc: &GitserverRepoStoreSetLastErrorFunc{
 			defaultHook: func(context.Context, api.RepoName, string, string) error {
 				panic("unexpected invocation of MockGitserverRepoStore.SetLastError")
@@ -27453,11 +28301,26 @@ func NewStrictMockGitserverRepoStore() *MockGitserverRepoStore {
 				panic("unexpected invocation of MockGitserverRepoStore.SetLastFetched")
 			},
 		},
+		SetLastMirroredFunc: &GitserverRepoStoreSetLastMirroredFunc{
+			defaultHook: func(context.Context, api.RepoName, time.Time, string) error {
+				panic("unexpected invocation of MockGitserverRepoStore.SetLastMirrored")
+			},
+		},
+		SetMirrorErrorFunc: &GitserverRepoStoreSetMirrorErrorFunc{
+			defaultHook: func(context.Context, api.RepoName, string, time.Time, string) error {
+				panic("unexpected invocation of MockGitserverRepoStore.SetMirrorError")
+			},
+		},
 		SetRepoSizeFunc: &GitserverRepoStoreSetRepoSizeFunc{
 			defaultHook: func(context.Context, api.RepoName, int64, string) error {
 				panic("unexpected invocation of MockGitserverRepoStore.SetRepoSize")
 			},
 		},
+		SetTransferProgressFunc: &GitserverRepoStoreSetTransferProgressFunc{
+			defaultHook: func(context.Context, api.RepoName, string, int64) error {
+				panic("unexpected invocation of MockGitserverRepoStore.SetTransferProgress")
+			},
+		},
 		TotalErroredCloudDefaultReposFunc: &GitserverRepoStoreTotalErroredCloudDefaultReposFunc{
 			defaultHook: func(context.Context) (int, error) {
 				panic("unexpected invocation of MockGitserverRepoStore.TotalErroredCloudDefaultRepos")
@@ -27486,6 +28349,9 @@ func NewStrictMockGitserverRepoStore() *MockGitserverRepoStore {
 // implementation, unless overwritten.
 func NewMockGitserverRepoStoreFrom(i GitserverRepoStore) *MockGitserverRepoStore {
 	return &MockGitserverRepoStore{
+		EndTransferFunc: &GitserverRepoStoreEndTransferFunc{
+			defaultHook: i.EndTransfer,
+		},
 		GetByIDFunc: &GitserverRepoStoreGetByIDFunc{
 			defaultHook: i.GetByID,
 		},
@@ -27504,6 +28370,9 @@ func NewMockGitserverRepoStoreFrom(i GitserverRepoStore) *MockGitserverRepoStore
 		IterateRepoGitserverStatusFunc: &GitserverRepoStoreIterateRepoGitserverStatusFunc{
 			defaultHook: i.IterateRepoGitserverStatus,
 		},
+		ListReposDueForMirrorFunc: &GitserverRepoStoreListReposDueForMirrorFunc{
+			defaultHook: i.ListReposDueForMirror,
+		},
 		ListReposWithLastErrorFunc: &GitserverRepoStoreListReposWithLastErrorFunc{
 			defaultHook: i.ListReposWithLastError,
 		},
@@ -27519,15 +28388,27 @@ func NewMockGitserverRepoStoreFrom(i GitserverRepoStore) *MockGitserverRepoStore
 		SetCloningProgressFunc: &GitserverRepoStoreSetCloningProgressFunc{
 			defaultHook: i.SetCloningProgress,
 		},
+		SetHealthReportFunc: &GitserverRepoStoreSetHealthReportFunc{
+			defaultHook: i.SetHealthReport,
+		},
 		SetLastErrorFunc: &GitserverRepoStoreSetLastErrorFunc{
 			defaultHook: i.SetLastError,
 		},
 		SetLastFetchedFunc: &GitserverRepoStoreSetLastFetchedFunc{
 			defaultHook: i.SetLastFetched,
 		},
+		SetLastMirroredFunc: &GitserverRepoStoreSetLastMirroredFunc{
+			defaultHook: i.SetLastMirrored,
+		},
+		SetMirrorErrorFunc: &GitserverRepoStoreSetMirrorErrorFunc{
+			defaultHook: i.SetMirrorError,
+		},
 		SetRepoSizeFunc: &GitserverRepoStoreSetRepoSizeFunc{
 			defaultHook: i.SetRepoSize,
 		},
+		SetTransferProgressFunc: &GitserverRepoStoreSetTransferProgressFunc{
+			defaultHook: i.SetTransferProgress,
+		},
 		TotalErroredCloudDefaultReposFunc: &GitserverRepoStoreTotalErroredCloudDefaultReposFunc{
 			defaultHook: i.TotalErroredCloudDefaultRepos,
 		},
@@ -27543,6 +28424,119 @@ func NewMockGitserverRepoStoreFrom(i GitserverRepoStore) *MockGitserverRepoStore
 	}
 }
 
+// GitserverRepoStoreEndTransferFunc describes the behavior when the
+// EndTransfer method of the parent MockGitserverRepoStore instance is
+// invoked.
+type GitserverRepoStoreEndTransferFunc struct {
+	defaultHook func(context.Context, api.RepoName, string, bool) error
+	hooks       []func(context.Context, api.RepoName, string, bool) error
+	history     []GitserverRepoStoreEndTransferFuncCall
+	mutex       sync.Mutex
+}
+
+// EndTransfer delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockGitserverRepoStore) EndTransfer(v0 context.Context, v1 api.RepoName, v2 string, v3 bool) error {
+	r0 := m.EndTransferFunc.nextHook()(v0, v1, v2, v3)
+	m.EndTransferFunc.appendCall(GitserverRepoStoreEndTransferFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the EndTransfer method
+// of the parent MockGitserverRepoStore instance is invoked and the hook
+// queue is empty.
+func (f *GitserverRepoStoreEndTransferFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, bool) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// EndTransfer method of the parent MockGitserverRepoStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *GitserverRepoStoreEndTransferFunc) PushHook(hook func(context.Context, api.RepoName, string, bool) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRepoStoreEndTransferFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, bool) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRepoStoreEndTransferFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoName, string, bool) error {
+		return r0
+	})
+}
+
+func (f *GitserverRepoStoreEndTransferFunc) nextHook() func(context.Context, api.RepoName, string, bool) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRepoStoreEndTransferFunc) appendCall(r0 GitserverRepoStoreEndTransferFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverRepoStoreEndTransferFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverRepoStoreEndTransferFunc) History() []GitserverRepoStoreEndTransferFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRepoStoreEndTransferFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRepoStoreEndTransferFuncCall is an object that describes an
+// invocation of method EndTransfer on an instance of
+// MockGitserverRepoStore.
+type GitserverRepoStoreEndTransferFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRepoStoreEndTransferFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRepoStoreEndTransferFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // GitserverRepoStoreGetByIDFunc describes the behavior when the GetByID
 // method of the parent MockGitserverRepoStore instance is invoked.
 type GitserverRepoStoreGetByIDFunc struct {
@@ -28201,6 +29195,121 @@ func (c GitserverRepoStoreIterateRepoGitserverStatusFuncCall) Results() []interf
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// GitserverRepoStoreListReposDueForMirrorFunc describes the behavior when
+// the ListReposDueForMirror method of the parent MockGitserverRepoStore
+// instance is invoked.
+type GitserverRepoStoreListReposDueForMirrorFunc struct {
+	defaultHook func(context.Context, string, int) ([]api.RepoName, error)
+	hooks       []func(context.Context, string, int) ([]api.RepoName, error)
+	history     []GitserverRepoStoreListReposDueForMirrorFuncCall
+	mutex       sync.Mutex
+}
+
+// ListReposDueForMirror delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockGitserverRepoStore) ListReposDueForMirror(v0 context.Context, v1 string, v2 int) ([]api.RepoName, error) {
+	r0, r1 := m.ListReposDueForMirrorFunc.nextHook()(v0, v1, v2)
+	m.ListReposDueForMirrorFunc.appendCall(GitserverRepoStoreListReposDueForMirrorFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// ListReposDueForMirror method of the parent MockGitserverRepoStore
+// instance is invoked and the hook queue is empty.
+func (f *GitserverRepoStoreListReposDueForMirrorFunc) SetDefaultHook(hook func(context.Context, string, int) ([]api.RepoName, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ListReposDueForMirror method of the parent MockGitserverRepoStore
+// instance invokes the hook at the front of the queue and discards it.
+// After the queue is empty, the default hook function is invoked for any
+// future action.
+func (f *GitserverRepoStoreListReposDueForMirrorFunc) PushHook(hook func(context.Context, string, int) ([]api.RepoName, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRepoStoreListReposDueForMirrorFunc) SetDefaultReturn(r0 []api.RepoName, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, int) ([]api.RepoName, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRepoStoreListReposDueForMirrorFunc) PushReturn(r0 []api.RepoName, r1 error) {
+	f.PushHook(func(context.Context, string, int) ([]api.RepoName, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverRepoStoreListReposDueForMirrorFunc) nextHook() func(context.Context, string, int) ([]api.RepoName, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRepoStoreListReposDueForMirrorFunc) appendCall(r0 GitserverRepoStoreListReposDueForMirrorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitserverRepoStoreListReposDueForMirrorFuncCall objects describing the
+// invocations of this function.
+func (f *GitserverRepoStoreListReposDueForMirrorFunc) History() []GitserverRepoStoreListReposDueForMirrorFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRepoStoreListReposDueForMirrorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRepoStoreListReposDueForMirrorFuncCall is an object that
+// describes an invocation of method ListReposDueForMirror on an instance of
+// MockGitserverRepoStore.
+type GitserverRepoStoreListReposDueForMirrorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []api.RepoName
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRepoStoreListReposDueForMirrorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRepoStoreListReposDueForMirrorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // GitserverRepoStoreListReposWithLastErrorFunc describes the behavior when
 // the ListReposWithLastError method of the parent MockGitserverRepoStore
 // instance is invoked.
@@ -28759,6 +29868,120 @@ func (c GitserverRepoStoreSetCloningProgressFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GitserverRepoStoreSetHealthReportFunc describes the behavior when the
+// SetHealthReport method of the parent MockGitserverRepoStore instance is
+// invoked.
+type GitserverRepoStoreSetHealthReportFunc struct {
+	defaultHook func(context.Context, api.RepoName, *types.RepoHealthReport, string) error
+	hooks       []func(context.Context, api.RepoName, *types.RepoHealthReport, string) error
+	history     []GitserverRepoStoreSetHealthReportFuncCall
+	mutex       sync.Mutex
+}
+
+// SetHealthReport delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverRepoStore) SetHealthReport(v0 context.Context, v1 api.RepoName, v2 *types.RepoHealthReport, v3 string) error {
+	r0 := m.SetHealthReportFunc.nextHook()(v0, v1, v2, v3)
+	m.SetHealthReportFunc.appendCall(GitserverRepoStoreSetHealthReportFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetHealthReport
+// method of the parent MockGitserverRepoStore instance is invoked and the
+// hook queue is empty.
+func (f *GitserverRepoStoreSetHealthReportFunc) SetDefaultHook(hook func(context.Context, api.RepoName, *types.RepoHealthReport, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetHealthReport method of the parent MockGitserverRepoStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverRepoStoreSetHealthReportFunc) PushHook(hook func(context.Context, api.RepoName, *types.RepoHealthReport, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRepoStoreSetHealthReportFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, *types.RepoHealthReport, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRepoStoreSetHealthReportFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoName, *types.RepoHealthReport, string) error {
+		return r0
+	})
+}
+
+func (f *GitserverRepoStoreSetHealthReportFunc) nextHook() func(context.Context, api.RepoName, *types.RepoHealthReport, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRepoStoreSetHealthReportFunc) appendCall(r0 GitserverRepoStoreSetHealthReportFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverRepoStoreSetHealthReportFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverRepoStoreSetHealthReportFunc) History() []GitserverRepoStoreSetHealthReportFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRepoStoreSetHealthReportFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRepoStoreSetHealthReportFuncCall is an object that describes an
+// invocation of method SetHealthReport on an instance of
+// MockGitserverRepoStore.
+type GitserverRepoStoreSetHealthReportFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 *types.RepoHealthReport
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRepoStoreSetHealthReportFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRepoStoreSetHealthReportFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // GitserverRepoStoreSetLastErrorFunc describes the behavior when the
 // SetLastError method of the parent MockGitserverRepoStore instance is
 // invoked.
@@ -28983,6 +30206,237 @@ func (c GitserverRepoStoreSetLastFetchedFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GitserverRepoStoreSetLastMirroredFunc describes the behavior when the
+// SetLastMirrored method of the parent MockGitserverRepoStore instance is
+// invoked.
+type GitserverRepoStoreSetLastMirroredFunc struct {
+	defaultHook func(context.Context, api.RepoName, time.Time, string) error
+	hooks       []func(context.Context, api.RepoName, time.Time, string) error
+	history     []GitserverRepoStoreSetLastMirroredFuncCall
+	mutex       sync.Mutex
+}
+
+// SetLastMirrored delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverRepoStore) SetLastMirrored(v0 context.Context, v1 api.RepoName, v2 time.Time, v3 string) error {
+	r0 := m.SetLastMirroredFunc.nextHook()(v0, v1, v2, v3)
+	m.SetLastMirroredFunc.appendCall(GitserverRepoStoreSetLastMirroredFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetLastMirrored
+// method of the parent MockGitserverRepoStore instance is invoked and the
+// hook queue is empty.
+func (f *GitserverRepoStoreSetLastMirroredFunc) SetDefaultHook(hook func(context.Context, api.RepoName, time.Time, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetLastMirrored method of the parent MockGitserverRepoStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverRepoStoreSetLastMirroredFunc) PushHook(hook func(context.Context, api.RepoName, time.Time, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRepoStoreSetLastMirroredFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, time.Time, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRepoStoreSetLastMirroredFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoName, time.Time, string) error {
+		return r0
+	})
+}
+
+func (f *GitserverRepoStoreSetLastMirroredFunc) nextHook() func(context.Context, api.RepoName, time.Time, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRepoStoreSetLastMirroredFunc) appendCall(r0 GitserverRepoStoreSetLastMirroredFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverRepoStoreSetLastMirroredFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverRepoStoreSetLastMirroredFunc) History() []GitserverRepoStoreSetLastMirroredFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRepoStoreSetLastMirroredFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRepoStoreSetLastMirroredFuncCall is an object that describes an
+// invocation of method SetLastMirrored on an instance of
+// MockGitserverRepoStore.
+type GitserverRepoStoreSetLastMirroredFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRepoStoreSetLastMirroredFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRepoStoreSetLastMirroredFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// GitserverRepoStoreSetMirrorErrorFunc describes the behavior when the
+// SetMirrorError method of the parent MockGitserverRepoStore instance is
+// invoked.
+type GitserverRepoStoreSetMirrorErrorFunc struct {
+	defaultHook func(context.Context, api.RepoName, string, time.Time, string) error
+	hooks       []func(context.Context, api.RepoName, string, time.Time, string) error
+	history     []GitserverRepoStoreSetMirrorErrorFuncCall
+	mutex       sync.Mutex
+}
+
+// SetMirrorError delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverRepoStore) SetMirrorError(v0 context.Context, v1 api.RepoName, v2 string, v3 time.Time, v4 string) error {
+	r0 := m.SetMirrorErrorFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.SetMirrorErrorFunc.appendCall(GitserverRepoStoreSetMirrorErrorFuncCall{v0, v1, v2, v3, v4, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetMirrorError
+// method of the parent MockGitserverRepoStore instance is invoked and the
+// hook queue is empty.
+func (f *GitserverRepoStoreSetMirrorErrorFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, time.Time, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetMirrorError method of the parent MockGitserverRepoStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverRepoStoreSetMirrorErrorFunc) PushHook(hook func(context.Context, api.RepoName, string, time.Time, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRepoStoreSetMirrorErrorFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, time.Time, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRepoStoreSetMirrorErrorFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoName, string, time.Time, string) error {
+		return r0
+	})
+}
+
+func (f *GitserverRepoStoreSetMirrorErrorFunc) nextHook() func(context.Context, api.RepoName, string, time.Time, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRepoStoreSetMirrorErrorFunc) appendCall(r0 GitserverRepoStoreSetMirrorErrorFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverRepoStoreSetMirrorErrorFuncCall
+// objects describing the invocations of this function.
+func (f *GitserverRepoStoreSetMirrorErrorFunc) History() []GitserverRepoStoreSetMirrorErrorFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRepoStoreSetMirrorErrorFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRepoStoreSetMirrorErrorFuncCall is an object that describes an
+// invocation of method SetMirrorError on an instance of
+// MockGitserverRepoStore.
+type GitserverRepoStoreSetMirrorErrorFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRepoStoreSetMirrorErrorFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRepoStoreSetMirrorErrorFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // GitserverRepoStoreSetRepoSizeFunc describes the behavior when the
 // SetRepoSize method of the parent MockGitserverRepoStore instance is
 // invoked.
@@ -29096,6 +30550,121 @@ func (c GitserverRepoStoreSetRepoSizeFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// GitserverRepoStoreSetTransferProgressFunc describes the behavior when the
+// SetTransferProgress method of the parent MockGitserverRepoStore instance
+// is invoked.
+type GitserverRepoStoreSetTransferProgressFunc struct {
+	defaultHook func(context.Context, api.RepoName, string, int64) error
+	hooks       []func(context.Context, api.RepoName, string, int64) error
+	history     []GitserverRepoStoreSetTransferProgressFuncCall
+	mutex       sync.Mutex
+}
+
+// SetTransferProgress delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockGitserverRepoStore) SetTransferProgress(v0 context.Context, v1 api.RepoName, v2 string, v3 int64) error {
+	r0 := m.SetTransferProgressFunc.nextHook()(v0, v1, v2, v3)
+	m.SetTransferProgressFunc.appendCall(GitserverRepoStoreSetTransferProgressFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the SetTransferProgress
+// method of the parent MockGitserverRepoStore instance is invoked and the
+// hook queue is empty.
+func (f *GitserverRepoStoreSetTransferProgressFunc) SetDefaultHook(hook func(context.Context, api.RepoName, string, int64) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// SetTransferProgress method of the parent MockGitserverRepoStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *GitserverRepoStoreSetTransferProgressFunc) PushHook(hook func(context.Context, api.RepoName, string, int64) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *GitserverRepoStoreSetTransferProgressFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, string, int64) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *GitserverRepoStoreSetTransferProgressFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoName, string, int64) error {
+		return r0
+	})
+}
+
+func (f *GitserverRepoStoreSetTransferProgressFunc) nextHook() func(context.Context, api.RepoName, string, int64) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverRepoStoreSetTransferProgressFunc) appendCall(r0 GitserverRepoStoreSetTransferProgressFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of
+// GitserverRepoStoreSetTransferProgressFuncCall objects describing the
+// invocations of this function.
+func (f *GitserverRepoStoreSetTransferProgressFunc) History() []GitserverRepoStoreSetTransferProgressFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverRepoStoreSetTransferProgressFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverRepoStoreSetTransferProgressFuncCall is an object that describes
+// an invocation of method SetTransferProgress on an instance of
+// MockGitserverRepoStore.
+type GitserverRepoStoreSetTransferProgressFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int64
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverRepoStoreSetTransferProgressFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverRepoStoreSetTransferProgressFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // GitserverRepoStoreTotalErroredCloudDefaultReposFunc describes the
 // behavior when the TotalErroredCloudDefaultRepos method of the parent
 // MockGitserverRepoStore instance is invoked.
@@ -45214,9 +46783,21 @@ type MockRepoPathStore struct {
 	// AggregateFileCountFunc is an instance of a mock function object
 	// controlling the behavior of the method AggregateFileCount.
 	AggregateFileCountFunc *RepoPathStoreAggregateFileCountFunc
+	// IteratePathIndexFunc is an instance of a mock function object
+	// controlling the behavior of the method IteratePathIndex.
+	IteratePathIndexFunc *RepoPathStoreIteratePathIndexFunc
+	// PatchPathIndexFunc is an instance of a mock function object
+	// controlling the behavior of the method PatchPathIndex.
+	PatchPathIndexFunc *RepoPathStorePatchPathIndexFunc
+	// PathIndexCommitsFunc is an instance of a mock function object
+	// controlling the behavior of the method PathIndexCommits.
+	PathIndexCommitsFunc *RepoPathStorePathIndexCommitsFunc
 	// UpdateFileCountsFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdateFileCounts.
 	UpdateFileCountsFunc *RepoPathStoreUpdateFileCountsFunc
+	// UpdatePathIndexFunc is an instance of a mock function object
+	// controlling the behavior of the method UpdatePathIndex.
+	UpdatePathIndexFunc *RepoPathStoreUpdatePathIndexFunc
 }
 
 // NewMockRepoPathStore creates a new mock of the RepoPathStore interface.
@@ -45228,11 +46809,31 @@ func NewMockRepoPathStore() *MockRepoPathStore {
 				return
 			},
 		},
+		IteratePathIndexFunc: &RepoPathStoreIteratePathIndexFunc{
+			defaultHook: func(context.Context, api.RepoID, []string, func(path string) error) (r0 error) {
+				return
+			},
+		},
+		PatchPathIndexFunc: &RepoPathStorePatchPathIndexFunc{
+			defaultHook: func(context.Context, api.RepoID, api.CommitID, api.CommitID, []string, []string) (r0 error) {
+				return
+			},
+		},
+		PathIndexCommitsFunc: &RepoPathStorePathIndexCommitsFunc{
+			defaultHook: func(context.Context, []api.RepoID) (r0 map[api.RepoID]api.CommitID, r1 error) {
+				return
+			},
+		},
 		UpdateFileCountsFunc: &RepoPathStoreUpdateFileCountsFunc{
 			defaultHook: func(context.Context, api.RepoID, RepoTreeCounts, time.Time) (r0 int, r1 error) {
 				return
 			},
 		},
+		UpdatePathIndexFunc: &RepoPathStoreUpdatePathIndexFunc{
+			defaultHook: func(context.Context, api.RepoID, api.CommitID, []string) (r0 error) {
+				return
+			},
+		},
 	}
 }
 
@@ -45245,11 +46846,31 @@ func NewStrictMockRepoPathStore() *MockRepoPathStore {
 				panic("unexpected invocation of MockRepoPathStore.AggregateFileCount")
 			},
 		},
+		IteratePathIndexFunc: &RepoPathStoreIteratePathIndexFunc{
+			defaultHook: func(context.Context, api.RepoID, []string, func(path string) error) error {
+				panic("unexpected invocation of MockRepoPathStore.IteratePathIndex")
+			},
+		},
+		PatchPathIndexFunc: &RepoPathStorePatchPathIndexFunc{
+			defaultHook: func(context.Context, api.RepoID, api.CommitID, api.CommitID, []string, []string) error {
+				panic("unexpected invocation of MockRepoPathStore.PatchPathIndex")
+			},
+		},
+		PathIndexCommitsFunc: &RepoPathStorePathIndexCommitsFunc{
+			defaultHook: func(context.Context, []api.RepoID) (map[api.RepoID]api.CommitID, error) {
+				panic("unexpected invocation of MockRepoPathStore.PathIndexCommits")
+			},
+		},
 		UpdateFileCountsFunc: &RepoPathStoreUpdateFileCountsFunc{
 			defaultHook: func(context.Context, api.RepoID, RepoTreeCounts, time.Time) (int, error) {
 				panic("unexpected invocation of MockRepoPathStore.UpdateFileCounts")
 			},
 		},
+		UpdatePathIndexFunc: &RepoPathStoreUpdatePathIndexFunc{
+			defaultHook: func(context.Context, api.RepoID, api.CommitID, []string) error {
+				panic("unexpected invocation of MockRepoPathStore.UpdatePathIndex")
+			},
+		},
 	}
 }
 
@@ -45261,9 +46882,21 @@ func NewMockRepoPathStoreFrom(i RepoPathStore) *MockRepoPathStore {
 		AggregateFileCountFunc: &RepoPathStoreAggregateFileCountFunc{
 			defaultHook: i.AggregateFileCount,
 		},
+		IteratePathIndexFunc: &RepoPathStoreIteratePathIndexFunc{
+			defaultHook: i.IteratePathIndex,
+		},
+		PatchPathIndexFunc: &RepoPathStorePatchPathIndexFunc{
+			defaultHook: i.PatchPathIndex,
+		},
+		PathIndexCommitsFunc: &RepoPathStorePathIndexCommitsFunc{
+			defaultHook: i.PathIndexCommits,
+		},
 		UpdateFileCountsFunc: &RepoPathStoreUpdateFileCountsFunc{
 			defaultHook: i.UpdateFileCounts,
 		},
+		UpdatePathIndexFunc: &RepoPathStoreUpdatePathIndexFunc{
+			defaultHook: i.UpdatePathIndex,
+		},
 	}
 }
 
@@ -45378,6 +47011,347 @@ func (c RepoPathStoreAggregateFileCountFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// RepoPathStoreIteratePathIndexFunc describes the behavior when the
+// IteratePathIndex method of the parent MockRepoPathStore instance is
+// invoked.
+type RepoPathStoreIteratePathIndexFunc struct {
+	defaultHook func(context.Context, api.RepoID, []string, func(path string) error) error
+	hooks       []func(context.Context, api.RepoID, []string, func(path string) error) error
+	history     []RepoPathStoreIteratePathIndexFuncCall
+	mutex       sync.Mutex
+}
+
+// IteratePathIndex delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoPathStore) IteratePathIndex(v0 context.Context, v1 api.RepoID, v2 []string, v3 func(path string) error) error {
+	r0 := m.IteratePathIndexFunc.nextHook()(v0, v1, v2, v3)
+	m.IteratePathIndexFunc.appendCall(RepoPathStoreIteratePathIndexFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the IteratePathIndex
+// method of the parent MockRepoPathStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoPathStoreIteratePathIndexFunc) SetDefaultHook(hook func(context.Context, api.RepoID, []string, func(path string) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// IteratePathIndex method of the parent MockRepoPathStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoPathStoreIteratePathIndexFunc) PushHook(hook func(context.Context, api.RepoID, []string, func(path string) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoPathStoreIteratePathIndexFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, []string, func(path string) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoPathStoreIteratePathIndexFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, []string, func(path string) error) error {
+		return r0
+	})
+}
+
+func (f *RepoPathStoreIteratePathIndexFunc) nextHook() func(context.Context, api.RepoID, []string, func(path string) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoPathStoreIteratePathIndexFunc) appendCall(r0 RepoPathStoreIteratePathIndexFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoPathStoreIteratePathIndexFuncCall
+// objects describing the invocations of this function.
+func (f *RepoPathStoreIteratePathIndexFunc) History() []RepoPathStoreIteratePathIndexFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoPathStoreIteratePathIndexFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoPathStoreIteratePathIndexFuncCall is an object that describes an
+// invocation of method IteratePathIndex on an instance of
+// MockRepoPathStore.
+type RepoPathStoreIteratePathIndexFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 []string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 func(path string) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoPathStoreIteratePathIndexFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoPathStoreIteratePathIndexFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoPathStorePatchPathIndexFunc describes the behavior when the
+// PatchPathIndex method of the parent MockRepoPathStore instance is
+// invoked.
+type RepoPathStorePatchPathIndexFunc struct {
+	defaultHook func(context.Context, api.RepoID, api.CommitID, api.CommitID, []string, []string) error
+	hooks       []func(context.Context, api.RepoID, api.CommitID, api.CommitID, []string, []string) error
+	history     []RepoPathStorePatchPathIndexFuncCall
+	mutex       sync.Mutex
+}
+
+// PatchPathIndex delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoPathStore) PatchPathIndex(v0 context.Context, v1 api.RepoID, v2 api.CommitID, v3 api.CommitID, v4 []string, v5 []string) error {
+	r0 := m.PatchPathIndexFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.PatchPathIndexFunc.appendCall(RepoPathStorePatchPathIndexFuncCall{v0, v1, v2, v3, v4, v5, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the PatchPathIndex
+// method of the parent MockRepoPathStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoPathStorePatchPathIndexFunc) SetDefaultHook(hook func(context.Context, api.RepoID, api.CommitID, api.CommitID, []string, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PatchPathIndex method of the parent MockRepoPathStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoPathStorePatchPathIndexFunc) PushHook(hook func(context.Context, api.RepoID, api.CommitID, api.CommitID, []string, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoPathStorePatchPathIndexFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, api.CommitID, api.CommitID, []string, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoPathStorePatchPathIndexFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, api.CommitID, api.CommitID, []string, []string) error {
+		return r0
+	})
+}
+
+func (f *RepoPathStorePatchPathIndexFunc) nextHook() func(context.Context, api.RepoID, api.CommitID, api.CommitID, []string, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoPathStorePatchPathIndexFunc) appendCall(r0 RepoPathStorePatchPathIndexFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoPathStorePatchPathIndexFuncCall objects
+// describing the invocations of this function.
+func (f *RepoPathStorePatchPathIndexFunc) History() []RepoPathStorePatchPathIndexFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoPathStorePatchPathIndexFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoPathStorePatchPathIndexFuncCall is an object that describes an
+// invocation of method PatchPathIndex on an instance of MockRepoPathStore.
+type RepoPathStorePatchPathIndexFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.CommitID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 api.CommitID
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 []string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoPathStorePatchPathIndexFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoPathStorePatchPathIndexFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// RepoPathStorePathIndexCommitsFunc describes the behavior when the
+// PathIndexCommits method of the parent MockRepoPathStore instance is
+// invoked.
+type RepoPathStorePathIndexCommitsFunc struct {
+	defaultHook func(context.Context, []api.RepoID) (map[api.RepoID]api.CommitID, error)
+	hooks       []func(context.Context, []api.RepoID) (map[api.RepoID]api.CommitID, error)
+	history     []RepoPathStorePathIndexCommitsFuncCall
+	mutex       sync.Mutex
+}
+
+// PathIndexCommits delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoPathStore) PathIndexCommits(v0 context.Context, v1 []api.RepoID) (map[api.RepoID]api.CommitID, error) {
+	r0, r1 := m.PathIndexCommitsFunc.nextHook()(v0, v1)
+	m.PathIndexCommitsFunc.appendCall(RepoPathStorePathIndexCommitsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the PathIndexCommits
+// method of the parent MockRepoPathStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoPathStorePathIndexCommitsFunc) SetDefaultHook(hook func(context.Context, []api.RepoID) (map[api.RepoID]api.CommitID, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// PathIndexCommits method of the parent MockRepoPathStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoPathStorePathIndexCommitsFunc) PushHook(hook func(context.Context, []api.RepoID) (map[api.RepoID]api.CommitID, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoPathStorePathIndexCommitsFunc) SetDefaultReturn(r0 map[api.RepoID]api.CommitID, r1 error) {
+	f.SetDefaultHook(func(context.Context, []api.RepoID) (map[api.RepoID]api.CommitID, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoPathStorePathIndexCommitsFunc) PushReturn(r0 map[api.RepoID]api.CommitID, r1 error) {
+	f.PushHook(func(context.Context, []api.RepoID) (map[api.RepoID]api.CommitID, error) {
+		return r0, r1
+	})
+}
+
+func (f *RepoPathStorePathIndexCommitsFunc) nextHook() func(context.Context, []api.RepoID) (map[api.RepoID]api.CommitID, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoPathStorePathIndexCommitsFunc) appendCall(r0 RepoPathStorePathIndexCommitsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoPathStorePathIndexCommitsFuncCall
+// objects describing the invocations of this function.
+func (f *RepoPathStorePathIndexCommitsFunc) History() []RepoPathStorePathIndexCommitsFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoPathStorePathIndexCommitsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoPathStorePathIndexCommitsFuncCall is an object that describes an
+// invocation of method PathIndexCommits on an instance of
+// MockRepoPathStore.
+type RepoPathStorePathIndexCommitsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []api.RepoID
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[api.RepoID]api.CommitID
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoPathStorePathIndexCommitsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoPathStorePathIndexCommitsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // RepoPathStoreUpdateFileCountsFunc describes the behavior when the
 // UpdateFileCounts method of the parent MockRepoPathStore instance is
 // invoked.
@@ -45494,6 +47468,118 @@ func (c RepoPathStoreUpdateFileCountsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// RepoPathStoreUpdatePathIndexFunc describes the behavior when the
+// UpdatePathIndex method of the parent MockRepoPathStore instance is
+// invoked.
+type RepoPathStoreUpdatePathIndexFunc struct {
+	defaultHook func(context.Context, api.RepoID, api.CommitID, []string) error
+	hooks       []func(context.Context, api.RepoID, api.CommitID, []string) error
+	history     []RepoPathStoreUpdatePathIndexFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdatePathIndex delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockRepoPathStore) UpdatePathIndex(v0 context.Context, v1 api.RepoID, v2 api.CommitID, v3 []string) error {
+	r0 := m.UpdatePathIndexFunc.nextHook()(v0, v1, v2, v3)
+	m.UpdatePathIndexFunc.appendCall(RepoPathStoreUpdatePathIndexFuncCall{v0, v1, v2, v3, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UpdatePathIndex
+// method of the parent MockRepoPathStore instance is invoked and the hook
+// queue is empty.
+func (f *RepoPathStoreUpdatePathIndexFunc) SetDefaultHook(hook func(context.Context, api.RepoID, api.CommitID, []string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdatePathIndex method of the parent MockRepoPathStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *RepoPathStoreUpdatePathIndexFunc) PushHook(hook func(context.Context, api.RepoID, api.CommitID, []string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *RepoPathStoreUpdatePathIndexFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoID, api.CommitID, []string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *RepoPathStoreUpdatePathIndexFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, api.RepoID, api.CommitID, []string) error {
+		return r0
+	})
+}
+
+func (f *RepoPathStoreUpdatePathIndexFunc) nextHook() func(context.Context, api.RepoID, api.CommitID, []string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *RepoPathStoreUpdatePathIndexFunc) appendCall(r0 RepoPathStoreUpdatePathIndexFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of RepoPathStoreUpdatePathIndexFuncCall
+// objects describing the invocations of this function.
+func (f *RepoPathStoreUpdatePathIndexFunc) History() []RepoPathStoreUpdatePathIndexFuncCall {
+	f.mutex.Lock()
+	history := make([]RepoPathStoreUpdatePathIndexFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// RepoPathStoreUpdatePathIndexFuncCall is an object that describes an
+// invocation of method UpdatePathIndex on an instance of MockRepoPathStore.
+type RepoPathStoreUpdatePathIndexFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoID
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.CommitID
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c RepoPathStoreUpdatePathIndexFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c RepoPathStoreUpdatePathIndexFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockRepoStatisticsStore is a mock implementation of the
 // RepoStatisticsStore interface (from the package
 // github.com/sourcegraph/sourcegraph/internal/database) used for unit
@@ -3,12 +3,15 @@ package database
 import (
 	"context"
 	"path"
+	"strings"
 	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
@@ -122,6 +125,20 @@ type RepoPathStore interface {
 	// setting only RepoID gives counts for repo root, while setting none gives counts for the whole
 	// instance. Lack of data counts as 0.
 	AggregateFileCount(context.Context, TreeLocationOpts) (int32, error)
+	// UpdatePathIndex replaces the path index of given repository with the paths
+	// of the files at commitID.
+	UpdatePathIndex(ctx context.Context, repoID api.RepoID, commitID api.CommitID, paths []string) error
+	// PatchPathIndex moves the path index of given repository from baseCommitID to
+	// commitID by adding and deleting the given paths. It returns ErrPathIndexConflict
+	// if the path index is not at baseCommitID.
+	PatchPathIndex(ctx context.Context, repoID api.RepoID, baseCommitID, commitID api.CommitID, added, deleted []string) error
+	// PathIndexCommits returns the commit the path index of each of given repositories
+	// was built from. Repositories without a path index are omitted.
+	PathIndexCommits(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]api.CommitID, error)
+	// IteratePathIndex calls f for every path in the path index of given repository
+	// which contains all of the given substrings, ignoring case, in lexicographic
+	// order. Iteration stops at the first error returned by f.
+	IteratePathIndex(ctx context.Context, repoID api.RepoID, substrings []string, f func(path string) error) error
 }
 
 var _ RepoPathStore = &repoPathStore{}
@@ -179,3 +196,140 @@ func (s *repoPathStore) AggregateFileCount(ctx context.Context, opts TreeLocatio
 	}
 	return count, nil
 }
+
+var updatePathIndexStateFmtstr = `
+	INSERT INTO repo_path_index_state (repo_id, commit_id, updated_at)
+	VALUES (%s, %s, NOW())
+	ON CONFLICT (repo_id) DO UPDATE
+	SET commit_id = EXCLUDED.commit_id,
+	updated_at = EXCLUDED.updated_at
+`
+
+func (s *repoPathStore) UpdatePathIndex(ctx context.Context, repoID api.RepoID, commitID api.CommitID, paths []string) error {
+	return s.WithTransact(ctx, func(tx *basestore.Store) error {
+		if err := tx.Exec(ctx, sqlf.Sprintf("DELETE FROM repo_path_index WHERE repo_id = %s", repoID)); err != nil {
+			return err
+		}
+		inserter := batch.NewInserter(ctx, tx.Handle(), "repo_path_index", batch.MaxNumPostgresParameters, "repo_id", "path")
+		for _, p := range paths {
+			if err := inserter.Insert(ctx, int32(repoID), p); err != nil {
+				return err
+			}
+		}
+		if err := inserter.Flush(ctx); err != nil {
+			return err
+		}
+		return tx.Exec(ctx, sqlf.Sprintf(updatePathIndexStateFmtstr, repoID, string(commitID)))
+	})
+}
+
+// ErrPathIndexConflict is returned by PatchPathIndex if the path index is not at
+// the expected commit.
+var ErrPathIndexConflict = errors.New("path index is not at the base commit")
+
+var patchPathIndexStateFmtstr = `
+	UPDATE repo_path_index_state
+	SET commit_id = %s,
+	updated_at = NOW()
+	WHERE repo_id = %s AND commit_id = %s
+`
+
+var patchPathIndexDeleteFmtstr = `
+	DELETE FROM repo_path_index
+	WHERE repo_id = %s AND path = ANY(%s)
+`
+
+var patchPathIndexInsertFmtstr = `
+	INSERT INTO repo_path_index (repo_id, path)
+	SELECT %s, unnest(%s::text[])
+	ON CONFLICT DO NOTHING
+`
+
+func (s *repoPathStore) PatchPathIndex(ctx context.Context, repoID api.RepoID, baseCommitID, commitID api.CommitID, added, deleted []string) error {
+	return s.WithTransact(ctx, func(tx *basestore.Store) error {
+		// Updating the state first locks it, so concurrent patches of the same
+		// repository are applied one after the other.
+		res, err := tx.ExecResult(ctx, sqlf.Sprintf(patchPathIndexStateFmtstr, string(commitID), repoID, string(baseCommitID)))
+		if err != nil {
+			return err
+		}
+		if rows, err := res.RowsAffected(); err != nil {
+			return err
+		} else if rows == 0 {
+			return ErrPathIndexConflict
+		}
+		if len(deleted) > 0 {
+			if err := tx.Exec(ctx, sqlf.Sprintf(patchPathIndexDeleteFmtstr, repoID, pq.Array(deleted))); err != nil {
+				return err
+			}
+		}
+		if len(added) > 0 {
+			return tx.Exec(ctx, sqlf.Sprintf(patchPathIndexInsertFmtstr, repoID, pq.Array(added)))
+		}
+		return nil
+	})
+}
+
+var pathIndexCommitsFmtstr = `
+	SELECT repo_id, commit_id
+	FROM repo_path_index_state
+	WHERE repo_id = ANY(%s)
+`
+
+func (s *repoPathStore) PathIndexCommits(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]api.CommitID, error) {
+	ids := make([]int32, 0, len(repoIDs))
+	for _, id := range repoIDs {
+		ids = append(ids, int32(id))
+	}
+	rows, err := s.Query(ctx, sqlf.Sprintf(pathIndexCommitsFmtstr, pq.Array(ids)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	commits := make(map[api.RepoID]api.CommitID, len(repoIDs))
+	for rows.Next() {
+		var (
+			repoID   api.RepoID
+			commitID api.CommitID
+		)
+		if err := rows.Scan(&repoID, &commitID); err != nil {
+			return nil, err
+		}
+		commits[repoID] = commitID
+	}
+	return commits, rows.Err()
+}
+
+var iteratePathIndexFmtstr = `
+	SELECT path
+	FROM repo_path_index
+	WHERE repo_id = %s AND %s
+	ORDER BY path
+`
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (s *repoPathStore) IteratePathIndex(ctx context.Context, repoID api.RepoID, substrings []string, f func(path string) error) error {
+	// Substrings are matched with the trigram index on lower(path).
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	for _, sub := range substrings {
+		conds = append(conds, sqlf.Sprintf("lower(path) LIKE %s", "%"+likeEscaper.Replace(strings.ToLower(sub))+"%"))
+	}
+	rows, err := s.Query(ctx, sqlf.Sprintf(iteratePathIndexFmtstr, repoID, sqlf.Join(conds, " AND ")))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			return err
+		}
+		if err := f(p); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
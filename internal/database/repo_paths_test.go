@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -102,3 +103,66 @@ func TestAggregateFileCounts(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, int32(counts1[""]+counts2[""]), count)
 }
+
+func TestPathIndex(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	logger := logtest.Scoped(t)
+	d := NewDB(logger, dbtest.NewDB(logger, t))
+	ctx := context.Background()
+	// Create repos
+	repo1 := mustCreate(ctx, t, d, &types.Repo{Name: "a/b"})
+	repo2 := mustCreate(ctx, t, d, &types.Repo{Name: "c/d"})
+
+	listPaths := func(repoID api.RepoID, substrings ...string) []string {
+		var paths []string
+		err := d.RepoPaths().IteratePathIndex(ctx, repoID, substrings, func(path string) error {
+			paths = append(paths, path)
+			return nil
+		})
+		require.NoError(t, err)
+		return paths
+	}
+
+	// Repos without a path index.
+	commits, err := d.RepoPaths().PathIndexCommits(ctx, []api.RepoID{repo1.ID, repo2.ID})
+	require.NoError(t, err)
+	assert.Empty(t, commits)
+	assert.Empty(t, listPaths(repo1.ID))
+
+	err = d.RepoPaths().UpdatePathIndex(ctx, repo1.ID, "c1", []string{"b.go", "a/a.go"})
+	require.NoError(t, err)
+	commits, err = d.RepoPaths().PathIndexCommits(ctx, []api.RepoID{repo1.ID, repo2.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[api.RepoID]api.CommitID{repo1.ID: "c1"}, commits)
+	assert.Equal(t, []string{"a/a.go", "b.go"}, listPaths(repo1.ID))
+
+	// Updating replaces the paths.
+	err = d.RepoPaths().UpdatePathIndex(ctx, repo1.ID, "c2", []string{"c.go"})
+	require.NoError(t, err)
+	commits, err = d.RepoPaths().PathIndexCommits(ctx, []api.RepoID{repo1.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[api.RepoID]api.CommitID{repo1.ID: "c2"}, commits)
+	assert.Equal(t, []string{"c.go"}, listPaths(repo1.ID))
+	assert.Empty(t, listPaths(repo2.ID))
+
+	// Patching adds and deletes paths.
+	err = d.RepoPaths().PatchPathIndex(ctx, repo1.ID, "c2", "c3", []string{"Dir/D_1.go", "d%2.go"}, []string{"c.go"})
+	require.NoError(t, err)
+	commits, err = d.RepoPaths().PathIndexCommits(ctx, []api.RepoID{repo1.ID})
+	require.NoError(t, err)
+	assert.Equal(t, map[api.RepoID]api.CommitID{repo1.ID: "c3"}, commits)
+	assert.Equal(t, []string{"Dir/D_1.go", "d%2.go"}, listPaths(repo1.ID))
+
+	// Patching an index which is not at the base commit fails.
+	err = d.RepoPaths().PatchPathIndex(ctx, repo1.ID, "c2", "c4", nil, []string{"d%2.go"})
+	assert.ErrorIs(t, err, ErrPathIndexConflict)
+	assert.Equal(t, []string{"Dir/D_1.go", "d%2.go"}, listPaths(repo1.ID))
+
+	// Substrings are matched ignoring case, and LIKE wildcards are escaped.
+	assert.Equal(t, []string{"Dir/D_1.go"}, listPaths(repo1.ID, "dir/", "d_"))
+	assert.Equal(t, []string{"d%2.go"}, listPaths(repo1.ID, "%"))
+	assert.Empty(t, listPaths(repo1.ID, "dir", "_2"))
+}
@@ -39,6 +39,7 @@ func NewGitserverAddressesFromConf(cfg *conf.Unified) GitserverAddresses {
 	}
 	if cfg.ExperimentalFeatures != nil {
 		addrs.PinnedServers = cfg.ExperimentalFeatures.GitServerPinnedRepos
+		addrs.ReplicationFactor = cfg.ExperimentalFeatures.GitServerReplicationFactor
 	}
 	return addrs
 }
@@ -51,6 +52,9 @@ type TestClientSourceOptions struct {
 	// Logger is the log.Logger instance that the test ClientSource will use to
 	// log various metadata to.
 	Logger log.Logger
+
+	// ReplicationFactor is the number of gitservers each repo is cloned to.
+	ReplicationFactor int
 }
 
 func NewTestClientSource(t *testing.T, addrs []string, options ...func(o *TestClientSourceOptions)) ClientSource {
@@ -83,7 +87,8 @@ func NewTestClientSource(t *testing.T, addrs []string, options ...func(o *TestCl
 	source := testGitserverConns{
 		conns: &GitserverConns{
 			GitserverAddresses: GitserverAddresses{
-				Addresses: addrs,
+				Addresses:         addrs,
+				ReplicationFactor: opts.ReplicationFactor,
 			},
 			grpcConns: conns,
 		},
@@ -112,6 +117,20 @@ func (c *testGitserverConns) Addresses() []AddressWithClient {
 	return c.testAddresses
 }
 
+// AddressesForRepo returns the gitserver addresses holding the given repo.
+func (c *testGitserverConns) AddressesForRepo(userAgent string, repo api.RepoName) []AddressWithClient {
+	addrs := c.conns.AddrsForRepo(userAgent, repo)
+	result := make([]AddressWithClient, 0, len(addrs))
+	for _, addr := range addrs {
+		for _, ta := range c.testAddresses {
+			if ta.Address() == addr {
+				result = append(result, ta)
+			}
+		}
+	}
+	return result
+}
+
 // ClientForRepo returns a client or host for the given repo name.
 func (c *testGitserverConns) ClientForRepo(userAgent string, repo api.RepoName) (proto.GitserverServiceClient, error) {
 	conn, err := c.conns.ConnForRepo(userAgent, repo)
@@ -154,6 +173,10 @@ type GitserverAddresses struct {
 	// ensures that, even if the number of gitservers changes, these repos will
 	// not be moved.
 	PinnedServers map[string]string
+
+	// ReplicationFactor is the number of gitservers each repo is cloned to.
+	// Values below 1 mean a repo is only cloned to its primary gitserver.
+	ReplicationFactor int
 }
 
 // AddrForRepo returns the gitserver address to use for the given repo name.
@@ -170,6 +193,55 @@ func (g GitserverAddresses) AddrForRepo(userAgent string, repo api.RepoName) str
 	return addrForKey(rs, g.Addresses)
 }
 
+// AddrsForRepo returns the addresses of the gitservers holding the given repo.
+// The first address is the primary gitserver of the repo, as returned by
+// AddrForRepo, and the others are its replicas in the order reads should fail
+// over to them.
+func (g GitserverAddresses) AddrsForRepo(userAgent string, repo api.RepoName) []string {
+	if len(g.Addresses) == 0 {
+		return nil
+	}
+
+	primary := g.AddrForRepo(userAgent, repo)
+	addrs := []string{primary}
+	if g.ReplicationFactor <= 1 || !slices.Contains(g.Addresses, primary) {
+		return addrs
+	}
+
+	// The replicas are the other gitservers ranking highest for the repo by
+	// rendezvous hashing. Changing the replication factor does not move any
+	// primary, and adding or removing a gitserver only moves the replicas
+	// it ranks for.
+	key := string(protocol.NormalizeRepo(repo))
+	type candidate struct {
+		addr   string
+		weight uint64
+	}
+	candidates := make([]candidate, 0, len(g.Addresses)-1)
+	for _, addr := range g.Addresses {
+		if addr != primary {
+			candidates = append(candidates, candidate{addr: addr, weight: rendezvousWeight(key, addr)})
+		}
+	}
+	slices.SortFunc(candidates, func(a, b candidate) bool {
+		if a.weight != b.weight {
+			return a.weight > b.weight
+		}
+		return a.addr < b.addr
+	})
+	for i := 0; i < len(candidates) && len(addrs) < g.ReplicationFactor; i++ {
+		addrs = append(addrs, candidates[i].addr)
+	}
+	return addrs
+}
+
+// rendezvousWeight returns the weight of the gitserver at addr for the given
+// string key. The gitservers with the highest weights hold the key.
+func rendezvousWeight(key, addr string) uint64 {
+	sum := md5.Sum([]byte(key + "\x00" + addr))
+	return binary.BigEndian.Uint64(sum[:])
+}
+
 // addrForKey returns the gitserver address to use for the given string key,
 // which is hashed for sharding purposes.
 func addrForKey(key string, addrs []string) string {
@@ -247,6 +319,25 @@ func (a *atomicGitServerConns) Addresses() []AddressWithClient {
 	return addrs
 }
 
+func (a *atomicGitServerConns) AddressesForRepo(userAgent string, repo api.RepoName) []AddressWithClient {
+	conns := a.get()
+	addrs := conns.AddrsForRepo(userAgent, repo)
+	result := make([]AddressWithClient, 0, len(addrs))
+	for _, addr := range addrs {
+		ce, ok := conns.grpcConns[addr]
+		if !ok {
+			// A pinned gitserver which isn't one of the gitserver addresses.
+			ce.err = errors.Newf("no gRPC connection found for address %q", addr)
+		}
+		result = append(result, &connAndErr{
+			address: addr,
+			conn:    ce.conn,
+			err:     ce.err,
+		})
+	}
+	return result
+}
+
 func (a *atomicGitServerConns) get() *GitserverConns {
 	a.initOnce()
 	return a.conns.Load()
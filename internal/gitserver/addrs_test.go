@@ -1,8 +1,11 @@
 package gitserver
 
 import (
+	"fmt"
 	"testing"
 
+	"golang.org/x/exp/slices"
+
 	"github.com/sourcegraph/sourcegraph/internal/api"
 )
 
@@ -50,3 +53,111 @@ func TestAddrForRepo(t *testing.T) {
 		})
 	}
 }
+
+func TestAddrsForRepo(t *testing.T) {
+	ga := GitserverAddresses{
+		Addresses: []string{"gitserver-1", "gitserver-2", "gitserver-3"},
+		PinnedServers: map[string]string{
+			"repo2": "gitserver-1",
+			"repo3": "gitserver-4",
+		},
+		ReplicationFactor: 2,
+	}
+
+	testCases := []struct {
+		name string
+		repo api.RepoName
+		want []string
+	}{
+		{
+			name: "replica",
+			repo: api.RepoName("repo1"),
+			want: []string{"gitserver-3", "gitserver-1"},
+		},
+		{
+			name: "another repo",
+			repo: api.RepoName("github.com/sourcegraph/sourcegraph.git"),
+			want: []string{"gitserver-2", "gitserver-3"},
+		},
+		{
+			name: "pinned repo",
+			repo: api.RepoName("repo2"),
+			want: []string{"gitserver-1", "gitserver-3"},
+		},
+		{
+			name: "pinned to unknown server",
+			repo: api.RepoName("repo3"),
+			want: []string{"gitserver-4"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ga.AddrsForRepo("gitserver", tc.repo)
+			if !slices.Equal(got, tc.want) {
+				t.Fatalf("Want %q, got %q", tc.want, got)
+			}
+		})
+	}
+
+	t.Run("replication factor larger than number of gitservers", func(t *testing.T) {
+		ga := ga
+		ga.ReplicationFactor = 5
+		got := ga.AddrsForRepo("gitserver", "repo1")
+		want := []string{"gitserver-3", "gitserver-1", "gitserver-2"}
+		if !slices.Equal(got, want) {
+			t.Fatalf("Want %q, got %q", want, got)
+		}
+	})
+
+	t.Run("replicas are stable", func(t *testing.T) {
+		// Adding a gitserver only moves the replicas it ranks for.
+		ga := GitserverAddresses{Addresses: []string{"gitserver-1", "gitserver-2", "gitserver-3", "gitserver-4"}, ReplicationFactor: 2}
+		grown := ga
+		grown.Addresses = append(slices.Clone(ga.Addresses), "gitserver-5")
+		larger := ga
+		larger.ReplicationFactor = 3
+
+		moved := 0
+		for i := 0; i < 1000; i++ {
+			repo := api.RepoName(fmt.Sprintf("github.com/foo/repo%d", i))
+			before := ga.AddrsForRepo("gitserver", repo)
+
+			// Increasing the replication factor only adds replicas.
+			if got := larger.AddrsForRepo("gitserver", repo); !slices.Equal(got[:2], before) {
+				t.Fatalf("%s: replicas moved when increasing the replication factor: %q -> %q", repo, before, got)
+			}
+
+			after := grown.AddrsForRepo("gitserver", repo)
+			if before[0] != after[0] || after[1] == "gitserver-5" {
+				continue
+			}
+			if before[1] != after[1] {
+				moved++
+			}
+		}
+		if moved > 0 {
+			t.Fatalf("%d replicas moved between existing gitservers", moved)
+		}
+	})
+}
+
+func TestAddressesForRepo_PinnedToUnknownServer(t *testing.T) {
+	var a atomicGitServerConns
+	a.watchOnce.Do(func() {})
+	a.conns.Store(&GitserverConns{
+		GitserverAddresses: GitserverAddresses{
+			Addresses:     []string{"gitserver-1"},
+			PinnedServers: map[string]string{"repo": "gitserver-2"},
+		},
+		grpcConns: map[string]connAndErr{"gitserver-1": {}},
+	})
+
+	addrs := a.AddressesForRepo("gitserver", "repo")
+	if len(addrs) != 1 || addrs[0].Address() != "gitserver-2" {
+		t.Fatalf("unexpected addresses %v", addrs)
+	}
+	if _, err := addrs[0].GRPCClient(); err == nil {
+		t.Fatal("expected an error for a gitserver without a connection")
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -34,17 +35,23 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	internalgrpc "github.com/sourcegraph/sourcegraph/internal/grpc"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/streamio"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/limiter"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/xcontext"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
 const git = "git"
 
+// replicaUpdateTimeout is how long we let a replica of a repo update it after
+// the update of its primary gitserver was requested.
+const replicaUpdateTimeout = 5 * time.Minute
+
 var (
 	clientFactory  = httpcli.NewInternalClientFactory("gitserver")
 	defaultDoer, _ = clientFactory.Doer()
@@ -78,6 +85,9 @@ type ClientSource interface {
 	AddrForRepo(userAgent string, repo api.RepoName) string
 	// Address the current list of gitserver addresses.
 	Addresses() []AddressWithClient
+	// AddressesForRepo returns the gitservers holding the given repo. The
+	// first one is the primary gitserver of the repo, followed by its replicas.
+	AddressesForRepo(userAgent string, repo api.RepoName) []AddressWithClient
 }
 
 // NewClient returns a new gitserver.Client.
@@ -326,6 +336,11 @@ type Client interface {
 	// Stat returns a FileInfo describing the named file at commit.
 	Stat(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, path string) (fs.FileInfo, error)
 
+	// BlobOIDs returns the OIDs of the blobs of the files at paths at commit
+	// with a single git command. Paths which aren't files at commit are
+	// missing from the returned map.
+	BlobOIDs(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, paths []string) (map[string]gitdomain.OID, error)
+
 	// DiffPath returns a position-ordered slice of changes (additions or deletions)
 	// of the given path between the given source and target commits.
 	DiffPath(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, sourceCommit, targetCommit, path string) ([]*diff.Hunk, error)
@@ -365,7 +380,18 @@ type Client interface {
 
 	// ReadFile returns the first maxBytes of the named file at commit. If maxBytes <= 0, the entire
 	// file is read. (If you just need to check a file's existence, use Stat, not ReadFile.)
-	ReadFile(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, name string) ([]byte, error)
+	ReadFile(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, name string, opts ReadFileOptions) ([]byte, error)
+
+	// ReadLFSObject returns the contents of the Git LFS object pointer points to
+	// in repo. gitserver fetches the object from the code host of repo and
+	// caches it.
+	ReadLFSObject(ctx context.Context, repo api.RepoName, pointer gitdomain.LFSPointer) ([]byte, error)
+
+	// ReadFiles reads the named files at commit and calls onFile with the
+	// contents of each of them, in the order of names. Files which don't exist
+	// or which the actor can't access are skipped. Unlike calling ReadFile for
+	// each file, all files are read by a single git process on gitserver.
+	ReadFiles(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, names []string, onFile func(name string, content []byte) error) error
 
 	// BranchesContaining returns a map from branch names to branch tip hashes for
 	// each branch containing the given commit.
@@ -402,6 +428,12 @@ type Client interface {
 
 	CommitLog(ctx context.Context, repo api.RepoName, after time.Time) ([]CommitLog, error)
 
+	// CommitLogForPaths returns the log of the commits reachable from commit
+	// which modified any of paths, newest first. The changed files of each
+	// commit only include paths. If after is not empty, only commits after
+	// that date are returned.
+	CommitLogForPaths(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, after string, paths []string) ([]CommitLog, error)
+
 	// CommitsUniqueToBranch returns a map from commits that exist on a particular
 	// branch in the given repository to their committer date. This set of commits is
 	// determined by listing `{branchName} ^HEAD`, which is interpreted as: all
@@ -468,6 +500,60 @@ func (c *clientImplementor) ConnForRepo(repo api.RepoName) (*grpc.ClientConn, er
 	return c.clientSource.ConnForRepo(c.userAgent, repo)
 }
 
+var replicaFailoverCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_gitserver_client_replica_failover",
+	Help: "Times that a read failed over to a replica because a gitserver was unavailable",
+})
+
+// unavailableError marks an error after which a read can be retried on a
+// replica of the repo, because the gitserver could not be reached before it
+// served any of the read.
+type unavailableError struct{ error }
+
+// failover returns err marked as an unavailableError if it means that the
+// gitserver could not be reached.
+func failover(err error) error {
+	if isUnavailable(err) {
+		return unavailableError{err}
+	}
+	return err
+}
+
+// isUnavailable returns true if err means that a gitserver could not be
+// reached.
+func isUnavailable(err error) bool {
+	if status.Code(err) == codes.Unavailable {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// withFailover calls f with the gitservers holding repo, starting with its
+// primary gitserver, until f returns an error which is not an
+// unavailableError. Reads use it to fail over to the replicas of repo.
+func (c *clientImplementor) withFailover(repo api.RepoName, f func(AddressWithClient) error) error {
+	addrs := c.clientSource.AddressesForRepo(c.userAgent, repo)
+	if len(addrs) == 0 {
+		return errors.Newf("no gitserver found for repo %q", repo)
+	}
+
+	var err error
+	for i, addr := range addrs {
+		if i > 0 {
+			replicaFailoverCounter.Inc()
+			c.logger.Warn("gitserver unavailable, failing over to replica", sglog.String("repo", string(repo)), sglog.String("replica", addr.Address()), sglog.Error(err))
+		}
+		err = f(addr)
+		u, ok := err.(unavailableError)
+		if !ok {
+			return err
+		}
+		err = u.error
+	}
+	return err
+}
+
 // ArchiveOptions contains options for the Archive func.
 type ArchiveOptions struct {
 	Treeish   string               // the tree or commit to produce an archive for
@@ -552,8 +638,8 @@ func (a *archiveReader) Close() error {
 }
 
 // archiveURL returns a URL from which an archive of the given Git repository can
-// be downloaded from.
-func (c *clientImplementor) archiveURL(repo api.RepoName, opt ArchiveOptions) *url.URL {
+// be downloaded from the gitserver at addr.
+func (c *clientImplementor) archiveURL(addr string, repo api.RepoName, opt ArchiveOptions) *url.URL {
 	q := url.Values{
 		"repo":    {string(repo)},
 		"treeish": {opt.Treeish},
@@ -564,10 +650,9 @@ func (c *clientImplementor) archiveURL(repo api.RepoName, opt ArchiveOptions) *u
 		q.Add("path", string(pathspec))
 	}
 
-	addrForRepo := c.AddrForRepo(repo)
 	return &url.URL{
 		Scheme:   "http",
-		Host:     addrForRepo,
+		Host:     addr,
 		Path:     "/archive",
 		RawQuery: q.Encode(),
 	}
@@ -602,11 +687,6 @@ func (c *RemoteGitCommand) sendExec(ctx context.Context) (_ io.ReadCloser, err e
 	}
 
 	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.execer.ClientForRepo(repoName)
-		if err != nil {
-			return nil, err
-		}
-
 		req := &proto.ExecRequest{
 			Repo:           string(repoName),
 			EnsureRevision: c.EnsureRevision(),
@@ -615,12 +695,39 @@ func (c *RemoteGitCommand) sendExec(ctx context.Context) (_ io.ReadCloser, err e
 			NoTimeout:      c.noTimeout,
 		}
 
-		stream, err := client.Exec(ctx, req)
+		var (
+			stream    proto.GitserverService_ExecClient
+			firstMsg  *proto.ExecResponse
+			firstErr  error
+			firstRead bool
+		)
+		err := c.execer.withFailover(repoName, func(addr AddressWithClient) error {
+			client, err := addr.GRPCClient()
+			if err != nil {
+				return err
+			}
+			stream, err = client.Exec(ctx, req)
+			if err != nil {
+				return failover(err)
+			}
+			// Errors only surface once we receive from the stream, so we
+			// receive the first message here to fail over to a replica if
+			// the gitserver is unavailable.
+			firstMsg, firstErr = stream.Recv()
+			if isUnavailable(firstErr) {
+				return failover(firstErr)
+			}
+			return nil
+		})
 		if err != nil {
 			return nil, err
 		}
 		r := streamio.NewReader(func() ([]byte, error) {
-			msg, err := stream.Recv()
+			msg, err := firstMsg, firstErr
+			if firstRead {
+				msg, err = stream.Recv()
+			}
+			firstRead = true
 			if status.Code(err) == codes.Canceled {
 				return nil, context.Canceled
 			} else if err != nil {
@@ -639,7 +746,11 @@ func (c *RemoteGitCommand) sendExec(ctx context.Context) (_ io.ReadCloser, err e
 			Stdin:          c.stdin,
 			NoTimeout:      c.noTimeout,
 		}
-		resp, err := c.execer.httpPost(ctx, repoName, "exec", req)
+		var resp *http.Response
+		err := c.execer.withFailover(repoName, func(addr AddressWithClient) (err error) {
+			resp, err = c.execer.httpPostTo(ctx, addr.Address(), repoName, "exec", req)
+			return failover(err)
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -755,35 +866,44 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 	repoName := protocol.NormalizeRepo(args.Repo)
 
 	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.ClientForRepo(repoName)
-		if err != nil {
-			return false, err
-		}
-
-		cs, err := client.Search(ctx, args.ToProto())
-		if err != nil {
-			return false, convertGitserverError(err)
-		}
-
 		limitHit := false
-		for {
-			msg, err := cs.Recv()
+		err := c.withFailover(repoName, func(addr AddressWithClient) error {
+			client, err := addr.GRPCClient()
 			if err != nil {
-				return limitHit, convertGitserverError(err)
+				return err
 			}
 
-			switch m := msg.Message.(type) {
-			case *proto.SearchResponse_LimitHit:
-				limitHit = limitHit || m.LimitHit
-			case *proto.SearchResponse_Match:
-				onMatches([]protocol.CommitMatch{protocol.CommitMatchFromProto(m.Match)})
-			default:
-				return false, errors.Newf("unknown message type %T", m)
+			cs, err := client.Search(ctx, args.ToProto())
+			if err != nil {
+				return failover(convertGitserverError(err))
 			}
-		}
-	}
 
-	addrForRepo := c.AddrForRepo(repoName)
+			// We can only fail over to a replica as long as we did not
+			// receive anything from the gitserver.
+			received := false
+			for {
+				msg, err := cs.Recv()
+				if err != nil {
+					if received {
+						return convertGitserverError(err)
+					}
+					return failover(convertGitserverError(err))
+				}
+				received = true
+
+				switch m := msg.Message.(type) {
+				case *proto.SearchResponse_LimitHit:
+					limitHit = limitHit || m.LimitHit
+				case *proto.SearchResponse_Match:
+					onMatches([]protocol.CommitMatch{protocol.CommitMatchFromProto(m.Match)})
+				default:
+					limitHit = false
+					return errors.Newf("unknown message type %T", m)
+				}
+			}
+		})
+		return limitHit, err
+	}
 
 	protocol.RegisterGob()
 	var buf bytes.Buffer
@@ -792,8 +912,12 @@ func (c *clientImplementor) Search(ctx context.Context, args *protocol.SearchReq
 		return false, err
 	}
 
-	uri := "http://" + addrForRepo + "/search"
-	resp, err := c.do(ctx, repoName, "POST", uri, buf.Bytes())
+	var resp *http.Response
+	err = c.withFailover(repoName, func(addr AddressWithClient) (err error) {
+		uri := "http://" + addr.Address() + "/search"
+		resp, err = c.do(ctx, repoName, "POST", uri, buf.Bytes())
+		return failover(err)
+	})
 	if err != nil {
 		return false, err
 	}
@@ -1112,8 +1236,31 @@ func (c *clientImplementor) RequestRepoUpdate(ctx context.Context, repo api.Repo
 		Since: since,
 	}
 
+	addrs := c.clientSource.AddressesForRepo(c.userAgent, repo)
+	if len(addrs) == 0 {
+		return nil, errors.Newf("no gitserver found for repo %q", repo)
+	}
+
+	// The replicas of the repo are cloned and kept up to date by sending them
+	// the same updates as its primary gitserver. We don't wait for them, so
+	// only the response of the primary gitserver is returned.
+	for _, addr := range addrs[1:] {
+		addr := addr
+		goroutine.Go(func() {
+			ctx, cancel := context.WithTimeout(xcontext.Detach(ctx), replicaUpdateTimeout)
+			defer cancel()
+			if _, err := c.requestRepoUpdate(ctx, addr, req); err != nil {
+				c.logger.Warn("failed to update replica", sglog.String("repo", string(repo)), sglog.String("replica", addr.Address()), sglog.Error(err))
+			}
+		})
+	}
+
+	return c.requestRepoUpdate(ctx, addrs[0], req)
+}
+
+func (c *clientImplementor) requestRepoUpdate(ctx context.Context, addr AddressWithClient, req *protocol.RepoUpdateRequest) (*protocol.RepoUpdateResponse, error) {
 	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.ClientForRepo(repo)
+		client, err := addr.GRPCClient()
 		if err != nil {
 			return nil, err
 		}
@@ -1129,7 +1276,7 @@ func (c *clientImplementor) RequestRepoUpdate(ctx context.Context, repo api.Repo
 		return &info, nil
 
 	} else {
-		resp, err := c.httpPost(ctx, repo, "repo-update", req)
+		resp, err := c.httpPostTo(ctx, addr.Address(), req.Repo, "repo-update", req)
 		if err != nil {
 			return nil, err
 		}
@@ -1455,19 +1602,25 @@ func (c *clientImplementor) Remove(ctx context.Context, repo api.RepoName) error
 	// In case the repo has already been deleted from the database we need to pass
 	// the old name in order to land on the correct gitserver instance
 	repo = api.UndeletedRepoName(repo)
-	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.ClientForRepo(repo)
-		if err != nil {
-			return err
+
+	// The repo is removed from its replicas as well.
+	var errs error
+	for _, addr := range c.clientSource.AddressesForRepo(c.userAgent, repo) {
+		if internalgrpc.IsGRPCEnabled(ctx) {
+			client, err := addr.GRPCClient()
+			if err != nil {
+				errs = errors.Append(errs, err)
+				continue
+			}
+			_, err = client.RepoDelete(ctx, &proto.RepoDeleteRequest{
+				Repo: string(repo),
+			})
+			errs = errors.Append(errs, err)
+		} else {
+			errs = errors.Append(errs, c.RemoveFrom(ctx, repo, addr.Address()))
 		}
-		_, err = client.RepoDelete(ctx, &proto.RepoDeleteRequest{
-			Repo: string(repo),
-		})
-		return err
-	} else {
-		addr := c.AddrForRepo(repo)
-		return c.RemoveFrom(ctx, repo, addr)
 	}
+	return errs
 }
 
 func (c *clientImplementor) RemoveFrom(ctx context.Context, repo api.RepoName, from string) error {
@@ -1498,13 +1651,18 @@ func (c *clientImplementor) RemoveFrom(ctx context.Context, repo api.RepoName, f
 // httpPost will apply the MD5 hashing scheme on the repo name to determine the gitserver instance
 // to which the HTTP POST request is sent.
 func (c *clientImplementor) httpPost(ctx context.Context, repo api.RepoName, op string, payload any) (resp *http.Response, err error) {
+	return c.httpPostTo(ctx, c.AddrForRepo(repo), repo, op, payload)
+}
+
+// httpPostTo is like httpPost, but sends the request to the gitserver instance
+// at addr.
+func (c *clientImplementor) httpPostTo(ctx context.Context, addr string, repo api.RepoName, op string, payload any) (resp *http.Response, err error) {
 	b, err := json.Marshal(payload)
 	if err != nil {
 		return nil, err
 	}
 
-	addrForRepo := c.AddrForRepo(repo)
-	uri := "http://" + addrForRepo + "/" + op
+	uri := "http://" + addr + "/" + op
 	return c.do(ctx, repo, "POST", uri, b)
 }
 
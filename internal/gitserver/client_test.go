@@ -29,6 +29,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/sourcegraph/log/logtest"
 
@@ -56,6 +57,7 @@ func newMockDB() database.DB {
 	db := database.NewMockDB()
 	db.GitserverReposFunc.SetDefaultReturn(database.NewMockGitserverRepoStore())
 	db.FeatureFlagsFunc.SetDefaultReturn(database.NewMockFeatureFlagStore())
+	db.RepoPathsFunc.SetDefaultReturn(database.NewMockRepoPathStore())
 
 	r := database.NewMockRepoStore()
 	r.GetByNameFunc.SetDefaultHook(func(ctx context.Context, repoName api.RepoName) (*types.Repo, error) {
@@ -1439,6 +1441,357 @@ func TestGitserverClient_RepoClone(t *testing.T) {
 	}
 }
 
+func TestClient_ReadFileFailoverGRPC(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExperimentalFeatures: &schema.ExperimentalFeatures{
+				EnableGRPC: true,
+			},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	addrs := []string{"172.16.8.1:8080", "172.16.8.2:8080"}
+	repo := api.RepoName("github.com/sourcegraph/sourcegraph")
+	primary := gitserver.GitserverAddresses{Addresses: addrs}.AddrForRepo("test", repo)
+
+	mkClient := func(t *testing.T, replicationFactor int) (gitserver.Client, map[string]int) {
+		t.Helper()
+
+		called := map[string]int{}
+		var mu sync.Mutex
+		source := gitserver.NewTestClientSource(t, addrs, func(o *gitserver.TestClientSourceOptions) {
+			o.ReplicationFactor = replicationFactor
+			o.ClientFunc = func(cc *grpc.ClientConn) proto.GitserverServiceClient {
+				addr := cc.Target()
+				mockExec := func(ctx context.Context, in *proto.ExecRequest, opts ...grpc.CallOption) (proto.GitserverService_ExecClient, error) {
+					mu.Lock()
+					called[addr]++
+					mu.Unlock()
+					if addr == primary {
+						return &mockExecClient{err: status.Error(codes.Unavailable, "connection refused")}, nil
+					}
+					return &mockExecClient{data: []byte("hello")}, nil
+				}
+				return &mockClient{mockExec: mockExec}
+			}
+		})
+		return gitserver.NewTestClient(http.DefaultClient, source), called
+	}
+
+	t.Run("fails over to replica", func(t *testing.T) {
+		cli, called := mkClient(t, 2)
+		data, err := cli.ReadFile(context.Background(), nil, repo, "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef", "README.md", gitserver.ReadFileOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "hello", string(data))
+		require.Len(t, called, 2)
+	})
+
+	t.Run("no replicas", func(t *testing.T) {
+		cli, called := mkClient(t, 1)
+		_, err := cli.ReadFile(context.Background(), nil, repo, "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef", "README.md", gitserver.ReadFileOptions{})
+		require.Error(t, err)
+		require.Equal(t, map[string]int{primary: 1}, called)
+	})
+}
+
+func TestClient_ReadFilesGRPC(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExperimentalFeatures: &schema.ExperimentalFeatures{
+				EnableGRPC: true,
+			},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	repo := api.RepoName("github.com/sourcegraph/sourcegraph")
+	commit := api.CommitID("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef")
+
+	var req *proto.BatchReadFilesRequest
+	source := gitserver.NewTestClientSource(t, []string{"172.16.8.1:8080"}, func(o *gitserver.TestClientSourceOptions) {
+		o.ClientFunc = func(cc *grpc.ClientConn) proto.GitserverServiceClient {
+			mockBatchReadFiles := func(ctx context.Context, in *proto.BatchReadFilesRequest, opts ...grpc.CallOption) (proto.GitserverService_BatchReadFilesClient, error) {
+				req = in
+				return &mockBatchReadFilesClient{responses: []*proto.BatchReadFilesResponse{
+					{Path: "README.md", Data: []byte("hello ")},
+					{Path: "README.md", Data: []byte("world")},
+					{Path: "missing.go", NotFound: true},
+					{Path: "empty.txt"},
+					{Path: "main.go", Data: []byte("package main")},
+				}}, nil
+			}
+			return &mockClient{mockBatchReadFiles: mockBatchReadFiles}
+		}
+	})
+	cli := gitserver.NewTestClient(http.DefaultClient, source)
+
+	files := map[string]string{}
+	var order []string
+	err := cli.ReadFiles(context.Background(), nil, repo, commit, []string{"/README.md", "missing.go", "empty.txt", "main.go", "README.md"}, func(name string, content []byte) error {
+		files[name] = string(content)
+		order = append(order, name)
+		return nil
+	})
+	require.NoError(t, err)
+
+	// Duplicate paths are only requested once, relative to the root.
+	require.Equal(t, []string{"README.md", "missing.go", "empty.txt", "main.go"}, req.GetPaths())
+	require.Equal(t, []string{"/README.md", "empty.txt", "main.go"}, order)
+	require.Equal(t, map[string]string{
+		"/README.md": "hello world",
+		"empty.txt":  "",
+		"main.go":    "package main",
+	}, files)
+
+	t.Run("revision not found", func(t *testing.T) {
+		source := gitserver.NewTestClientSource(t, []string{"172.16.8.1:8080"}, func(o *gitserver.TestClientSourceOptions) {
+			o.ClientFunc = func(cc *grpc.ClientConn) proto.GitserverServiceClient {
+				mockBatchReadFiles := func(ctx context.Context, in *proto.BatchReadFilesRequest, opts ...grpc.CallOption) (proto.GitserverService_BatchReadFilesClient, error) {
+					return &mockBatchReadFilesClient{err: status.Error(codes.NotFound, "revision not found")}, nil
+				}
+				return &mockClient{mockBatchReadFiles: mockBatchReadFiles}
+			}
+		})
+		cli := gitserver.NewTestClient(http.DefaultClient, source)
+
+		err := cli.ReadFiles(context.Background(), nil, repo, commit, []string{"README.md"}, func(string, []byte) error {
+			t.Fatal("unexpected file")
+			return nil
+		})
+		require.True(t, errors.HasType(err, &gitdomain.RevisionNotFoundError{}), "unexpected error: %v", err)
+	})
+}
+
+func TestClient_ReadFileResolveLFSGRPC(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExperimentalFeatures: &schema.ExperimentalFeatures{
+				EnableGRPC: true,
+			},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	repo := api.RepoName("github.com/sourcegraph/sourcegraph")
+	commit := api.CommitID("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef")
+	oid := "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+	pointer := "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 11\n"
+
+	mkClient := func(t *testing.T, lfsErr error) (gitserver.Client, **proto.ReadLFSObjectRequest) {
+		t.Helper()
+
+		var req *proto.ReadLFSObjectRequest
+		source := gitserver.NewTestClientSource(t, []string{"172.16.8.1:8080"}, func(o *gitserver.TestClientSourceOptions) {
+			o.ClientFunc = func(cc *grpc.ClientConn) proto.GitserverServiceClient {
+				mockExec := func(ctx context.Context, in *proto.ExecRequest, opts ...grpc.CallOption) (proto.GitserverService_ExecClient, error) {
+					return &mockExecClient{data: []byte(pointer)}, nil
+				}
+				mockReadLFSObject := func(ctx context.Context, in *proto.ReadLFSObjectRequest, opts ...grpc.CallOption) (proto.GitserverService_ReadLFSObjectClient, error) {
+					req = in
+					if lfsErr != nil {
+						return &mockReadLFSObjectClient{err: lfsErr}, nil
+					}
+					return &mockReadLFSObjectClient{responses: []*proto.ReadLFSObjectResponse{
+						{Data: []byte("hello ")},
+						{Data: []byte("world")},
+					}}, nil
+				}
+				return &mockClient{mockExec: mockExec, mockReadLFSObject: mockReadLFSObject}
+			}
+		})
+		return gitserver.NewTestClient(http.DefaultClient, source), &req
+	}
+
+	t.Run("resolves pointers", func(t *testing.T) {
+		cli, req := mkClient(t, nil)
+		data, err := cli.ReadFile(context.Background(), nil, repo, commit, "asset.txt", gitserver.ReadFileOptions{ResolveLFS: true})
+		require.NoError(t, err)
+		require.Equal(t, "hello world", string(data))
+		require.Equal(t, string(repo), (*req).GetRepo())
+		require.Equal(t, oid, (*req).GetOid())
+		require.Equal(t, int64(11), (*req).GetSize())
+	})
+
+	t.Run("returns pointers without the option", func(t *testing.T) {
+		cli, req := mkClient(t, nil)
+		data, err := cli.ReadFile(context.Background(), nil, repo, commit, "asset.txt", gitserver.ReadFileOptions{})
+		require.NoError(t, err)
+		require.Equal(t, pointer, string(data))
+		require.Nil(t, *req)
+	})
+
+	t.Run("returns the pointer if the object can't be read", func(t *testing.T) {
+		cli, _ := mkClient(t, status.Error(codes.FailedPrecondition, "LFS object is too large"))
+		data, err := cli.ReadFile(context.Background(), nil, repo, commit, "asset.txt", gitserver.ReadFileOptions{ResolveLFS: true})
+		require.NoError(t, err)
+		require.Equal(t, pointer, string(data))
+	})
+
+	t.Run("does not read objects larger than their pointer", func(t *testing.T) {
+		cli, _ := mkClient(t, nil)
+		_, err := cli.ReadLFSObject(context.Background(), repo, gitdomain.LFSPointer{OID: oid, Size: 5})
+		require.Error(t, err)
+	})
+
+	t.Run("does not read objects larger than the maximum size", func(t *testing.T) {
+		cli, req := mkClient(t, nil)
+		_, err := cli.ReadLFSObject(context.Background(), repo, gitdomain.LFSPointer{OID: oid, Size: gitdomain.LFSObjectMaxSize + 1})
+		require.Error(t, err)
+		require.Nil(t, *req)
+	})
+}
+
+func TestClient_BlameFileGRPC(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExperimentalFeatures: &schema.ExperimentalFeatures{
+				EnableGRPC: true,
+			},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	repo := api.RepoName("github.com/sourcegraph/sourcegraph")
+	commit := api.CommitID("deadbeefdeadbeefdeadbeefdeadbeefdeadbeef")
+	date := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mkClient := func(t *testing.T, blameErr error) (gitserver.Client, **proto.BlameRequest) {
+		t.Helper()
+
+		var req *proto.BlameRequest
+		source := gitserver.NewTestClientSource(t, []string{"172.16.8.1:8080"}, func(o *gitserver.TestClientSourceOptions) {
+			o.ClientFunc = func(cc *grpc.ClientConn) proto.GitserverServiceClient {
+				mockBlame := func(ctx context.Context, in *proto.BlameRequest, opts ...grpc.CallOption) (proto.GitserverService_BlameClient, error) {
+					req = in
+					if blameErr != nil {
+						return &mockBlameClient{err: blameErr}, nil
+					}
+					author := &proto.CommitMatch_Signature{Name: "a", Email: "a@a.com", Date: timestamppb.New(date)}
+					return &mockBlameClient{responses: []*proto.BlameResponse{
+						{Hunk: &proto.BlameHunk{StartLine: 1, EndLine: 3, StartByte: 0, EndByte: 12, Commit: "a", Author: author, Message: "first", Filename: "f"}},
+						{Hunk: &proto.BlameHunk{StartLine: 3, EndLine: 4, StartByte: 12, EndByte: 18, Commit: "b", Author: author, Message: "second", Filename: "f2"}},
+					}}, nil
+				}
+				return &mockClient{mockBlame: mockBlame}
+			}
+		})
+		return gitserver.NewTestClient(http.DefaultClient, source), &req
+	}
+
+	wantHunks := []*gitserver.Hunk{
+		{StartLine: 1, EndLine: 3, StartByte: 0, EndByte: 12, CommitID: "a", Author: gitdomain.Signature{Name: "a", Email: "a@a.com", Date: date}, Message: "first", Filename: "f"},
+		{StartLine: 3, EndLine: 4, StartByte: 12, EndByte: 18, CommitID: "b", Author: gitdomain.Signature{Name: "a", Email: "a@a.com", Date: date}, Message: "second", Filename: "f2"},
+	}
+
+	t.Run("BlameFile", func(t *testing.T) {
+		cli, req := mkClient(t, nil)
+		hunks, err := cli.BlameFile(context.Background(), nil, repo, "f2", &gitserver.BlameOptions{NewestCommit: commit, StartLine: 1, EndLine: 3})
+		require.NoError(t, err)
+		require.Equal(t, wantHunks, hunks)
+		require.Equal(t, string(commit), (*req).GetCommit())
+		require.Equal(t, "f2", (*req).GetPath())
+		require.Equal(t, uint32(1), (*req).GetStartLine())
+		require.Equal(t, uint32(3), (*req).GetEndLine())
+	})
+
+	t.Run("StreamBlameFile", func(t *testing.T) {
+		cli, _ := mkClient(t, nil)
+		hr, err := cli.StreamBlameFile(context.Background(), nil, repo, "f2", &gitserver.BlameOptions{NewestCommit: commit})
+		require.NoError(t, err)
+		defer hr.Close()
+
+		var hunks []*gitserver.Hunk
+		for {
+			h, err := hr.Read()
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			require.NoError(t, err)
+			hunks = append(hunks, h)
+		}
+		require.Equal(t, wantHunks, hunks)
+	})
+
+	t.Run("revision not found", func(t *testing.T) {
+		cli, _ := mkClient(t, status.Error(codes.NotFound, "revision not found"))
+		_, err := cli.BlameFile(context.Background(), nil, repo, "f2", &gitserver.BlameOptions{NewestCommit: commit})
+		require.True(t, errors.HasType(err, &gitdomain.RevisionNotFoundError{}), "unexpected error: %v", err)
+	})
+}
+
+type mockBlameClient struct {
+	responses []*proto.BlameResponse
+	err       error
+	grpc.ClientStream
+}
+
+func (m *mockBlameClient) Recv() (*proto.BlameResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if len(m.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+type mockReadLFSObjectClient struct {
+	responses []*proto.ReadLFSObjectResponse
+	err       error
+	grpc.ClientStream
+}
+
+func (m *mockReadLFSObjectClient) Recv() (*proto.ReadLFSObjectResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if len(m.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+type mockBatchReadFilesClient struct {
+	responses []*proto.BatchReadFilesResponse
+	err       error
+	grpc.ClientStream
+}
+
+func (m *mockBatchReadFilesClient) Recv() (*proto.BatchReadFilesResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if len(m.responses) == 0 {
+		return nil, io.EOF
+	}
+	resp := m.responses[0]
+	m.responses = m.responses[1:]
+	return resp, nil
+}
+
+type mockExecClient struct {
+	data []byte
+	err  error
+	grpc.ClientStream
+}
+
+func (m *mockExecClient) Recv() (*proto.ExecResponse, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.data == nil {
+		return nil, io.EOF
+	}
+	data := m.data
+	m.data = nil
+	return &proto.ExecResponse{Data: data}, nil
+}
+
 type mockClient struct {
 	mockBatchLog                    func(ctx context.Context, in *proto.BatchLogRequest, opts ...grpc.CallOption) (*proto.BatchLogResponse, error)
 	mockCreateCommitFromPatchBinary func(ctx context.Context, in *proto.CreateCommitFromPatchBinaryRequest, opts ...grpc.CallOption) (*proto.CreateCommitFromPatchBinaryResponse, error)
@@ -1454,6 +1807,10 @@ type mockClient struct {
 	mockArchive                     func(ctx context.Context, in *proto.ArchiveRequest, opts ...grpc.CallOption) (proto.GitserverService_ArchiveClient, error)
 	mockSearch                      func(ctx context.Context, in *proto.SearchRequest, opts ...grpc.CallOption) (proto.GitserverService_SearchClient, error)
 	mockP4Exec                      func(ctx context.Context, in *proto.P4ExecRequest, opts ...grpc.CallOption) (proto.GitserverService_P4ExecClient, error)
+	mockBatchReadFiles              func(ctx context.Context, in *proto.BatchReadFilesRequest, opts ...grpc.CallOption) (proto.GitserverService_BatchReadFilesClient, error)
+	mockReadLFSObject               func(ctx context.Context, in *proto.ReadLFSObjectRequest, opts ...grpc.CallOption) (proto.GitserverService_ReadLFSObjectClient, error)
+	mockBlame                       func(ctx context.Context, in *proto.BlameRequest, opts ...grpc.CallOption) (proto.GitserverService_BlameClient, error)
+	mockRepoPack                    func(ctx context.Context, in *proto.RepoPackRequest, opts ...grpc.CallOption) (proto.GitserverService_RepoPackClient, error)
 }
 
 // BatchLog implements v1.GitserverServiceClient.
@@ -1524,6 +1881,26 @@ func (mc *mockClient) Archive(ctx context.Context, in *proto.ArchiveRequest, opt
 	return mc.mockArchive(ctx, in, opts...)
 }
 
+// BatchReadFiles implements v1.GitserverServiceClient
+func (mc *mockClient) BatchReadFiles(ctx context.Context, in *proto.BatchReadFilesRequest, opts ...grpc.CallOption) (proto.GitserverService_BatchReadFilesClient, error) {
+	return mc.mockBatchReadFiles(ctx, in, opts...)
+}
+
+// ReadLFSObject implements v1.GitserverServiceClient
+func (mc *mockClient) ReadLFSObject(ctx context.Context, in *proto.ReadLFSObjectRequest, opts ...grpc.CallOption) (proto.GitserverService_ReadLFSObjectClient, error) {
+	return mc.mockReadLFSObject(ctx, in, opts...)
+}
+
+// Blame implements v1.GitserverServiceClient
+func (mc *mockClient) Blame(ctx context.Context, in *proto.BlameRequest, opts ...grpc.CallOption) (proto.GitserverService_BlameClient, error) {
+	return mc.mockBlame(ctx, in, opts...)
+}
+
+// RepoPack implements v1.GitserverServiceClient
+func (mc *mockClient) RepoPack(ctx context.Context, in *proto.RepoPackRequest, opts ...grpc.CallOption) (proto.GitserverService_RepoPackClient, error) {
+	return mc.mockRepoPack(ctx, in, opts...)
+}
+
 var _ proto.GitserverServiceClient = &mockClient{}
 
 var _ proto.GitserverService_P4ExecClient = &mockP4ExecClient{}
@@ -24,8 +24,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/sourcegraph/go-diff/diff"
+	sglog "github.com/sourcegraph/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
@@ -34,6 +37,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/fileutil"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
 	internalgrpc "github.com/sourcegraph/sourcegraph/internal/grpc"
 	"github.com/sourcegraph/sourcegraph/internal/grpc/streamio"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
@@ -303,9 +307,7 @@ func (c *clientImplementor) CommitGraph(ctx context.Context, repo api.RepoName,
 	return gitdomain.ParseCommitGraph(strings.Split(string(out), "\n")), nil
 }
 
-// CommitLog returns the repository commit log, including the file paths that were changed. The general approach to parsing
-// is to separate the first line (the metadata line) from the remaining lines (the files), and then parse the metadata line
-// into component parts separately.
+// CommitLog returns the repository commit log, including the file paths that were changed.
 func (c *clientImplementor) CommitLog(ctx context.Context, repo api.RepoName, after time.Time) ([]CommitLog, error) {
 	args := []string{"log", "--pretty=format:%H<!>%ae<!>%an<!>%ad", "--name-only", "--topo-order", "--no-merges"}
 	if !after.IsZero() {
@@ -318,6 +320,124 @@ func (c *clientImplementor) CommitLog(ctx context.Context, repo api.RepoName, af
 		return nil, errors.Wrap(err, "gitCommand")
 	}
 
+	return parseCommitLog(out)
+}
+
+// CommitLogForPaths returns the log of the commits reachable from commit which
+// modified any of paths, newest first. The changed files of each commit only
+// include paths. If after is not empty, only commits after that date are
+// returned.
+func (c *clientImplementor) CommitLogForPaths(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, after string, paths []string) (_ []CommitLog, err error) {
+	ctx, _, endObservation := c.operations.commitLogForPaths.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("repo", string(repo)),
+		attribute.String("commit", string(commit)),
+		attribute.String("after", after),
+		attribute.Int("paths", len(paths)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	rev := string(commit)
+	if rev == "" {
+		rev = "HEAD"
+	}
+	if err := checkSpecArgSafety(rev); err != nil {
+		return nil, err
+	}
+	paths, err = filterPaths(ctx, checker, repo, paths)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	args := []string{"log", "--pretty=format:%H<!>%ae<!>%an<!>%ad", "--name-only", "--no-merges"}
+	if after != "" {
+		args = append(args, "--after="+after)
+	}
+	args = append(args, rev, "--")
+	args = append(args, paths...)
+
+	cmd := c.gitCommand(repo, args...)
+	out, stderr, err := cmd.DividedOutput(ctx)
+	if err != nil {
+		if isBadObjectErr(string(stderr), rev) {
+			return nil, &gitdomain.RevisionNotFoundError{Repo: repo, Spec: rev}
+		}
+		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args(), stderr))
+	}
+
+	return parseCommitLog(out)
+}
+
+func (c *clientImplementor) BlobOIDs(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, paths []string) (_ map[string]gitdomain.OID, err error) {
+	ctx, _, endObservation := c.operations.blobOIDs.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("repo", string(repo)),
+		attribute.String("commit", string(commit)),
+		attribute.Int("paths", len(paths)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := checkSpecArgSafety(string(commit)); err != nil {
+		return nil, err
+	}
+	paths, err = filterPaths(ctx, checker, repo, paths)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, nil
+	}
+
+	// git ls-tree matches paths literally and lists the entries of files in
+	// subdirectories without recursing into other directories.
+	args := append([]string{"ls-tree", "-z", "--full-name", string(commit), "--"}, paths...)
+	cmd := c.gitCommand(repo, args...)
+	out, stderr, err := cmd.DividedOutput(ctx)
+	if err != nil {
+		if isBadObjectErr(string(stderr), string(commit)) {
+			return nil, &gitdomain.RevisionNotFoundError{Repo: repo, Spec: string(commit)}
+		}
+		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args(), stderr))
+	}
+
+	return parseBlobOIDs(out)
+}
+
+// parseBlobOIDs parses the output of git ls-tree -z into a map from the path
+// of each blob to its OID.
+func parseBlobOIDs(out []byte) (map[string]gitdomain.OID, error) {
+	oids := make(map[string]gitdomain.OID)
+	for _, entry := range bytes.Split(out, []byte{0}) {
+		if len(entry) == 0 {
+			continue
+		}
+		// <mode> SP <type> SP <object> TAB <file>
+		info, path, ok := bytes.Cut(entry, []byte{'\t'})
+		if !ok {
+			return nil, errors.Errorf("unexpected ls-tree entry %q", entry)
+		}
+		fields := bytes.Fields(info)
+		if len(fields) != 3 {
+			return nil, errors.Errorf("unexpected ls-tree entry %q", entry)
+		}
+		if string(fields[1]) != "blob" {
+			continue
+		}
+		oid, err := decodeOID(string(fields[2]))
+		if err != nil {
+			return nil, err
+		}
+		oids[string(path)] = oid
+	}
+	return oids, nil
+}
+
+// parseCommitLog parses the output of git log with the format of CommitLog.
+// The general approach to parsing is to separate the first line (the metadata
+// line) from the remaining lines (the files), and then parse the metadata line
+// into component parts separately.
+func parseCommitLog(out []byte) ([]CommitLog, error) {
 	var ls []CommitLog
 	lines := strings.Split(string(out), "\n\n")
 
@@ -329,9 +449,16 @@ func (c *clientImplementor) CommitLog(ctx context.Context, repo api.RepoName, af
 		metaLine := partitions[0]
 		var changedFiles []string
 		for _, pt := range partitions[1:] {
-			if pt != "" {
-				changedFiles = append(changedFiles, pt)
+			if pt == "" {
+				continue
 			}
+			// git quotes paths with unusual characters like a C string.
+			if strings.HasPrefix(pt, `"`) {
+				if unquoted, err := strconv.Unquote(pt); err == nil {
+					pt = unquoted
+				}
+			}
+			changedFiles = append(changedFiles, pt)
 		}
 
 		parts := strings.Split(metaLine, "<!>")
@@ -748,6 +875,18 @@ func (c *clientImplementor) StreamBlameFile(ctx context.Context, checker authz.S
 	})
 	defer endObservation(1, observation.Args{})
 
+	if useBlameRPC(ctx, opt) {
+		a := actor.FromContext(ctx)
+		hasAccess, err := authz.FilterActorPath(ctx, checker, a, repo, path)
+		if err != nil {
+			return nil, err
+		}
+		if !hasAccess {
+			return nil, errUnauthorizedStreamBlame{Repo: repo}
+		}
+		return c.blame(ctx, repo, path, opt)
+	}
+
 	return streamBlameFileCmd(ctx, checker, repo, path, opt, c.gitserverGitCommandFunc(repo))
 }
 
@@ -803,9 +942,85 @@ func (c *clientImplementor) BlameFile(ctx context.Context, checker authz.SubRepo
 	})
 	defer endObservation(1, observation.Args{})
 
+	if useBlameRPC(ctx, opt) {
+		a := actor.FromContext(ctx)
+		if hasAccess, err := authz.FilterActorPath(ctx, checker, a, repo, path); err != nil || !hasAccess {
+			return nil, err
+		}
+		hr, err := c.blame(ctx, repo, path, opt)
+		if err != nil {
+			return nil, err
+		}
+		defer hr.Close()
+
+		var hunks []*Hunk
+		for {
+			h, err := hr.Read()
+			if errors.Is(err, io.EOF) {
+				return hunks, nil
+			} else if err != nil {
+				return nil, err
+			}
+			hunks = append(hunks, h)
+		}
+	}
+
 	return blameFileCmd(ctx, checker, c.gitserverGitCommandFunc(repo), path, opt, repo)
 }
 
+// useBlameRPC returns true if a blame with opt uses the Blame RPC of
+// gitserver, which caches blames per commit and path. Blames of revisions
+// which aren't absolute run git blame on gitserver instead.
+func useBlameRPC(ctx context.Context, opt *BlameOptions) bool {
+	return internalgrpc.IsGRPCEnabled(ctx) && opt != nil && IsAbsoluteRevision(string(opt.NewestCommit))
+}
+
+// blame returns a HunkReader reading the hunks of the blame of path which
+// gitserver sends with the Blame RPC.
+func (c *clientImplementor) blame(ctx context.Context, repo api.RepoName, path string, opt *BlameOptions) (HunkReader, error) {
+	req := &proto.BlameRequest{
+		Repo:      string(repo),
+		Commit:    string(opt.NewestCommit),
+		Path:      filepath.ToSlash(path),
+		StartLine: uint32(opt.StartLine),
+		EndLine:   uint32(opt.EndLine),
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	var hr *grpcBlameHunkReader
+	err := c.withFailover(repo, func(addr AddressWithClient) error {
+		client, err := addr.GRPCClient()
+		if err != nil {
+			return err
+		}
+
+		stream, err := client.Blame(ctx, req)
+		if err != nil {
+			return failover(convertGitserverError(err))
+		}
+
+		// We can only fail over to a replica as long as we did not receive
+		// anything from the gitserver, so we wait for the first hunk here.
+		first, err := stream.Recv()
+		if err != nil && !errors.Is(err, io.EOF) {
+			err = convertGitserverError(err)
+			// A repo which doesn't exist is converted, so this means that
+			// the commit doesn't exist.
+			if status.Code(err) == codes.NotFound {
+				return &gitdomain.RevisionNotFoundError{Repo: repo, Spec: string(opt.NewestCommit)}
+			}
+			return failover(err)
+		}
+		hr = &grpcBlameHunkReader{stream: stream, next: first, done: err != nil, cancel: cancel}
+		return nil
+	})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return hr, nil
+}
+
 func blameFileCmd(ctx context.Context, checker authz.SubRepoPermissionChecker, command gitCommandFunc, path string, opt *BlameOptions, repo api.RepoName) ([]*Hunk, error) {
 	a := actor.FromContext(ctx)
 	if hasAccess, err := authz.FilterActorPath(ctx, checker, a, repo, path); err != nil || !hasAccess {
@@ -1369,13 +1584,23 @@ func (c *clientImplementor) GetBehindAhead(ctx context.Context, repo api.RepoNam
 	return &gitdomain.BehindAhead{Behind: uint32(b), Ahead: uint32(a)}, nil
 }
 
+// ReadFileOptions contains options for ReadFile.
+type ReadFileOptions struct {
+	// ResolveLFS makes ReadFile return the contents of the Git LFS object a
+	// pointer file points to instead of the pointer. The pointer is returned if
+	// the object can't be fetched, e.g. because it's larger than gitserver
+	// allows or the code host doesn't serve LFS objects.
+	ResolveLFS bool
+}
+
 // ReadFile returns the first maxBytes of the named file at commit. If maxBytes <= 0, the entire
 // file is read. (If you just need to check a file's existence, use Stat, not ReadFile.)
-func (c *clientImplementor) ReadFile(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, name string) (_ []byte, err error) {
+func (c *clientImplementor) ReadFile(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, name string, opts ReadFileOptions) (_ []byte, err error) {
 	ctx, _, endObservation := c.operations.readFile.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
 		attribute.String("repo", string(repo)),
 		attribute.String("commit", string(commit)),
 		attribute.String("name", name),
+		attribute.Bool("resolveLFS", opts.ResolveLFS),
 	}})
 	defer endObservation(1, observation.Args{})
 
@@ -1390,9 +1615,226 @@ func (c *clientImplementor) ReadFile(ctx context.Context, checker authz.SubRepoP
 	if err != nil {
 		return nil, err
 	}
+
+	if opts.ResolveLFS {
+		if pointer, ok := gitdomain.ParseLFSPointer(data); ok {
+			object, err := c.ReadLFSObject(ctx, repo, pointer)
+			if err == nil {
+				return object, nil
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			c.logger.Warn("failed to resolve LFS pointer", sglog.String("repo", string(repo)), sglog.String("oid", pointer.OID), sglog.Error(err))
+		}
+	}
 	return data, nil
 }
 
+// errLFSObjectSizeMismatch is returned if gitserver sends more data for an LFS
+// object than its pointer says it has.
+var errLFSObjectSizeMismatch = errors.New("LFS object is larger than its pointer")
+
+// ReadLFSObject returns the contents of the Git LFS object pointer points to
+// in repo. Objects larger than gitdomain.LFSObjectMaxSize are not read.
+func (c *clientImplementor) ReadLFSObject(ctx context.Context, repo api.RepoName, pointer gitdomain.LFSPointer) (_ []byte, err error) {
+	ctx, _, endObservation := c.operations.readLFSObject.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("repo", string(repo)),
+		attribute.String("oid", pointer.OID),
+		attribute.Int64("size", pointer.Size),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if pointer.Size < 0 || pointer.Size > gitdomain.LFSObjectMaxSize {
+		return nil, errors.Newf("LFS object size %d is out of range", pointer.Size)
+	}
+
+	req := protocol.ReadLFSObjectRequest{Repo: repo, OID: pointer.OID, Size: pointer.Size}
+
+	if !internalgrpc.IsGRPCEnabled(ctx) {
+		resp, err := c.httpPost(ctx, repo, "lfs-object", req)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+			return nil, errors.Errorf("ReadLFSObject: http status %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+		}
+		object, err := io.ReadAll(io.LimitReader(resp.Body, pointer.Size+1))
+		if err == nil && int64(len(object)) > pointer.Size {
+			return nil, errLFSObjectSizeMismatch
+		}
+		return object, err
+	}
+
+	var object []byte
+	err = c.withFailover(repo, func(addr AddressWithClient) error {
+		client, err := addr.GRPCClient()
+		if err != nil {
+			return err
+		}
+
+		stream, err := client.ReadLFSObject(ctx, req.ToProto())
+		if err != nil {
+			return failover(convertGitserverError(err))
+		}
+
+		// The buffer grows as data arrives rather than being allocated for
+		// the size the pointer claims upfront.
+		object = nil
+		received := false
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil
+				}
+				// We can only fail over to a replica as long as we did not
+				// receive anything from the gitserver.
+				if received {
+					return convertGitserverError(err)
+				}
+				return failover(convertGitserverError(err))
+			}
+			received = true
+			if int64(len(object)+len(msg.GetData())) > pointer.Size {
+				return errLFSObjectSizeMismatch
+			}
+			object = append(object, msg.GetData()...)
+		}
+	})
+	return object, err
+}
+
+// ReadFiles reads the named files at commit and calls onFile with the contents
+// of each of them, in the order of names. Files which don't exist or which the
+// actor can't access are skipped.
+func (c *clientImplementor) ReadFiles(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, names []string, onFile func(name string, content []byte) error) (err error) {
+	ctx, _, endObservation := c.operations.readFiles.With(ctx, &err, observation.Args{Attrs: []attribute.KeyValue{
+		attribute.String("repo", string(repo)),
+		attribute.String("commit", string(commit)),
+		attribute.Int("names", len(names)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if err := gitdomain.EnsureAbsoluteCommit(commit); err != nil {
+		return err
+	}
+
+	// gitserver reads the files by their path relative to the repository
+	// root, but onFile gets the name they were requested with.
+	a := actor.FromContext(ctx)
+	paths := make([]string, 0, len(names))
+	namesByPath := make(map[string]string, len(names))
+	for _, name := range names {
+		if hasAccess, err := authz.FilterActorPath(ctx, checker, a, repo, name); err != nil {
+			return err
+		} else if !hasAccess {
+			continue
+		}
+		path := rel(name)
+		if _, ok := namesByPath[path]; ok {
+			continue
+		}
+		namesByPath[path] = name
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	if !internalgrpc.IsGRPCEnabled(ctx) {
+		for _, path := range paths {
+			content, err := c.readBlob(ctx, repo, commit, path)
+			if err != nil {
+				if os.IsNotExist(err) {
+					continue
+				}
+				return err
+			}
+			if err := onFile(namesByPath[path], content); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return c.withFailover(repo, func(addr AddressWithClient) error {
+		client, err := addr.GRPCClient()
+		if err != nil {
+			return err
+		}
+
+		stream, err := client.BatchReadFiles(ctx, &proto.BatchReadFilesRequest{
+			Repo:   string(repo),
+			Commit: string(commit),
+			Paths:  paths,
+		})
+		if err != nil {
+			return failover(convertGitserverError(err))
+		}
+
+		// Files are sent one after the other, each in one or more chunks.
+		var (
+			path     string
+			content  []byte
+			received bool
+		)
+		flush := func() error {
+			if path == "" {
+				return nil
+			}
+			name := namesByPath[path]
+			path = ""
+			return onFile(name, content)
+		}
+		for {
+			msg, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return flush()
+				}
+				err = convertGitserverError(err)
+				// A repo which doesn't exist is converted, so this means
+				// that commit doesn't exist.
+				if status.Code(err) == codes.NotFound {
+					return &gitdomain.RevisionNotFoundError{Repo: repo, Spec: string(commit)}
+				}
+				// We can only fail over to a replica as long as we did not
+				// receive anything from the gitserver.
+				if received {
+					return err
+				}
+				return failover(err)
+			}
+			received = true
+
+			if msg.GetPath() != path || msg.GetNotFound() {
+				if err := flush(); err != nil {
+					return err
+				}
+				if msg.GetNotFound() {
+					continue
+				}
+				path = msg.GetPath()
+				content = make([]byte, 0, len(msg.GetData()))
+			}
+			content = append(content, msg.GetData()...)
+		}
+	})
+}
+
+// readBlob returns the contents of the file at path at commit.
+func (c *clientImplementor) readBlob(ctx context.Context, repo api.RepoName, commit api.CommitID, path string) ([]byte, error) {
+	br, err := c.newBlobReader(ctx, repo, commit, path)
+	if err != nil {
+		return nil, err
+	}
+	defer br.Close()
+	return io.ReadAll(br)
+}
+
 // NewFileReader returns an io.ReadCloser reading from the named file at commit.
 // The caller should always close the reader after use
 func (c *clientImplementor) NewFileReader(ctx context.Context, checker authz.SubRepoPermissionChecker, repo api.RepoName, commit api.CommitID, name string) (_ io.ReadCloser, err error) {
@@ -2534,49 +2976,64 @@ func (c *clientImplementor) ArchiveReader(
 	}
 
 	if internalgrpc.IsGRPCEnabled(ctx) {
-		client, err := c.clientSource.ClientForRepo(c.userAgent, repo)
-		if err != nil {
-			return nil, err
-		}
-
 		req := options.ToProto(string(repo)) // HACK: ArchiveOptions doesn't have a repository here, so we have to add it ourselves.
 
-		ctx, cancel := context.WithCancel(ctx)
-
-		stream, err := client.Archive(ctx, req)
-		if err != nil {
-			cancel()
-			return nil, err
-		}
-
-		// first message from the gRPC stream needs to be read to check for errors before continuing
-		// to read the rest of the stream. If the first message is an error, we cancel the stream
-		// and return the error.
-		//
-		// This is necessary to provide parity between the REST and gRPC implementations of
-		// ArchiveReader. Users of cli.ArchiveReader may assume error handling occurs immediately,
-		// as is the case with the HTTP implementation where errors are returned as soon as the
-		// function returns. gRPC is asynchronous, so we have to start consuming messages from
-		// the stream to see any errors from the server. Reading the first message ensures we
-		// handle any errors synchronously, similar to the HTTP implementation.
-
-		firstMessage, firstError := stream.Recv()
-		if firstError != nil {
-			// Hack: The ArchiveReader.Read() implementation handles surfacing the
-			// any "revision not found" errors returned from the invoked git binary.
-			//
-			// In order to maintainparity with the HTTP API, we return this error in the ArchiveReader.Read() method
-			// instead of returning it immediately.
-
-			// We return early only if this isn't a revision not found error.
+		var (
+			cancel       context.CancelFunc
+			stream       proto.GitserverService_ArchiveClient
+			firstMessage *proto.ArchiveResponse
+			firstError   error
+		)
+		err := c.withFailover(repo, func(addr AddressWithClient) error {
+			client, err := addr.GRPCClient()
+			if err != nil {
+				return err
+			}
 
-			err := convertGRPCErrorToGitDomainError(firstError)
+			var streamCtx context.Context
+			streamCtx, cancel = context.WithCancel(ctx)
 
-			var cse *CommandStatusError
-			if !errors.As(err, &cse) || !isRevisionNotFound(cse.Stderr) {
+			stream, err = client.Archive(streamCtx, req)
+			if err != nil {
 				cancel()
-				return nil, convertGRPCErrorToGitDomainError(err)
+				return failover(err)
+			}
+
+			// first message from the gRPC stream needs to be read to check for errors before continuing
+			// to read the rest of the stream. If the first message is an error, we cancel the stream
+			// and return the error.
+			//
+			// This is necessary to provide parity between the REST and gRPC implementations of
+			// ArchiveReader. Users of cli.ArchiveReader may assume error handling occurs immediately,
+			// as is the case with the HTTP implementation where errors are returned as soon as the
+			// function returns. gRPC is asynchronous, so we have to start consuming messages from
+			// the stream to see any errors from the server. Reading the first message ensures we
+			// handle any errors synchronously, similar to the HTTP implementation.
+			//
+			// Reading the first message is also when we learn that the gitserver is
+			// unavailable, in which case we fail over to a replica of the repo.
+			firstMessage, firstError = stream.Recv()
+			if firstError != nil {
+				// Hack: The ArchiveReader.Read() implementation handles surfacing the
+				// any "revision not found" errors returned from the invoked git binary.
+				//
+				// In order to maintainparity with the HTTP API, we return this error in the ArchiveReader.Read() method
+				// instead of returning it immediately.
+
+				// We return early only if this isn't a revision not found error.
+
+				err := convertGRPCErrorToGitDomainError(firstError)
+
+				var cse *CommandStatusError
+				if !errors.As(err, &cse) || !isRevisionNotFound(cse.Stderr) {
+					cancel()
+					return failover(err)
+				}
 			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
 		}
 
 		firstMessageRead := false
@@ -2612,8 +3069,12 @@ func (c *clientImplementor) ArchiveReader(
 
 	} else {
 		// Fall back to http request
-		u := c.archiveURL(repo, options)
-		resp, err := c.do(ctx, repo, "POST", u.String(), nil)
+		var resp *http.Response
+		err := c.withFailover(repo, func(addr AddressWithClient) (err error) {
+			u := c.archiveURL(addr.Address(), repo, options)
+			resp, err = c.do(ctx, repo, "POST", u.String(), nil)
+			return failover(err)
+		})
 		if err != nil {
 			return nil, err
 		}
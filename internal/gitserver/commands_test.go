@@ -2018,6 +2018,45 @@ func TestParseCommitsUniqueToBranch(t *testing.T) { // KEEP
 	}
 }
 
+func TestParseCommitLog(t *testing.T) {
+	out := "abc<!>a@example.com<!>Alice<!>Mon Jan 2 15:04:05 2006 -0700\nplain.go\n\"caf\\303\\251 \\\"x\\\".go\"\n\n" +
+		"def<!>b@example.com<!>Bob<!>Mon Jan 2 15:04:05 2006 -0700\nother.go"
+	got, err := parseCommitLog([]byte(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d commits, want 2", len(got))
+	}
+	if diff := cmp.Diff([]string{"plain.go", "café \"x\".go"}, got[0].ChangedFiles); diff != "" {
+		t.Errorf("unexpected changed files (-want +got):\n%s", diff)
+	}
+	if got[1].AuthorName != "Bob" || got[1].SHA != "def" {
+		t.Errorf("unexpected second commit %+v", got[1])
+	}
+}
+
+func TestParseBlobOIDs(t *testing.T) {
+	out := "100644 blob 0123456789abcdef0123456789abcdef01234567\ta/b.go\x00" +
+		"040000 tree 89abcdef0123456789abcdef0123456789abcdef\tc\x00" +
+		"100644 blob 89abcdef0123456789abcdef0123456789abcdef\td e\tf.go\x00"
+	got, err := parseBlobOIDs([]byte(out))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"a/b.go":    "0123456789abcdef0123456789abcdef01234567",
+		"d e\tf.go": "89abcdef0123456789abcdef0123456789abcdef",
+	}
+	gotStrings := make(map[string]string, len(got))
+	for path, oid := range got {
+		gotStrings[path] = oid.String()
+	}
+	if diff := cmp.Diff(want, gotStrings); diff != "" {
+		t.Errorf("unexpected blob OIDs (-want +got):\n%s", diff)
+	}
+}
+
 func TestParseBranchesContaining(t *testing.T) { // KEEP
 	names := parseBranchesContaining([]string{
 		"refs/tags/v0.7.0",
@@ -2606,7 +2645,7 @@ func TestRead(t *testing.T) {
 		}
 
 		t.Run(name+"-ReadFile", func(t *testing.T) {
-			data, err := client.ReadFile(ctx, nil, repo, commitID, test.file)
+			data, err := client.ReadFile(ctx, nil, repo, commitID, test.file, ReadFileOptions{})
 			checkFn(t, err, data)
 		})
 		t.Run(name+"-ReadFile-with-sub-repo-permissions-no-op", func(t *testing.T) {
@@ -2619,7 +2658,7 @@ func TestRead(t *testing.T) {
 				}
 				return authz.None, nil
 			})
-			data, err := client.ReadFile(ctx, checker, repo, commitID, test.file)
+			data, err := client.ReadFile(ctx, checker, repo, commitID, test.file, ReadFileOptions{})
 			checkFn(t, err, data)
 		})
 		t.Run(name+"-ReadFile-with-sub-repo-permissions-filters-file", func(t *testing.T) {
@@ -2629,7 +2668,7 @@ func TestRead(t *testing.T) {
 			checker.PermissionsFunc.SetDefaultHook(func(ctx context.Context, i int32, content authz.RepoContent) (authz.Perms, error) {
 				return authz.None, nil
 			})
-			data, err := client.ReadFile(ctx, checker, repo, commitID, test.file)
+			data, err := client.ReadFile(ctx, checker, repo, commitID, test.file, ReadFileOptions{})
 			if err != os.ErrNotExist {
 				t.Errorf("unexpected error reading file: %s", err)
 			}
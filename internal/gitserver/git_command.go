@@ -175,7 +175,8 @@ type RemoteGitCommand struct {
 }
 
 type execer interface {
-	httpPost(ctx context.Context, repo api.RepoName, op string, payload any) (resp *http.Response, err error)
+	httpPostTo(ctx context.Context, addr string, repo api.RepoName, op string, payload any) (resp *http.Response, err error)
+	withFailover(repo api.RepoName, f func(AddressWithClient) error) error
 	AddrForRepo(repo api.RepoName) string
 	ClientForRepo(repo api.RepoName) (proto.GitserverServiceClient, error)
 }
@@ -0,0 +1,79 @@
+package gitdomain
+
+import (
+	"bytes"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// LFSPointerMaxSize is the maximum size of a Git LFS pointer file. Larger
+// files are never pointers.
+const LFSPointerMaxSize = 1024
+
+// LFSObjectMaxSize is the maximum size of a Git LFS object we read. Pointers
+// to larger objects are not treated as pointers, so they are never read into
+// memory.
+const LFSObjectMaxSize = 1 << 30
+
+const lfsPointerVersion = "version https://git-lfs.github.com/spec/v1"
+
+// LFSPointer is the contents of a Git LFS pointer file, which is committed in
+// place of a file stored in Git LFS.
+type LFSPointer struct {
+	// OID is the SHA-256 of the object, as hex.
+	OID string
+	// Size is the size of the object in bytes.
+	Size int64
+}
+
+// ParseLFSPointer parses data as a Git LFS pointer file. It returns false if
+// data is not a pointer file, or if it points to an object larger than
+// LFSObjectMaxSize.
+//
+// See https://github.com/git-lfs/git-lfs/blob/main/docs/spec.md.
+func ParseLFSPointer(data []byte) (LFSPointer, bool) {
+	if len(data) > LFSPointerMaxSize || !bytes.HasPrefix(data, []byte(lfsPointerVersion+"\n")) {
+		return LFSPointer{}, false
+	}
+
+	var (
+		p       LFSPointer
+		hasSize bool
+	)
+	for _, line := range strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")[1:] {
+		key, value, ok := strings.Cut(line, " ")
+		if !ok {
+			return LFSPointer{}, false
+		}
+		switch key {
+		case "oid":
+			oid := strings.TrimPrefix(value, "sha256:")
+			if oid == value || !IsValidLFSOID(oid) {
+				return LFSPointer{}, false
+			}
+			p.OID = oid
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || size < 0 || size > LFSObjectMaxSize {
+				return LFSPointer{}, false
+			}
+			p.Size = size
+			hasSize = true
+		}
+	}
+	if p.OID == "" || !hasSize {
+		return LFSPointer{}, false
+	}
+	return p, true
+}
+
+// IsValidLFSOID returns true if oid is a lowercase hex SHA-256, as used to
+// identify Git LFS objects.
+func IsValidLFSOID(oid string) bool {
+	if len(oid) != 64 || strings.ToLower(oid) != oid {
+		return false
+	}
+	_, err := hex.DecodeString(oid)
+	return err == nil
+}
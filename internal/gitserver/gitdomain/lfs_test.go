@@ -0,0 +1,49 @@
+package gitdomain
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLFSPointer(t *testing.T) {
+	const oid = "4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393"
+
+	for _, tc := range []struct {
+		name    string
+		data    string
+		pointer LFSPointer
+		ok      bool
+	}{
+		{
+			name:    "Pointer",
+			data:    "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 12345\n",
+			pointer: LFSPointer{OID: oid, Size: 12345},
+			ok:      true,
+		},
+		{
+			name:    "Pointer with extension",
+			data:    "version https://git-lfs.github.com/spec/v1\next-0-foo sha256:" + oid + "\noid sha256:" + oid + "\nsize 0\n",
+			pointer: LFSPointer{OID: oid, Size: 0},
+			ok:      true,
+		},
+		{name: "Empty", data: ""},
+		{name: "Regular file", data: "hello world\n"},
+		{name: "Missing size", data: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\n"},
+		{name: "Missing oid", data: "version https://git-lfs.github.com/spec/v1\nsize 1\n"},
+		{name: "Unknown hash", data: "version https://git-lfs.github.com/spec/v1\noid sha1:" + oid + "\nsize 1\n"},
+		{name: "Short oid", data: "version https://git-lfs.github.com/spec/v1\noid sha256:abc\nsize 1\n"},
+		{name: "Uppercase oid", data: "version https://git-lfs.github.com/spec/v1\noid sha256:" + strings.ToUpper(oid) + "\nsize 1\n"},
+		{name: "Negative size", data: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize -1\n"},
+		{name: "Object too large", data: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize " + strconv.Itoa(LFSObjectMaxSize+1) + "\n"},
+		{name: "Too large", data: "version https://git-lfs.github.com/spec/v1\noid sha256:" + oid + "\nsize 1\n" + strings.Repeat("x", LFSPointerMaxSize)},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pointer, ok := ParseLFSPointer([]byte(tc.data))
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.pointer, pointer)
+		})
+	}
+}
@@ -40,6 +40,9 @@ type MockClient struct {
 	// BlameFileFunc is an instance of a mock function object controlling
 	// the behavior of the method BlameFile.
 	BlameFileFunc *ClientBlameFileFunc
+	// BlobOIDsFunc is an instance of a mock function object controlling the
+	// behavior of the method BlobOIDs.
+	BlobOIDsFunc *ClientBlobOIDsFunc
 	// BranchesContainingFunc is an instance of a mock function object
 	// controlling the behavior of the method BranchesContaining.
 	BranchesContainingFunc *ClientBranchesContainingFunc
@@ -55,6 +58,9 @@ type MockClient struct {
 	// CommitLogFunc is an instance of a mock function object controlling
 	// the behavior of the method CommitLog.
 	CommitLogFunc *ClientCommitLogFunc
+	// CommitLogForPathsFunc is an instance of a mock function object
+	// controlling the behavior of the method CommitLogForPaths.
+	CommitLogForPathsFunc *ClientCommitLogForPathsFunc
 	// CommitsFunc is an instance of a mock function object controlling the
 	// behavior of the method Commits.
 	CommitsFunc *ClientCommitsFunc
@@ -142,6 +148,12 @@ type MockClient struct {
 	// ReadFileFunc is an instance of a mock function object controlling the
 	// behavior of the method ReadFile.
 	ReadFileFunc *ClientReadFileFunc
+	// ReadFilesFunc is an instance of a mock function object controlling the
+	// behavior of the method ReadFiles.
+	ReadFilesFunc *ClientReadFilesFunc
+	// ReadLFSObjectFunc is an instance of a mock function object
+	// controlling the behavior of the method ReadLFSObject.
+	ReadLFSObjectFunc *ClientReadLFSObjectFunc
 	// RefDescriptionsFunc is an instance of a mock function object
 	// controlling the behavior of the method RefDescriptions.
 	RefDescriptionsFunc *ClientRefDescriptionsFunc
@@ -212,6 +224,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		BlobOIDsFunc: &ClientBlobOIDsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string) (r0 map[string]gitdomain.OID, r1 error) {
+				return
+			},
+		},
 		BranchesContainingFunc: &ClientBranchesContainingFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID) (r0 []string, r1 error) {
 				return
@@ -237,6 +254,11 @@ func NewMockClient() *MockClient {
 				return
 			},
 		},
+		CommitLogForPathsFunc: &ClientCommitLogForPathsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, []string) (r0 []CommitLog, r1 error) {
+				return
+			},
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, CommitsOptions) (r0 []*gitdomain.Commit, r1 error) {
 				return
@@ -378,7 +400,17 @@ func NewMockClient() *MockClient {
 			},
 		},
 		ReadFileFunc: &ClientReadFileFunc{
-			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string) (r0 []byte, r1 error) {
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, ReadFileOptions) (r0 []byte, r1 error) {
+				return
+			},
+		},
+		ReadFilesFunc: &ClientReadFilesFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string, func(string, []byte) error) (r0 error) {
+				return
+			},
+		},
+		ReadLFSObjectFunc: &ClientReadLFSObjectFunc{
+			defaultHook: func(context.Context, api.RepoName, gitdomain.LFSPointer) (r0 []byte, r1 error) {
 				return
 			},
 		},
@@ -479,6 +511,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.BlameFile")
 			},
 		},
+		BlobOIDsFunc: &ClientBlobOIDsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string) (map[string]gitdomain.OID, error) {
+				panic("unexpected invocation of MockClient.BlobOIDs")
+			},
+		},
 		BranchesContainingFunc: &ClientBranchesContainingFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID) ([]string, error) {
 				panic("unexpected invocation of MockClient.BranchesContaining")
@@ -504,6 +541,11 @@ func NewStrictMockClient() *MockClient {
 				panic("unexpected invocation of MockClient.CommitLog")
 			},
 		},
+		CommitLogForPathsFunc: &ClientCommitLogForPathsFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, []string) ([]CommitLog, error) {
+				panic("unexpected invocation of MockClient.CommitLogForPaths")
+			},
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, CommitsOptions) ([]*gitdomain.Commit, error) {
 				panic("unexpected invocation of MockClient.Commits")
@@ -645,10 +687,20 @@ func NewStrictMockClient() *MockClient {
 			},
 		},
 		ReadFileFunc: &ClientReadFileFunc{
-			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string) ([]byte, error) {
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, ReadFileOptions) ([]byte, error) {
 				panic("unexpected invocation of MockClient.ReadFile")
 			},
 		},
+		ReadFilesFunc: &ClientReadFilesFunc{
+			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string, func(string, []byte) error) error {
+				panic("unexpected invocation of MockClient.ReadFiles")
+			},
+		},
+		ReadLFSObjectFunc: &ClientReadLFSObjectFunc{
+			defaultHook: func(context.Context, api.RepoName, gitdomain.LFSPointer) ([]byte, error) {
+				panic("unexpected invocation of MockClient.ReadLFSObject")
+			},
+		},
 		RefDescriptionsFunc: &ClientRefDescriptionsFunc{
 			defaultHook: func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, ...string) (map[string][]gitdomain.RefDescription, error) {
 				panic("unexpected invocation of MockClient.RefDescriptions")
@@ -736,6 +788,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		BlameFileFunc: &ClientBlameFileFunc{
 			defaultHook: i.BlameFile,
 		},
+		BlobOIDsFunc: &ClientBlobOIDsFunc{
+			defaultHook: i.BlobOIDs,
+		},
 		BranchesContainingFunc: &ClientBranchesContainingFunc{
 			defaultHook: i.BranchesContaining,
 		},
@@ -751,6 +806,9 @@ func NewMockClientFrom(i Client) *MockClient {
 		CommitLogFunc: &ClientCommitLogFunc{
 			defaultHook: i.CommitLog,
 		},
+		CommitLogForPathsFunc: &ClientCommitLogForPathsFunc{
+			defaultHook: i.CommitLogForPaths,
+		},
 		CommitsFunc: &ClientCommitsFunc{
 			defaultHook: i.Commits,
 		},
@@ -838,6 +896,12 @@ func NewMockClientFrom(i Client) *MockClient {
 		ReadFileFunc: &ClientReadFileFunc{
 			defaultHook: i.ReadFile,
 		},
+		ReadFilesFunc: &ClientReadFilesFunc{
+			defaultHook: i.ReadFiles,
+		},
+		ReadLFSObjectFunc: &ClientReadLFSObjectFunc{
+			defaultHook: i.ReadLFSObject,
+		},
 		RefDescriptionsFunc: &ClientRefDescriptionsFunc{
 			defaultHook: i.RefDescriptions,
 		},
@@ -1415,6 +1479,122 @@ func (c ClientBlameFileFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientBlobOIDsFunc describes the behavior when the BlobOIDs method of the
+// parent MockClient instance is invoked.
+type ClientBlobOIDsFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string) (map[string]gitdomain.OID, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string) (map[string]gitdomain.OID, error)
+	history     []ClientBlobOIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// BlobOIDs delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockClient) BlobOIDs(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 api.CommitID, v4 []string) (map[string]gitdomain.OID, error) {
+	r0, r1 := m.BlobOIDsFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.BlobOIDsFunc.appendCall(ClientBlobOIDsFuncCall{v0, v1, v2, v3, v4, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the BlobOIDs method of
+// the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientBlobOIDsFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string) (map[string]gitdomain.OID, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// BlobOIDs method of the parent MockClient instance invokes the hook at the
+// front of the queue and discards it. After the queue is empty, the default
+// hook function is invoked for any future action.
+func (f *ClientBlobOIDsFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string) (map[string]gitdomain.OID, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientBlobOIDsFunc) SetDefaultReturn(r0 map[string]gitdomain.OID, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string) (map[string]gitdomain.OID, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientBlobOIDsFunc) PushReturn(r0 map[string]gitdomain.OID, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string) (map[string]gitdomain.OID, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientBlobOIDsFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string) (map[string]gitdomain.OID, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientBlobOIDsFunc) appendCall(r0 ClientBlobOIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientBlobOIDsFuncCall objects describing
+// the invocations of this function.
+func (f *ClientBlobOIDsFunc) History() []ClientBlobOIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientBlobOIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientBlobOIDsFuncCall is an object that describes an invocation of
+// method BlobOIDs on an instance of MockClient.
+type ClientBlobOIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 api.CommitID
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]gitdomain.OID
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientBlobOIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientBlobOIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientBranchesContainingFunc describes the behavior when the
 // BranchesContaining method of the parent MockClient instance is invoked.
 type ClientBranchesContainingFunc struct {
@@ -1981,6 +2161,126 @@ func (c ClientCommitLogFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientCommitLogForPathsFunc describes the behavior when the
+// CommitLogForPaths method of the parent MockClient instance is invoked.
+type ClientCommitLogForPathsFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, []string) ([]CommitLog, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, []string) ([]CommitLog, error)
+	history     []ClientCommitLogForPathsFuncCall
+	mutex       sync.Mutex
+}
+
+// CommitLogForPaths delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockClient) CommitLogForPaths(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 api.CommitID, v4 string, v5 []string) ([]CommitLog, error) {
+	r0, r1 := m.CommitLogForPathsFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.CommitLogForPathsFunc.appendCall(ClientCommitLogForPathsFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the CommitLogForPaths
+// method of the parent MockClient instance is invoked and the hook queue is
+// empty.
+func (f *ClientCommitLogForPathsFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, []string) ([]CommitLog, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CommitLogForPaths method of the parent MockClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ClientCommitLogForPathsFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, []string) ([]CommitLog, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientCommitLogForPathsFunc) SetDefaultReturn(r0 []CommitLog, r1 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, []string) ([]CommitLog, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientCommitLogForPathsFunc) PushReturn(r0 []CommitLog, r1 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, []string) ([]CommitLog, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientCommitLogForPathsFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, []string) ([]CommitLog, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientCommitLogForPathsFunc) appendCall(r0 ClientCommitLogForPathsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientCommitLogForPathsFuncCall objects
+// describing the invocations of this function.
+func (f *ClientCommitLogForPathsFunc) History() []ClientCommitLogForPathsFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientCommitLogForPathsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientCommitLogForPathsFuncCall is an object that describes an invocation
+// of method CommitLogForPaths on an instance of MockClient.
+type ClientCommitLogForPathsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 api.CommitID
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []CommitLog
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientCommitLogForPathsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientCommitLogForPathsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientCommitsFunc describes the behavior when the Commits method of the
 // parent MockClient instance is invoked.
 type ClientCommitsFunc struct {
@@ -5182,23 +5482,23 @@ func (c ClientReadDirFuncCall) Results() []interface{} {
 // ClientReadFileFunc describes the behavior when the ReadFile method of the
 // parent MockClient instance is invoked.
 type ClientReadFileFunc struct {
-	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string) ([]byte, error)
-	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string) ([]byte, error)
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, ReadFileOptions) ([]byte, error)
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, ReadFileOptions) ([]byte, error)
 	history     []ClientReadFileFuncCall
 	mutex       sync.Mutex
 }
 
 // ReadFile delegates to the next hook function in the queue and stores the
 // parameter and result values of this invocation.
-func (m *MockClient) ReadFile(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 api.CommitID, v4 string) ([]byte, error) {
-	r0, r1 := m.ReadFileFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.ReadFileFunc.appendCall(ClientReadFileFuncCall{v0, v1, v2, v3, v4, r0, r1})
+func (m *MockClient) ReadFile(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 api.CommitID, v4 string, v5 ReadFileOptions) ([]byte, error) {
+	r0, r1 := m.ReadFileFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.ReadFileFunc.appendCall(ClientReadFileFuncCall{v0, v1, v2, v3, v4, v5, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the ReadFile method of
 // the parent MockClient instance is invoked and the hook queue is empty.
-func (f *ClientReadFileFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string) ([]byte, error)) {
+func (f *ClientReadFileFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, ReadFileOptions) ([]byte, error)) {
 	f.defaultHook = hook
 }
 
@@ -5206,7 +5506,7 @@ func (f *ClientReadFileFunc) SetDefaultHook(hook func(context.Context, authz.Sub
 // ReadFile method of the parent MockClient instance invokes the hook at the
 // front of the queue and discards it. After the queue is empty, the default
 // hook function is invoked for any future action.
-func (f *ClientReadFileFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string) ([]byte, error)) {
+func (f *ClientReadFileFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, ReadFileOptions) ([]byte, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5215,19 +5515,19 @@ func (f *ClientReadFileFunc) PushHook(hook func(context.Context, authz.SubRepoPe
 // SetDefaultReturn calls SetDefaultHook with a function that returns the
 // given values.
 func (f *ClientReadFileFunc) SetDefaultReturn(r0 []byte, r1 error) {
-	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string) ([]byte, error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, ReadFileOptions) ([]byte, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushHook with a function that returns the given values.
 func (f *ClientReadFileFunc) PushReturn(r0 []byte, r1 error) {
-	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string) ([]byte, error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, ReadFileOptions) ([]byte, error) {
 		return r0, r1
 	})
 }
 
-func (f *ClientReadFileFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string) ([]byte, error) {
+func (f *ClientReadFileFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, string, ReadFileOptions) ([]byte, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5275,6 +5575,9 @@ type ClientReadFileFuncCall struct {
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 ReadFileOptions
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 []byte
@@ -5286,7 +5589,7 @@ type ClientReadFileFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c ClientReadFileFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -5295,6 +5598,232 @@ func (c ClientReadFileFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ClientReadFilesFunc describes the behavior when the ReadFiles method of
+// the parent MockClient instance is invoked.
+type ClientReadFilesFunc struct {
+	defaultHook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string, func(string, []byte) error) error
+	hooks       []func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string, func(string, []byte) error) error
+	history     []ClientReadFilesFuncCall
+	mutex       sync.Mutex
+}
+
+// ReadFiles delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockClient) ReadFiles(v0 context.Context, v1 authz.SubRepoPermissionChecker, v2 api.RepoName, v3 api.CommitID, v4 []string, v5 func(string, []byte) error) error {
+	r0 := m.ReadFilesFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.ReadFilesFunc.appendCall(ClientReadFilesFuncCall{v0, v1, v2, v3, v4, v5, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ReadFiles method of
+// the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientReadFilesFunc) SetDefaultHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string, func(string, []byte) error) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReadFiles method of the parent MockClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ClientReadFilesFunc) PushHook(hook func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string, func(string, []byte) error) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientReadFilesFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string, func(string, []byte) error) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientReadFilesFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string, func(string, []byte) error) error {
+		return r0
+	})
+}
+
+func (f *ClientReadFilesFunc) nextHook() func(context.Context, authz.SubRepoPermissionChecker, api.RepoName, api.CommitID, []string, func(string, []byte) error) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientReadFilesFunc) appendCall(r0 ClientReadFilesFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientReadFilesFuncCall objects describing
+// the invocations of this function.
+func (f *ClientReadFilesFunc) History() []ClientReadFilesFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientReadFilesFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientReadFilesFuncCall is an object that describes an invocation of
+// method ReadFiles on an instance of MockClient.
+type ClientReadFilesFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 authz.SubRepoPermissionChecker
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 api.RepoName
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 api.CommitID
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 []string
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 func(string, []byte) error
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientReadFilesFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientReadFilesFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// ClientReadLFSObjectFunc describes the behavior when the ReadLFSObject
+// method of the parent MockClient instance is invoked.
+type ClientReadLFSObjectFunc struct {
+	defaultHook func(context.Context, api.RepoName, gitdomain.LFSPointer) ([]byte, error)
+	hooks       []func(context.Context, api.RepoName, gitdomain.LFSPointer) ([]byte, error)
+	history     []ClientReadLFSObjectFuncCall
+	mutex       sync.Mutex
+}
+
+// ReadLFSObject delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockClient) ReadLFSObject(v0 context.Context, v1 api.RepoName, v2 gitdomain.LFSPointer) ([]byte, error) {
+	r0, r1 := m.ReadLFSObjectFunc.nextHook()(v0, v1, v2)
+	m.ReadLFSObjectFunc.appendCall(ClientReadLFSObjectFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ReadLFSObject method
+// of the parent MockClient instance is invoked and the hook queue is empty.
+func (f *ClientReadLFSObjectFunc) SetDefaultHook(hook func(context.Context, api.RepoName, gitdomain.LFSPointer) ([]byte, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ReadLFSObject method of the parent MockClient instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *ClientReadLFSObjectFunc) PushHook(hook func(context.Context, api.RepoName, gitdomain.LFSPointer) ([]byte, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultHook with a function that returns the
+// given values.
+func (f *ClientReadLFSObjectFunc) SetDefaultReturn(r0 []byte, r1 error) {
+	f.SetDefaultHook(func(context.Context, api.RepoName, gitdomain.LFSPointer) ([]byte, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushHook with a function that returns the given values.
+func (f *ClientReadLFSObjectFunc) PushReturn(r0 []byte, r1 error) {
+	f.PushHook(func(context.Context, api.RepoName, gitdomain.LFSPointer) ([]byte, error) {
+		return r0, r1
+	})
+}
+
+func (f *ClientReadLFSObjectFunc) nextHook() func(context.Context, api.RepoName, gitdomain.LFSPointer) ([]byte, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ClientReadLFSObjectFunc) appendCall(r0 ClientReadLFSObjectFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ClientReadLFSObjectFuncCall objects
+// describing the invocations of this function.
+func (f *ClientReadLFSObjectFunc) History() []ClientReadLFSObjectFuncCall {
+	f.mutex.Lock()
+	history := make([]ClientReadLFSObjectFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ClientReadLFSObjectFuncCall is an object that describes an invocation of
+// method ReadLFSObject on an instance of MockClient.
+type ClientReadLFSObjectFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 api.RepoName
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 gitdomain.LFSPointer
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []byte
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ClientReadLFSObjectFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ClientReadLFSObjectFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ClientRefDescriptionsFunc describes the behavior when the RefDescriptions
 // method of the parent MockClient instance is invoked.
 type ClientRefDescriptionsFunc struct {
@@ -13,33 +13,37 @@ import (
 )
 
 type operations struct {
-	archiveReader    *observation.Operation
-	batchLog         *observation.Operation
-	batchLogSingle   *observation.Operation
-	blameFile        *observation.Operation
-	commits          *observation.Operation
-	contributorCount *observation.Operation
-	do               *observation.Operation
-	exec             *observation.Operation
-	firstEverCommit  *observation.Operation
-	getBehindAhead   *observation.Operation
-	getCommit        *observation.Operation
-	getCommits       *observation.Operation
-	hasCommitAfter   *observation.Operation
-	listBranches     *observation.Operation
-	listRefs         *observation.Operation
-	listTags         *observation.Operation
-	lstat            *observation.Operation
-	mergeBase        *observation.Operation
-	newFileReader    *observation.Operation
-	p4Exec           *observation.Operation
-	readDir          *observation.Operation
-	readFile         *observation.Operation
-	resolveRevision  *observation.Operation
-	revList          *observation.Operation
-	search           *observation.Operation
-	stat             *observation.Operation
-	streamBlameFile  *observation.Operation
+	archiveReader     *observation.Operation
+	batchLog          *observation.Operation
+	batchLogSingle    *observation.Operation
+	blameFile         *observation.Operation
+	blobOIDs          *observation.Operation
+	commits           *observation.Operation
+	commitLogForPaths *observation.Operation
+	contributorCount  *observation.Operation
+	do                *observation.Operation
+	exec              *observation.Operation
+	firstEverCommit   *observation.Operation
+	getBehindAhead    *observation.Operation
+	getCommit         *observation.Operation
+	getCommits        *observation.Operation
+	hasCommitAfter    *observation.Operation
+	listBranches      *observation.Operation
+	listRefs          *observation.Operation
+	listTags          *observation.Operation
+	lstat             *observation.Operation
+	mergeBase         *observation.Operation
+	newFileReader     *observation.Operation
+	p4Exec            *observation.Operation
+	readDir           *observation.Operation
+	readFile          *observation.Operation
+	readFiles         *observation.Operation
+	readLFSObject     *observation.Operation
+	resolveRevision   *observation.Operation
+	revList           *observation.Operation
+	search            *observation.Operation
+	stat              *observation.Operation
+	streamBlameFile   *observation.Operation
 }
 
 func newOperations(observationCtx *observation.Context) *operations {
@@ -82,33 +86,37 @@ func newOperations(observationCtx *observation.Context) *operations {
 	})
 
 	return &operations{
-		archiveReader:    op("ArchiveReader"),
-		batchLog:         op("BatchLog"),
-		batchLogSingle:   subOp("batchLogSingle"),
-		blameFile:        op("BlameFile"),
-		commits:          op("Commits"),
-		contributorCount: op("ContributorCount"),
-		do:               subOp("do"),
-		exec:             op("Exec"),
-		firstEverCommit:  op("FirstEverCommit"),
-		getBehindAhead:   op("GetBehindAhead"),
-		getCommit:        op("GetCommit"),
-		getCommits:       op("GetCommits"),
-		hasCommitAfter:   op("HasCommitAfter"),
-		listBranches:     op("ListBranches"),
-		listRefs:         op("ListRefs"),
-		listTags:         op("ListTags"),
-		lstat:            subOp("lStat"),
-		mergeBase:        op("MergeBase"),
-		newFileReader:    op("NewFileReader"),
-		p4Exec:           op("P4Exec"),
-		readDir:          op("ReadDir"),
-		readFile:         op("ReadFile"),
-		resolveRevision:  resolveRevisionOperation,
-		revList:          op("RevList"),
-		search:           op("Search"),
-		stat:             op("Stat"),
-		streamBlameFile:  op("StreamBlameFile"),
+		archiveReader:     op("ArchiveReader"),
+		batchLog:          op("BatchLog"),
+		batchLogSingle:    subOp("batchLogSingle"),
+		blameFile:         op("BlameFile"),
+		blobOIDs:          op("BlobOIDs"),
+		commits:           op("Commits"),
+		commitLogForPaths: op("CommitLogForPaths"),
+		contributorCount:  op("ContributorCount"),
+		do:                subOp("do"),
+		exec:              op("Exec"),
+		firstEverCommit:   op("FirstEverCommit"),
+		getBehindAhead:    op("GetBehindAhead"),
+		getCommit:         op("GetCommit"),
+		getCommits:        op("GetCommits"),
+		hasCommitAfter:    op("HasCommitAfter"),
+		listBranches:      op("ListBranches"),
+		listRefs:          op("ListRefs"),
+		listTags:          op("ListTags"),
+		lstat:             subOp("lStat"),
+		mergeBase:         op("MergeBase"),
+		newFileReader:     op("NewFileReader"),
+		p4Exec:            op("P4Exec"),
+		readDir:           op("ReadDir"),
+		readFile:          op("ReadFile"),
+		readFiles:         op("ReadFiles"),
+		readLFSObject:     op("ReadLFSObject"),
+		resolveRevision:   resolveRevisionOperation,
+		revList:           op("RevList"),
+		search:            op("Search"),
+		stat:              op("Stat"),
+		streamBlameFile:   op("StreamBlameFile"),
 	}
 }
 
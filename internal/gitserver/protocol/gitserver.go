@@ -521,6 +521,33 @@ func (i *IsRepoCloneableResponse) FromProto(p *proto.IsRepoCloneableResponse) {
 	}
 }
 
+// ReadLFSObjectRequest is a request for the contents of the Git LFS object a
+// pointer file of a repository points to.
+type ReadLFSObjectRequest struct {
+	// Repo is the repository the pointer file belongs to.
+	Repo api.RepoName `json:"repo"`
+	// OID is the SHA-256 of the object, as hex.
+	OID string `json:"oid"`
+	// Size is the size of the object in bytes.
+	Size int64 `json:"size"`
+}
+
+func (r *ReadLFSObjectRequest) ToProto() *proto.ReadLFSObjectRequest {
+	return &proto.ReadLFSObjectRequest{
+		Repo: string(r.Repo),
+		Oid:  r.OID,
+		Size: r.Size,
+	}
+}
+
+func (r *ReadLFSObjectRequest) FromProto(p *proto.ReadLFSObjectRequest) {
+	*r = ReadLFSObjectRequest{
+		Repo: api.RepoName(p.GetRepo()),
+		OID:  p.GetOid(),
+		Size: p.GetSize(),
+	}
+}
+
 // RepoDeleteRequest is a request to delete a repository clone on gitserver
 type RepoDeleteRequest struct {
 	// Repo is the repository to delete.
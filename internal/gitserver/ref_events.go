@@ -0,0 +1,63 @@
+package gitserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+const (
+	// refEventsBatchSize is the number of ref events SubscribeRefEvents loads at
+	// once.
+	refEventsBatchSize = 100
+
+	// refEventsPollInterval is how long SubscribeRefEvents waits before looking
+	// for new ref events once it has handled all of them.
+	refEventsPollInterval = 5 * time.Second
+)
+
+// SubscribeRefEvents calls handle for each event gitserver records when a repo
+// update moves refs of a repository, in the order of the transactions which
+// recorded them, until ctx is canceled or handle returns an error.
+//
+// The position of the subscriber in the stream of events is stored in the
+// database, so a subscriber which restarts resumes after the last event it
+// handled successfully. A new subscriber starts with the events recorded after
+// it first subscribes. Events are only kept for a limited time, so subscribers
+// which stop for longer than that miss events.
+func SubscribeRefEvents(ctx context.Context, db database.DB, subscriber string, handle func(context.Context, *database.GitserverRefEvent) error) error {
+	store := db.GitserverRefEvents()
+
+	after, err := store.GetBookmark(ctx, subscriber)
+	if err != nil {
+		return err
+	}
+
+	for {
+		events, err := store.ListAfter(ctx, after, refEventsBatchSize)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			if err := handle(ctx, event); err != nil {
+				return err
+			}
+			if err := store.UpdateBookmark(ctx, subscriber, event.Position()); err != nil {
+				return err
+			}
+			after = event.Position()
+		}
+
+		if len(events) == refEventsBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(refEventsPollInterval):
+		}
+	}
+}
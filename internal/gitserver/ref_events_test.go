@@ -0,0 +1,64 @@
+package gitserver
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestSubscribeRefEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Events are ordered by the transaction which recorded them.
+	all := []*database.GitserverRefEvent{{ID: 3, TransactionID: 10}, {ID: 7, TransactionID: 11}, {ID: 4, TransactionID: 12}}
+	store := database.NewMockGitserverRefEventStore()
+	store.GetBookmarkFunc.SetDefaultReturn(all[0].Position(), nil)
+	store.ListAfterFunc.SetDefaultHook(func(_ context.Context, after database.GitserverRefEventPosition, limit int) ([]*database.GitserverRefEvent, error) {
+		var events []*database.GitserverRefEvent
+		for _, e := range all {
+			if e.TransactionID > after.TransactionID && len(events) < limit {
+				events = append(events, e)
+			}
+		}
+		return events, nil
+	})
+	db := database.NewMockDB()
+	db.GitserverRefEventsFunc.SetDefaultReturn(store)
+
+	var handled []int64
+	err := SubscribeRefEvents(ctx, db, "test", func(_ context.Context, e *database.GitserverRefEvent) error {
+		handled = append(handled, e.ID)
+		if e.ID == 4 {
+			// Caught up, stop waiting for new events.
+			cancel()
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.Equal(t, []int64{7, 4}, handled)
+	require.Equal(t, "test", store.GetBookmarkFunc.History()[0].Arg1)
+	bookmarks := store.UpdateBookmarkFunc.History()
+	require.Len(t, bookmarks, 2)
+	require.Equal(t, all[2].Position(), bookmarks[1].Arg2)
+
+	// The bookmark is not moved past an event which failed to be handled.
+	store = database.NewMockGitserverRefEventStore()
+	store.ListAfterFunc.SetDefaultReturn(all, nil)
+	db.GitserverRefEventsFunc.SetDefaultReturn(store)
+	handleErr := errors.New("failed")
+	err = SubscribeRefEvents(context.Background(), db, "test", func(_ context.Context, e *database.GitserverRefEvent) error {
+		if e.ID == 7 {
+			return handleErr
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, handleErr)
+	bookmarks = store.UpdateBookmarkFunc.History()
+	require.Len(t, bookmarks, 1)
+	require.Equal(t, all[0].Position(), bookmarks[0].Arg2)
+}
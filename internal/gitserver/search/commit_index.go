@@ -0,0 +1,742 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp/syntax" //nolint:depguard // using the grafana fork of regexp clashes with zoekt, which uses the std regexp/syntax.
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/search/casetransform"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// commitIndexDirName is the name of the directory inside the git directory of
+// a repository that holds its commit index.
+const commitIndexDirName = "sg_commit_index"
+
+const (
+	// maxCommitIndexSegments is the number of segments at which an update
+	// merges all segments of an index into one.
+	maxCommitIndexSegments = 8
+
+	// maxIndexedPaths is the maximum number of paths we record for a commit.
+	// Commits touching more paths are never skipped because of their paths.
+	maxIndexedPaths = 1024
+
+	// commitIndexCacheSize is the number of commit indexes whose segment
+	// metadata is kept in memory. The indexed commits are read from disk by
+	// each search.
+	commitIndexCacheSize = 32
+
+	// trigramFilterBitsPerTrigram is the number of bits of the bloom filter of
+	// message trigrams per distinct trigram, which makes for a false positive
+	// rate of about 3%.
+	trigramFilterBitsPerTrigram = 10
+)
+
+// commitIndexes caches the metadata of the commit indexes read from disk by
+// the git directory of their repository.
+var commitIndexes, _ = lru.New[string, *commitIndex](commitIndexCacheSize)
+
+// commitIndex records for every commit reachable from the refs of a repository
+// the metadata commit searches filter on: the author, committer, commit date,
+// the trigrams of the message and the touched paths. Searches use it to skip
+// commits that can't match a query before reading and diffing them.
+//
+// The index is stored as a list of immutable segments. Each segment holds the
+// commits that became reachable since the previous segment and the tips of
+// the refs at the time it was written. Every ancestor of an indexed commit is
+// indexed. A commitIndex only holds the metadata of the segments, which is
+// never modified once loaded, so it is safe for concurrent use. The commits of
+// the segments are read with a commitIndexReader.
+type commitIndex struct {
+	dir      string
+	segments []segmentFile
+	tips     []string
+	mailmap  string
+}
+
+// indexedCommit is the metadata of a commit recorded in the commit index.
+type indexedCommit struct {
+	AuthorName     string
+	AuthorEmail    string
+	CommitterName  string
+	CommitterEmail string
+	CommitterDate  int64
+
+	// Paths are the paths touched by the commit as reported by --name-status.
+	// PathsTruncated is set if the commit touched more than maxIndexedPaths
+	// paths, in which case Paths is empty.
+	Paths          []string
+	PathsTruncated bool
+
+	// Trigrams is a bloom filter of the trigrams of the ASCII-lowercased
+	// commit message.
+	Trigrams []uint64
+}
+
+// segmentFile identifies a segment file of a commit index. Segment numbers
+// keep increasing when an index is rebuilt, and we compare the size and
+// modification time too, so that a cached index is never mistaken for an
+// index rebuilt in a recreated directory.
+type segmentFile struct {
+	name    string
+	size    int64
+	modTime time.Time
+}
+
+// commitIndexSegment is a segment of a commit index. On disk, the header is
+// encoded before the commits so that the header can be read on its own.
+type commitIndexSegment struct {
+	commitIndexSegmentHeader
+	commitIndexSegmentCommits
+}
+
+type commitIndexSegmentHeader struct {
+	Tips []string
+	// Mailmap is the ID of the .mailmap blob at HEAD that the author and
+	// committer names and emails were mapped with.
+	Mailmap string
+}
+
+type commitIndexSegmentCommits struct {
+	Oids    []string
+	Commits []indexedCommit
+}
+
+// loadCommitIndex returns the commit index of the repository in the git
+// directory dir, or nil if it has not been built yet. Only the header of the
+// newest segment is read, as that holds the tips and mailmap of the index.
+func loadCommitIndex(dir string) (*commitIndex, error) {
+	indexDir := filepath.Join(dir, commitIndexDirName)
+	segments, err := listCommitIndexSegments(indexDir)
+	if err != nil {
+		return nil, err
+	}
+	if len(segments) == 0 {
+		return nil, nil
+	}
+
+	if cached, ok := commitIndexes.Get(dir); ok && segmentsEqual(cached.segments, segments) {
+		return cached, nil
+	}
+
+	newest := segments[len(segments)-1]
+	header, err := readCommitIndexSegmentHeader(filepath.Join(indexDir, newest.name))
+	if err != nil {
+		return nil, errors.Wrapf(err, "reading commit index segment %s", newest.name)
+	}
+	idx := &commitIndex{
+		dir:      indexDir,
+		segments: segments,
+		tips:     header.Tips,
+		mailmap:  header.Mailmap,
+	}
+	commitIndexes.Add(dir, idx)
+	return idx, nil
+}
+
+// reader returns a reader of the commits of idx.
+func (idx *commitIndex) reader() *commitIndexReader {
+	return &commitIndexReader{idx: idx, commits: make(map[string]*indexedCommit)}
+}
+
+// commitIndexReader looks up commits in a commit index. It reads the segments
+// of the index from the newest to the oldest as lookups need them, so that a
+// search which only visits recent commits doesn't read the older segments. It
+// is not safe for concurrent use.
+type commitIndexReader struct {
+	idx     *commitIndex
+	read    int // the number of segments read
+	commits map[string]*indexedCommit
+	err     error
+}
+
+// lookup returns the indexed metadata of the commit oid, or false if it is not
+// indexed or the segment holding it can't be read.
+func (r *commitIndexReader) lookup(oid string) (*indexedCommit, bool) {
+	for {
+		if c, ok := r.commits[oid]; ok {
+			return c, true
+		}
+		if !r.readNext() {
+			return nil, false
+		}
+	}
+}
+
+// all returns the indexed metadata of all commits of the index.
+func (r *commitIndexReader) all() (map[string]*indexedCommit, error) {
+	for r.readNext() {
+	}
+	return r.commits, r.err
+}
+
+// readNext reads the newest segment which has not been read yet. It returns
+// false if all segments have been read or reading one failed.
+func (r *commitIndexReader) readNext() bool {
+	if r.err != nil || r.read == len(r.idx.segments) {
+		return false
+	}
+	sf := r.idx.segments[len(r.idx.segments)-1-r.read]
+	seg, err := readCommitIndexSegment(filepath.Join(r.idx.dir, sf.name))
+	if err != nil {
+		// The segment may have been merged into a new one by a concurrent
+		// update.
+		r.err = errors.Wrapf(err, "reading commit index segment %s", sf.name)
+		return false
+	}
+	for i, oid := range seg.Oids {
+		r.commits[oid] = &seg.Commits[i]
+	}
+	r.read++
+	return true
+}
+
+// listCommitIndexSegments returns the segment files in indexDir ordered by
+// their number.
+func listCommitIndexSegments(indexDir string) ([]segmentFile, error) {
+	entries, err := os.ReadDir(indexDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var segments []segmentFile
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".gob") {
+			continue
+		}
+		fi, err := e.Info()
+		if err != nil {
+			if os.IsNotExist(err) {
+				// Removed by a concurrent update.
+				continue
+			}
+			return nil, err
+		}
+		segments = append(segments, segmentFile{name: e.Name(), size: fi.Size(), modTime: fi.ModTime()})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segmentNumber(segments[i].name) < segmentNumber(segments[j].name)
+	})
+	return segments, nil
+}
+
+func readCommitIndexSegmentHeader(path string) (*commitIndexSegmentHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var header commitIndexSegmentHeader
+	if err := gob.NewDecoder(f).Decode(&header); err != nil {
+		return nil, err
+	}
+	return &header, nil
+}
+
+func readCommitIndexSegment(path string) (*commitIndexSegment, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var seg commitIndexSegment
+	dec := gob.NewDecoder(f)
+	if err := dec.Decode(&seg.commitIndexSegmentHeader); err != nil {
+		return nil, err
+	}
+	if err := dec.Decode(&seg.commitIndexSegmentCommits); err != nil {
+		return nil, err
+	}
+	if len(seg.Oids) != len(seg.Commits) {
+		return nil, errors.New("corrupt segment")
+	}
+	return &seg, nil
+}
+
+// HasCommitIndex returns true if the commit index of the repository in the
+// git directory dir has been built.
+func HasCommitIndex(dir string) (bool, error) {
+	segments, err := listCommitIndexSegments(filepath.Join(dir, commitIndexDirName))
+	return len(segments) > 0, err
+}
+
+// UpdateCommitIndex brings the commit index of the repository in the git
+// directory dir up to date with its refs by indexing the commits that became
+// reachable since the last update. The index is built from scratch if it
+// doesn't exist, can't be read or if .mailmap changed. Updates of the same
+// repository must not run concurrently.
+func UpdateCommitIndex(ctx context.Context, dir string) error {
+	idx, err := loadCommitIndex(dir)
+	if err != nil {
+		idx = nil
+	}
+
+	tips, err := refTipCommits(ctx, dir)
+	if err != nil {
+		return err
+	}
+	mailmap, err := mailmapBlob(ctx, dir)
+	if err != nil {
+		return err
+	}
+	if idx != nil && idx.mailmap != mailmap {
+		// The names and emails of authors and committers of all commits may
+		// have changed.
+		idx = nil
+	}
+	if idx != nil && stringsEqual(idx.tips, tips) {
+		return nil
+	}
+
+	var exclude []string
+	if idx != nil {
+		// Old tips may have been garbage collected after a force push.
+		if exclude, err = existingCommits(ctx, dir, idx.tips); err != nil {
+			return err
+		}
+	}
+	seg, err := indexCommits(ctx, dir, tips, exclude)
+	if err != nil {
+		return err
+	}
+	seg.Mailmap = mailmap
+
+	indexDir := filepath.Join(dir, commitIndexDirName)
+	if err := os.MkdirAll(indexDir, os.ModePerm); err != nil {
+		return err
+	}
+	// We continue the numbering of the existing segments, also when we
+	// rebuild the index, so that the name of a segment is never reused.
+	existing, err := listCommitIndexSegments(indexDir)
+	if err != nil {
+		return err
+	}
+	next := 0
+	if len(existing) > 0 {
+		next = segmentNumber(existing[len(existing)-1].name) + 1
+	}
+	var obsolete []segmentFile
+	switch {
+	case idx == nil:
+		obsolete = existing
+	case len(idx.segments)+1 >= maxCommitIndexSegments:
+		// Merge all segments into one so that searches don't read ever more
+		// files.
+		commits, err := idx.reader().all()
+		if err != nil {
+			return err
+		}
+		for oid, c := range commits {
+			seg.Oids = append(seg.Oids, oid)
+			seg.Commits = append(seg.Commits, *c)
+		}
+		obsolete = idx.segments
+	}
+
+	if err := writeCommitIndexSegment(filepath.Join(indexDir, fmt.Sprintf("%010d.gob", next)), seg); err != nil {
+		return err
+	}
+	for _, sf := range obsolete {
+		if err := os.Remove(filepath.Join(indexDir, sf.name)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+func segmentNumber(name string) int {
+	n, _ := strconv.Atoi(strings.TrimSuffix(name, ".gob"))
+	return n
+}
+
+// writeCommitIndexSegment atomically writes seg to path.
+func writeCommitIndexSegment(path string, seg *commitIndexSegment) (err error) {
+	f, err := os.CreateTemp(filepath.Dir(path), "tmp-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+
+	enc := gob.NewEncoder(f)
+	if err := enc.Encode(&seg.commitIndexSegmentHeader); err != nil {
+		f.Close()
+		return err
+	}
+	if err := enc.Encode(&seg.commitIndexSegmentCommits); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// refTipCommits returns the sorted IDs of the commits the refs of the
+// repository point to.
+func refTipCommits(ctx context.Context, dir string) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-list", "--no-walk", "--all")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "git rev-list")
+	}
+	tips := strings.Fields(string(out))
+	sort.Strings(tips)
+	return tips, nil
+}
+
+// mailmapBlob returns the ID of the .mailmap blob at HEAD, which git uses to
+// map the names and emails of authors and committers in bare repositories, or
+// "" if there is none.
+func mailmapBlob(ctx context.Context, dir string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", "HEAD:.mailmap")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "git rev-parse")
+	}
+	return string(bytes.TrimSpace(out)), nil
+}
+
+// existingCommits returns the commits that still exist in the repository.
+func existingCommits(ctx context.Context, dir string, commits []string) ([]string, error) {
+	if len(commits) == 0 {
+		return nil, nil
+	}
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "--batch-check=%(objectname) %(objecttype)")
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(strings.Join(commits, "\n") + "\n")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "git cat-file")
+	}
+	var existing []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if oid, typ, ok := strings.Cut(line, " "); ok && typ == "commit" {
+			existing = append(existing, oid)
+		}
+	}
+	return existing, nil
+}
+
+// indexCommits returns a segment with the commits reachable from tips but not
+// from exclude.
+func indexCommits(ctx context.Context, dir string, tips, exclude []string) (*commitIndexSegment, error) {
+	seg := &commitIndexSegment{commitIndexSegmentHeader: commitIndexSegmentHeader{Tips: tips}}
+	if len(tips) == 0 {
+		// git log would default to HEAD.
+		return seg, nil
+	}
+
+	var stdin strings.Builder
+	for _, tip := range tips {
+		stdin.WriteString(tip + "\n")
+	}
+	for _, tip := range exclude {
+		stdin.WriteString("^" + tip + "\n")
+	}
+
+	args := append(append([]string{}, logArgs...), "--name-status", "--stdin")
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stdin = strings.NewReader(stdin.String())
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	scanner := NewCommitScanner(stdout)
+	for scanner.Scan() {
+		lc := &LazyCommit{RawCommit: scanner.NextRawCommit()}
+		committerDate, err := lc.CommitterDate()
+		if err != nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+			return nil, errors.Wrapf(err, "invalid committer date of commit %s", lc.Hash)
+		}
+		c := indexedCommit{
+			AuthorName:     string(lc.AuthorName),
+			AuthorEmail:    string(lc.AuthorEmail),
+			CommitterName:  string(lc.CommitterName),
+			CommitterEmail: string(lc.CommitterEmail),
+			CommitterDate:  committerDate.Unix(),
+			Trigrams:       trigramFilter(lc.Message),
+		}
+		if paths := lc.ModifiedFiles(); len(paths) > maxIndexedPaths {
+			c.PathsTruncated = true
+		} else {
+			c.Paths = paths
+		}
+		seg.Oids = append(seg.Oids, string(lc.Hash))
+		seg.Commits = append(seg.Commits, c)
+	}
+	if err := scanner.Err(); err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return nil, err
+	}
+	if err := cmd.Wait(); err != nil {
+		return nil, errors.Wrapf(err, "git log failed with stderr %q", stderr.String())
+	}
+	return seg, nil
+}
+
+// commitPruner reports whether a commit with the indexed metadata c may match
+// a query. It may report false positives but never false negatives.
+type commitPruner func(c *indexedCommit, lowerBuf *[]byte) bool
+
+func mayMatchAll(*indexedCommit, *[]byte) bool { return true }
+
+// newCommitPruner returns the commitPruner for mt.
+func newCommitPruner(mt MatchTree) commitPruner {
+	switch v := mt.(type) {
+	case *AuthorMatches:
+		return func(c *indexedCommit, lowerBuf *[]byte) bool {
+			return v.Regexp.Match([]byte(c.AuthorName), lowerBuf) || v.Regexp.Match([]byte(c.AuthorEmail), lowerBuf)
+		}
+	case *CommitterMatches:
+		return func(c *indexedCommit, lowerBuf *[]byte) bool {
+			return v.Regexp.Match([]byte(c.CommitterName), lowerBuf) || v.Regexp.Match([]byte(c.CommitterEmail), lowerBuf)
+		}
+	case *CommitBefore:
+		return func(c *indexedCommit, _ *[]byte) bool {
+			return time.Unix(c.CommitterDate, 0).Before(v.Time)
+		}
+	case *CommitAfter:
+		return func(c *indexedCommit, _ *[]byte) bool {
+			return time.Unix(c.CommitterDate, 0).After(v.Time)
+		}
+	case *MessageMatches:
+		trigrams := requiredTrigrams(v.Regexp)
+		if len(trigrams) == 0 {
+			return mayMatchAll
+		}
+		return func(c *indexedCommit, _ *[]byte) bool {
+			for _, t := range trigrams {
+				if !trigramFilterContains(c.Trigrams, t) {
+					return false
+				}
+			}
+			return true
+		}
+	case *DiffModifiesFile:
+		return func(c *indexedCommit, lowerBuf *[]byte) bool {
+			if c.PathsTruncated {
+				return true
+			}
+			for _, path := range c.Paths {
+				if v.Regexp.Match([]byte(path), lowerBuf) {
+					return true
+				}
+			}
+			return false
+		}
+	case *Constant:
+		return func(*indexedCommit, *[]byte) bool {
+			return v.Value
+		}
+	case *Operator:
+		operands := make([]commitPruner, 0, len(v.Operands))
+		for _, operand := range v.Operands {
+			operands = append(operands, newCommitPruner(operand))
+		}
+		switch v.Kind {
+		case protocol.And:
+			return func(c *indexedCommit, lowerBuf *[]byte) bool {
+				for _, operand := range operands {
+					if !operand(c, lowerBuf) {
+						return false
+					}
+				}
+				return true
+			}
+		case protocol.Or:
+			return func(c *indexedCommit, lowerBuf *[]byte) bool {
+				for _, operand := range operands {
+					if operand(c, lowerBuf) {
+						return true
+					}
+				}
+				return false
+			}
+		}
+	}
+	// We can't tell from the index whether a commit doesn't match a negation
+	// or the content of its diff.
+	return mayMatchAll
+}
+
+// trigram packs the first three bytes of b into a uint32.
+func trigram(b []byte) uint32 {
+	return uint32(b[0])<<16 | uint32(b[1])<<8 | uint32(b[2])
+}
+
+// trigramFilterBits returns the two bits set for trigram t in a bloom filter
+// of n bits.
+func trigramFilterBits(t uint32, n int) (uint32, uint32) {
+	h1 := t * 0x9e3779b1
+	h2 := (t ^ t>>15) * 0x85ebca6b
+	return h1 % uint32(n), h2 % uint32(n)
+}
+
+// trigramFilter returns a bloom filter of the trigrams of the ASCII-lowercased
+// msg, or nil if msg has no trigrams.
+func trigramFilter(msg []byte) []uint64 {
+	lower := make([]byte, len(msg))
+	casetransform.BytesToLowerASCII(lower, msg)
+
+	trigrams := make(map[uint32]struct{})
+	for i := 0; i+3 <= len(lower); i++ {
+		trigrams[trigram(lower[i:])] = struct{}{}
+	}
+	if len(trigrams) == 0 {
+		return nil
+	}
+
+	filter := make([]uint64, (len(trigrams)*trigramFilterBitsPerTrigram+63)/64)
+	for t := range trigrams {
+		b1, b2 := trigramFilterBits(t, len(filter)*64)
+		filter[b1/64] |= 1 << (b1 % 64)
+		filter[b2/64] |= 1 << (b2 % 64)
+	}
+	return filter
+}
+
+func trigramFilterContains(filter []uint64, t uint32) bool {
+	if len(filter) == 0 {
+		return false
+	}
+	b1, b2 := trigramFilterBits(t, len(filter)*64)
+	return filter[b1/64]&(1<<(b1%64)) != 0 && filter[b2/64]&(1<<(b2%64)) != 0
+}
+
+// requiredTrigrams returns the ASCII-lowercased trigrams that every text
+// matched by re contains.
+func requiredTrigrams(re *casetransform.Regexp) []uint32 {
+	syn, err := syntax.Parse(re.String(), syntax.Perl)
+	if err != nil {
+		return nil
+	}
+
+	var trigrams []uint32
+	for _, lit := range requiredLiterals(syn) {
+		lower := make([]byte, len(lit))
+		casetransform.BytesToLowerASCII(lower, []byte(lit))
+		for i := 0; i+3 <= len(lower); i++ {
+			trigrams = append(trigrams, trigram(lower[i:]))
+		}
+	}
+	return trigrams
+}
+
+// requiredLiterals returns strings that every text matched by re contains.
+func requiredLiterals(re *syntax.Regexp) []string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		if re.Flags&syntax.FoldCase == 0 {
+			return []string{string(re.Rune)}
+		}
+		// A case-insensitive literal also matches runes that fold to its
+		// runes, like the Kelvin sign for k. Only runs of runes whose folds
+		// are all ASCII are required, since we lowercase ASCII only.
+		var lits []string
+		var run []rune
+		for _, r := range re.Rune {
+			if asciiFolds(r) {
+				run = append(run, r)
+				continue
+			}
+			if len(run) > 0 {
+				lits = append(lits, string(run))
+				run = nil
+			}
+		}
+		if len(run) > 0 {
+			lits = append(lits, string(run))
+		}
+		return lits
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiterals(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min >= 1 {
+			return requiredLiterals(re.Sub[0])
+		}
+	case syntax.OpConcat:
+		var lits []string
+		for _, sub := range re.Sub {
+			lits = append(lits, requiredLiterals(sub)...)
+		}
+		return lits
+	}
+	return nil
+}
+
+// asciiFolds reports whether r and all the runes it case-folds to are ASCII.
+func asciiFolds(r rune) bool {
+	if r >= utf8.RuneSelf {
+		return false
+	}
+	for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+		if f >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+func segmentsEqual(a, b []segmentFile) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].name != b[i].name || a[i].size != b[i].size || !a[i].modTime.Equal(b[i].modTime) {
+			return false
+		}
+	}
+	return true
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,204 @@
+package search
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp/syntax" //nolint:depguard // using the grafana fork of regexp clashes with zoekt, which uses the std regexp/syntax.
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+func TestRequiredLiterals(t *testing.T) {
+	cases := []struct {
+		pattern string
+		want    []string
+	}{
+		{"foo", []string{"foo"}},
+		{"foo.*bar", []string{"foo", "bar"}},
+		{"(foo)+bar?", []string{"foo", "ba"}},
+		{"(?:abc){2,}", []string{"abc"}},
+		{"foo|bar", nil},
+		{"(foo)*", nil},
+		{"[ab]cde", []string{"cde"}},
+		// k and s fold to non-ASCII runes.
+		{"(?i)mask", []string{"MA"}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.pattern, func(t *testing.T) {
+			re, err := syntax.Parse(tc.pattern, syntax.Perl)
+			require.NoError(t, err)
+			require.Equal(t, tc.want, requiredLiterals(re))
+		})
+	}
+}
+
+func TestCommitIndex(t *testing.T) {
+	commit := func(name, date, msg string) string {
+		return "GIT_COMMITTER_NAME=" + name + " GIT_COMMITTER_EMAIL=" + name + "@example.com GIT_COMMITTER_DATE=" + date +
+			" GIT_AUTHOR_NAME=" + name + " GIT_AUTHOR_EMAIL=" + name + "@example.com GIT_AUTHOR_DATE=" + date +
+			" git commit -m '" + msg + "'"
+	}
+	dir := initGitRepository(t,
+		"echo lorem ipsum > file1",
+		"git add -A",
+		commit("alice", "2006-01-02T15:04:05Z", "Add lorem"),
+		"git checkout -b feature",
+		"echo dolor sit amet > file2",
+		"git add -A",
+		commit("bob", "2007-01-02T15:04:05Z", "Fix the widget renderer"),
+		"git checkout -",
+		"echo consectetur > file3",
+		"git add -A",
+		commit("alice", "2008-01-02T15:04:05Z", "Update docs"),
+	)
+	ctx := context.Background()
+	require.NoError(t, UpdateCommitIndex(ctx, dir))
+
+	search := func(t *testing.T, q protocol.Node, useIndex bool) []*protocol.CommitMatch {
+		t.Helper()
+		tree, err := ToMatchTree(q)
+		require.NoError(t, err)
+		searcher := &CommitSearcher{
+			RepoDir:              dir,
+			Query:                tree,
+			Revisions:            []protocol.RevisionSpecifier{{RefGlob: "refs/heads/*"}},
+			IncludeModifiedFiles: true,
+			UseCommitIndex:       useIndex,
+		}
+		var matches []*protocol.CommitMatch
+		err = searcher.Search(ctx, func(match *protocol.CommitMatch) {
+			matches = append(matches, match)
+		})
+		require.NoError(t, err)
+		return matches
+	}
+
+	queries := map[string]protocol.Node{
+		"message":           &protocol.MessageMatches{Expr: "widget", IgnoreCase: true},
+		"message no match":  &protocol.MessageMatches{Expr: "gadget"},
+		"author":            &protocol.AuthorMatches{Expr: "alice"},
+		"after":             &protocol.CommitAfter{Time: time.Date(2007, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"file":              &protocol.DiffModifiesFile{Expr: "file2"},
+		"diff":              &protocol.DiffMatches{Expr: "consectetur"},
+		"not":               protocol.NewNot(&protocol.AuthorMatches{Expr: "alice"}),
+		"or":                protocol.NewOr(&protocol.AuthorMatches{Expr: "bob"}, &protocol.MessageMatches{Expr: "docs"}),
+		"and":               protocol.NewAnd(&protocol.AuthorMatches{Expr: "alice"}, &protocol.MessageMatches{Expr: "lorem", IgnoreCase: true}),
+		"and with no match": protocol.NewAnd(&protocol.AuthorMatches{Expr: "bob"}, &protocol.DiffModifiesFile{Expr: "file3"}),
+	}
+	for name, q := range queries {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, search(t, q, false), search(t, q, true))
+		})
+	}
+
+	t.Run("pruning", func(t *testing.T) {
+		commits := indexedCommits(t, dir)
+		require.Len(t, commits, 3)
+
+		tree, err := ToMatchTree(protocol.NewAnd(
+			&protocol.MessageMatches{Expr: "WIDGET", IgnoreCase: true},
+			&protocol.DiffModifiesFile{Expr: "file2"},
+		))
+		require.NoError(t, err)
+		mayMatch := newCommitPruner(tree)
+		var candidates []string
+		for _, c := range commits {
+			if mayMatch(c, new([]byte)) {
+				candidates = append(candidates, c.AuthorName)
+			}
+		}
+		require.Equal(t, []string{"bob"}, candidates)
+	})
+
+	t.Run("stale index", func(t *testing.T) {
+		out, err := gitCommand(dir, "bash", "-c", "echo more > file4 && git add file4 && "+commit("carol", "2009-01-02T15:04:05Z", "Add more widgets")).CombinedOutput()
+		require.NoError(t, err, string(out))
+
+		q := &protocol.MessageMatches{Expr: "widget"}
+		require.Len(t, search(t, q, true), 2)
+
+		require.NoError(t, UpdateCommitIndex(ctx, dir))
+		idx, err := loadCommitIndex(dir)
+		require.NoError(t, err)
+		require.Len(t, idx.segments, 2)
+		require.Len(t, indexedCommits(t, dir), 4)
+		require.Equal(t, search(t, q, false), search(t, q, true))
+
+		// Looking up the new commit only reads the newest segment.
+		head, err := gitCommand(dir, "git", "rev-parse", "HEAD").Output()
+		require.NoError(t, err)
+		r := idx.reader()
+		_, ok := r.lookup(strings.TrimSpace(string(head)))
+		require.True(t, ok)
+		require.Equal(t, 1, r.read)
+	})
+
+	t.Run("merges segments", func(t *testing.T) {
+		for i := 0; i < maxCommitIndexSegments; i++ {
+			out, err := gitCommand(dir, "bash", "-c", commit("dave", "2010-01-02T15:04:05Z", "empty")+" --allow-empty").CombinedOutput()
+			require.NoError(t, err, string(out))
+			require.NoError(t, UpdateCommitIndex(ctx, dir))
+		}
+		segments, err := filepath.Glob(filepath.Join(dir, commitIndexDirName, "*.gob"))
+		require.NoError(t, err)
+		require.Less(t, len(segments), maxCommitIndexSegments)
+
+		require.Len(t, indexedCommits(t, dir), 4+maxCommitIndexSegments)
+	})
+
+	t.Run("rebuilds corrupt index", func(t *testing.T) {
+		segments, err := filepath.Glob(filepath.Join(dir, commitIndexDirName, "*.gob"))
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(segments[len(segments)-1], []byte("garbage"), 0o600))
+		commitIndexes.Remove(dir)
+		_, err = loadCommitIndex(dir)
+		require.Error(t, err)
+
+		require.NoError(t, UpdateCommitIndex(ctx, dir))
+		require.Len(t, indexedCommits(t, dir), 4+maxCommitIndexSegments)
+	})
+	t.Run("rebuild invalidates cached index", func(t *testing.T) {
+		before, err := loadCommitIndex(dir)
+		require.NoError(t, err)
+		require.Len(t, before.segments, 1)
+
+		// Changing .mailmap rebuilds the index under a new segment name.
+		out, err := gitCommand(dir, "bash", "-c", "echo 'Alice <alice@example.com> <alice@example.com>' > .mailmap && git add .mailmap && "+commit("erin", "2011-01-02T15:04:05Z", "Add mailmap")).CombinedOutput()
+		require.NoError(t, err, string(out))
+		require.NoError(t, UpdateCommitIndex(ctx, dir))
+		idx, err := loadCommitIndex(dir)
+		require.NoError(t, err)
+		require.Len(t, idx.segments, 1)
+		require.Greater(t, segmentNumber(idx.segments[0].name), segmentNumber(before.segments[0].name))
+		commits := indexedCommits(t, dir)
+		require.Len(t, commits, 5+maxCommitIndexSegments)
+		root, err := gitCommand(dir, "git", "rev-list", "--max-parents=0", "HEAD").Output()
+		require.NoError(t, err)
+		require.Equal(t, "Alice", commits[strings.TrimSpace(string(root))].AuthorName)
+
+		// A recreated index reuses segment names, but not their size and
+		// modification time.
+		require.NoError(t, os.RemoveAll(filepath.Join(dir, commitIndexDirName)))
+		out, err = gitCommand(dir, "bash", "-c", commit("frank", "2012-01-02T15:04:05Z", "empty")+" --allow-empty").CombinedOutput()
+		require.NoError(t, err, string(out))
+		require.NoError(t, UpdateCommitIndex(ctx, dir))
+		require.Len(t, indexedCommits(t, dir), 6+maxCommitIndexSegments)
+	})
+}
+
+// indexedCommits returns all commits of the commit index of the repository in
+// dir.
+func indexedCommits(t *testing.T, dir string) map[string]*indexedCommit {
+	t.Helper()
+	idx, err := loadCommitIndex(dir)
+	require.NoError(t, err)
+	commits, err := idx.reader().all()
+	require.NoError(t, err)
+	return commits
+}
@@ -89,6 +89,11 @@ type CommitSearcher struct {
 	IncludeDiff          bool
 	IncludeModifiedFiles bool
 	RepoName             api.RepoName
+
+	// UseCommitIndex makes the search skip the commits which the commit index
+	// of the repository shows can't match Query. If the index doesn't cover
+	// all searched revisions, all commits are searched.
+	UseCommitIndex bool
 }
 
 // Search runs a search for commits matching the given predicate across the revisions passed in as revisionArgs.
@@ -144,6 +149,15 @@ func (cs *CommitSearcher) gitArgs() []string {
 }
 
 func (cs *CommitSearcher) feedBatches(ctx context.Context, jobs chan job, resultChans chan chan *protocol.CommitMatch) (err error) {
+	if cs.UseCommitIndex {
+		idx, err := cs.commitIndex(ctx)
+		if err != nil {
+			cs.Logger.Warn("failed to load commit index, searching all commits", log.Error(err))
+		} else if idx != nil {
+			return cs.feedIndexedBatches(ctx, idx, jobs, resultChans)
+		}
+	}
+
 	cmd := exec.CommandContext(ctx, "git", cs.gitArgs()...)
 	cmd.Dir = cs.RepoDir
 	stdoutReader, err := cmd.StdoutPipe()
@@ -165,16 +179,6 @@ func (cs *CommitSearcher) feedBatches(ctx context.Context, jobs chan job, result
 	}()
 
 	batch := make([]*RawCommit, 0, batchSize)
-	sendBatch := func() {
-		resultChan := make(chan *protocol.CommitMatch, 128)
-		resultChans <- resultChan
-		jobs <- job{
-			batch:      batch,
-			resultChan: resultChan,
-		}
-		batch = make([]*RawCommit, 0, batchSize)
-	}
-
 	scanner := NewCommitScanner(stdoutReader)
 	for scanner.Scan() {
 		if ctx.Err() != nil {
@@ -183,17 +187,157 @@ func (cs *CommitSearcher) feedBatches(ctx context.Context, jobs chan job, result
 		cv := scanner.NextRawCommit()
 		batch = append(batch, cv)
 		if len(batch) == batchSize {
-			sendBatch()
+			sendBatch(jobs, resultChans, batch)
+			batch = make([]*RawCommit, 0, batchSize)
 		}
 	}
 
 	if len(batch) > 0 {
-		sendBatch()
+		sendBatch(jobs, resultChans, batch)
+	}
+
+	return scanner.Err()
+}
+
+func sendBatch(jobs chan job, resultChans chan chan *protocol.CommitMatch, batch []*RawCommit) {
+	resultChan := make(chan *protocol.CommitMatch, 128)
+	resultChans <- resultChan
+	jobs <- job{
+		batch:      batch,
+		resultChan: resultChan,
+	}
+}
+
+// commitIndex returns a reader of the commit index of the repository if it
+// covers all searched revisions, or nil otherwise.
+func (cs *CommitSearcher) commitIndex(ctx context.Context) (*commitIndexReader, error) {
+	idx, err := loadCommitIndex(cs.RepoDir)
+	if err != nil || idx == nil {
+		return nil, err
+	}
+
+	// The ancestors of indexed commits are indexed, so the index covers the
+	// revisions if it contains the commits the walk starts from.
+	cmd := exec.CommandContext(ctx, "git", append([]string{"rev-list", "--no-walk"}, revsToGitArgs(cs.Revisions)...)...)
+	cmd.Dir = cs.RepoDir
+	out, err := cmd.Output()
+	if err != nil {
+		// Let the full walk report invalid revisions.
+		return nil, nil
+	}
+	r := idx.reader()
+	for _, commit := range strings.Fields(string(out)) {
+		if _, ok := r.lookup(commit); !ok {
+			// The index is stale.
+			return nil, r.err
+		}
+	}
+	return r, nil
+}
+
+// feedIndexedBatches is like feedBatches, but only reads the commits which
+// the commit index shows may match the query.
+//
+// We walk the revisions with a git log that only outputs commit hashes and
+// the refs they were reached from, which is much cheaper than reading whole
+// commits and their modified files. We then read the remaining candidates in
+// batches, in the order of the walk.
+func (cs *CommitSearcher) feedIndexedBatches(ctx context.Context, idx *commitIndexReader, jobs chan job, resultChans chan chan *protocol.CommitMatch) (err error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"log", "--format=format:%H%x00%S"}, revsToGitArgs(cs.Revisions)...)...)
+	cmd.Dir = cs.RepoDir
+	stdoutReader, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	var stderrBuf bytes.Buffer
+	cmd.Stderr = &stderrBuf
+
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	defer func() {
+		// Always call cmd.Wait to avoid leaving zombie processes around.
+		if e := cmd.Wait(); e != nil {
+			err = errors.Append(err, tryInterpretErrorWithStderr(ctx, err, stderrBuf.String(), cs.Logger))
+		}
+	}()
+
+	mayMatch := newCommitPruner(cs.Query)
+	var lowerBuf []byte
+
+	hashes := make([]string, 0, batchSize)
+	sourceRefs := make([][]byte, 0, batchSize)
+	readBatch := func() error {
+		batch, err := cs.readCommits(ctx, hashes)
+		if err != nil {
+			return err
+		}
+		for i, cv := range batch {
+			// The refs a commit was reached from are only known to the walk.
+			cv.SourceRefs = sourceRefs[i]
+		}
+		sendBatch(jobs, resultChans, batch)
+		hashes = make([]string, 0, batchSize)
+		sourceRefs = make([][]byte, 0, batchSize)
+		return nil
+	}
+
+	scanner := bufio.NewScanner(stdoutReader)
+	for scanner.Scan() {
+		if ctx.Err() != nil {
+			return nil
+		}
+		hash, source, _ := bytes.Cut(scanner.Bytes(), sep)
+		if c, ok := idx.lookup(string(hash)); ok && !mayMatch(c, &lowerBuf) {
+			continue
+		}
+		hashes = append(hashes, string(hash))
+		sourceRefs = append(sourceRefs, append([]byte(nil), source...))
+		if len(hashes) == batchSize {
+			if err := readBatch(); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(hashes) > 0 {
+		if err := readBatch(); err != nil {
+			return err
+		}
 	}
 
 	return scanner.Err()
 }
 
+// readCommits reads the commits with the given hashes, in order.
+func (cs *CommitSearcher) readCommits(ctx context.Context, hashes []string) ([]*RawCommit, error) {
+	args := append(append([]string{}, logArgs...), "--no-walk=unsorted", "--stdin")
+	if cs.IncludeModifiedFiles {
+		args = append(args, "--name-status")
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = cs.RepoDir
+	cmd.Stdin = strings.NewReader(strings.Join(hashes, "\n") + "\n")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, errors.Wrap(err, "git log")
+	}
+
+	batch := make([]*RawCommit, 0, len(hashes))
+	scanner := NewCommitScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		batch = append(batch, scanner.NextRawCommit())
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(batch) != len(hashes) {
+		return nil, errors.Errorf("expected %d commits, got %d", len(hashes), len(batch))
+	}
+	return batch, nil
+}
+
 func tryInterpretErrorWithStderr(ctx context.Context, err error, stderr string, logger log.Logger) error {
 	if ctx.Err() != nil {
 		// Ignore errors when context is cancelled
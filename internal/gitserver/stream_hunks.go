@@ -2,12 +2,15 @@ package gitserver
 
 import (
 	"bufio"
+	"context"
 	"io"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	proto "github.com/sourcegraph/sourcegraph/internal/gitserver/v1"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -173,6 +176,55 @@ func splitLine(line string) (annotation string, content string) {
 	return line, ""
 }
 
+// grpcBlameHunkReader reads the hunks gitserver sends with the Blame RPC.
+type grpcBlameHunkReader struct {
+	stream proto.GitserverService_BlameClient
+	// next is the next response to return, if it was already received.
+	next   *proto.BlameResponse
+	done   bool
+	cancel context.CancelFunc
+}
+
+func (r *grpcBlameHunkReader) Read() (*Hunk, error) {
+	msg := r.next
+	r.next = nil
+	if msg == nil {
+		if r.done {
+			return nil, io.EOF
+		}
+		var err error
+		msg, err = r.stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				r.done = true
+				return nil, io.EOF
+			}
+			return nil, convertGitserverError(err)
+		}
+	}
+
+	h := msg.GetHunk()
+	return &Hunk{
+		StartLine: int(h.GetStartLine()),
+		EndLine:   int(h.GetEndLine()),
+		StartByte: int(h.GetStartByte()),
+		EndByte:   int(h.GetEndByte()),
+		CommitID:  api.CommitID(h.GetCommit()),
+		Author: gitdomain.Signature{
+			Name:  h.GetAuthor().GetName(),
+			Email: h.GetAuthor().GetEmail(),
+			Date:  h.GetAuthor().GetDate().AsTime(),
+		},
+		Message:  h.GetMessage(),
+		Filename: h.GetFilename(),
+	}, nil
+}
+
+func (r *grpcBlameHunkReader) Close() error {
+	r.cancel()
+	return nil
+}
+
 type mockHunkReader struct {
 	hunks []*Hunk
 	err   error
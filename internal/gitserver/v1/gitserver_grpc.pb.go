@@ -33,6 +33,10 @@ const (
 	GitserverService_RepoDelete_FullMethodName                  = "/gitserver.v1.GitserverService/RepoDelete"
 	GitserverService_RepoUpdate_FullMethodName                  = "/gitserver.v1.GitserverService/RepoUpdate"
 	GitserverService_ReposStats_FullMethodName                  = "/gitserver.v1.GitserverService/ReposStats"
+	GitserverService_BatchReadFiles_FullMethodName              = "/gitserver.v1.GitserverService/BatchReadFiles"
+	GitserverService_ReadLFSObject_FullMethodName               = "/gitserver.v1.GitserverService/ReadLFSObject"
+	GitserverService_Blame_FullMethodName                       = "/gitserver.v1.GitserverService/Blame"
+	GitserverService_RepoPack_FullMethodName                    = "/gitserver.v1.GitserverService/RepoPack"
 )
 
 // GitserverServiceClient is the client API for GitserverService service.
@@ -53,6 +57,10 @@ type GitserverServiceClient interface {
 	RepoDelete(ctx context.Context, in *RepoDeleteRequest, opts ...grpc.CallOption) (*RepoDeleteResponse, error)
 	RepoUpdate(ctx context.Context, in *RepoUpdateRequest, opts ...grpc.CallOption) (*RepoUpdateResponse, error)
 	ReposStats(ctx context.Context, in *ReposStatsRequest, opts ...grpc.CallOption) (*ReposStatsResponse, error)
+	BatchReadFiles(ctx context.Context, in *BatchReadFilesRequest, opts ...grpc.CallOption) (GitserverService_BatchReadFilesClient, error)
+	ReadLFSObject(ctx context.Context, in *ReadLFSObjectRequest, opts ...grpc.CallOption) (GitserverService_ReadLFSObjectClient, error)
+	Blame(ctx context.Context, in *BlameRequest, opts ...grpc.CallOption) (GitserverService_BlameClient, error)
+	RepoPack(ctx context.Context, in *RepoPackRequest, opts ...grpc.CallOption) (GitserverService_RepoPackClient, error)
 }
 
 type gitserverServiceClient struct {
@@ -281,6 +289,133 @@ func (c *gitserverServiceClient) ReposStats(ctx context.Context, in *ReposStatsR
 	return out, nil
 }
 
+func (c *gitserverServiceClient) BatchReadFiles(ctx context.Context, in *BatchReadFilesRequest, opts ...grpc.CallOption) (GitserverService_BatchReadFilesClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GitserverService_ServiceDesc.Streams[4], GitserverService_BatchReadFiles_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitserverServiceBatchReadFilesClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitserverService_BatchReadFilesClient interface {
+	Recv() (*BatchReadFilesResponse, error)
+	grpc.ClientStream
+}
+
+type gitserverServiceBatchReadFilesClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitserverServiceBatchReadFilesClient) Recv() (*BatchReadFilesResponse, error) {
+	m := new(BatchReadFilesResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+func (c *gitserverServiceClient) ReadLFSObject(ctx context.Context, in *ReadLFSObjectRequest, opts ...grpc.CallOption) (GitserverService_ReadLFSObjectClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GitserverService_ServiceDesc.Streams[5], GitserverService_ReadLFSObject_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitserverServiceReadLFSObjectClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitserverService_ReadLFSObjectClient interface {
+	Recv() (*ReadLFSObjectResponse, error)
+	grpc.ClientStream
+}
+
+type gitserverServiceReadLFSObjectClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitserverServiceReadLFSObjectClient) Recv() (*ReadLFSObjectResponse, error) {
+	m := new(ReadLFSObjectResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gitserverServiceClient) Blame(ctx context.Context, in *BlameRequest, opts ...grpc.CallOption) (GitserverService_BlameClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GitserverService_ServiceDesc.Streams[6], GitserverService_Blame_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitserverServiceBlameClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitserverService_BlameClient interface {
+	Recv() (*BlameResponse, error)
+	grpc.ClientStream
+}
+
+type gitserverServiceBlameClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitserverServiceBlameClient) Recv() (*BlameResponse, error) {
+	m := new(BlameResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *gitserverServiceClient) RepoPack(ctx context.Context, in *RepoPackRequest, opts ...grpc.CallOption) (GitserverService_RepoPackClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GitserverService_ServiceDesc.Streams[7], GitserverService_RepoPack_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &gitserverServiceRepoPackClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GitserverService_RepoPackClient interface {
+	Recv() (*RepoPackResponse, error)
+	grpc.ClientStream
+}
+
+type gitserverServiceRepoPackClient struct {
+	grpc.ClientStream
+}
+
+func (x *gitserverServiceRepoPackClient) Recv() (*RepoPackResponse, error) {
+	m := new(RepoPackResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // GitserverServiceServer is the server API for GitserverService service.
 // All implementations must embed UnimplementedGitserverServiceServer
 // for forward compatibility
@@ -299,6 +434,10 @@ type GitserverServiceServer interface {
 	RepoDelete(context.Context, *RepoDeleteRequest) (*RepoDeleteResponse, error)
 	RepoUpdate(context.Context, *RepoUpdateRequest) (*RepoUpdateResponse, error)
 	ReposStats(context.Context, *ReposStatsRequest) (*ReposStatsResponse, error)
+	BatchReadFiles(*BatchReadFilesRequest, GitserverService_BatchReadFilesServer) error
+	ReadLFSObject(*ReadLFSObjectRequest, GitserverService_ReadLFSObjectServer) error
+	Blame(*BlameRequest, GitserverService_BlameServer) error
+	RepoPack(*RepoPackRequest, GitserverService_RepoPackServer) error
 	mustEmbedUnimplementedGitserverServiceServer()
 }
 
@@ -348,6 +487,18 @@ func (UnimplementedGitserverServiceServer) RepoUpdate(context.Context, *RepoUpda
 func (UnimplementedGitserverServiceServer) ReposStats(context.Context, *ReposStatsRequest) (*ReposStatsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ReposStats not implemented")
 }
+func (UnimplementedGitserverServiceServer) BatchReadFiles(*BatchReadFilesRequest, GitserverService_BatchReadFilesServer) error {
+	return status.Errorf(codes.Unimplemented, "method BatchReadFiles not implemented")
+}
+func (UnimplementedGitserverServiceServer) ReadLFSObject(*ReadLFSObjectRequest, GitserverService_ReadLFSObjectServer) error {
+	return status.Errorf(codes.Unimplemented, "method ReadLFSObject not implemented")
+}
+func (UnimplementedGitserverServiceServer) Blame(*BlameRequest, GitserverService_BlameServer) error {
+	return status.Errorf(codes.Unimplemented, "method Blame not implemented")
+}
+func (UnimplementedGitserverServiceServer) RepoPack(*RepoPackRequest, GitserverService_RepoPackServer) error {
+	return status.Errorf(codes.Unimplemented, "method RepoPack not implemented")
+}
 func (UnimplementedGitserverServiceServer) mustEmbedUnimplementedGitserverServiceServer() {}
 
 // UnsafeGitserverServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -625,6 +776,89 @@ func _GitserverService_ReposStats_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GitserverService_BatchReadFiles_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BatchReadFilesRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitserverServiceServer).BatchReadFiles(m, &gitserverServiceBatchReadFilesServer{stream})
+}
+
+type GitserverService_BatchReadFilesServer interface {
+	Send(*BatchReadFilesResponse) error
+	grpc.ServerStream
+}
+
+type gitserverServiceBatchReadFilesServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitserverServiceBatchReadFilesServer) Send(m *BatchReadFilesResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+func _GitserverService_ReadLFSObject_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReadLFSObjectRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitserverServiceServer).ReadLFSObject(m, &gitserverServiceReadLFSObjectServer{stream})
+}
+
+type GitserverService_ReadLFSObjectServer interface {
+	Send(*ReadLFSObjectResponse) error
+	grpc.ServerStream
+}
+
+type gitserverServiceReadLFSObjectServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitserverServiceReadLFSObjectServer) Send(m *ReadLFSObjectResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GitserverService_Blame_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(BlameRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitserverServiceServer).Blame(m, &gitserverServiceBlameServer{stream})
+}
+
+type GitserverService_BlameServer interface {
+	Send(*BlameResponse) error
+	grpc.ServerStream
+}
+
+type gitserverServiceBlameServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitserverServiceBlameServer) Send(m *BlameResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GitserverService_RepoPack_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RepoPackRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GitserverServiceServer).RepoPack(m, &gitserverServiceRepoPackServer{stream})
+}
+
+type GitserverService_RepoPackServer interface {
+	Send(*RepoPackResponse) error
+	grpc.ServerStream
+}
+
+type gitserverServiceRepoPackServer struct {
+	grpc.ServerStream
+}
+
+func (x *gitserverServiceRepoPackServer) Send(m *RepoPackResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // GitserverService_ServiceDesc is the grpc.ServiceDesc for GitserverService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -694,6 +928,26 @@ var GitserverService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GitserverService_P4Exec_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BatchReadFiles",
+			Handler:       _GitserverService_BatchReadFiles_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ReadLFSObject",
+			Handler:       _GitserverService_ReadLFSObject_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Blame",
+			Handler:       _GitserverService_Blame_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "RepoPack",
+			Handler:       _GitserverService_RepoPack_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "gitserver.proto",
 }
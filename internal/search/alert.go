@@ -19,6 +19,8 @@ type Alert struct {
 	Kind            string // An identifier indicating the kind of alert
 	// The higher the priority the more important is the alert.
 	Priority int
+	// Diagnostics are the problems found in the query by query.Lint.
+	Diagnostics []query.Diagnostic
 }
 
 func MaxPriorityAlert(alerts ...*Alert) (max *Alert) {
@@ -166,6 +168,18 @@ func AlertForInvalidRevision(revision string) *Alert {
 	}
 }
 
+// AlertForRevisionDiffLimitHit returns an alert for a comparison of the results
+// at two revisions where one of the searches did not return all results.
+func AlertForRevisionDiffLimitHit() *Alert {
+	return &Alert{
+		PrometheusType: "revision_diff_limit_hit",
+		Kind:           "revision-diff-limit-hit",
+		Title:          "Too many results to compare revisions",
+		Description:    "The search at one of the revisions hit a result limit, so the files that differ between the revisions cannot be determined. Narrow your query with `file:` or a more specific pattern, or add `count:all`.",
+		Priority:       2,
+	}
+}
+
 func AlertForUnownedResult() *Alert {
 	return &Alert{
 		Kind:        "unowned-results",
@@ -58,6 +58,12 @@ func transformExpression(expr string, ignoreCase bool) (string, error) {
 	return syn.String(), nil
 }
 
+// String returns the pattern r was compiled from, after the
+// transformations applied by CompileRegexp.
+func (r *Regexp) String() string {
+	return r.re.String()
+}
+
 func (r *Regexp) FindAllIndex(b []byte, n int, lowerBuf *[]byte) [][]int {
 	if !r.ignoreCase {
 		return r.re.FindAllIndex(b, n)
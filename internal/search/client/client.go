@@ -131,7 +131,7 @@ func (s *searchClient) Plan(
 		query.With(searchContextsQueryEnabled, substituteContextsStep),
 	)
 	if err != nil {
-		return nil, &QueryError{Query: searchQuery, Err: err}
+		return nil, &QueryError{Query: searchQuery, Err: err, Diagnostics: query.Lint(searchQuery, searchType)}
 	}
 	tr.LazyPrintf("parsing done")
 
@@ -227,6 +227,10 @@ func sanitizeSearchPatterns(ctx context.Context, db database.DB, log log.Logger)
 type QueryError struct {
 	Query string
 	Err   error
+
+	// Diagnostics are all the problems found in Query, while Err is only
+	// the first one.
+	Diagnostics []query.Diagnostic
 }
 
 func (e *QueryError) Error() string {
@@ -315,6 +319,7 @@ func ToFeatures(flagSet *featureflag.FlagSet, logger log.Logger) *search.Feature
 		HybridSearch:            flagSet.GetBoolOr("search-hybrid", true), // can remove flag in 4.5
 		Ranking:                 flagSet.GetBoolOr("search-ranking", true),
 		Debug:                   flagSet.GetBoolOr("search-debug", false),
+		PreciseSymbolSearch:     flagSet.GetBoolOr("symbol.precise", false),
 	}
 }
 
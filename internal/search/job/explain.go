@@ -0,0 +1,144 @@
+package job
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/zoekt"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+)
+
+type explainKey struct{}
+
+// ExplainNode records what a job did while running. It is the node of an
+// EXPLAIN ANALYZE style tree: unlike the job tree it only contains the jobs
+// which actually ran, once per run, together with runtime statistics.
+//
+// Nodes are created by StartSpan for every job run with a context returned by
+// WithExplain.
+type ExplainNode struct {
+	Name       string
+	Attributes []attribute.KeyValue
+
+	// The following fields are set once the job finished running.
+	Duration time.Duration
+	Results  int64
+	Alert    string
+	Error    string
+
+	mu       sync.Mutex
+	children []*ExplainNode
+	shards   map[string]*zoekt.Stats
+}
+
+// NewExplain returns the root of an explain tree named name.
+func NewExplain(name string) *ExplainNode {
+	return &ExplainNode{Name: name}
+}
+
+// WithExplain returns a context which records the jobs run with it as children
+// of n.
+func WithExplain(ctx context.Context, n *ExplainNode) context.Context {
+	return context.WithValue(ctx, explainKey{}, n)
+}
+
+// ExplainFromContext returns the node of the job running with ctx. It returns
+// nil if the search is not explained.
+func ExplainFromContext(ctx context.Context) *ExplainNode {
+	n, _ := ctx.Value(explainKey{}).(*ExplainNode)
+	return n
+}
+
+// Children returns the nodes of the jobs run by n in the order they started.
+func (n *ExplainNode) Children() []*ExplainNode {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return append([]*ExplainNode(nil), n.children...)
+}
+
+// Shard is the Zoekt statistics of a shard searched by a job.
+type Shard struct {
+	Name  string
+	Stats zoekt.Stats
+}
+
+// Shards returns the statistics of the Zoekt shards searched by n, ordered by
+// name.
+func (n *ExplainNode) Shards() []Shard {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	shards := make([]Shard, 0, len(n.shards))
+	for name, stats := range n.shards {
+		shards = append(shards, Shard{Name: name, Stats: *stats})
+	}
+	sort.Slice(shards, func(i, j int) bool { return shards[i].Name < shards[j].Name })
+	return shards
+}
+
+// AddZoektStats records the statistics of a result streamed by Zoekt. It is a
+// no-op if n is nil.
+//
+// Zoekt does not tell us which shard produced a result, so we attribute the
+// statistics to the repository of its file matches, which is its shard unless
+// repositories are merged into compound shards. Results without file matches
+// or with matches from several repositories are recorded with an empty name.
+func (n *ExplainNode) AddZoektStats(event *zoekt.SearchResult) {
+	if n == nil || event == nil {
+		return
+	}
+
+	var name string
+	for i, file := range event.Files {
+		if i == 0 {
+			name = file.Repository
+		} else if file.Repository != name {
+			name = ""
+			break
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.shards == nil {
+		n.shards = make(map[string]*zoekt.Stats)
+	}
+	stats, ok := n.shards[name]
+	if !ok {
+		stats = &zoekt.Stats{}
+		n.shards[name] = stats
+	}
+	stats.Add(event.Stats)
+	// Stats.Add does not sum durations since they overlap across
+	// shards. Within a shard they don't.
+	stats.Duration += event.Stats.Duration
+}
+
+// startChild records that job started running as a child of n.
+func (n *ExplainNode) startChild(job Job) *ExplainNode {
+	child := &ExplainNode{
+		Name:       job.Name(),
+		Attributes: job.Attributes(VerbosityBasic),
+	}
+	n.mu.Lock()
+	n.children = append(n.children, child)
+	n.mu.Unlock()
+	return child
+}
+
+// finish records the outcome of the job of n.
+func (n *ExplainNode) finish(duration time.Duration, results int64, alert *search.Alert, err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.Duration = duration
+	n.Results = results
+	if alert != nil {
+		n.Alert = alert.Title
+	}
+	if err != nil {
+		n.Error = err.Error()
+	}
+}
@@ -0,0 +1,141 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// dedupeContentConcurrency is the number of repository commits of a single
+// event for which we look up blob OIDs concurrently.
+const dedupeContentConcurrency = 8
+
+// dedupeContentBatchSize is the maximum number of paths we look up the blob
+// OIDs of with a single gitserver request.
+const dedupeContentBatchSize = 500
+
+// NewDedupeContentJob creates a job which collapses file matches with the
+// same content for dedupe:content. Matches are compared by the OID of their
+// blob, which we look up in gitserver for the matches of each commit of a
+// repository at once. The first match found for a blob is sent with the
+// locations of the other matches of the same event as its duplicates. Later
+// matches of the same blob are dropped, since the first one was already sent.
+func NewDedupeContentJob(child job.Job) job.Job {
+	return &dedupeContentJob{child: child}
+}
+
+type dedupeContentJob struct {
+	child job.Job
+}
+
+func (j *dedupeContentJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		mu      sync.Mutex
+		deduper = result.NewContentDeduper()
+	)
+
+	return j.child.Run(ctx, clients, streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var fileMatches []*result.FileMatch
+		for _, m := range event.Results {
+			if fm, ok := m.(*result.FileMatch); ok {
+				fileMatches = append(fileMatches, fm)
+			}
+		}
+		oids := blobOIDs(ctx, clients.Gitserver, fileMatches)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		others := event.Results[:0]
+		for _, m := range event.Results {
+			if fm, ok := m.(*result.FileMatch); ok {
+				deduper.Add(fm, oids[fm])
+			} else {
+				others = append(others, m)
+			}
+		}
+		event.Results = append(others, deduper.Flush()...)
+		stream.Send(event)
+	}))
+}
+
+// blobOIDs returns the OIDs of the blobs of fileMatches. A match is missing
+// if we fail to look up its OID, in which case it is never collapsed.
+func blobOIDs(ctx context.Context, client gitserver.Client, fileMatches []*result.FileMatch) map[*result.FileMatch]string {
+	type repoCommit struct {
+		repo   api.RepoName
+		commit api.CommitID
+	}
+	var batches [][]*result.FileMatch
+	batchIndexes := make(map[repoCommit]int)
+	for _, fm := range fileMatches {
+		if fm.CommitID == "" {
+			continue
+		}
+		key := repoCommit{fm.Repo.Name, fm.CommitID}
+		i, ok := batchIndexes[key]
+		if !ok || len(batches[i]) == dedupeContentBatchSize {
+			i = len(batches)
+			batches = append(batches, nil)
+			batchIndexes[key] = i
+		}
+		batches[i] = append(batches[i], fm)
+	}
+
+	var mu sync.Mutex
+	oids := make(map[*result.FileMatch]string, len(fileMatches))
+	p := pool.New().WithMaxGoroutines(dedupeContentConcurrency)
+	for _, batch := range batches {
+		batch := batch
+		p.Go(func() {
+			paths := make([]string, len(batch))
+			for i, fm := range batch {
+				paths[i] = fm.Path
+			}
+			found, err := client.BlobOIDs(ctx, authz.DefaultSubRepoPermsChecker, batch[0].Repo.Name, batch[0].CommitID, paths)
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for _, fm := range batch {
+				if oid, ok := found[fm.Path]; ok {
+					oids[fm] = oid.String()
+				}
+			}
+		})
+	}
+	p.Wait()
+	return oids
+}
+
+func (j *dedupeContentJob) MapChildren(f job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, f)
+	return &cp
+}
+
+func (j *dedupeContentJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *dedupeContentJob) Attributes(job.Verbosity) []attribute.KeyValue {
+	return nil
+}
+
+func (j *dedupeContentJob) Name() string {
+	return "DedupeContentJob"
+}
@@ -0,0 +1,98 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/gitdomain"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDedupeContentJob(t *testing.T) {
+	// blobs maps a path to the first byte of its blob OID.
+	blobs := map[string]byte{
+		"lib/x.go":             1,
+		"vendor/lib/x.go":      1,
+		"third_party/lib/x.go": 1,
+		"y.go":                 2,
+	}
+
+	gs := gitserver.NewMockClient()
+	gs.BlobOIDsFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, paths []string) (map[string]gitdomain.OID, error) {
+		oids := make(map[string]gitdomain.OID)
+		for _, path := range paths {
+			if b, ok := blobs[path]; ok {
+				oids[path] = gitdomain.OID{b}
+			}
+		}
+		return oids, nil
+	})
+
+	fileMatch := func(repo, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{
+			Repo:     types.MinimalRepo{Name: api.RepoName(repo)},
+			CommitID: "deadbeef",
+			Path:     path,
+		}}
+	}
+
+	child := mockjob.NewMockJob()
+	child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{
+			fileMatch("a", "lib/x.go"),
+			fileMatch("a", "vendor/lib/x.go"),
+			&result.RepoMatch{Name: "a"},
+		}})
+		s.Send(streaming.ResumeEvent(1, streaming.ResumeCursor{}))
+		s.Send(streaming.SearchEvent{Results: result.Matches{
+			fileMatch("b", "third_party/lib/x.go"),
+			fileMatch("b", "y.go"),
+			fileMatch("b", "missing.go"),
+		}})
+		return nil, nil
+	})
+
+	s := streaming.NewAggregatingStream()
+	_, err := NewDedupeContentJob(child).Run(context.Background(), job.RuntimeClients{Gitserver: gs}, s)
+	require.NoError(t, err)
+
+	type location struct{ repo, path string }
+	toLocation := func(f result.File) location { return location{string(f.Repo.Name), f.Path} }
+
+	var got []location
+	duplicates := map[location][]location{}
+	for _, m := range s.Results {
+		switch v := m.(type) {
+		case *result.RepoMatch:
+			got = append(got, location{repo: string(v.Name)})
+		case *result.FileMatch:
+			got = append(got, toLocation(v.File))
+			for _, d := range v.Duplicates {
+				duplicates[toLocation(v.File)] = append(duplicates[toLocation(v.File)], toLocation(d))
+			}
+		}
+	}
+
+	// Duplicates of a match sent with an earlier event are dropped.
+	require.Equal(t, []location{{"a", ""}, {"a", "lib/x.go"}, {"b", "y.go"}, {"b", "missing.go"}}, got)
+	require.Equal(t, map[location][]location{
+		{"a", "lib/x.go"}: {{"a", "vendor/lib/x.go"}},
+	}, duplicates)
+
+	// The OIDs of the matches of each repository commit are looked up at
+	// once.
+	require.Len(t, gs.BlobOIDsFunc.History(), 2)
+
+	// Matches are streamed, so the child can be resumed.
+	require.NotEmpty(t, s.Stats.Resume)
+}
@@ -14,6 +14,7 @@ import (
 type EnterpriseJobs interface {
 	FileHasOwnerJob(child job.Job, features *search.Features, includeOwners, excludeOwners []string) job.Job
 	SelectFileOwnerJob(child job.Job, features *search.Features) job.Job
+	PreciseSymbolSearchJob(fallback job.Job, patternInfo *search.TextPatternInfo, limit int, features *search.Features) job.Job
 }
 
 func NewUnimplementedEnterpriseJobs() EnterpriseJobs {
@@ -30,6 +31,10 @@ func (e *enterpriseJobs) SelectFileOwnerJob(child job.Job, features *search.Feat
 	return NewUnimplementedJob("`select:file.owners` searches are not available on this instance")
 }
 
+func (e *enterpriseJobs) PreciseSymbolSearchJob(fallback job.Job, patternInfo *search.TextPatternInfo, limit int, features *search.Features) job.Job {
+	return fallback
+}
+
 func NewUnimplementedJob(msg string) *UnimplementedJob {
 	return &UnimplementedJob{msg: msg}
 }
@@ -51,6 +51,9 @@ func (a *AndJob) Run(ctx context.Context, clients job.RuntimeClients, stream str
 				if len(event.Results) > 0 {
 					sentResults.Store(true)
 				}
+				// Matches are held back until they are seen by all
+				// children, so children cannot be resumed.
+				event.Stats.Resume = nil
 				if len(event.Results) > 0 || !event.Stats.Zero() {
 					stream.Send(event)
 				}
@@ -146,6 +149,9 @@ func (j *OrJob) Run(ctx context.Context, clients job.RuntimeClients, stream stre
 		p.Go(func(ctx context.Context) error {
 			unioningStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
 				event.Results = merger.AddMatches(event.Results, childNum)
+				// Matches are held back until all children are done, so
+				// children cannot be resumed.
+				event.Stats.Resume = nil
 				if len(event.Results) > 0 || !event.Stats.Zero() {
 					stream.Send(event)
 				}
@@ -0,0 +1,213 @@
+package jobutil
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/attribute"
+	"golang.org/x/exp/slices"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// NewFileDiffJob creates a job that compares the file matches of two searches
+// which only differ in the revisions they search. It streams the file matches
+// which are only found by after as added, and the file matches which are only
+// found by before as removed. Files which match in both are not sent.
+//
+// Since a file can only be reported once both children have completed, all
+// matches are sent when the job finishes. If either child hits a limit, its
+// results are incomplete and files would be reported as added or removed even
+// though they did not change. In that case no matches are sent and an alert
+// asks the user to narrow the query instead.
+func NewFileDiffJob(before, after job.Job) job.Job {
+	return &fileDiffJob{
+		before: before,
+		after:  after,
+	}
+}
+
+type fileDiffJob struct {
+	before job.Job
+	after  job.Job
+}
+
+// fileDiffKey identifies a file independently of the revision it was found
+// at.
+type fileDiffKey struct {
+	repo api.RepoID
+	path string
+}
+
+func (j *fileDiffJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	var (
+		pl         = pool.New().WithContext(ctx)
+		maxAlerter search.MaxAlerter
+		matches    = make([]map[fileDiffKey]*result.FileMatch, 2)
+		limitHit   = make([]bool, 2)
+	)
+	for i, child := range []job.Job{j.before, j.after} {
+		i, child := i, child
+		matches[i] = make(map[fileDiffKey]*result.FileMatch)
+
+		var mu sync.Mutex
+		collect := streaming.StreamFunc(func(event streaming.SearchEvent) {
+			mu.Lock()
+			for _, m := range event.Results {
+				if fm, ok := m.(*result.FileMatch); ok {
+					matches[i][fileDiffKey{repo: fm.Repo.ID, path: fm.Path}] = fm
+				}
+			}
+			limitHit[i] = limitHit[i] || event.Stats.IsLimitHit
+			mu.Unlock()
+
+			// Stats are not specific to a revision, so we forward them
+			// as they come in. Matches are only sent once both children
+			// are done, so children cannot be resumed. Both children
+			// search the same repos, so the counts which the stream
+			// sums up are only taken from the after child.
+			stats := event.Stats
+			stats.Resume = nil
+			if i == 0 {
+				stats.BackendsMissing = 0
+				stats.ExcludedForks = 0
+				stats.ExcludedArchived = 0
+			}
+			if !stats.Zero() {
+				stream.Send(streaming.SearchEvent{Stats: stats})
+			}
+		})
+
+		pl.Go(func(ctx context.Context) error {
+			alert, err := child.Run(ctx, clients, collect)
+			maxAlerter.Add(alert)
+			return err
+		})
+	}
+	if err := pl.Wait(); err != nil {
+		return maxAlerter.Alert, err
+	}
+
+	if limitHit[0] || limitHit[1] {
+		maxAlerter.Add(search.AlertForRevisionDiffLimitHit())
+		return maxAlerter.Alert, nil
+	}
+
+	diff := diffFileMatches(matches[0], matches[1])
+	if len(diff) > 0 {
+		stream.Send(streaming.SearchEvent{Results: diff})
+	}
+	return maxAlerter.Alert, nil
+}
+
+// diffFileMatches returns the matches in after which are not in before marked
+// as added, followed by the matches in before which are not in after marked as
+// removed. The result is sorted by repository and path within each group.
+func diffFileMatches(before, after map[fileDiffKey]*result.FileMatch) result.Matches {
+	var added, removed []*result.FileMatch
+	for k, fm := range after {
+		if _, ok := before[k]; !ok {
+			fm.RevisionDiff = result.RevisionDiffAdded
+			added = append(added, fm)
+		}
+	}
+	for k, fm := range before {
+		if _, ok := after[k]; !ok {
+			fm.RevisionDiff = result.RevisionDiffRemoved
+			removed = append(removed, fm)
+		}
+	}
+
+	less := func(a, b *result.FileMatch) bool {
+		if a.Repo.Name != b.Repo.Name {
+			return a.Repo.Name < b.Repo.Name
+		}
+		return a.Path < b.Path
+	}
+	slices.SortFunc(added, less)
+	slices.SortFunc(removed, less)
+
+	res := make(result.Matches, 0, len(added)+len(removed))
+	for _, fm := range added {
+		res = append(res, fm)
+	}
+	for _, fm := range removed {
+		res = append(res, fm)
+	}
+	return res
+}
+
+func (j *fileDiffJob) Name() string {
+	return "FileDiffJob"
+}
+
+func (j *fileDiffJob) Attributes(job.Verbosity) []attribute.KeyValue { return nil }
+
+func (j *fileDiffJob) Children() []job.Describer {
+	return []job.Describer{j.before, j.after}
+}
+
+func (j *fileDiffJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.before = job.Map(j.before, fn)
+	cp.after = job.Map(j.after, fn)
+	return &cp
+}
+
+// splitRevisionRange returns two copies of b, where every repo:foo@base...head
+// filter is replaced by repo:foo@base in before and by repo:foo@head in after.
+// ok is false if b does not contain a revision range.
+func splitRevisionRange(b query.Basic) (before, after query.Basic, ok bool) {
+	beforeParams := make([]query.Parameter, 0, len(b.Parameters))
+	afterParams := make([]query.Parameter, 0, len(b.Parameters))
+	for _, p := range b.Parameters {
+		beforeParam, afterParam := p, p
+		if p.Field == query.FieldRepo && !p.Negated && !p.Annotation.Labels.IsSet(query.IsPredicate) {
+			if repo, revs, found := strings.Cut(p.Value, "@"); found {
+				beforeRevs, afterRevs, isRange := splitRevs(revs)
+				if isRange {
+					ok = true
+					beforeParam.Value = repo + "@" + beforeRevs
+					afterParam.Value = repo + "@" + afterRevs
+				}
+			}
+		}
+		beforeParams = append(beforeParams, beforeParam)
+		afterParams = append(afterParams, afterParam)
+	}
+	if !ok {
+		return b, b, false
+	}
+	return b.MapParameters(beforeParams), b.MapParameters(afterParams), true
+}
+
+// splitRevs splits a ':'-separated list of revspecs into the base and head
+// revspecs of any revision ranges it contains.
+func splitRevs(revs string) (before, after string, isRange bool) {
+	parts := strings.Split(revs, ":")
+	beforeParts := make([]string, 0, len(parts))
+	afterParts := make([]string, 0, len(parts))
+	for _, part := range parts {
+		// Ref globs are prefixed with '*' and are never ranges.
+		base, head, ok := query.RevisionSpecifier{RevSpec: part}.Range()
+		if !ok || strings.HasPrefix(part, "*") {
+			beforeParts = append(beforeParts, part)
+			afterParts = append(afterParts, part)
+			continue
+		}
+		isRange = true
+		beforeParts = append(beforeParts, base)
+		afterParts = append(afterParts, head)
+	}
+	return strings.Join(beforeParts, ":"), strings.Join(afterParts, ":"), isRange
+}
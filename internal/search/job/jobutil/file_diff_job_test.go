@@ -0,0 +1,144 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestFileDiffJob(t *testing.T) {
+	fileMatch := func(repo, commit, path string) *result.FileMatch {
+		return &result.FileMatch{
+			File: result.File{
+				Repo:     types.MinimalRepo{ID: 1, Name: api.RepoName(repo)},
+				CommitID: api.CommitID(commit),
+				Path:     path,
+			},
+		}
+	}
+
+	sendJob := func(matches ...result.Match) job.Job {
+		j := mockjob.NewMockJob()
+		j.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			for _, m := range matches {
+				s.Send(streaming.SearchEvent{Results: result.Matches{m}})
+			}
+			return nil, nil
+		})
+		return j
+	}
+
+	before := sendJob(
+		fileMatch("repo", "a", "both.go"),
+		fileMatch("repo", "a", "removed.go"),
+		&result.RepoMatch{Name: "repo", ID: 1},
+	)
+	after := sendJob(
+		fileMatch("repo", "b", "added.go"),
+		fileMatch("repo", "b", "both.go"),
+	)
+
+	s := streaming.NewAggregatingStream()
+	_, err := NewFileDiffJob(before, after).Run(context.Background(), job.RuntimeClients{}, s)
+	require.NoError(t, err)
+
+	type got struct {
+		path   string
+		commit api.CommitID
+		diff   result.RevisionDiff
+	}
+	var gots []got
+	for _, m := range s.Results {
+		fm := m.(*result.FileMatch)
+		gots = append(gots, got{path: fm.Path, commit: fm.CommitID, diff: fm.RevisionDiff})
+	}
+	require.Equal(t, []got{
+		{path: "added.go", commit: "b", diff: result.RevisionDiffAdded},
+		{path: "removed.go", commit: "a", diff: result.RevisionDiffRemoved},
+	}, gots)
+}
+
+func TestFileDiffJobLimitHit(t *testing.T) {
+	fileMatch := func(path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: types.MinimalRepo{ID: 1, Name: "repo"}, Path: path}}
+	}
+
+	before := mockjob.NewMockJob()
+	before.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{
+			Results: result.Matches{fileMatch("a.go")},
+			Stats:   streaming.Stats{IsLimitHit: true},
+		})
+		return nil, nil
+	})
+	after := mockjob.NewMockJob()
+	after.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+		s.Send(streaming.SearchEvent{Results: result.Matches{fileMatch("a.go"), fileMatch("b.go")}})
+		return nil, nil
+	})
+
+	s := streaming.NewAggregatingStream()
+	alert, err := NewFileDiffJob(before, after).Run(context.Background(), job.RuntimeClients{}, s)
+	require.NoError(t, err)
+	require.Empty(t, s.Results)
+	require.NotNil(t, alert)
+	require.Equal(t, "revision-diff-limit-hit", alert.Kind)
+}
+
+func TestFileDiffJobStats(t *testing.T) {
+	statsJob := func() job.Job {
+		j := mockjob.NewMockJob()
+		j.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Stats: streaming.Stats{
+				Repos:            map[api.RepoID]struct{}{1: {}},
+				BackendsMissing:  1,
+				ExcludedForks:    2,
+				ExcludedArchived: 3,
+			}})
+			return nil, nil
+		})
+		return j
+	}
+
+	s := streaming.NewAggregatingStream()
+	_, err := NewFileDiffJob(statsJob(), statsJob()).Run(context.Background(), job.RuntimeClients{}, s)
+	require.NoError(t, err)
+	require.Len(t, s.Stats.Repos, 1)
+	require.Equal(t, 1, s.Stats.BackendsMissing)
+	require.Equal(t, 2, s.Stats.ExcludedForks)
+	require.Equal(t, 3, s.Stats.ExcludedArchived)
+}
+
+func TestSplitRevisionRange(t *testing.T) {
+	test := func(input string) []string {
+		plan, err := query.Pipeline(query.Init(input, query.SearchTypeLiteral))
+		require.NoError(t, err)
+		before, after, ok := splitRevisionRange(plan[0])
+		if !ok {
+			return nil
+		}
+		return []string{before.StringHuman(), after.StringHuman()}
+	}
+
+	require.Equal(t,
+		[]string{`repo:foo@v1.2 bar`, `repo:foo@v1.3 bar`},
+		test(`repo:foo@v1.2...v1.3 bar`))
+	require.Equal(t,
+		[]string{`repo:foo@v1.2 bar`, `repo:foo@v1.3 bar`},
+		test(`repo:foo rev:v1.2...v1.3 bar`))
+	require.Equal(t,
+		[]string{`repo:foo@main:v1 repo:baz@v2 bar`, `repo:foo@main:v1 repo:baz@v3 bar`},
+		test(`repo:foo@main:v1 repo:baz@v2...v3 bar`))
+	require.Nil(t, test(`repo:foo@v1.2 bar`))
+	require.Nil(t, test(`repo:foo@*refs/heads/a...b bar`))
+}
@@ -0,0 +1,286 @@
+package jobutil
+
+import (
+	"context"
+	"sync"
+
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// fileHistoryConcurrency is the number of batches of file matches of a
+// single event we check against gitserver concurrently.
+const fileHistoryConcurrency = 8
+
+// fileHistoryBatchSize is the maximum number of paths we ask gitserver for the
+// history of in one call.
+const fileHistoryBatchSize = 500
+
+// NewFileHistoryFilterJob creates a filter job to post-filter file matches for
+// the file:has.commit.after() and file:modified.by() predicates.
+//
+// For the file matches streamed by the child, we ask gitserver for the log of
+// the matched paths at the matched commit, batched by repo and commit. We need
+// at most two calls per batch: one for the commits after the time of
+// file:has.commit.after(), and one for the whole history if file:modified.by()
+// needs it.
+//
+// The arguments of file:modified.by() are regular expressions, which are
+// matched against the author of a commit formatted as "Name <email>" like
+// git log --author does.
+//
+// When file:has.commit.after() is not negated, it also restricts the commits
+// considered by file:modified.by(). This allows expressing "files modified by
+// alice in the last 30 days".
+func NewFileHistoryFilterJob(commitAfter *query.FileHasCommitAfterArgs, includeAuthors, excludeAuthors []string, child job.Job) job.Job {
+	return &fileHistoryFilterJob{
+		commitAfter:    commitAfter,
+		includeAuthors: includeAuthors,
+		excludeAuthors: excludeAuthors,
+		child:          child,
+	}
+}
+
+type fileHistoryFilterJob struct {
+	commitAfter    *query.FileHasCommitAfterArgs
+	includeAuthors []string
+	excludeAuthors []string
+
+	child job.Job
+}
+
+func (j *fileHistoryFilterJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	includeAuthors, err := compileAuthors(j.includeAuthors)
+	if err != nil {
+		return nil, err
+	}
+	excludeAuthors, err := compileAuthors(j.excludeAuthors)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu   sync.Mutex
+		errs error
+	)
+
+	filteredStream := streaming.StreamFunc(func(event streaming.SearchEvent) {
+		var err error
+		event.Results, err = j.filterMatches(ctx, clients.Gitserver, includeAuthors, excludeAuthors, event.Results)
+		if err != nil {
+			mu.Lock()
+			errs = errors.Append(errs, err)
+			mu.Unlock()
+		}
+		stream.Send(event)
+	})
+
+	alert, err = j.child.Run(ctx, clients, filteredStream)
+	if err != nil {
+		errs = errors.Append(errs, err)
+	}
+	return alert, errs
+}
+
+func compileAuthors(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid file:modified.by argument %q", pattern)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+// fileHistoryBatch is a batch of file matches of the same repo and commit.
+type fileHistoryBatch struct {
+	repo    api.RepoName
+	commit  api.CommitID
+	indexes []int
+}
+
+// filterMatches returns the matches whose history satisfies all predicates.
+// Matches we fail to read the history of are dropped, and the errors are
+// returned.
+func (j *fileHistoryFilterJob) filterMatches(ctx context.Context, client gitserver.Client, includeAuthors, excludeAuthors []*regexp.Regexp, matches result.Matches) (result.Matches, error) {
+	type batchKey struct {
+		repo   api.RepoName
+		commit api.CommitID
+	}
+	var batches []*fileHistoryBatch
+	byKey := make(map[batchKey]*fileHistoryBatch)
+	for i, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			// Only file matches have a path history, so we drop
+			// any other match.
+			continue
+		}
+		key := batchKey{repo: fm.Repo.Name, commit: fm.CommitID}
+		b := byKey[key]
+		if b == nil || len(b.indexes) == fileHistoryBatchSize {
+			b = &fileHistoryBatch{repo: key.repo, commit: key.commit}
+			byKey[key] = b
+			batches = append(batches, b)
+		}
+		b.indexes = append(b.indexes, i)
+	}
+
+	keep := make([]bool, len(matches))
+	p := pool.New().WithErrors().WithMaxGoroutines(fileHistoryConcurrency)
+	for _, b := range batches {
+		b := b
+		p.Go(func() error {
+			paths := make([]string, 0, len(b.indexes))
+			for _, i := range b.indexes {
+				paths = append(paths, matches[i].(*result.FileMatch).Path)
+			}
+			matching, err := j.matchingPaths(ctx, client, includeAuthors, excludeAuthors, b, paths)
+			if err != nil {
+				return errors.Wrapf(err, "reading the history of files in %s", b.repo)
+			}
+			for k, i := range b.indexes {
+				keep[i] = matching[paths[k]]
+			}
+			return nil
+		})
+	}
+	err := p.Wait()
+
+	filtered := matches[:0]
+	for i, m := range matches {
+		if keep[i] {
+			filtered = append(filtered, m)
+		}
+	}
+	return filtered, err
+}
+
+// matchingPaths returns the set of paths of the batch whose history satisfies
+// all predicates.
+func (j *fileHistoryFilterJob) matchingPaths(ctx context.Context, client gitserver.Client, includeAuthors, excludeAuthors []*regexp.Regexp, b *fileHistoryBatch, paths []string) (map[string]bool, error) {
+	logForPaths := func(after string) ([]gitserver.CommitLog, error) {
+		return client.CommitLogForPaths(ctx, authz.DefaultSubRepoPermsChecker, b.repo, b.commit, after, paths)
+	}
+
+	matching := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		matching[path] = true
+	}
+
+	// authorsLog is the log file:modified.by() considers.
+	var authorsLog []gitserver.CommitLog
+	needsAuthors := len(includeAuthors) > 0 || len(excludeAuthors) > 0
+
+	if j.commitAfter != nil {
+		recent, err := logForPaths(j.commitAfter.TimeRef)
+		if err != nil {
+			return nil, err
+		}
+		hasRecent := make(map[string]bool)
+		for _, c := range recent {
+			for _, path := range c.ChangedFiles {
+				hasRecent[path] = true
+			}
+		}
+		for _, path := range paths {
+			if hasRecent[path] == j.commitAfter.Negated {
+				matching[path] = false
+			}
+		}
+		if !j.commitAfter.Negated {
+			authorsLog = recent
+			needsAuthors = false
+		}
+	}
+
+	if needsAuthors {
+		var err error
+		if authorsLog, err = logForPaths(""); err != nil {
+			return nil, err
+		}
+	}
+
+	modifiedBy := func(authors []*regexp.Regexp) []map[string]bool {
+		res := make([]map[string]bool, len(authors))
+		for k := range authors {
+			res[k] = make(map[string]bool)
+		}
+		for _, c := range authorsLog {
+			author := c.AuthorName + " <" + c.AuthorEmail + ">"
+			for k, re := range authors {
+				if !re.MatchString(author) {
+					continue
+				}
+				for _, path := range c.ChangedFiles {
+					res[k][path] = true
+				}
+			}
+		}
+		return res
+	}
+	for _, modified := range modifiedBy(includeAuthors) {
+		for _, path := range paths {
+			if !modified[path] {
+				matching[path] = false
+			}
+		}
+	}
+	for _, modified := range modifiedBy(excludeAuthors) {
+		for _, path := range paths {
+			if modified[path] {
+				matching[path] = false
+			}
+		}
+	}
+	return matching, nil
+}
+
+func (j *fileHistoryFilterJob) MapChildren(f job.MapFunc) job.Job {
+	cp := *j
+	cp.child = job.Map(j.child, f)
+	return &cp
+}
+
+func (j *fileHistoryFilterJob) Children() []job.Describer {
+	return []job.Describer{j.child}
+}
+
+func (j *fileHistoryFilterJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		fallthrough
+	case job.VerbosityBasic:
+		if j.commitAfter != nil {
+			res = append(res,
+				attribute.String("commitAfter", j.commitAfter.TimeRef),
+				attribute.Bool("commitAfterNegated", j.commitAfter.Negated),
+			)
+		}
+		res = append(res,
+			attribute.StringSlice("includeAuthors", j.includeAuthors),
+			attribute.StringSlice("excludeAuthors", j.excludeAuthors),
+		)
+	}
+	return res
+}
+
+func (j *fileHistoryFilterJob) Name() string {
+	return "FileHistoryFilterJob"
+}
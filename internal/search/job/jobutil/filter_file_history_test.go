@@ -0,0 +1,150 @@
+package jobutil
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+func TestFileHistoryFilterJob(t *testing.T) {
+	// history maps a path to the authors who modified it recently ("new")
+	// and a long time ago ("old").
+	history := map[string]map[string][]string{
+		"recent-alice.go": {"new": {"alice"}, "old": {"bob"}},
+		"recent-bob.go":   {"new": {"bob"}},
+		"old-alice.go":    {"old": {"alice"}},
+	}
+
+	gs := gitserver.NewMockClient()
+	gs.CommitLogForPathsFunc.SetDefaultHook(func(_ context.Context, _ authz.SubRepoPermissionChecker, _ api.RepoName, _ api.CommitID, after string, paths []string) ([]gitserver.CommitLog, error) {
+		periods := []string{"new"}
+		if after == "" {
+			periods = append(periods, "old")
+		}
+		var log []gitserver.CommitLog
+		for _, period := range periods {
+			for _, path := range paths {
+				for _, author := range history[path][period] {
+					log = append(log, gitserver.CommitLog{
+						AuthorName:   author,
+						AuthorEmail:  author + "@example.com",
+						ChangedFiles: []string{path},
+					})
+				}
+			}
+		}
+		return log, nil
+	})
+
+	cases := []struct {
+		name           string
+		commitAfter    *query.FileHasCommitAfterArgs
+		includeAuthors []string
+		excludeAuthors []string
+		want           []string
+	}{{
+		name:        "has.commit.after",
+		commitAfter: &query.FileHasCommitAfterArgs{TimeRef: "1 month ago"},
+		want:        []string{"recent-alice.go", "recent-bob.go"},
+	}, {
+		name:        "negated has.commit.after",
+		commitAfter: &query.FileHasCommitAfterArgs{TimeRef: "1 month ago", Negated: true},
+		want:        []string{"old-alice.go"},
+	}, {
+		name:           "modified.by",
+		includeAuthors: []string{"alice"},
+		want:           []string{"recent-alice.go", "old-alice.go"},
+	}, {
+		name:           "negated modified.by",
+		excludeAuthors: []string{"alice"},
+		want:           []string{"recent-bob.go"},
+	}, {
+		name:           "modified.by regexp",
+		includeAuthors: []string{"^b.* <bob@"},
+		want:           []string{"recent-alice.go", "recent-bob.go"},
+	}, {
+		name:           "modified.by since",
+		commitAfter:    &query.FileHasCommitAfterArgs{TimeRef: "1 month ago"},
+		includeAuthors: []string{"alice"},
+		want:           []string{"recent-alice.go"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			child := mockjob.NewMockJob()
+			child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+				s.Send(streaming.SearchEvent{Results: result.Matches{
+					&result.FileMatch{File: result.File{Path: "recent-alice.go"}},
+					&result.FileMatch{File: result.File{Path: "recent-bob.go"}},
+					&result.FileMatch{File: result.File{Path: "old-alice.go"}},
+					&result.RepoMatch{Name: "repo"},
+				}})
+				return nil, nil
+			})
+
+			s := streaming.NewAggregatingStream()
+			j := NewFileHistoryFilterJob(tc.commitAfter, tc.includeAuthors, tc.excludeAuthors, child)
+			_, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gs}, s)
+			require.NoError(t, err)
+
+			var got []string
+			for _, m := range s.Results {
+				got = append(got, m.(*result.FileMatch).Path)
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	t.Run("batches by repo and commit", func(t *testing.T) {
+		gs := gitserver.NewMockClient()
+		gs.CommitLogForPathsFunc.SetDefaultReturn(nil, nil)
+
+		child := mockjob.NewMockJob()
+		child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: result.Matches{
+				&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "a"}, CommitID: "1", Path: "x"}},
+				&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "a"}, CommitID: "1", Path: "y"}},
+				&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "a"}, CommitID: "2", Path: "x"}},
+				&result.FileMatch{File: result.File{Repo: types.MinimalRepo{Name: "b"}, CommitID: "1", Path: "x"}},
+			}})
+			return nil, nil
+		})
+
+		j := NewFileHistoryFilterJob(nil, nil, []string{"alice"}, child)
+		_, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gs}, streaming.NewAggregatingStream())
+		require.NoError(t, err)
+		require.Len(t, gs.CommitLogForPathsFunc.History(), 3)
+	})
+
+	t.Run("gitserver errors", func(t *testing.T) {
+		gs := gitserver.NewMockClient()
+		gs.CommitLogForPathsFunc.SetDefaultReturn(nil, errors.New("gitserver unavailable"))
+
+		child := mockjob.NewMockJob()
+		child.RunFunc.SetDefaultHook(func(_ context.Context, _ job.RuntimeClients, s streaming.Sender) (*search.Alert, error) {
+			s.Send(streaming.SearchEvent{Results: result.Matches{
+				&result.FileMatch{File: result.File{Path: "recent-alice.go"}},
+			}})
+			return nil, nil
+		})
+
+		s := streaming.NewAggregatingStream()
+		j := NewFileHistoryFilterJob(nil, []string{"alice"}, nil, child)
+		_, err := j.Run(context.Background(), job.RuntimeClients{Gitserver: gs}, s)
+		require.ErrorContains(t, err, "gitserver unavailable")
+		require.Empty(t, s.Results)
+	})
+}
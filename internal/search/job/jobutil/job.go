@@ -63,11 +63,26 @@ func NewPlanJob(inputs *search.Inputs, plan query.Plan, enterpriseJobs Enterpris
 
 	alertJob := NewAlertJob(inputs, jobTree)
 	logJob := NewLogJob(inputs, alertJob)
-	return logJob, nil
+	return assignResumeKeys(logJob), nil
 }
 
 // NewBasicJob converts a query.Basic into its job tree representation.
 func NewBasicJob(inputs *search.Inputs, b query.Basic, enterpriseJobs EnterpriseJobs) (job.Job, error) {
+	// A revision range like repo:foo@v1.2...v1.3 in a file search compares
+	// the results at both revisions. Commit and diff searches pass ranges
+	// to git log as is.
+	if before, after, ok := splitRevisionRange(b); ok && !computeResultTypes(b, inputs.PatternType).Has(result.TypeCommit|result.TypeDiff) {
+		beforeJob, err := NewBasicJob(inputs, before, enterpriseJobs)
+		if err != nil {
+			return nil, err
+		}
+		afterJob, err := NewBasicJob(inputs, after, enterpriseJobs)
+		if err != nil {
+			return nil, err
+		}
+		return NewFileDiffJob(beforeJob, afterJob), nil
+	}
+
 	var children []job.Job
 	addJob := func(j job.Job) {
 		children = append(children, j)
@@ -145,6 +160,17 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic, enterpriseJobs Enterprise
 				if err != nil {
 					return nil, err
 				}
+				// Indexed symbol searches of an atomic pattern may be
+				// answered by precise code intelligence data instead of
+				// the symbols Zoekt indexed.
+				if _, ok := searchJob.(*zoekt.SymbolSearchJob); ok && isAtomicPattern(b.Pattern) {
+					searchJob = enterpriseJobs.PreciseSymbolSearchJob(
+						searchJob,
+						toTextPatternInfo(b, resultTypes, inputs.Protocol),
+						b.ToParseTree().MaxResults(inputs.DefaultLimit()),
+						inputs.Features,
+					)
+				}
 				addJob(&repoPagerJob{
 					child:            &reposPartialJob{searchJob},
 					repoOpts:         repoOptions,
@@ -180,6 +206,12 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic, enterpriseJobs Enterprise
 		if err != nil {
 			return nil, err
 		}
+
+		// Unindexed symbol searches may be answered by precise code
+		// intelligence data instead of ctags.
+		flatJob = job.MapType(flatJob, func(j *searcher.SymbolSearchJob) job.Job {
+			return enterpriseJobs.PreciseSymbolSearchJob(j, j.PatternInfo, j.Limit, inputs.Features)
+		})
 		addJob(flatJob)
 	}
 
@@ -195,6 +227,14 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic, enterpriseJobs Enterprise
 		}
 	}
 
+	{ // Apply file:has.commit.after() and file:modified.by() post-filter
+		commitAfter := b.FileHasCommitAfter()
+		includeAuthors, excludeAuthors := b.FileModifiedBy()
+		if commitAfter != nil || len(includeAuthors) > 0 || len(excludeAuthors) > 0 {
+			basicJob = NewFileHistoryFilterJob(commitAfter, includeAuthors, excludeAuthors, basicJob)
+		}
+	}
+
 	{ // Apply code ownership post-search filter
 		if includeOwners, excludeOwners, ok := isOwnershipSearch(b); ok {
 			basicJob = enterpriseJobs.FileHasOwnerJob(basicJob, inputs.Features, includeOwners, excludeOwners)
@@ -226,6 +266,14 @@ func NewBasicJob(inputs *search.Inputs, b query.Basic, enterpriseJobs Enterprise
 		}
 	}
 
+	{ // Apply content deduplication
+		// This runs before the limit, so collapsed duplicates don't count
+		// towards it.
+		if v, _ := b.ToParseTree().StringValue(query.FieldDedupe); strings.EqualFold(v, query.DedupeContent) {
+			basicJob = NewDedupeContentJob(basicJob)
+		}
+	}
+
 	{ // Apply limit
 		maxResults := b.ToParseTree().MaxResults(inputs.DefaultLimit())
 		basicJob = NewLimitJob(maxResults, basicJob)
@@ -338,7 +386,7 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 		if resultTypes.Has(result.TypeFile | result.TypePath) {
 			// Create Text Search jobs over repo set.
 			if !skipRepoSubsetSearch {
-				searcherJob := &searcher.TextSearchJob{
+				var searcherJob job.Job = &searcher.TextSearchJob{
 					PatternInfo:     patternInfo,
 					Indexed:         false,
 					UseFullDeadline: useFullDeadline,
@@ -346,6 +394,12 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 					PathRegexps:     getPathRegexpsFromTextPatternInfo(patternInfo),
 				}
 
+				// Path-only searches are answered from the path index
+				// kept by gitserver rather than by fetching archives.
+				if usePathIndex(patternInfo) {
+					searcherJob = NewPathIndexJob(patternInfo, searcherJob)
+				}
+
 				addJob(&repoPagerJob{
 					child:            &reposPartialJob{searcherJob},
 					repoOpts:         repoOptions,
@@ -405,6 +459,8 @@ func NewFlatJob(searchInputs *search.Inputs, f query.Flat) (job.Job, error) {
 					query.FieldRepoHasCommitAfter: {},
 					query.FieldPatternType:        {},
 					query.FieldSelect:             {},
+					query.FieldExplain:            {},
+					query.FieldDedupe:             {},
 				}
 
 				// Don't run a repo search if the search contains fields that aren't on the allowlist.
@@ -604,6 +660,16 @@ func count(b query.Basic, p search.Protocol) int {
 	panic("unreachable")
 }
 
+// isAtomicPattern returns true if pattern is nil or a single pattern node that
+// is not negated, which are the patterns toTextPatternInfo represents exactly.
+func isAtomicPattern(pattern query.Node) bool {
+	if pattern == nil {
+		return true
+	}
+	p, ok := pattern.(query.Pattern)
+	return ok && !p.Negated
+}
+
 // toTextPatternInfo converts a an atomic query to internal values that drive
 // text search. An atomic query is a Basic query where the Pattern is either
 // nil, or comprises only one Pattern node (hence, an atom, and not an
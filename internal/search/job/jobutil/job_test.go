@@ -532,6 +532,42 @@ func TestNewPlanJob(t *testing.T) {
                   (numRepos . 0)
                   (limit . 500))))
             NOOP))))))`),
+	}, {
+		query:      `type:path repo:test test`,
+		protocol:   search.Streaming,
+		searchType: query.SearchTypeRegex,
+		want: autogold.Expect(`
+(LOG
+  (ALERT
+    (query . )
+    (originalQuery . )
+    (patternType . regex)
+    (TIMEOUT
+      (timeout . 20s)
+      (LIMIT
+        (limit . 500)
+        (PARALLEL
+          (SEQUENTIAL
+            (ensureUnique . false)
+            (REPOPAGER
+              (repoOpts.repoFilters . [test])
+              (PARTIALREPOS
+                (ZOEKTREPOSUBSETTEXTSEARCH
+                  (query . file_substr:"test")
+                  (type . text))))
+            (REPOPAGER
+              (repoOpts.repoFilters . [test])
+              (PARTIALREPOS
+                (PATHINDEXSEARCH
+                  (patternInfo.pattern . test)
+                  (patternInfo.isRegexp . true)
+                  (patternInfo.fileMatchLimit . 500)
+                  (patternInfo.patternMatchesPath . true)
+                  (SEARCHERTEXTSEARCH
+                    (indexed . false))))))
+          (REPOSCOMPUTEEXCLUDED
+            (repoOpts.repoFilters . [test]))
+          NOOP)))))`),
 	}, {
 		query:      `(type:commit or type:diff) (a or b)`,
 		protocol:   search.Streaming,
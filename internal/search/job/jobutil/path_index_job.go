@@ -0,0 +1,328 @@
+package jobutil
+
+import (
+	"context"
+	"regexp/syntax"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/grafana/regexp"
+	"github.com/sourcegraph/conc/pool"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// pathIndexConcurrency is the number of repositories we read from the path
+// index concurrently.
+const pathIndexConcurrency = 8
+
+// usePathIndex returns true if the search described by patternInfo only
+// matches paths, so it can be answered by the path index. This is the case
+// for type:path and for select:file without a pattern.
+func usePathIndex(patternInfo *search.TextPatternInfo) bool {
+	if patternInfo.IsStructuralPat {
+		return false
+	}
+	if patternInfo.PatternMatchesPath && !patternInfo.PatternMatchesContent {
+		return true
+	}
+	return patternInfo.Select.Root() == filter.File && patternInfo.Pattern == ""
+}
+
+// NewPathIndexJob creates a job which searches the paths of unindexed
+// repositories in the path index kept by gitserver, rather than fetching
+// archives with searcher. Repositories which are not in the path index, or
+// are searched at revisions other than the default branch, are searched by
+// fallback.
+func NewPathIndexJob(patternInfo *search.TextPatternInfo, fallback job.Job) job.Job {
+	return &pathIndexJob{
+		patternInfo: patternInfo,
+		pathRegexps: getPathRegexpsFromTextPatternInfo(patternInfo),
+		fallback:    fallback,
+	}
+}
+
+type pathIndexJob struct {
+	patternInfo *search.TextPatternInfo
+	pathRegexps []*regexp.Regexp // used for getting file path match ranges
+	repos       []*search.RepositoryRevisions
+	fallback    job.Job
+}
+
+func (j *pathIndexJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	tr, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	if len(j.repos) == 0 {
+		return nil, nil
+	}
+
+	match, err := compilePathIndexMatcher(j.patternInfo)
+	if err != nil {
+		return nil, err
+	}
+	substrings := pathIndexSubstrings(j.patternInfo)
+
+	ids := make([]api.RepoID, 0, len(j.repos))
+	for _, repo := range j.repos {
+		if isDefaultBranchOnly(repo) {
+			ids = append(ids, repo.Repo.ID)
+		}
+	}
+	commits, err := clients.DB.RepoPaths().PathIndexCommits(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var indexed, rest []*search.RepositoryRevisions
+	for _, repo := range j.repos {
+		if _, ok := commits[repo.Repo.ID]; ok && isDefaultBranchOnly(repo) {
+			indexed = append(indexed, repo)
+		} else {
+			rest = append(rest, repo)
+		}
+	}
+	tr.SetAttributes(
+		attribute.Int("indexedRepos", len(indexed)),
+		attribute.Int("fallbackRepos", len(rest)))
+
+	var (
+		p          = pool.New().WithContext(ctx).WithMaxGoroutines(pathIndexConcurrency)
+		maxAlerter search.MaxAlerter
+	)
+	if len(rest) > 0 {
+		fallback := setRepos(j.fallback, nil, rest)
+		p.Go(func(ctx context.Context) error {
+			alert, err := fallback.Run(ctx, clients, stream)
+			maxAlerter.Add(alert)
+			return err
+		})
+	}
+	for _, repo := range indexed {
+		repo := repo
+		p.Go(func(ctx context.Context) error {
+			return j.searchRepo(ctx, clients, repo, commits[repo.Repo.ID], substrings, match, stream)
+		})
+	}
+	return maxAlerter.Alert, p.Wait()
+}
+
+// errPathIndexLimitHit stops iterating the path index of a repository once we
+// found enough matches.
+var errPathIndexLimitHit = errors.New("path index limit hit")
+
+func (j *pathIndexJob) searchRepo(ctx context.Context, clients job.RuntimeClients, repo *search.RepositoryRevisions, commit api.CommitID, substrings []string, match func(string) bool, stream streaming.Sender) error {
+	rev := repo.Revs[0]
+
+	var matches result.Matches
+	err := clients.DB.RepoPaths().IteratePathIndex(ctx, repo.Repo.ID, substrings, func(path string) error {
+		if !match(path) {
+			return nil
+		}
+		if len(matches) >= int(j.patternInfo.FileMatchLimit) {
+			return errPathIndexLimitHit
+		}
+		matches = append(matches, &result.FileMatch{
+			File: result.File{
+				Path:     path,
+				Repo:     repo.Repo,
+				CommitID: commit,
+				InputRev: &rev,
+			},
+			PathMatches: pathMatchRanges(j.pathRegexps, path),
+		})
+		return nil
+	})
+
+	limitHit := errors.Is(err, errPathIndexLimitHit)
+	if limitHit {
+		err = nil
+	}
+	status, limitHit, err := search.HandleRepoSearchResult(repo.Repo.ID, repo.Revs, limitHit, false, err)
+	stream.Send(streaming.SearchEvent{
+		Results: matches,
+		Stats: streaming.Stats{
+			Status:     status,
+			IsLimitHit: limitHit,
+		},
+	})
+	return err
+}
+
+// isDefaultBranchOnly returns true if repo is only searched at its default
+// branch, which is what the path index contains.
+func isDefaultBranchOnly(repo *search.RepositoryRevisions) bool {
+	return len(repo.Revs) == 1 && repo.Revs[0] == ""
+}
+
+// compilePathIndexMatcher returns a function which reports whether a path
+// matches patternInfo, following the semantics of searcher for path matches.
+func compilePathIndexMatcher(patternInfo *search.TextPatternInfo) (func(string) bool, error) {
+	compile := func(expr string, caseSensitive bool) (*regexp.Regexp, error) {
+		if !caseSensitive {
+			expr = "(?i:" + expr + ")"
+		}
+		return regexp.Compile(expr)
+	}
+
+	var pattern *regexp.Regexp
+	if patternInfo.Pattern != "" {
+		expr := patternInfo.Pattern
+		if !patternInfo.IsRegExp {
+			expr = regexp.QuoteMeta(expr)
+		}
+		if patternInfo.IsWordMatch {
+			expr = `\b` + expr + `\b`
+		}
+		var err error
+		if pattern, err = compile(expr, patternInfo.IsCaseSensitive); err != nil {
+			return nil, err
+		}
+	}
+
+	include := make([]*regexp.Regexp, 0, len(patternInfo.IncludePatterns))
+	for _, p := range patternInfo.IncludePatterns {
+		re, err := compile(p, patternInfo.PathPatternsAreCaseSensitive)
+		if err != nil {
+			return nil, err
+		}
+		include = append(include, re)
+	}
+
+	var exclude *regexp.Regexp
+	if patternInfo.ExcludePattern != "" {
+		var err error
+		if exclude, err = compile(patternInfo.ExcludePattern, patternInfo.PathPatternsAreCaseSensitive); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(path string) bool {
+		for _, re := range include {
+			if !re.MatchString(path) {
+				return false
+			}
+		}
+		if exclude != nil && exclude.MatchString(path) {
+			return false
+		}
+		if pattern == nil {
+			return true
+		}
+		return pattern.MatchString(path) != patternInfo.IsNegated
+	}, nil
+}
+
+// pathIndexSubstrings returns substrings which every path matched by
+// patternInfo contains. The path index is filtered by these in the database
+// before we match paths exactly.
+func pathIndexSubstrings(patternInfo *search.TextPatternInfo) []string {
+	var exprs []string
+	if patternInfo.Pattern != "" && !patternInfo.IsNegated {
+		expr := patternInfo.Pattern
+		if !patternInfo.IsRegExp {
+			expr = regexp.QuoteMeta(expr)
+		}
+		exprs = append(exprs, expr)
+	}
+	exprs = append(exprs, patternInfo.IncludePatterns...)
+
+	var substrings []string
+	for _, expr := range exprs {
+		re, err := syntax.Parse(expr, syntax.Perl)
+		if err != nil {
+			continue
+		}
+		if lit := requiredLiteral(re.Simplify()); lit != "" {
+			substrings = append(substrings, lit)
+		}
+	}
+	return substrings
+}
+
+// requiredLiteral returns the longest literal which every match of re
+// contains. Only ASCII literals are returned, since the database may fold the
+// case of other characters differently than we do.
+func requiredLiteral(re *syntax.Regexp) string {
+	switch re.Op {
+	case syntax.OpLiteral:
+		lit := string(re.Rune)
+		if strings.IndexFunc(lit, func(r rune) bool { return r >= utf8.RuneSelf }) >= 0 {
+			return ""
+		}
+		return lit
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiteral(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min == 0 {
+			return ""
+		}
+		return requiredLiteral(re.Sub[0])
+	case syntax.OpConcat:
+		longest := ""
+		for _, sub := range re.Sub {
+			if lit := requiredLiteral(sub); len(lit) > len(longest) {
+				longest = lit
+			}
+		}
+		return longest
+	default:
+		return ""
+	}
+}
+
+func pathMatchRanges(pathRegexps []*regexp.Regexp, path string) []result.Range {
+	var ranges []result.Range
+	for _, re := range pathRegexps {
+		for _, sm := range re.FindAllStringSubmatchIndex(path, -1) {
+			ranges = append(ranges, result.Range{
+				Start: result.Location{
+					Offset: sm[0],
+					Column: utf8.RuneCountInString(path[:sm[0]]),
+				},
+				End: result.Location{
+					Offset: sm[1],
+					Column: utf8.RuneCountInString(path[:sm[1]]),
+				},
+			})
+		}
+	}
+	return ranges
+}
+
+func (j *pathIndexJob) Name() string {
+	return "PathIndexSearchJob"
+}
+
+func (j *pathIndexJob) Attributes(v job.Verbosity) (res []attribute.KeyValue) {
+	switch v {
+	case job.VerbosityMax:
+		res = append(res,
+			attribute.Int("numRepos", len(j.repos)),
+		)
+		fallthrough
+	case job.VerbosityBasic:
+		res = append(res,
+			trace.Scoped("patternInfo", j.patternInfo.Fields()...)...,
+		)
+	}
+	return res
+}
+
+func (j *pathIndexJob) Children() []job.Describer {
+	return []job.Describer{j.fallback}
+}
+
+func (j *pathIndexJob) MapChildren(fn job.MapFunc) job.Job {
+	cp := *j
+	cp.fallback = job.Map(j.fallback, fn)
+	return &cp
+}
@@ -0,0 +1,124 @@
+package jobutil
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestPathIndexJob(t *testing.T) {
+	index := map[api.RepoID][]string{
+		1: {"README.md", "cmd/main.go", "internal/main_test.go"},
+	}
+
+	repoPaths := database.NewMockRepoPathStore()
+	repoPaths.PathIndexCommitsFunc.SetDefaultHook(func(_ context.Context, ids []api.RepoID) (map[api.RepoID]api.CommitID, error) {
+		commits := map[api.RepoID]api.CommitID{}
+		for _, id := range ids {
+			if _, ok := index[id]; ok {
+				commits[id] = "deadbeef"
+			}
+		}
+		return commits, nil
+	})
+	repoPaths.IteratePathIndexFunc.SetDefaultHook(func(_ context.Context, id api.RepoID, substrings []string, f func(string) error) error {
+	next:
+		for _, p := range index[id] {
+			for _, sub := range substrings {
+				if !strings.Contains(strings.ToLower(p), strings.ToLower(sub)) {
+					continue next
+				}
+			}
+			if err := f(p); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	db := database.NewMockDB()
+	db.RepoPathsFunc.SetDefaultReturn(repoPaths)
+
+	repos := []*search.RepositoryRevisions{
+		{Repo: types.MinimalRepo{ID: 1, Name: "indexed"}, Revs: []string{""}},
+		{Repo: types.MinimalRepo{ID: 1, Name: "indexed"}, Revs: []string{"branch"}},
+		{Repo: types.MinimalRepo{ID: 2, Name: "unindexed"}, Revs: []string{""}},
+	}
+
+	cases := []struct {
+		name        string
+		patternInfo *search.TextPatternInfo
+		want        []string
+	}{{
+		name:        "pattern",
+		patternInfo: &search.TextPatternInfo{Pattern: "MAIN", IsRegExp: true, PatternMatchesPath: true, FileMatchLimit: 10},
+		want:        []string{"cmd/main.go", "internal/main_test.go"},
+	}, {
+		name:        "negated pattern",
+		patternInfo: &search.TextPatternInfo{Pattern: "main", IsRegExp: true, IsNegated: true, PatternMatchesPath: true, FileMatchLimit: 10},
+		want:        []string{"README.md"},
+	}, {
+		name:        "include and exclude",
+		patternInfo: &search.TextPatternInfo{IncludePatterns: []string{`\.go$`}, ExcludePattern: "_test", PatternMatchesPath: true, FileMatchLimit: 10},
+		want:        []string{"cmd/main.go"},
+	}, {
+		name:        "limit",
+		patternInfo: &search.TextPatternInfo{PatternMatchesPath: true, FileMatchLimit: 2},
+		want:        []string{"README.md", "cmd/main.go"},
+	}}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var fallbackRuns int
+			fallback := mockjob.NewMockJob()
+			fallback.RunFunc.SetDefaultHook(func(context.Context, job.RuntimeClients, streaming.Sender) (*search.Alert, error) {
+				fallbackRuns++
+				return nil, nil
+			})
+			fallback.MapChildrenFunc.SetDefaultReturn(fallback)
+
+			j := setRepos(NewPathIndexJob(tc.patternInfo, fallback), nil, repos)
+			s := streaming.NewAggregatingStream()
+			_, err := j.Run(context.Background(), job.RuntimeClients{DB: db}, s)
+			require.NoError(t, err)
+
+			var got []string
+			for _, m := range s.Results {
+				fm := m.(*result.FileMatch)
+				require.Equal(t, api.CommitID("deadbeef"), fm.CommitID)
+				got = append(got, fm.Path)
+			}
+			sort.Strings(got)
+			require.Equal(t, tc.want, got)
+			require.Equal(t, 1, fallbackRuns)
+		})
+	}
+}
+
+func TestPathIndexSubstrings(t *testing.T) {
+	cases := []struct {
+		patternInfo *search.TextPatternInfo
+		want        []string
+	}{
+		{&search.TextPatternInfo{Pattern: "main.go"}, []string{"main.go"}},
+		{&search.TextPatternInfo{Pattern: `cmd/.*_test\.go$`, IsRegExp: true}, []string{"_test.go"}},
+		{&search.TextPatternInfo{Pattern: "main", IsNegated: true}, nil},
+		{&search.TextPatternInfo{Pattern: "(foo|bar)", IsRegExp: true, IncludePatterns: []string{`\.go$`}}, []string{".go"}},
+		{&search.TextPatternInfo{Pattern: "(?:abc)?d", IsRegExp: true}, []string{"d"}},
+		{&search.TextPatternInfo{Pattern: "größe", IsRegExp: true}, nil},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.want, pathIndexSubstrings(tc.patternInfo), tc.patternInfo.Pattern)
+	}
+}
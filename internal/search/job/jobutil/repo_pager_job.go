@@ -18,6 +18,7 @@ type repoPagerJob struct {
 	repoOpts         search.RepoOptions
 	containsRefGlobs bool                          // whether to include repositories with refs
 	child            job.PartialJob[resolvedRepos] // child job tree that need populating a repos field to run
+	resumeKey        int                           // identifies the job in resume tokens, 0 if not resumable
 }
 
 // resolvedRepos is the set of information to complete the partial
@@ -71,6 +72,10 @@ func setRepos(j job.Job, indexed *zoekt.IndexedRepoRevs, unindexed []*search.Rep
 			cp := *v
 			cp.Repos = unindexed
 			return &cp
+		case *pathIndexJob:
+			cp := *v
+			cp.repos = unindexed
+			return &cp
 		default:
 			return j
 		}
@@ -83,8 +88,21 @@ func (p *repoPagerJob) Run(ctx context.Context, clients job.RuntimeClients, stre
 
 	var maxAlerter search.MaxAlerter
 
+	repoOpts := p.repoOpts
+	if c, ok := streaming.ResumeFromContext(ctx, p.resumeKey); ok {
+		if c.Done {
+			return nil, nil
+		}
+		repoOpts.Cursors = c.Next
+	}
+
+	// resumable is true as long as all the pages so far were completely
+	// searched. Since a resumed search continues after the last page we
+	// report, we stop reporting pages after the first incomplete one.
+	resumable := p.resumeKey != 0
+
 	repoResolver := repos.NewResolver(clients.Logger, clients.DB, clients.Gitserver, clients.SearcherURLs, clients.Zoekt)
-	it := repoResolver.Iterator(ctx, p.repoOpts)
+	it := repoResolver.Iterator(ctx, repoOpts)
 
 	for it.Next() {
 		page := it.Current()
@@ -104,12 +122,24 @@ func (p *repoPagerJob) Run(ctx context.Context, clients job.RuntimeClients, stre
 		}
 
 		job := p.child.Resolve(resolvedRepos{indexed, unindexed})
-		alert, err := job.Run(ctx, clients, stream)
+		pageStream := streaming.NewStatsObservingStream(stream)
+		alert, err := job.Run(ctx, clients, pageStream)
 		maxAlerter.Add(alert)
 
 		if err != nil {
 			return maxAlerter.Alert, err
 		}
+
+		if resumable {
+			if ctx.Err() != nil || pageStream.Status.Any(search.RepoStatusTimedout) {
+				resumable = false
+			} else {
+				stream.Send(streaming.ResumeEvent(p.resumeKey, streaming.ResumeCursor{
+					Done: page.Next == nil,
+					Next: page.Next,
+				}))
+			}
+		}
 	}
 
 	return maxAlerter.Alert, it.Err()
@@ -1,13 +1,21 @@
 package jobutil
 
 import (
+	"context"
+	"fmt"
 	"testing"
 
+	"github.com/sourcegraph/log/logtest"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/job/mockjob"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
 	"github.com/sourcegraph/sourcegraph/internal/search/searcher"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
 	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
@@ -32,3 +40,71 @@ func Test_setRepos(t *testing.T) {
 	require.Len(t, j.(*ParallelJob).children[0].(*zoekt.RepoSubsetTextSearchJob).Repos.RepoRevs, 1)
 	require.Len(t, j.(*ParallelJob).children[1].(*searcher.TextSearchJob).Repos, 2)
 }
+
+func TestRepoPagerJobResume(t *testing.T) {
+	allRepos := []types.MinimalRepo{
+		{ID: 3, Name: "repo3", Stars: 30},
+		{ID: 2, Name: "repo2", Stars: 20},
+		{ID: 1, Name: "repo1", Stars: 10},
+	}
+
+	repos := database.NewMockRepoStore()
+	repos.ListMinimalReposFunc.SetDefaultHook(func(_ context.Context, opts database.ReposListOptions) ([]types.MinimalRepo, error) {
+		// Pages are ordered by descending stars, so the cursor points
+		// to the first repository of the next page.
+		start := 0
+		if len(opts.Cursors) > 0 {
+			for i, r := range allRepos {
+				if fmt.Sprint(r.Stars) == opts.Cursors[0].Value {
+					start = i
+				}
+			}
+		}
+		end := start + opts.LimitOffset.Limit
+		if end > len(allRepos) {
+			end = len(allRepos)
+		}
+		return allRepos[start:end], nil
+	})
+	db := database.NewMockDB()
+	db.ReposFunc.SetDefaultReturn(repos)
+
+	child := mockjob.NewMockJob()
+	child.MapChildrenFunc.SetDefaultReturn(child)
+
+	pager := &repoPagerJob{
+		repoOpts:  search.RepoOptions{Limit: 2, UseIndex: query.No},
+		child:     &reposPartialJob{child},
+		resumeKey: 1,
+	}
+
+	run := func(ctx context.Context) streaming.ResumeState {
+		stream := streaming.NewAggregatingStream()
+		_, err := pager.Run(ctx, job.RuntimeClients{Logger: logtest.Scoped(t), DB: db}, stream)
+		require.NoError(t, err)
+		return stream.Stats.Resume
+	}
+
+	// The first run reports each completed page.
+	state := run(context.Background())
+	require.Len(t, child.RunFunc.History(), 2)
+	require.Equal(t, streaming.ResumeState{1: {Done: true}}, state)
+
+	// Resuming after the first page only searches the second page.
+	next := types.MultiCursor{
+		{Column: "stars", Value: "10", Direction: "prev"},
+		{Column: "id", Value: "1", Direction: "prev"},
+	}
+	state = run(streaming.WithResume(context.Background(), streaming.ResumeState{1: {Next: next}}))
+	require.Len(t, child.RunFunc.History(), 3)
+	require.Equal(t, next, repos.ListMinimalReposFunc.History()[2].Arg1.Cursors)
+	require.Equal(t, streaming.ResumeState{1: {Done: true}}, state)
+
+	// Searches of other jobs do not resume this job.
+	run(streaming.WithResume(context.Background(), streaming.ResumeState{2: {Done: true}}))
+	require.Len(t, child.RunFunc.History(), 5)
+
+	// Nothing is left to search once the job is done.
+	run(streaming.WithResume(context.Background(), streaming.ResumeState{1: {Done: true}}))
+	require.Len(t, child.RunFunc.History(), 5)
+}
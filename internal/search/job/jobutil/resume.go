@@ -0,0 +1,34 @@
+package jobutil
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/zoekt"
+)
+
+// assignResumeKeys numbers the resumable jobs of j in the order they appear
+// in the job tree, starting at 1. The numbers identify the jobs in resume
+// tokens, so they only depend on the query.
+func assignResumeKeys(j job.Job) job.Job {
+	key := 0
+	return job.Map(j, func(j job.Job) job.Job {
+		switch v := j.(type) {
+		case *repoPagerJob:
+			key++
+			cp := *v
+			cp.resumeKey = key
+			return &cp
+		case *zoekt.GlobalTextSearchJob:
+			key++
+			cp := *v
+			cp.ResumeKey = key
+			return &cp
+		case *zoekt.GlobalSymbolSearchJob:
+			key++
+			cp := *v
+			cp.ResumeKey = key
+			return &cp
+		default:
+			return j
+		}
+	})
+}
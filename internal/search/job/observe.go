@@ -2,6 +2,7 @@ package job
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/atomic"
@@ -19,7 +20,17 @@ func StartSpan(ctx context.Context, stream streaming.Sender, job Job) (*trace.Tr
 
 	observingStream := newObservingStream(tr, stream)
 
+	var explain *ExplainNode
+	if parent := ExplainFromContext(ctx); parent != nil {
+		explain = parent.startChild(job)
+		ctx = WithExplain(ctx, explain)
+	}
+	start := time.Now()
+
 	return tr, ctx, observingStream, func(alert *search.Alert, err error) {
+		if explain != nil {
+			explain.finish(time.Since(start), observingStream.totalEvents.Load(), alert, err)
+		}
 		tr.SetError(err)
 		if alert != nil {
 			tr.SetAttributes(attribute.String("alert", alert.Title))
@@ -0,0 +1,93 @@
+package printer
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sourcegraph/zoekt"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+)
+
+// ExplainAnalyze outputs an EXPLAIN ANALYZE style tree of the jobs which ran
+// below n, with one line per job run and per Zoekt shard searched. Zoekt
+// statistics are summed over the shards on the line of their job.
+func ExplainAnalyze(n *job.ExplainNode) string {
+	b := new(bytes.Buffer)
+
+	var write func(*job.ExplainNode, int)
+	write = func(n *job.ExplainNode, depth int) {
+		writeExplainIndent(b, depth)
+		b.WriteString(trimmedUpperName(n.Name))
+		fmt.Fprintf(b, " (time=%s results=%d)", formatDuration(n.Duration), n.Results)
+
+		shards := n.Shards()
+		if len(shards) > 0 {
+			var total zoekt.Stats
+			for _, shard := range shards {
+				total.Add(shard.Stats)
+			}
+			b.WriteString(" ")
+			writeZoektStats(b, total, len(shards))
+		}
+
+		for _, field := range n.Attributes {
+			fmt.Fprintf(b, " %s=%s", field.Key, field.Value.Emit())
+		}
+		if n.Alert != "" {
+			fmt.Fprintf(b, " alert=%q", n.Alert)
+		}
+		if n.Error != "" {
+			fmt.Fprintf(b, " error=%q", n.Error)
+		}
+
+		for _, shard := range shards {
+			b.WriteByte('\n')
+			writeExplainIndent(b, depth+1)
+			name := shard.Name
+			if name == "" {
+				name = "(unattributed)"
+			}
+			fmt.Fprintf(b, "shard %s: time=%s ", name, formatDuration(shard.Stats.Duration))
+			writeZoektStats(b, shard.Stats, 0)
+		}
+		for _, child := range n.Children() {
+			b.WriteByte('\n')
+			write(child, depth+1)
+		}
+	}
+	write(n, 0)
+	return b.String()
+}
+
+func writeZoektStats(b *bytes.Buffer, s zoekt.Stats, shards int) {
+	var fields []string
+	if shards > 0 {
+		fields = append(fields, fmt.Sprintf("shards=%d", shards))
+	}
+	fields = append(fields,
+		fmt.Sprintf("filesConsidered=%d", s.ShardFilesConsidered),
+		fmt.Sprintf("ngramMatches=%d", s.NgramMatches),
+		fmt.Sprintf("regexpsConsidered=%d", s.RegexpsConsidered),
+		fmt.Sprintf("filesLoaded=%d", s.FilesLoaded),
+		fmt.Sprintf("matches=%d", s.MatchCount),
+	)
+	if s.ShardsSkippedFilter > 0 {
+		fields = append(fields, fmt.Sprintf("shardsSkippedFilter=%d", s.ShardsSkippedFilter))
+	}
+	b.WriteString(strings.Join(fields, " "))
+}
+
+func writeExplainIndent(b *bytes.Buffer, depth int) {
+	if depth == 0 {
+		return
+	}
+	b.WriteString(strings.Repeat("  ", depth-1))
+	b.WriteString("-> ")
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Microsecond).String()
+}
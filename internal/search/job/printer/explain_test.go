@@ -0,0 +1,82 @@
+package printer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/sourcegraph/zoekt"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/job"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+// explainTestJob runs its children in order and then sends events, which
+// stand for results streamed by Zoekt.
+type explainTestJob struct {
+	*testJob
+	children []*explainTestJob
+	events   []*zoekt.SearchResult
+}
+
+func (j *explainTestJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
+	_, ctx, stream, finish := job.StartSpan(ctx, stream, j)
+	defer func() { finish(alert, err) }()
+
+	for _, child := range j.children {
+		if _, err := child.Run(ctx, clients, stream); err != nil {
+			return nil, err
+		}
+	}
+	for _, event := range j.events {
+		job.ExplainFromContext(ctx).AddZoektStats(event)
+	}
+	return nil, nil
+}
+
+func (j *explainTestJob) MapChildren(job.MapFunc) job.Job { return j }
+
+func TestExplainAnalyze(t *testing.T) {
+	zoektJob := &explainTestJob{
+		testJob: newTestJob("ZoektRepoSubsetTextSearchJob").withTags(attribute.String("query", "foo")),
+		events: []*zoekt.SearchResult{{
+			Files: []zoekt.FileMatch{{Repository: "a"}},
+			Stats: zoekt.Stats{ShardFilesConsidered: 10, NgramMatches: 4, RegexpsConsidered: 2, FilesLoaded: 2, MatchCount: 1},
+		}, {
+			Files: []zoekt.FileMatch{{Repository: "b"}},
+			Stats: zoekt.Stats{ShardFilesConsidered: 5, NgramMatches: 1, RegexpsConsidered: 1, FilesLoaded: 1, MatchCount: 1},
+		}, {
+			Stats: zoekt.Stats{ShardFilesConsidered: 7, ShardsSkippedFilter: 3},
+		}},
+	}
+	root := &explainTestJob{
+		testJob:  newTestJob("LimitJob").withTags(attribute.Int("limit", 500)),
+		children: []*explainTestJob{zoektJob, {testJob: newTestJob("SearcherTextSearchJob")}},
+	}
+
+	explain := job.NewExplain("Search")
+	_, err := root.Run(job.WithExplain(context.Background(), explain), job.RuntimeClients{}, streaming.NewNullStream())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Durations are not deterministic, so we reset them before printing.
+	var reset func(*job.ExplainNode)
+	reset = func(n *job.ExplainNode) {
+		n.Duration = 0
+		for _, child := range n.Children() {
+			reset(child)
+		}
+	}
+	reset(explain)
+
+	autogold.Expect(`SEARCH (time=0s results=0)
+-> LIMIT (time=0s results=0) limit=500
+  -> ZOEKTREPOSUBSETTEXTSEARCH (time=0s results=0) shards=3 filesConsidered=22 ngramMatches=5 regexpsConsidered=3 filesLoaded=3 matches=2 shardsSkippedFilter=3 query=foo
+    -> shard (unattributed): time=0s filesConsidered=7 ngramMatches=0 regexpsConsidered=0 filesLoaded=0 matches=0 shardsSkippedFilter=3
+    -> shard a: time=0s filesConsidered=10 ngramMatches=4 regexpsConsidered=2 filesLoaded=2 matches=1
+    -> shard b: time=0s filesConsidered=5 ngramMatches=1 regexpsConsidered=1 filesLoaded=1 matches=1
+  -> SEARCHERTEXTSEARCH (time=0s results=0)`).Equal(t, ExplainAnalyze(explain))
+}
@@ -33,8 +33,14 @@ const (
 	FieldTimeout   = "timeout"
 	FieldCombyRule = "rule"
 	FieldSelect    = "select"
+	FieldExplain   = "explain" // Searches that specify `explain:yes` stream an EXPLAIN ANALYZE style tree of the jobs which ran
+	FieldDedupe    = "dedupe"  // Searches that specify `dedupe:content` collapse file matches with the same content
 )
 
+// DedupeContent is the value of `dedupe:` which collapses file matches with
+// the same blob across repositories and paths.
+const DedupeContent = "content"
+
 var allFields = map[string]struct{}{
 	FieldCase:               empty,
 	FieldRepo:               empty,
@@ -71,6 +77,8 @@ var allFields = map[string]struct{}{
 	FieldRev:                empty,
 	"revision":              empty,
 	FieldSelect:             empty,
+	FieldExplain:            empty,
+	FieldDedupe:             empty,
 }
 
 var aliases = map[string]string{
@@ -0,0 +1,235 @@
+package query
+
+import (
+	"regexp/syntax"
+	"sort"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+)
+
+type DiagnosticSeverity string
+
+const (
+	// SeverityError is for problems which make the query invalid.
+	SeverityError DiagnosticSeverity = "error"
+	// SeverityWarning is for valid queries which are likely slow or not
+	// what the user meant.
+	SeverityWarning DiagnosticSeverity = "warning"
+)
+
+// Diagnostic is a problem found in a query by Lint.
+type Diagnostic struct {
+	Range    Range
+	Severity DiagnosticSeverity
+	Message  string
+
+	// Replacement is the suggested text to replace Range with to fix the
+	// problem. It is nil if there is no automatic fix.
+	Replacement *string
+}
+
+// Lint returns the problems found in the query in. Unlike validation, which
+// stops at the first error, Lint reports every problem it finds, ordered by
+// their position in the query. Besides errors it reports valid queries which
+// are likely slow or mistakes.
+func Lint(in string, searchType SearchType) []Diagnostic {
+	nodes, err := Run(Init(in, searchType))
+	if err != nil {
+		return []Diagnostic{{
+			Range:    newRange(0, len(in)),
+			Severity: SeverityError,
+			Message:  err.Error(),
+		}}
+	}
+
+	l := &linter{in: in}
+	l.lintParameters(nodes)
+	l.lintTypeWithoutTimeFrame(nodes)
+
+	// Checks which span several parameters are reported for the whole
+	// query.
+	for _, basic := range BuildPlan(nodes) {
+		for _, check := range []func([]Node) error{
+			validatePattern,
+			validateRepoRevPair,
+			validateRepoHasFile,
+			validateCommitParameters,
+			validateTypeStructural,
+			validateRefGlobs,
+		} {
+			if err := check(basic.ToParseTree()); err != nil {
+				l.add(Diagnostic{
+					Range:    newRange(0, len(in)),
+					Severity: SeverityError,
+					Message:  err.Error(),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(l.diagnostics, func(i, j int) bool {
+		return l.diagnostics[i].Range.Start.Column < l.diagnostics[j].Range.Start.Column
+	})
+	return l.diagnostics
+}
+
+type linter struct {
+	in          string
+	diagnostics []Diagnostic
+}
+
+// add adds d unless the same problem was already reported at the same range,
+// which happens for queries with several or-operands.
+func (l *linter) add(d Diagnostic) {
+	for _, prev := range l.diagnostics {
+		if prev.Range == d.Range && prev.Message == d.Message {
+			return
+		}
+	}
+	l.diagnostics = append(l.diagnostics, d)
+}
+
+func (l *linter) lintParameters(nodes []Node) {
+	seen := map[string]struct{}{}
+	VisitParameter(nodes, func(field, value string, negated bool, annotation Annotation) {
+		defer func() { seen[field] = struct{}{} }()
+
+		var err error
+		if annotation.Labels.IsSet(IsPredicate) {
+			err = validatePredicate(field, value, negated)
+		} else {
+			err = validateField(field, value, negated, seen)
+		}
+		if err != nil {
+			d := Diagnostic{
+				Range:    annotation.Range,
+				Severity: SeverityError,
+				Message:  err.Error(),
+			}
+			if field == FieldLang {
+				d.Replacement = suggestLanguage(value, negated)
+			}
+			l.add(d)
+			return
+		}
+
+		if field == FieldRepo && !negated && !annotation.Labels.IsSet(IsPredicate) {
+			l.lintExpensiveRepo(value, annotation.Range)
+		}
+	})
+}
+
+// minRepoLiteralLength is the length of the shortest literal a repo: pattern
+// must contain to be looked up in the trigram index of repository names.
+const minRepoLiteralLength = 3
+
+// lintExpensiveRepo warns about repo: patterns which contain no literal text
+// long enough to use the trigram index of repository names. These are matched
+// against the name of every repository.
+func (l *linter) lintExpensiveRepo(value string, r Range) {
+	repoRevs, err := ParseRepositoryRevisions(value)
+	if err != nil || repoRevs.Repo == "" {
+		return
+	}
+
+	re, err := syntax.Parse(repoRevs.Repo, syntax.Perl)
+	if err != nil || requiredLiteralLength(re.Simplify()) >= minRepoLiteralLength {
+		return
+	}
+	l.add(Diagnostic{
+		Range:    r,
+		Severity: SeverityWarning,
+		Message:  "this repo: pattern has no literal text of at least 3 characters, so it is checked against the name of every repository, which is expensive. Use a more specific pattern",
+	})
+}
+
+// requiredLiteralLength returns the length of the longest literal which every
+// match of re contains.
+func requiredLiteralLength(re *syntax.Regexp) int {
+	switch re.Op {
+	case syntax.OpLiteral:
+		return len(re.Rune)
+	case syntax.OpCapture, syntax.OpPlus:
+		return requiredLiteralLength(re.Sub[0])
+	case syntax.OpRepeat:
+		if re.Min == 0 {
+			return 0
+		}
+		return requiredLiteralLength(re.Sub[0])
+	case syntax.OpConcat:
+		longest := 0
+		for _, sub := range re.Sub {
+			if n := requiredLiteralLength(sub); n > longest {
+				longest = n
+			}
+		}
+		return longest
+	case syntax.OpAlternate:
+		shortest := -1
+		for _, sub := range re.Sub {
+			if n := requiredLiteralLength(sub); shortest < 0 || n < shortest {
+				shortest = n
+			}
+		}
+		if shortest < 0 {
+			return 0
+		}
+		return shortest
+	default:
+		return 0
+	}
+}
+
+// lintTypeWithoutTimeFrame warns about commit and diff searches without a
+// time frame, since these search the full history of every repository.
+func (l *linter) lintTypeWithoutTimeFrame(nodes []Node) {
+	hasTimeFrame := false
+	VisitParameter(nodes, func(field, _ string, _ bool, _ Annotation) {
+		if field == FieldAfter || field == FieldBefore {
+			hasTimeFrame = true
+		}
+	})
+	if hasTimeFrame {
+		return
+	}
+
+	VisitField(nodes, FieldType, func(value string, _ bool, annotation Annotation) {
+		if value != "diff" && value != "commit" {
+			return
+		}
+		replacement := l.text(annotation.Range) + ` after:"1 month ago"`
+		l.add(Diagnostic{
+			Range:       annotation.Range,
+			Severity:    SeverityWarning,
+			Message:     "type:" + value + " without after: or before: searches the full history of every repository. Add after: to limit the search to recent commits",
+			Replacement: &replacement,
+		})
+	})
+}
+
+// text returns the part of the query covered by r.
+func (l *linter) text(r Range) string {
+	start, end := r.Start.Column, r.End.Column
+	if start < 0 || end > len(l.in) || start > end {
+		return ""
+	}
+	return l.in[start:end]
+}
+
+// suggestLanguage returns a lang: parameter for the language whose files have
+// the extension value, e.g. lang:python for lang:py.
+func suggestLanguage(value string, negated bool) *string {
+	language, _ := enry.GetLanguageByExtension("file." + value)
+	if language == "" {
+		return nil
+	}
+	suggestion := FieldLang + ":" + strings.ToLower(language)
+	if _, ok := enry.GetLanguageByAlias(strings.ToLower(language)); !ok {
+		return nil
+	}
+	if negated {
+		suggestion = "-" + suggestion
+	}
+	return &suggestion
+}
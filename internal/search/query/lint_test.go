@@ -0,0 +1,39 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+)
+
+func TestLint(t *testing.T) {
+	test := func(input string) []string {
+		var got []string
+		for _, d := range Lint(input, SearchTypeStandard) {
+			s := string(d.Severity) + " " + d.Range.String() + ": " + d.Message
+			if d.Replacement != nil {
+				s += " => " + *d.Replacement
+			}
+			got = append(got, s)
+		}
+		return got
+	}
+
+	autogold.Expect([]string{}).Equal(t, test("repo:^github\\.com/sourcegraph/ lang:go foo"))
+	autogold.Expect([]string{
+		`warning {"start":{"line":0,"column":29},"end":{"line":0,"column":38}}: type:diff without after: or before: searches the full history of every repository. Add after: to limit the search to recent commits => type:diff after:"1 month ago"`,
+	}).Equal(t, test("repo:sourcegraph lang:golang type:diff"))
+	autogold.Expect([]string{
+		`warning {"start":{"line":0,"column":31},"end":{"line":0,"column":42}}: type:commit without after: or before: searches the full history of every repository. Add after: to limit the search to recent commits => type:commit after:"1 month ago"`,
+	}).Equal(t, test("r:.*sourcegraph@main -repo:foo type:commit author:alice"))
+	autogold.Expect([]string{
+		`warning {"start":{"line":0,"column":0},"end":{"line":0,"column":9}}: this repo: pattern has no literal text of at least 3 characters, so it is checked against the name of every repository, which is expensive. Use a more specific pattern`,
+		`warning {"start":{"line":0,"column":10},"end":{"line":0,"column":25}}: this repo: pattern has no literal text of at least 3 characters, so it is checked against the name of every repository, which is expensive. Use a more specific pattern`,
+	}).Equal(t, test("repo:a.*b repo:(foo|go)/x foo"))
+	autogold.Expect([]string{}).Equal(t, test("repo:(foo|bar)/x repo:^github\\.com/ foo"))
+	autogold.Expect([]string{
+		`error {"start":{"line":0,"column":9},"end":{"line":0,"column":16}}: field "case" may not be used more than once`,
+		`error {"start":{"line":0,"column":17},"end":{"line":0,"column":24}}: unknown language: "py" => lang:python`,
+	}).Equal(t, test("case:yes case:no lang:py"))
+	autogold.Expect([]string{"error {\"start\":{\"line\":0,\"column\":0},\"end\":{\"line\":0,\"column\":25}}: error parsing regexp: missing closing ): `foo(`"}).Equal(t, test("/foo(/ repo:^sourcegraph$"))
+}
@@ -48,6 +48,8 @@ var DefaultPredicateRegistry = PredicateRegistry{
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
 		"has.content":      func() Predicate { return &FileContainsContentPredicate{} },
 		"has.owner":        func() Predicate { return &FileHasOwnerPredicate{} },
+		"has.commit.after": func() Predicate { return &FileHasCommitAfterPredicate{} },
+		"modified.by":      func() Predicate { return &FileModifiedByPredicate{} },
 	},
 }
 
@@ -593,3 +595,44 @@ func (f *FileHasOwnerPredicate) Unmarshal(params string, negated bool) error {
 
 func (f FileHasOwnerPredicate) Field() string { return FieldFile }
 func (f FileHasOwnerPredicate) Name() string  { return "has.owner" }
+
+/* file:has.commit.after(...) */
+
+type FileHasCommitAfterPredicate struct {
+	TimeRef string
+	Negated bool
+}
+
+func (f *FileHasCommitAfterPredicate) Unmarshal(params string, negated bool) error {
+	if params == "" {
+		return errors.Errorf("file:has.commit.after argument should not be empty")
+	}
+	f.TimeRef = params
+	f.Negated = negated
+	return nil
+}
+
+func (f FileHasCommitAfterPredicate) Field() string { return FieldFile }
+func (f FileHasCommitAfterPredicate) Name() string  { return "has.commit.after" }
+
+/* file:modified.by(pattern) */
+
+type FileModifiedByPredicate struct {
+	Author  string
+	Negated bool
+}
+
+func (f *FileModifiedByPredicate) Unmarshal(params string, negated bool) error {
+	if _, err := syntax.Parse(params, syntax.Perl); err != nil {
+		return errors.Errorf("file:modified.by argument: %w", err)
+	}
+	if params == "" {
+		return errors.Errorf("file:modified.by argument should not be empty")
+	}
+	f.Author = params
+	f.Negated = negated
+	return nil
+}
+
+func (f FileModifiedByPredicate) Field() string { return FieldFile }
+func (f FileModifiedByPredicate) Name() string  { return "modified.by" }
@@ -330,3 +330,63 @@ func TestFileHasOwnerPredicate(t *testing.T) {
 		}
 	})
 }
+
+func TestFileHasCommitAfterPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		p := &FileHasCommitAfterPredicate{}
+		if err := p.Unmarshal(`30 days ago`, true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected := &FileHasCommitAfterPredicate{TimeRef: "30 days ago", Negated: true}
+		if !reflect.DeepEqual(expected, p) {
+			t.Fatalf("expected %#v, got %#v", expected, p)
+		}
+
+		if err := (&FileHasCommitAfterPredicate{}).Unmarshal(``, false); err == nil {
+			t.Fatal("expected error but got none")
+		}
+	})
+}
+
+func TestFileModifiedByPredicate(t *testing.T) {
+	t.Run("Unmarshal", func(t *testing.T) {
+		type test struct {
+			name     string
+			params   string
+			expected *FileModifiedByPredicate
+		}
+
+		valid := []test{
+			{`name`, `alice`, &FileModifiedByPredicate{Author: "alice"}},
+			{`email`, `alice@example\.com`, &FileModifiedByPredicate{Author: `alice@example\.com`}},
+		}
+
+		for _, tc := range valid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileModifiedByPredicate{}
+				err := p.Unmarshal(tc.params, false)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if !reflect.DeepEqual(tc.expected, p) {
+					t.Fatalf("expected %#v, got %#v", tc.expected, p)
+				}
+			})
+		}
+
+		invalid := []test{
+			{`empty`, ``, nil},
+			{`invalid regex`, `(alice`, nil},
+		}
+
+		for _, tc := range invalid {
+			t.Run(tc.name, func(t *testing.T) {
+				p := &FileModifiedByPredicate{}
+				if err := p.Unmarshal(tc.params, false); err == nil {
+					t.Fatal("expected error but got none")
+				}
+			})
+		}
+	})
+}
@@ -51,6 +51,19 @@ func (r1 RevisionSpecifier) HasRefGlob() bool {
 	return r1.RefGlob != "" || r1.ExcludeRefGlob != ""
 }
 
+// Range returns the two endpoints of a revspec of the form "base...head". ok
+// is false if r1 is not such a range or either endpoint is empty.
+func (r1 RevisionSpecifier) Range() (base, head string, ok bool) {
+	if r1.HasRefGlob() {
+		return "", "", false
+	}
+	base, head, ok = strings.Cut(r1.RevSpec, "...")
+	if !ok || base == "" || head == "" {
+		return "", "", false
+	}
+	return base, head, true
+}
+
 type ParsedRepoFilter struct {
 	Repo      string
 	RepoRegex *regexp.Regexp // A case-insensitive regex matching the Repo pattern
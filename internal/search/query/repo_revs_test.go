@@ -61,3 +61,27 @@ func TestParseRepositoryRevisions(t *testing.T) {
 		})
 	}
 }
+
+func TestRevisionSpecifier_Range(t *testing.T) {
+	tests := []struct {
+		rev  RevisionSpecifier
+		base string
+		head string
+		ok   bool
+	}{
+		{rev: RevisionSpecifier{RevSpec: "v1.2...v1.3"}, base: "v1.2", head: "v1.3", ok: true},
+		{rev: RevisionSpecifier{RevSpec: "main"}},
+		{rev: RevisionSpecifier{RevSpec: "v1.2..v1.3"}},
+		{rev: RevisionSpecifier{RevSpec: "...v1.3"}},
+		{rev: RevisionSpecifier{RevSpec: "v1.2..."}},
+		{rev: RevisionSpecifier{RefGlob: "refs/tags/v1...v2"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.rev.String(), func(t *testing.T) {
+			base, head, ok := tc.rev.Range()
+			if base != tc.base || head != tc.head || ok != tc.ok {
+				t.Fatalf("got (%q, %q, %t), want (%q, %q, %t)", base, head, ok, tc.base, tc.head, tc.ok)
+			}
+		})
+	}
+}
@@ -494,6 +494,32 @@ func (p Parameters) FileHasOwner() (include, exclude []string) {
 	return include, exclude
 }
 
+type FileHasCommitAfterArgs struct {
+	TimeRef string
+	Negated bool
+}
+
+func (p Parameters) FileHasCommitAfter() (res *FileHasCommitAfterArgs) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileHasCommitAfterPredicate) {
+		res = &FileHasCommitAfterArgs{
+			TimeRef: pred.TimeRef,
+			Negated: pred.Negated,
+		}
+	})
+	return res
+}
+
+func (p Parameters) FileModifiedBy() (include, exclude []string) {
+	VisitTypedPredicate(toNodes(p), func(pred *FileModifiedByPredicate) {
+		if pred.Negated {
+			exclude = append(exclude, pred.Author)
+		} else {
+			include = append(include, pred.Author)
+		}
+	})
+	return include, exclude
+}
+
 // Exists returns whether a parameter exists in the query (whether negated or not).
 func (p Parameters) Exists(field string) bool {
 	found := false
@@ -222,6 +222,13 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return err
 	}
 
+	isDedupeMode := func() error {
+		if strings.ToLower(value) != DedupeContent {
+			return errors.Errorf("invalid value %q for field %q. Valid values are: %s", value, field, DedupeContent)
+		}
+		return nil
+	}
+
 	isValidGitDate := func() error {
 		_, err := ParseGitDate(value, time.Now)
 		return err
@@ -241,7 +248,8 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		FieldDefault:
 		// Search patterns are not validated here, as it depends on the search type.
 	case
-		FieldCase:
+		FieldCase,
+		FieldExplain:
 		return satisfies(isSingular, isBoolean, isNotNegated)
 	case
 		FieldRepo:
@@ -298,6 +306,9 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldSelect:
 		return satisfies(isSingular, isNotNegated, isValidSelect)
+	case
+		FieldDedupe:
+		return satisfies(isSingular, isNotNegated, isDedupeMode)
 	default:
 		return isUnrecognizedField()
 	}
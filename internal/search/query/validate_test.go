@@ -21,6 +21,14 @@ func TestValidation(t *testing.T) {
 			input: "case:yes case:no",
 			want:  `field "case" may not be used more than once`,
 		},
+		{
+			input: "dedupe:repo",
+			want:  `invalid value "repo" for field "dedupe". Valid values are: content`,
+		},
+		{
+			input: "explain:maybe",
+			want:  `invalid boolean "maybe"`,
+		},
 		{
 			input: "repo:[",
 			want:  "error parsing regexp: missing closing ]: `[`",
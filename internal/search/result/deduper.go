@@ -39,3 +39,59 @@ func (d *Deduper) Seen(m Match) bool {
 func (d *Deduper) Results() Matches {
 	return d.results
 }
+
+// ContentDeduper collapses file matches with the same content, for example
+// vendored copies of a library. Matches are deduplicated by the OID of their
+// blob. The first match added for a blob is its representative, the locations
+// of the following ones are appended to its Duplicates until it is flushed.
+// Matches added for a blob whose representative was flushed are dropped.
+type ContentDeduper struct {
+	results Matches
+	// seen maps the OID of each blob to its representative, or to nil once
+	// the representative was flushed.
+	seen    map[string]*FileMatch
+	pending []string
+}
+
+func NewContentDeduper() ContentDeduper {
+	return ContentDeduper{
+		seen: make(map[string]*FileMatch),
+	}
+}
+
+// Add adds a file match with the blob OID oid. Matches with an empty oid are
+// never collapsed.
+func (d *ContentDeduper) Add(fm *FileMatch, oid string) {
+	if oid == "" {
+		d.results = append(d.results, fm)
+		return
+	}
+
+	if prev, seen := d.seen[oid]; seen {
+		if prev == nil {
+			return
+		}
+		if prev.Key() == fm.Key() {
+			// The same file found by several jobs.
+			prev.AppendMatches(fm)
+		} else {
+			prev.Duplicates = append(prev.Duplicates, fm.File)
+		}
+		return
+	}
+
+	d.results = append(d.results, fm)
+	d.seen[oid] = fm
+	d.pending = append(d.pending, oid)
+}
+
+// Flush returns the representative matches added since the last flush in the
+// order they were added.
+func (d *ContentDeduper) Flush() Matches {
+	results := d.results
+	for _, oid := range d.pending {
+		d.seen[oid] = nil
+	}
+	d.results, d.pending = nil, nil
+	return results
+}
@@ -123,3 +123,46 @@ func TestDeduper(t *testing.T) {
 		require.Equal(t, tc.expected, dedup.Results())
 	}
 }
+
+func TestContentDeduper(t *testing.T) {
+	file := func(repo, path string) *FileMatch {
+		return &FileMatch{
+			File: File{
+				Repo: types.MinimalRepo{Name: api.RepoName(repo)},
+				Path: path,
+			},
+		}
+	}
+
+	d := NewContentDeduper()
+	d.Add(file("a", "lib/x.go"), "1")
+	d.Add(file("a", "vendor/lib/x.go"), "1")
+	d.Add(file("b", "y.go"), "2")
+	d.Add(file("b", "third_party/lib/x.go"), "1")
+	d.Add(file("a", "lib/x.go"), "1")
+	d.Add(file("c", "z.go"), "")
+	d.Add(file("c", "w.go"), "")
+
+	type location struct{ repo, path string }
+	locations := func(matches Matches) []location {
+		var got []location
+		for _, m := range matches {
+			got = append(got, location{string(m.RepoName().Name), m.(*FileMatch).Path})
+		}
+		return got
+	}
+	results := d.Flush()
+	require.Equal(t, []location{{"a", "lib/x.go"}, {"b", "y.go"}, {"c", "z.go"}, {"c", "w.go"}}, locations(results))
+
+	var duplicates []location
+	for _, f := range results[0].(*FileMatch).Duplicates {
+		duplicates = append(duplicates, location{string(f.Repo.Name), f.Path})
+	}
+	require.Equal(t, []location{{"a", "vendor/lib/x.go"}, {"b", "third_party/lib/x.go"}}, duplicates)
+
+	// Matches of blobs whose representative was flushed are dropped.
+	d.Add(file("d", "x.go"), "1")
+	d.Add(file("d", "v.go"), "3")
+	require.Equal(t, []location{{"d", "v.go"}}, locations(d.Flush()))
+	require.Len(t, results[0].(*FileMatch).Duplicates, 2)
+}
@@ -54,8 +54,29 @@ type FileMatch struct {
 	// Note: this is a pointer since usually this is unset. Pointer is 8 bytes
 	// vs an empty string which is 16 bytes.
 	Debug *string `json:"-"`
+
+	// RevisionDiff is set when this match is the output of comparing the
+	// results of a search at two revisions of the same repository.
+	RevisionDiff RevisionDiff `json:"-"`
+
+	// Duplicates are the other locations of files with the same content as
+	// this match. It is only set for searches with dedupe:content.
+	Duplicates []File `json:"-"`
 }
 
+// RevisionDiff describes how a file match changed between the two revisions
+// of a revision range search.
+type RevisionDiff uint8
+
+const (
+	// RevisionDiffNone is the zero value, used for regular matches.
+	RevisionDiffNone RevisionDiff = iota
+	// RevisionDiffAdded means the file only matches at the head revision.
+	RevisionDiffAdded
+	// RevisionDiffRemoved means the file only matches at the base revision.
+	RevisionDiffRemoved
+)
+
 func (fm *FileMatch) RepoName() types.MinimalRepo {
 	return fm.File.Repo
 }
@@ -237,6 +237,12 @@ func pick(symbols []*SymbolMatch, satisfy func(*SymbolMatch) bool) []*SymbolMatc
 
 func SelectSymbolKind(symbols []*SymbolMatch, field string) []*SymbolMatch {
 	return pick(symbols, func(s *SymbolMatch) bool {
-		return field == toSelectKind[strings.ToLower(s.Symbol.Kind)]
+		return field == s.SelectKind()
 	})
 }
+
+// SelectKind returns the symbol selector kind value (eg "function" for
+// select:symbol.function) of s. It is empty if the kind is unknown.
+func (s *SymbolMatch) SelectKind() string {
+	return toSelectKind[strings.ToLower(s.Symbol.Kind)]
+}
@@ -0,0 +1,181 @@
+package smartsearch
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/grafana/regexp"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func init() {
+	conf.ContributeValidator(func(c conftypes.SiteConfigQuerier) (problems conf.Problems) {
+		for _, r := range c.SiteConfig().SearchSmartSearchRules {
+			if _, err := regexp.Compile(r.Match); err != nil {
+				problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("Not a valid regexp in search.smartSearch.rules: %s. See the valid syntax: https://golang.org/pkg/regexp/", r.Match)))
+			}
+		}
+		return
+	})
+}
+
+// configRules are the rules site admins define in search.smartSearch.rules,
+// split into narrowing and widening rules.
+var configRules = conf.Cached(func() configRuleSet {
+	var rules configRuleSet
+	for _, c := range conf.Get().SearchSmartSearchRules {
+		r, err := newConfigRule(c)
+		if err != nil {
+			// Skip if there's an error. A user-visible validation error will appear due to the ContributeValidator call above.
+			log15.Error("Site config: unable to compile Smart Search rule", "description", c.Description, "error", err)
+			continue
+		}
+		if c.Kind == "widen" {
+			rules.widen = append(rules.widen, r)
+		} else {
+			rules.narrow = append(rules.narrow, r)
+		}
+	}
+	return rules
+})
+
+type configRuleSet struct {
+	narrow []rule
+	widen  []rule
+}
+
+// newConfigRule converts a rule defined in site config to a rule. The rule
+// replaces the part of each pattern of a query matching c.Match with the
+// query c.Replace, adding the filters of c.Replace to the query.
+func newConfigRule(c *schema.SmartSearchRule) (rule, error) {
+	match, err := regexp.Compile(c.Match)
+	if err != nil {
+		return rule{}, err
+	}
+
+	apply := func(b query.Basic) *query.Basic {
+		if !hasFilters(b, c.RequireFilters, c.ForbidFilters) {
+			return nil
+		}
+		return replacePatterns(b, match, c.Replace)
+	}
+
+	return rule{
+		description: c.Description,
+		transform:   []transform{apply},
+	}, nil
+}
+
+// hasFilters returns true if b has all of the filters in require and none of
+// the filters in forbid.
+func hasFilters(b query.Basic, require, forbid []string) bool {
+	has := func(field string) bool {
+		for _, param := range b.Parameters {
+			if strings.EqualFold(param.Field, field) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, field := range require {
+		if !has(field) {
+			return false
+		}
+	}
+	for _, field := range forbid {
+		if has(field) {
+			return false
+		}
+	}
+	return true
+}
+
+// replacePatterns replaces the part of every non-negated pattern of b matching
+// match with the query replace, in which submatches of match are expanded.
+// Patterns of the replacement query take the place of the pattern, and its
+// filters are added to b.
+func replacePatterns(b query.Basic, match *regexp.Regexp, replace string) *query.Basic {
+	if b.Pattern == nil {
+		return nil
+	}
+
+	rawPatternTree, err := query.Parse(query.StringHuman([]query.Node{b.Pattern}), query.SearchTypeStandard)
+	if err != nil {
+		return nil
+	}
+
+	filterParams := []query.Node{}
+	changed := false
+	failed := false
+	newParseTree := query.MapPattern(rawPatternTree, func(value string, negated bool, annotation query.Annotation) query.Node {
+		unchanged := query.Pattern{
+			Value:      value,
+			Negated:    negated,
+			Annotation: annotation,
+		}
+		if negated || !match.MatchString(value) {
+			return unchanged
+		}
+
+		replacement, err := query.Parse(match.ReplaceAllString(value, replace), query.SearchTypeStandard)
+		if err != nil {
+			failed = true
+			return unchanged
+		}
+		changed = true
+
+		// Collect the parameters and add them after, like
+		// patternsToCodeHostFilters does.
+		var patterns []query.Node
+		query.VisitParameter(replacement, func(field, value string, negated bool, annotation query.Annotation) {
+			filterParams = append(filterParams, query.Parameter{
+				Field:      field,
+				Value:      value,
+				Negated:    negated,
+				Annotation: annotation,
+			})
+		})
+		query.VisitPattern(replacement, func(value string, negated bool, annotation query.Annotation) {
+			patterns = append(patterns, query.Pattern{
+				Value:      value,
+				Negated:    negated,
+				Annotation: annotation,
+			})
+		})
+
+		switch len(patterns) {
+		case 0:
+			return nil
+		case 1:
+			return patterns[0]
+		default:
+			return query.Operator{Kind: query.And, Operands: patterns}
+		}
+	})
+
+	if !changed || failed {
+		return nil
+	}
+
+	newParseTree = query.NewOperator(append(newParseTree, filterParams...), query.And) // Reduce with NewOperator to obtain valid partitioning.
+	newNodes, err := query.Sequence(query.For(query.SearchTypeStandard))(newParseTree)
+	if err != nil {
+		return nil
+	}
+
+	newBasic, err := query.ToBasicQuery(newNodes)
+	if err != nil {
+		return nil
+	}
+
+	return &query.Basic{
+		Parameters: append(append([]query.Parameter{}, b.Parameters...), newBasic.Parameters...),
+		Pattern:    newBasic.Pattern,
+	}
+}
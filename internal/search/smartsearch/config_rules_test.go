@@ -0,0 +1,57 @@
+package smartsearch
+
+import (
+	"testing"
+
+	"github.com/hexops/autogold/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func Test_configRule(t *testing.T) {
+	test := func(c *schema.SmartSearchRule, input string) string {
+		r, err := newConfigRule(c)
+		require.NoError(t, err)
+		return apply(input, r.transform)
+	}
+
+	teams := &schema.SmartSearchRule{
+		Description:   "search team services",
+		Match:         `^team-(\w+)$`,
+		Replace:       `repo:^github\.com/acme/$1-`,
+		ForbidFilters: []string{"repo"},
+	}
+	tests := &schema.SmartSearchRule{
+		Description:    "search tests",
+		Match:          `^(\w+)Test$`,
+		Replace:        `$1 file:_test\.go$`,
+		RequireFilters: []string{"lang"},
+	}
+
+	autogold.Expect(`{
+  "Input": "team-billing invoice",
+  "Query": "repo:^github\\.com/acme/billing- invoice"
+}`).Equal(t, test(teams, `team-billing invoice`))
+	autogold.Expect(`{
+  "Input": "repo:foo team-billing invoice",
+  "Query": "DOES NOT APPLY"
+}`).Equal(t, test(teams, `repo:foo team-billing invoice`))
+	autogold.Expect(`{
+  "Input": "-team-billing invoice",
+  "Query": "DOES NOT APPLY"
+}`).Equal(t, test(teams, `-team-billing invoice`))
+	autogold.Expect(`{
+  "Input": "lang:go parseTest",
+  "Query": "lang:go file:_test\\.go$ parse"
+}`).Equal(t, test(tests, `lang:go parseTest`))
+	autogold.Expect(`{
+  "Input": "parseTest",
+  "Query": "DOES NOT APPLY"
+}`).Equal(t, test(tests, `parseTest`))
+}
+
+func Test_configRuleInvalid(t *testing.T) {
+	_, err := newConfigRule(&schema.SmartSearchRule{Description: "invalid", Match: "(", Replace: ""})
+	require.Error(t, err)
+}
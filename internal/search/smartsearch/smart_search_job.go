@@ -31,12 +31,17 @@ type newJob func(query.Basic) (job.Job, error)
 // NewSmartSearchJob creates generators for opportunistic search queries
 // that apply various rules, transforming the original input plan into various
 // queries that alter its interpretation (e.g., search literally for quotes or
-// not, attempt to search the pattern as a regexp, and so on). There is no
-// random choice when applying rules.
+// not, attempt to search the pattern as a regexp, and so on). Rules defined in
+// site config are applied after the built-in rules of the same kind. There is
+// no random choice when applying rules.
 func NewSmartSearchJob(initialJob job.Job, newJob newJob, plan query.Plan) *FeelingLuckySearchJob {
+	custom := configRules()
+	narrow := append(append([]rule{}, rulesNarrow...), custom.narrow...)
+	widen := append(append([]rule{}, rulesWiden...), custom.widen...)
+
 	generators := make([]next, 0, len(plan))
 	for _, b := range plan {
-		generators = append(generators, NewGenerator(b, rulesNarrow, rulesWiden))
+		generators = append(generators, NewGenerator(b, narrow, widen))
 	}
 
 	newGeneratedJob := func(autoQ *autoQuery) job.Job {
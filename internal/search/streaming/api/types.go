@@ -94,3 +94,14 @@ const (
 	SeverityInfo SkippedSeverity = "info"
 	SeverityWarn SkippedSeverity = "warn"
 )
+
+// DiffStatus is an enum for the status of a file match when comparing the
+// results of a search at two revisions (eg repo:foo@v1.2...v1.3).
+type DiffStatus string
+
+const (
+	// DiffStatusAdded is a file which only matches at the head revision.
+	DiffStatusAdded DiffStatus = "added"
+	// DiffStatusRemoved is a file which only matches at the base revision.
+	DiffStatusRemoved DiffStatus = "removed"
+)
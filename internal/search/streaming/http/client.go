@@ -32,14 +32,31 @@ func NewRequestWithVersion(baseURL, query, version string) (*http.Request, error
 	return req, nil
 }
 
+// NewResumeRequest returns an http.Request against the streaming API which
+// continues the search for query from the token of a resume event.
+func NewResumeRequest(baseURL, query, token string) (*http.Request, error) {
+	req, err := NewRequest(baseURL, query)
+	if err != nil {
+		return nil, err
+	}
+	q := req.URL.Query()
+	q.Set("resume", token)
+	req.URL.RawQuery = q.Encode()
+	return req, nil
+}
+
 // FrontendStreamDecoder decodes streaming events from the frontend service
 type FrontendStreamDecoder struct {
-	OnProgress func(*api.Progress)
-	OnMatches  func([]EventMatch)
-	OnFilters  func([]*EventFilter)
-	OnAlert    func(*EventAlert)
-	OnError    func(*EventError)
-	OnUnknown  func(event, data []byte)
+	OnProgress    func(*api.Progress)
+	OnMatches     func([]EventMatch)
+	OnFilters     func([]*EventFilter)
+	OnAggregates  func([]*EventAggregate)
+	OnAlert       func(*EventAlert)
+	OnExplain     func(*EventExplain)
+	OnDiagnostics func([]QueryDiagnostic)
+	OnResume      func(*EventResume)
+	OnError       func(*EventError)
+	OnUnknown     func(event, data []byte)
 }
 
 func (rr FrontendStreamDecoder) ReadAll(r io.Reader) error {
@@ -80,6 +97,15 @@ func (rr FrontendStreamDecoder) ReadAll(r io.Reader) error {
 				return errors.Errorf("failed to decode filters payload: %w", err)
 			}
 			rr.OnFilters(d)
+		} else if bytes.Equal(event, []byte("aggregates")) {
+			if rr.OnAggregates == nil {
+				continue
+			}
+			var d []*EventAggregate
+			if err := json.Unmarshal(data, &d); err != nil {
+				return errors.Errorf("failed to decode aggregates payload: %w", err)
+			}
+			rr.OnAggregates(d)
 		} else if bytes.Equal(event, []byte("alert")) {
 			if rr.OnAlert == nil {
 				continue
@@ -89,6 +115,33 @@ func (rr FrontendStreamDecoder) ReadAll(r io.Reader) error {
 				return errors.Errorf("failed to decode alert payload: %w", err)
 			}
 			rr.OnAlert(&d)
+		} else if bytes.Equal(event, []byte("explain")) {
+			if rr.OnExplain == nil {
+				continue
+			}
+			var d EventExplain
+			if err := json.Unmarshal(data, &d); err != nil {
+				return errors.Errorf("failed to decode explain payload: %w", err)
+			}
+			rr.OnExplain(&d)
+		} else if bytes.Equal(event, []byte("diagnostics")) {
+			if rr.OnDiagnostics == nil {
+				continue
+			}
+			var d []QueryDiagnostic
+			if err := json.Unmarshal(data, &d); err != nil {
+				return errors.Errorf("failed to decode diagnostics payload: %w", err)
+			}
+			rr.OnDiagnostics(d)
+		} else if bytes.Equal(event, []byte("resume")) {
+			if rr.OnResume == nil {
+				continue
+			}
+			var d EventResume
+			if err := json.Unmarshal(data, &d); err != nil {
+				return errors.Errorf("failed to decode resume payload: %w", err)
+			}
+			rr.OnResume(&d)
 		} else if bytes.Equal(event, []byte("error")) {
 			if rr.OnError == nil {
 				continue
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming/api"
 	"github.com/sourcegraph/sourcegraph/lib/errors"
 )
 
@@ -31,6 +32,14 @@ type EventContentMatch struct {
 	LineMatches     []EventLineMatch `json:"lineMatches,omitempty"`
 	ChunkMatches    []ChunkMatch     `json:"chunkMatches,omitempty"`
 	Debug           string           `json:"debug,omitempty"`
+
+	// DiffStatus is set when comparing the results of a search at two
+	// revisions. It is "added" or "removed".
+	DiffStatus api.DiffStatus `json:"diffStatus,omitempty"`
+
+	// Duplicates are the other locations of files with the same content,
+	// collapsed into this match by dedupe:content.
+	Duplicates []EventFileLocation `json:"duplicates,omitempty"`
 }
 
 func (e *EventContentMatch) eventMatch() {}
@@ -52,10 +61,26 @@ type EventPathMatch struct {
 	Branches        []string   `json:"branches,omitempty"`
 	Commit          string     `json:"commit,omitempty"`
 	Debug           string     `json:"debug,omitempty"`
+
+	// DiffStatus is set when comparing the results of a search at two
+	// revisions. It is "added" or "removed".
+	DiffStatus api.DiffStatus `json:"diffStatus,omitempty"`
+
+	// Duplicates are the other locations of files with the same content,
+	// collapsed into this match by dedupe:content.
+	Duplicates []EventFileLocation `json:"duplicates,omitempty"`
 }
 
 func (e *EventPathMatch) eventMatch() {}
 
+// EventFileLocation identifies a file in a repository.
+type EventFileLocation struct {
+	RepositoryID int32  `json:"repositoryID"`
+	Repository   string `json:"repository"`
+	Commit       string `json:"commit,omitempty"`
+	Path         string `json:"path"`
+}
+
 type DecoratedHunk struct {
 	Content   DecoratedContent `json:"content"`
 	LineStart int              `json:"lineStart"`
@@ -127,6 +152,14 @@ type EventSymbolMatch struct {
 	Commit          string     `json:"commit,omitempty"`
 
 	Symbols []Symbol `json:"symbols"`
+
+	// DiffStatus is set when comparing the results of a search at two
+	// revisions. It is "added" or "removed".
+	DiffStatus api.DiffStatus `json:"diffStatus,omitempty"`
+
+	// Duplicates are the other locations of files with the same content,
+	// collapsed into this match by dedupe:content.
+	Duplicates []EventFileLocation `json:"duplicates,omitempty"`
 }
 
 func (e *EventSymbolMatch) eventMatch() {}
@@ -210,6 +243,13 @@ type EventFilter struct {
 	Kind     string `json:"kind"`
 }
 
+// EventAggregate is the running count of matches for a value selected by the
+// select: filter of a query.
+type EventAggregate struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
 // EventAlert is GQL.SearchAlert. It replaces when sent to match existing
 // behaviour.
 type EventAlert struct {
@@ -217,6 +257,18 @@ type EventAlert struct {
 	Description     string             `json:"description,omitempty"`
 	Kind            string             `json:"kind,omitempty"`
 	ProposedQueries []QueryDescription `json:"proposedQueries"`
+	Diagnostics     []QueryDiagnostic  `json:"diagnostics,omitempty"`
+}
+
+// QueryDiagnostic is a problem found in the query of a search. Ranges are on
+// the single line of the query.
+type QueryDiagnostic struct {
+	Range    Range  `json:"range"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+
+	// Replacement is the suggested text for Range which fixes the problem.
+	Replacement *string `json:"replacement,omitempty"`
 }
 
 // QueryDescription describes queries emitted in alerts.
@@ -231,6 +283,19 @@ type Annotation struct {
 	Value string `json:"value"`
 }
 
+// EventExplain is the EXPLAIN ANALYZE style tree of the jobs which ran for
+// a query with explain:yes.
+type EventExplain struct {
+	Tree string `json:"tree"`
+}
+
+// EventResume contains the token to pass as the resume parameter to continue
+// a search which was interrupted. The search continues with the repositories
+// which were not completely searched when the event was sent.
+type EventResume struct {
+	Token string `json:"token"`
+}
+
 // EventError emulates a JavaScript error with a message property
 // as is returned when the search encounters an error.
 type EventError struct {
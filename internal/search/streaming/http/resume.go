@@ -0,0 +1,51 @@
+package http
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/lib/errors"
+)
+
+// resumeToken is the decoded form of the opaque token sent in resume events.
+// It is bound to the query it was created for, since the jobs of a search are
+// keyed by their position in the job tree of the query.
+type resumeToken struct {
+	Query string                `json:"q"`
+	State streaming.ResumeState `json:"s"`
+}
+
+// EncodeResumeToken returns the token a client passes as the resume
+// parameter to continue the search for query from state.
+func EncodeResumeToken(query string, state streaming.ResumeState) (string, error) {
+	b, err := json.Marshal(resumeToken{Query: hashQuery(query), State: state})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeResumeToken returns the state encoded in token by EncodeResumeToken.
+// It returns an error if token was created for a different query.
+func DecodeResumeToken(query, token string) (streaming.ResumeState, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid resume token")
+	}
+	var t resumeToken
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, errors.Wrap(err, "invalid resume token")
+	}
+	if t.Query != hashQuery(query) {
+		return nil, errors.New("resume token was created for a different query")
+	}
+	return t.State, nil
+}
+
+func hashQuery(query string) string {
+	h := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(h[:8])
+}
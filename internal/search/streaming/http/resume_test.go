@@ -0,0 +1,30 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestResumeToken(t *testing.T) {
+	state := streaming.ResumeState{
+		1: {Done: true},
+		2: {Next: types.MultiCursor{{Column: "stars", Value: "10", Direction: "prev"}}},
+	}
+
+	token, err := EncodeResumeToken("count:all foo", state)
+	require.NoError(t, err)
+
+	got, err := DecodeResumeToken("count:all foo", token)
+	require.NoError(t, err)
+	require.Equal(t, state, got)
+
+	_, err = DecodeResumeToken("count:all bar", token)
+	require.Error(t, err)
+
+	_, err = DecodeResumeToken("count:all foo", "not a token")
+	require.Error(t, err)
+}
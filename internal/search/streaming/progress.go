@@ -33,6 +33,10 @@ type Stats struct {
 	// ExcludedArchived is the count of excluded archived repos because the
 	// search query doesn't apply to them, but that we want to know about.
 	ExcludedArchived int
+
+	// Resume records how far the resumable jobs of the search got. Jobs
+	// only send it after the results of the repositories it covers.
+	Resume ResumeState
 }
 
 // Update updates c with the other data, deduping as necessary. It modifies c but
@@ -58,6 +62,8 @@ func (c *Stats) Update(other *Stats) {
 	c.BackendsMissing += other.BackendsMissing
 	c.ExcludedForks += other.ExcludedForks
 	c.ExcludedArchived += other.ExcludedArchived
+
+	c.Resume.Update(other.Resume)
 }
 
 // Zero returns true if stats is empty. IE calling Update will result in no
@@ -72,7 +78,8 @@ func (c *Stats) Zero() bool {
 		c.Status.Len() > 0 ||
 		c.BackendsMissing > 0 ||
 		c.ExcludedForks > 0 ||
-		c.ExcludedArchived > 0)
+		c.ExcludedArchived > 0 ||
+		len(c.Resume) > 0)
 }
 
 func (c *Stats) String() string {
@@ -0,0 +1,67 @@
+package streaming
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// ResumeCursor records how far a resumable search job got. Resumable jobs
+// are the jobs paging through repositories and the jobs searching all
+// indexed repositories at once.
+type ResumeCursor struct {
+	// Done is true if the job searched all of its repositories.
+	Done bool `json:"done,omitempty"`
+
+	// Next points to the first page of repositories the job has not
+	// searched yet. It is nil if the job did not complete any page yet.
+	Next types.MultiCursor `json:"next,omitempty"`
+}
+
+// ResumeState maps the resume key of each resumable job of a search to how
+// far it got. Jobs are keyed by their position in the job tree, so a state
+// can only be used to resume the same query.
+type ResumeState map[int]ResumeCursor
+
+// Update updates s with the cursors in other. Cursors in other are more
+// recent than the ones in s, except that a job which is done stays done.
+func (s *ResumeState) Update(other ResumeState) {
+	if len(other) == 0 {
+		return
+	}
+	if *s == nil {
+		*s = make(ResumeState, len(other))
+	}
+	for key, c := range other {
+		if (*s)[key].Done {
+			continue
+		}
+		(*s)[key] = c
+	}
+}
+
+type resumeKey struct{}
+
+// WithResume returns a context which resumes the search run with it from s.
+func WithResume(ctx context.Context, s ResumeState) context.Context {
+	return context.WithValue(ctx, resumeKey{}, s)
+}
+
+// ResumeFromContext returns the cursor the job with key resumes from. It
+// returns false if the job should search from the start. Jobs with key 0 are
+// not resumable.
+func ResumeFromContext(ctx context.Context, key int) (ResumeCursor, bool) {
+	if key == 0 {
+		return ResumeCursor{}, false
+	}
+	s, _ := ctx.Value(resumeKey{}).(ResumeState)
+	c, ok := s[key]
+	return c, ok
+}
+
+// ResumeEvent returns the event a resumable job with key sends once it got
+// as far as c. It must be sent after the results of the repositories c
+// covers.
+func ResumeEvent(key int, c ResumeCursor) SearchEvent {
+	return SearchEvent{Stats: Stats{Resume: ResumeState{key: c}}}
+}
@@ -0,0 +1,111 @@
+package streaming
+
+import (
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// maxAggregates is the maximum number of groups returned by
+// SearchAggregates.Compute.
+const maxAggregates = 500
+
+// Aggregate is the running count of matches for a value selected by a
+// select: path.
+type Aggregate struct {
+	// Value is the selected value. It is a repository name for select:repo,
+	// a repository name joined with a path for select:file and
+	// select:content, a symbol kind for select:symbol and an owner handle
+	// for select:file.owners.
+	Value string
+
+	// Count is the number of matches with Value.
+	Count int
+}
+
+// SearchAggregates computes running counts of the matches of a search grouped
+// by the value selected by its select: path. This allows building histograms
+// without fetching every match.
+type SearchAggregates struct {
+	selector filter.SelectPath
+	counts   map[string]int
+
+	// Dirty is true if s has changed since the last call to Compute.
+	Dirty bool
+}
+
+// NewSearchAggregates returns a SearchAggregates which groups matches by the
+// value selected by selector.
+func NewSearchAggregates(selector filter.SelectPath) *SearchAggregates {
+	return &SearchAggregates{
+		selector: selector,
+		counts:   make(map[string]int),
+	}
+}
+
+// Update internal state for the results in event.
+func (s *SearchAggregates) Update(event SearchEvent) {
+	if len(event.Results) > 0 {
+		s.Dirty = true
+	}
+	for _, match := range event.Results {
+		switch v := match.(type) {
+		case *result.FileMatch:
+			if s.selector.Root() == filter.Symbol {
+				for _, sym := range v.Symbols {
+					if kind := sym.SelectKind(); kind != "" {
+						s.counts[kind]++
+					}
+				}
+				continue
+			}
+			s.counts[string(v.Repo.Name)+"/"+v.Path] += v.ResultCount()
+		case *result.RepoMatch:
+			s.counts[string(v.Name)] += v.ResultCount()
+		case *result.CommitMatch:
+			s.counts[string(v.Repo.Name)] += v.ResultCount()
+		case *result.OwnerMatch:
+			if value := ownerValue(v.ResolvedOwner); value != "" {
+				s.counts[value] += v.ResultCount()
+			}
+		}
+	}
+}
+
+func ownerValue(owner result.Owner) string {
+	switch v := owner.(type) {
+	case *result.OwnerPerson:
+		if v.Handle != "" {
+			return v.Handle
+		}
+		return v.Email
+	case *result.OwnerTeam:
+		if v.Team != nil {
+			return v.Team.Name
+		}
+		return v.Handle
+	}
+	return ""
+}
+
+// Compute returns the aggregates ordered by descending count. At most
+// maxAggregates are returned.
+func (s *SearchAggregates) Compute() []*Aggregate {
+	s.Dirty = false
+
+	aggs := make([]*Aggregate, 0, len(s.counts))
+	for value, count := range s.counts {
+		aggs = append(aggs, &Aggregate{Value: value, Count: count})
+	}
+	sort.Slice(aggs, func(i, j int) bool {
+		if aggs[i].Count != aggs[j].Count {
+			return aggs[i].Count > aggs[j].Count
+		}
+		return aggs[i].Value < aggs[j].Value
+	})
+	if len(aggs) > maxAggregates {
+		aggs = aggs[:maxAggregates]
+	}
+	return aggs
+}
@@ -0,0 +1,83 @@
+package streaming
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/filter"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSearchAggregates(t *testing.T) {
+	repo := types.MinimalRepo{Name: "foo"}
+	symbol := func(kind string) *result.SymbolMatch {
+		return &result.SymbolMatch{Symbol: result.Symbol{Kind: kind}}
+	}
+
+	cases := []struct {
+		name     string
+		selector filter.SelectPath
+		events   []SearchEvent
+		want     []*Aggregate
+	}{
+		{
+			name:     "repo",
+			selector: filter.SelectPath{filter.Repository},
+			events: []SearchEvent{
+				{Results: []result.Match{&result.RepoMatch{Name: "foo"}, &result.RepoMatch{Name: "bar"}}},
+				{Results: []result.Match{&result.RepoMatch{Name: "foo"}}},
+			},
+			want: []*Aggregate{{Value: "foo", Count: 2}, {Value: "bar", Count: 1}},
+		},
+		{
+			name:     "file",
+			selector: filter.SelectPath{filter.File},
+			events: []SearchEvent{{Results: []result.Match{
+				&result.FileMatch{File: result.File{Repo: repo, Path: "a.go"}},
+				&result.FileMatch{File: result.File{Repo: repo, Path: "b.go"}},
+			}}},
+			want: []*Aggregate{{Value: "foo/a.go", Count: 1}, {Value: "foo/b.go", Count: 1}},
+		},
+		{
+			name:     "symbol kinds",
+			selector: filter.SelectPath{filter.Symbol},
+			events: []SearchEvent{{Results: []result.Match{
+				&result.FileMatch{
+					File:    result.File{Repo: repo, Path: "a.go"},
+					Symbols: []*result.SymbolMatch{symbol("func"), symbol("function"), symbol("var")},
+				},
+			}}},
+			want: []*Aggregate{{Value: "function", Count: 2}, {Value: "variable", Count: 1}},
+		},
+		{
+			name:     "owners",
+			selector: filter.SelectPath{filter.File, "owners"},
+			events: []SearchEvent{{Results: []result.Match{
+				&result.OwnerMatch{ResolvedOwner: &result.OwnerPerson{Handle: "alice"}},
+				&result.OwnerMatch{ResolvedOwner: &result.OwnerPerson{Email: "bob@example.com"}},
+				&result.OwnerMatch{ResolvedOwner: &result.OwnerPerson{Handle: "alice"}},
+			}}},
+			want: []*Aggregate{{Value: "alice", Count: 2}, {Value: "bob@example.com", Count: 1}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := NewSearchAggregates(tc.selector)
+			for _, event := range tc.events {
+				s.Update(event)
+			}
+			if !s.Dirty {
+				t.Fatal("expected aggregates to be dirty after update")
+			}
+			if diff := cmp.Diff(tc.want, s.Compute()); diff != "" {
+				t.Fatalf("unexpected aggregates (-want +got):\n%s", diff)
+			}
+			if s.Dirty {
+				t.Fatal("expected aggregates to not be dirty after compute")
+			}
+		})
+	}
+}
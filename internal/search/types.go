@@ -411,6 +411,13 @@ type Features struct {
 	// CodeOwnershipSearch when true will enable searching through code ownership
 	// using `file:has.owner({owner})` and `select:file.owners` filters.
 	CodeOwnershipSearch bool `json:"codeownership"`
+
+	// PreciseSymbolSearch when true will answer unindexed symbol searches
+	// from the SCIP symbols of the closest precise code intelligence upload,
+	// even when that upload is not for the searched commit. Without it,
+	// precise symbols are only used when an upload exists for the searched
+	// commit.
+	PreciseSymbolSearch bool `json:"symbol.precise"`
 }
 
 func (f *Features) String() string {
@@ -292,7 +292,9 @@ func DoZoektSearchGlobal(ctx context.Context, logger log.Logger, client zoekt.St
 		defer cancel()
 	}
 
+	explain := job.ExplainFromContext(ctx)
 	return client.StreamSearch(ctx, params.Query, searchOpts, backend.ZoektStreamFunc(func(event *zoekt.SearchResult) {
+		explain.AddZoektStats(event)
 		sendMatches(event, pathRegexps, func(file *zoekt.FileMatch) (types.MinimalRepo, []string) {
 			repo := types.MinimalRepo{
 				ID:   api.RepoID(file.RepositoryID),
@@ -334,8 +336,10 @@ func zoektSearch(ctx context.Context, logger log.Logger, repos *IndexedRepoRevs,
 		defer cancel()
 	}
 
+	explain := job.ExplainFromContext(ctx)
 	foundResults := atomic.Bool{}
 	err := client.StreamSearch(ctx, finalQuery, searchOpts, backend.ZoektStreamFunc(func(event *zoekt.SearchResult) {
+		explain.AddZoektStats(event)
 		foundResults.CompareAndSwap(false, event.FileCount != 0 || event.MatchCount != 0)
 		sendMatches(event, pathRegexps, repos.getRepoInputRev, typ, zoektParams.Select, c)
 	}))
@@ -710,17 +714,26 @@ type GlobalTextSearchJob struct {
 	ZoektParams             *search.ZoektParameters
 	RepoOpts                search.RepoOptions
 	GlobalZoektQueryRegexps []*regexp.Regexp // used for getting file path match ranges
+	ResumeKey               int              `json:"-"` // identifies the job in resume tokens, 0 if not resumable
 }
 
 func (t *GlobalTextSearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
 	_, ctx, stream, finish := job.StartSpan(ctx, stream, t)
 	defer func() { finish(alert, err) }()
 
+	if c, ok := streaming.ResumeFromContext(ctx, t.ResumeKey); ok && c.Done {
+		return nil, nil
+	}
+
 	userPrivateRepos := privateReposForActor(ctx, clients.Logger, clients.DB, t.RepoOpts)
 	t.GlobalZoektQuery.ApplyPrivateFilter(userPrivateRepos)
 	t.ZoektParams.Query = t.GlobalZoektQuery.Generate()
 
-	return nil, DoZoektSearchGlobal(ctx, clients.Logger, clients.Zoekt, t.ZoektParams, t.GlobalZoektQueryRegexps, stream)
+	err = DoZoektSearchGlobal(ctx, clients.Logger, clients.Zoekt, t.ZoektParams, t.GlobalZoektQueryRegexps, stream)
+	if err == nil && ctx.Err() == nil && t.ResumeKey != 0 {
+		stream.Send(streaming.ResumeEvent(t.ResumeKey, streaming.ResumeCursor{Done: true}))
+	}
+	return nil, err
 }
 
 func (*GlobalTextSearchJob) Name() string {
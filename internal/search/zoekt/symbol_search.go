@@ -86,12 +86,17 @@ type GlobalSymbolSearchJob struct {
 	GlobalZoektQuery *GlobalZoektQuery
 	ZoektParams      *search.ZoektParameters
 	RepoOpts         search.RepoOptions
+	ResumeKey        int `json:"-"` // identifies the job in resume tokens, 0 if not resumable
 }
 
 func (s *GlobalSymbolSearchJob) Run(ctx context.Context, clients job.RuntimeClients, stream streaming.Sender) (alert *search.Alert, err error) {
 	tr, ctx, stream, finish := job.StartSpan(ctx, stream, s)
 	defer func() { finish(alert, err) }()
 
+	if c, ok := streaming.ResumeFromContext(ctx, s.ResumeKey); ok && c.Done {
+		return nil, nil
+	}
+
 	userPrivateRepos := privateReposForActor(ctx, clients.Logger, clients.DB, s.RepoOpts)
 	s.GlobalZoektQuery.ApplyPrivateFilter(userPrivateRepos)
 	s.ZoektParams.Query = s.GlobalZoektQuery.Generate()
@@ -104,6 +109,8 @@ func (s *GlobalSymbolSearchJob) Run(ctx context.Context, clients job.RuntimeClie
 		if ctx.Err() == nil {
 			return nil, err
 		}
+	} else if ctx.Err() == nil && s.ResumeKey != 0 {
+		stream.Send(streaming.ResumeEvent(s.ResumeKey, streaming.ResumeCursor{Done: true}))
 	}
 
 	return nil, nil
@@ -589,6 +589,27 @@ type GitserverRepo struct {
 	// A log of the different types of corruption that was detected on this repo. The order of the log entries are
 	// stored from most recent to least recent and capped at 10 entries. See LogCorruption on Gitserverrepo store.
 	CorruptionLogs []RepoCorruptionLog
+	// The report of the last health check gitserver ran on this repo, or nil
+	// if it has not been checked yet.
+	HealthReport *RepoHealthReport
+	// The last time gitserver pushed all refs of the repo to the secondary
+	// remote configured for its code host connection.
+	LastMirroredAt time.Time
+	// The error of the last push to the secondary remote or empty if the last
+	// push was successful.
+	MirrorError string
+	// The number of consecutive failed pushes to the secondary remote.
+	MirrorFailures int
+	// The earliest time gitserver retries pushing to the secondary remote
+	// after a failed push.
+	NextMirrorAt time.Time
+	// The gitserver which is copying the repo from its owner, or empty if the
+	// repo is not being transferred.
+	TransferShardID string
+	// The number of bytes of the repo the transfer has copied so far.
+	TransferBytes int64
+	// When the gitserver in TransferShardID started to copy the repo.
+	TransferStartedAt time.Time
 }
 
 // RepoCorruptionLog represents a corruption event that has been detected on a repo.
@@ -599,6 +620,49 @@ type RepoCorruptionLog struct {
 	Reason string `json:"reason"`
 }
 
+// RepoHealthReport is the result of a health check gitserver runs periodically
+// on the clone of a repo, along with the repairs it attempted.
+type RepoHealthReport struct {
+	// When the repo was checked
+	CheckedAt time.Time `json:"checkedAt"`
+	// Objects which are referenced but missing from the repo. Capped to a
+	// sample of the missing objects.
+	MissingObjects []string `json:"missingObjects,omitempty"`
+	// Refs which point to objects that do not exist.
+	DanglingRefs []string `json:"danglingRefs,omitempty"`
+	// Other errors git fsck reported. Capped to a sample of the errors.
+	FsckErrors []string `json:"fsckErrors,omitempty"`
+	// The number of pack files and loose objects in the repo
+	PackCount        int `json:"packCount"`
+	LooseObjectCount int `json:"looseObjectCount"`
+	// Whether the repo has more pack files or loose objects than gitserver
+	// tolerates before repacking.
+	PackBloat bool `json:"packBloat"`
+	// Whether the commit-graph is missing or older than the newest pack file.
+	CommitGraphStale bool `json:"commitGraphStale"`
+	// The repairs attempted for the problems found, in the order they ran.
+	Repairs []RepoRepair `json:"repairs,omitempty"`
+}
+
+// Corrupt returns true if the report found objects or refs to be missing or
+// broken. Pack bloat and a stale commit-graph only make the repo slower.
+func (r *RepoHealthReport) Corrupt() bool {
+	return len(r.MissingObjects) > 0 || len(r.DanglingRefs) > 0 || len(r.FsckErrors) > 0
+}
+
+// Healthy returns true if the report found no problems at all.
+func (r *RepoHealthReport) Healthy() bool {
+	return !r.Corrupt() && !r.PackBloat && !r.CommitGraphStale
+}
+
+// RepoRepair is a repair gitserver attempted on a repo after a health check.
+type RepoRepair struct {
+	// The repair action, such as "repack", "refetch" or "reclone"
+	Action string `json:"action"`
+	// Why the action failed, or empty if it succeeded
+	Error string `json:"error,omitempty"`
+}
+
 // ExternalService is a connection to an external service.
 type ExternalService struct {
 	ID             int64
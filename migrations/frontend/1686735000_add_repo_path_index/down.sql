@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS repo_path_index_state;
+DROP TABLE IF EXISTS repo_path_index;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS repo_path_index (
+    repo_id INTEGER NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    path TEXT NOT NULL,
+    PRIMARY KEY (repo_id, path)
+);
+
+COMMENT ON TABLE repo_path_index IS 'The paths of all files at the default branch of a repository, used to answer path-only searches without fetching archives.';
+
+CREATE TABLE IF NOT EXISTS repo_path_index_state (
+    repo_id INTEGER PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    commit_id TEXT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE repo_path_index_state IS 'The commit of the default branch the paths in repo_path_index were read from.';
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS gitserver_ref_event_bookmarks;
+DROP TABLE IF EXISTS gitserver_ref_events;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS gitserver_ref_events (
+    id BIGSERIAL PRIMARY KEY,
+    repo_id INTEGER NOT NULL REFERENCES repo(id) ON DELETE CASCADE DEFERRABLE,
+    ref_changes JSONB NOT NULL,
+    fetched_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS gitserver_ref_events_repo_id ON gitserver_ref_events USING btree (repo_id);
+CREATE INDEX IF NOT EXISTS gitserver_ref_events_fetched_at ON gitserver_ref_events USING btree (fetched_at);
+
+COMMENT ON TABLE gitserver_ref_events IS 'Changes gitserver made to the refs of a repository when fetching it, which services subscribe to rather than polling repositories for changes.';
+COMMENT ON COLUMN gitserver_ref_events.ref_changes IS 'The refs which moved, with the commits they pointed to before and after the fetch. The old commit is omitted for created refs, the new commit for deleted refs.';
+
+CREATE TABLE IF NOT EXISTS gitserver_ref_event_bookmarks (
+    subscriber TEXT PRIMARY KEY,
+    event_id BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+COMMENT ON TABLE gitserver_ref_event_bookmarks IS 'The last gitserver_ref_events row each subscriber processed.';
@@ -0,0 +1,4 @@
+ALTER TABLE gitserver_repos
+    ADD COLUMN IF NOT EXISTS health_report JSONB;
+
+COMMENT ON COLUMN gitserver_repos.health_report IS 'The report of the last health check gitserver ran on the repo, including the repairs it attempted - encoded as json';
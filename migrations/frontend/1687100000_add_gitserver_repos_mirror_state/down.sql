@@ -0,0 +1,5 @@
+ALTER TABLE gitserver_repos
+    DROP COLUMN IF EXISTS last_mirrored_at,
+    DROP COLUMN IF EXISTS mirror_error,
+    DROP COLUMN IF EXISTS mirror_failures,
+    DROP COLUMN IF EXISTS next_mirror_at;
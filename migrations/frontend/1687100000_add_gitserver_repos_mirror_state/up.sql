@@ -0,0 +1,10 @@
+ALTER TABLE gitserver_repos
+    ADD COLUMN IF NOT EXISTS last_mirrored_at TIMESTAMP WITH TIME ZONE,
+    ADD COLUMN IF NOT EXISTS mirror_error TEXT,
+    ADD COLUMN IF NOT EXISTS mirror_failures INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS next_mirror_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN gitserver_repos.last_mirrored_at IS 'The last time gitserver pushed all refs of the repo to the secondary remote configured for its code host connection';
+COMMENT ON COLUMN gitserver_repos.mirror_error IS 'The error of the last failed push to the secondary remote, if the last push failed';
+COMMENT ON COLUMN gitserver_repos.mirror_failures IS 'The number of consecutive failed pushes to the secondary remote';
+COMMENT ON COLUMN gitserver_repos.next_mirror_at IS 'The earliest time gitserver retries pushing to the secondary remote after a failed push';
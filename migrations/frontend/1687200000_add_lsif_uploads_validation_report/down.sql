@@ -0,0 +1 @@
+ALTER TABLE lsif_uploads DROP COLUMN IF EXISTS validation_report;
@@ -0,0 +1,4 @@
+ALTER TABLE lsif_uploads
+    ADD COLUMN IF NOT EXISTS validation_report JSONB;
+
+COMMENT ON COLUMN lsif_uploads.validation_report IS 'The quality report computed by the upload processor while validating the SCIP index - encoded as json';
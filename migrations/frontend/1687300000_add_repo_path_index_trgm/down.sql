@@ -0,0 +1 @@
+DROP INDEX IF EXISTS repo_path_index_path_trgm;
@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS repo_path_index_path_trgm ON repo_path_index USING GIN (lower(path) gin_trgm_ops);
@@ -0,0 +1,5 @@
+ALTER TABLE gitserver_ref_event_bookmarks DROP COLUMN IF EXISTS transaction_id;
+
+DROP INDEX IF EXISTS gitserver_ref_events_transaction_id_id;
+
+ALTER TABLE gitserver_ref_events DROP COLUMN IF EXISTS transaction_id;
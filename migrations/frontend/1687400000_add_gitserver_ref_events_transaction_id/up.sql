@@ -0,0 +1,7 @@
+ALTER TABLE gitserver_ref_events ADD COLUMN IF NOT EXISTS transaction_id BIGINT NOT NULL DEFAULT txid_current();
+
+CREATE INDEX IF NOT EXISTS gitserver_ref_events_transaction_id_id ON gitserver_ref_events USING btree (transaction_id, id);
+
+COMMENT ON COLUMN gitserver_ref_events.transaction_id IS 'The ID of the transaction which recorded the event. Events are listed in the order of their transaction ID and only once every transaction with a lower ID finished, so that events of transactions which commit late are not skipped.';
+
+ALTER TABLE gitserver_ref_event_bookmarks ADD COLUMN IF NOT EXISTS transaction_id BIGINT NOT NULL DEFAULT 0;
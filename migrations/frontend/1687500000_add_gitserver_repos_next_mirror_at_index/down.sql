@@ -0,0 +1 @@
+DROP INDEX IF EXISTS gitserver_repos_next_mirror_at_idx;
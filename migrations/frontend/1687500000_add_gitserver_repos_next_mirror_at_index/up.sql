@@ -0,0 +1 @@
+CREATE INDEX CONCURRENTLY IF NOT EXISTS gitserver_repos_next_mirror_at_idx ON gitserver_repos USING btree (shard_id, next_mirror_at) WHERE mirror_error IS NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE gitserver_repos
+    DROP COLUMN IF EXISTS transfer_shard_id,
+    DROP COLUMN IF EXISTS transfer_bytes,
+    DROP COLUMN IF EXISTS transfer_started_at;
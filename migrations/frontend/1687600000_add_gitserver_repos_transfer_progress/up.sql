@@ -0,0 +1,8 @@
+ALTER TABLE gitserver_repos
+    ADD COLUMN IF NOT EXISTS transfer_shard_id TEXT,
+    ADD COLUMN IF NOT EXISTS transfer_bytes BIGINT NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS transfer_started_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN gitserver_repos.transfer_shard_id IS 'The gitserver which is copying the repo from the gitserver in shard_id, if the repo moved to another gitserver';
+COMMENT ON COLUMN gitserver_repos.transfer_bytes IS 'The number of bytes of the repo the gitserver in transfer_shard_id has copied so far';
+COMMENT ON COLUMN gitserver_repos.transfer_started_at IS 'When the gitserver in transfer_shard_id started to copy the repo';
@@ -344,6 +344,16 @@ type BitbucketServerAuthorization struct {
 	// Oauth description: OAuth configuration specified when creating the Bitbucket Server / Bitbucket Data Center Application Link with incoming authentication. Two Legged OAuth with 'ExecuteAs=admin' must be enabled as well as user impersonation.
 	Oauth BitbucketServerOAuth `json:"oauth"`
 }
+type BitbucketServerCloneStrategy struct {
+	// Depth description: Number of commits of history to clone. The full history is cloned if it is not set.
+	Depth int `json:"depth,omitempty"`
+	// Filter description: Filter for the objects to clone, as passed to `git fetch --filter`. Objects left out of the clone are fetched when they are read.
+	Filter string `json:"filter,omitempty"`
+	// Pattern description: Regular expression which matches against the name of the repositories the strategy applies to.
+	Pattern string `json:"pattern"`
+	// SparsePaths description: Directories to clone the files of. Files outside of them are left out of the clone and fetched when they are read. Requires a filter.
+	SparsePaths []string `json:"sparsePaths,omitempty"`
+}
 
 // BitbucketServerConnection description: Configuration for a connection to Bitbucket Server / Bitbucket Data Center.
 type BitbucketServerConnection struct {
@@ -351,6 +361,8 @@ type BitbucketServerConnection struct {
 	Authorization *BitbucketServerAuthorization `json:"authorization,omitempty"`
 	// Certificate description: TLS certificate of the Bitbucket Server / Bitbucket Data Center instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	Certificate string `json:"certificate,omitempty"`
+	// CloneStrategies description: Partial clone strategies for large repositories. The first strategy whose pattern matches the name of a repository is used to clone it. Other repositories are cloned in full.
+	CloneStrategies []*BitbucketServerCloneStrategy `json:"cloneStrategies,omitempty"`
 	// Exclude description: A list of repositories to never mirror from this Bitbucket Server / Bitbucket Data Center instance. Takes precedence over "repos" and "repositoryQuery".
 	//
 	// Supports excluding by name ({"name": "projectKey/repositorySlug"}) or by ID ({"id": 42}).
@@ -365,6 +377,8 @@ type BitbucketServerConnection struct {
 	GitURLType string `json:"gitURLType,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. BitBucket repositories can no longer be enabled or disabled explicitly.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
+	// MirrorTo description: A secondary Git remote to push all refs of the repositories of this connection to after each update, for example a bare repository server kept for disaster recovery. Failed pushes are retried with backoff.
+	MirrorTo *BitbucketServerMirrorTo `json:"mirrorTo,omitempty"`
 	// Password description: The password to use when authenticating to the Bitbucket Server / Bitbucket Data Center instance. Also set the corresponding "username" field.
 	//
 	// For Bitbucket Server / Bitbucket Data Center instances that support personal access tokens (Bitbucket Server / Bitbucket Data Center version 5.5 and newer), it is recommended to provide a token instead (in the "token" field).
@@ -428,6 +442,11 @@ func (v *BitbucketServerIdentityProvider) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"username"})
 }
 
+type BitbucketServerMirrorTo struct {
+	// Url description: URL of the remote repository to push a repository to. `{repo}` is replaced with the name of the repository.
+	Url string `json:"url"`
+}
+
 // BitbucketServerOAuth description: OAuth configuration specified when creating the Bitbucket Server / Bitbucket Data Center Application Link with incoming authentication. Two Legged OAuth with 'ExecuteAs=admin' must be enabled as well as user impersonation.
 type BitbucketServerOAuth struct {
 	// ConsumerKey description: The OAuth consumer key specified when creating the Bitbucket Server / Bitbucket Data Center Application Link with incoming authentication.
@@ -805,6 +824,8 @@ type ExperimentalFeatures struct {
 	EventLogging string `json:"eventLogging,omitempty"`
 	// GitServerPinnedRepos description: List of repositories pinned to specific gitserver instances. The specified repositories will remain at their pinned servers on scaling the cluster. If the specified pinned server differs from the current server that stores the repository, then it must be re-cloned to the specified server.
 	GitServerPinnedRepos map[string]string `json:"gitServerPinnedRepos,omitempty"`
+	// GitServerReplicationFactor description: The number of gitserver instances each repository is cloned to. Reads fail over to another instance holding the repository when its primary gitserver is unavailable. The replicas of a repository are the instances following its primary gitserver in the list of gitserver addresses.
+	GitServerReplicationFactor int `json:"gitServerReplicationFactor,omitempty"`
 	// GoPackages description: Allow adding Go package host connections
 	GoPackages string `json:"goPackages,omitempty"`
 	// InsightsAlternateLoadingStrategy description: Use an in-memory strategy of loading Code Insights. Should only be used for benchmarking on large instances, not for customer use currently.
@@ -889,6 +910,7 @@ func (v *ExperimentalFeatures) UnmarshalJSON(data []byte) error {
 	delete(m, "enableStorm")
 	delete(m, "eventLogging")
 	delete(m, "gitServerPinnedRepos")
+	delete(m, "gitServerReplicationFactor")
 	delete(m, "goPackages")
 	delete(m, "insightsAlternateLoadingStrategy")
 	delete(m, "insightsBackfillerV2")
@@ -1069,6 +1091,16 @@ type GitHubAuthorization struct {
 	// GroupsCacheTTL description: Experimental: If set, configures hours cached permissions from teams and organizations should be kept for. Setting a negative value disables syncing from teams and organizations, and falls back to the default behaviour of syncing all permisisons directly from user-repository affiliations instead. [Learn more](https://docs.sourcegraph.com/admin/external_service/github#teams-and-organizations-permissions-caching).
 	GroupsCacheTTL float64 `json:"groupsCacheTTL,omitempty"`
 }
+type GitHubCloneStrategy struct {
+	// Depth description: Number of commits of history to clone. The full history is cloned if it is not set.
+	Depth int `json:"depth,omitempty"`
+	// Filter description: Filter for the objects to clone, as passed to `git fetch --filter`. Objects left out of the clone are fetched when they are read.
+	Filter string `json:"filter,omitempty"`
+	// Pattern description: Regular expression which matches against the name of the repositories the strategy applies to.
+	Pattern string `json:"pattern"`
+	// SparsePaths description: Directories to clone the files of. Files outside of them are left out of the clone and fetched when they are read. Requires a filter.
+	SparsePaths []string `json:"sparsePaths,omitempty"`
+}
 
 // GitHubConnection description: Configuration for a connection to GitHub or GitHub Enterprise.
 type GitHubConnection struct {
@@ -1076,6 +1108,8 @@ type GitHubConnection struct {
 	Authorization *GitHubAuthorization `json:"authorization,omitempty"`
 	// Certificate description: TLS certificate of the GitHub Enterprise instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	Certificate string `json:"certificate,omitempty"`
+	// CloneStrategies description: Partial clone strategies for large repositories. The first strategy whose pattern matches the name of a repository is used to clone it. Other repositories are cloned in full.
+	CloneStrategies []*GitHubCloneStrategy `json:"cloneStrategies,omitempty"`
 	// CloudDefault description: Only used to override the cloud_default column from a config file specified by EXTSVC_CONFIG_FILE
 	CloudDefault bool `json:"cloudDefault,omitempty"`
 	// CloudGlobal description: When set to true, this external service will be chosen as our 'Global' GitHub service. Only valid on Sourcegraph.com. Only one service can have this flag set.
@@ -1098,6 +1132,8 @@ type GitHubConnection struct {
 	GithubAppInstallationID string `json:"githubAppInstallationID,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. GitHub repositories can no longer be enabled or disabled explicitly. Configure repositories to be mirrored via "repos", "exclude" and "repositoryQuery" instead.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
+	// MirrorTo description: A secondary Git remote to push all refs of the repositories of this connection to after each update, for example a bare repository server kept for disaster recovery. Failed pushes are retried with backoff.
+	MirrorTo *GitHubMirrorTo `json:"mirrorTo,omitempty"`
 	// Orgs description: An array of organization names identifying GitHub organizations whose repositories should be mirrored on Sourcegraph.
 	Orgs []string `json:"orgs,omitempty"`
 	// Pending description: Whether the code host connection is in a pending state.
@@ -1137,6 +1173,11 @@ type GitHubConnection struct {
 	Webhooks []*GitHubWebhook `json:"webhooks,omitempty"`
 }
 
+type GitHubMirrorTo struct {
+	// Url description: URL of the remote repository to push a repository to. `{repo}` is replaced with the name of the repository.
+	Url string `json:"url"`
+}
+
 // GitHubRateLimit description: Rate limit applied when making background API requests to GitHub.
 type GitHubRateLimit struct {
 	// Enabled description: true if rate limiting is enabled.
@@ -1179,6 +1220,16 @@ type GitLabAuthorization struct {
 	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the GitLab identity to use for a given Sourcegraph user.
 	IdentityProvider IdentityProvider `json:"identityProvider"`
 }
+type GitLabCloneStrategy struct {
+	// Depth description: Number of commits of history to clone. The full history is cloned if it is not set.
+	Depth int `json:"depth,omitempty"`
+	// Filter description: Filter for the objects to clone, as passed to `git fetch --filter`. Objects left out of the clone are fetched when they are read.
+	Filter string `json:"filter,omitempty"`
+	// Pattern description: Regular expression which matches against the name of the repositories the strategy applies to.
+	Pattern string `json:"pattern"`
+	// SparsePaths description: Directories to clone the files of. Files outside of them are left out of the clone and fetched when they are read. Requires a filter.
+	SparsePaths []string `json:"sparsePaths,omitempty"`
+}
 
 // GitLabConnection description: Configuration for a connection to GitLab (GitLab.com or GitLab self-managed).
 type GitLabConnection struct {
@@ -1186,6 +1237,8 @@ type GitLabConnection struct {
 	Authorization *GitLabAuthorization `json:"authorization,omitempty"`
 	// Certificate description: TLS certificate of the GitLab instance. This is only necessary if the certificate is self-signed or signed by an internal CA. To get the certificate run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.
 	Certificate string `json:"certificate,omitempty"`
+	// CloneStrategies description: Partial clone strategies for large repositories. The first strategy whose pattern matches the name of a repository is used to clone it. Other repositories are cloned in full.
+	CloneStrategies []*GitLabCloneStrategy `json:"cloneStrategies,omitempty"`
 	// CloudDefault description: Only used to override the cloud_default column from a config file specified by EXTSVC_CONFIG_FILE
 	CloudDefault bool `json:"cloudDefault,omitempty"`
 	// CloudGlobal description: When set to true, this external service will be chosen as our 'Global' GitLab service. Only valid on Sourcegraph.com. Only one service can have this flag set.
@@ -1200,6 +1253,8 @@ type GitLabConnection struct {
 	GitURLType string `json:"gitURLType,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. GitLab repositories can no longer be enabled or disabled explicitly.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
+	// MirrorTo description: A secondary Git remote to push all refs of the repositories of this connection to after each update, for example a bare repository server kept for disaster recovery. Failed pushes are retried with backoff.
+	MirrorTo *GitLabMirrorTo `json:"mirrorTo,omitempty"`
 	// NameTransformations description: An array of transformations will apply to the repository name. Currently, only regex replacement is supported. All transformations happen after "repositoryPathPattern" is processed.
 	NameTransformations []*GitLabNameTransformation `json:"nameTransformations,omitempty"`
 	// ProjectQuery description: An array of strings specifying which GitLab projects to mirror on Sourcegraph. Each string is a URL path and query that targets a GitLab API endpoint returning a list of projects. If the string only contains a query, then "projects" is used as the path. Examples: "?membership=true&search=foo", "groups/mygroup/projects".
@@ -1229,6 +1284,10 @@ type GitLabConnection struct {
 	// Webhooks description: An array of webhook configurations
 	Webhooks []*GitLabWebhook `json:"webhooks,omitempty"`
 }
+type GitLabMirrorTo struct {
+	// Url description: URL of the remote repository to push a repository to. `{repo}` is replaced with the name of the repository.
+	Url string `json:"url"`
+}
 type GitLabNameTransformation struct {
 	// Regex description: The regex to match for the occurrences of its replacement.
 	Regex string `json:"regex,omitempty"`
@@ -1659,12 +1718,26 @@ type OrganizationInvitations struct {
 	// The legacy invitation will be deprecated in the future and creating an organization invitation will fail with an error if this setting is not present.
 	SigningKey string `json:"signingKey"`
 }
+type OtherCloneStrategy struct {
+	// Depth description: Number of commits of history to clone. The full history is cloned if it is not set.
+	Depth int `json:"depth,omitempty"`
+	// Filter description: Filter for the objects to clone, as passed to `git fetch --filter`. Objects left out of the clone are fetched when they are read.
+	Filter string `json:"filter,omitempty"`
+	// Pattern description: Regular expression which matches against the name of the repositories the strategy applies to.
+	Pattern string `json:"pattern"`
+	// SparsePaths description: Directories to clone the files of. Files outside of them are left out of the clone and fetched when they are read. Requires a filter.
+	SparsePaths []string `json:"sparsePaths,omitempty"`
+}
 
 // OtherExternalServiceConnection description: Configuration for a Connection to Git repositories for which an external service integration isn't yet available.
 type OtherExternalServiceConnection struct {
+	// CloneStrategies description: Partial clone strategies for large repositories. The first strategy whose pattern matches the name of a repository is used to clone it. Other repositories are cloned in full.
+	CloneStrategies []*OtherCloneStrategy `json:"cloneStrategies,omitempty"`
 	// Exclude description: A list of repositories to never mirror by name after applying repositoryPathPattern. Supports excluding by exact name ({"name": "myrepo"}) or regular expression ({"pattern": ".*secret.*"}).
 	Exclude []*ExcludedOtherRepo `json:"exclude,omitempty"`
-	Repos   []string             `json:"repos"`
+	// MirrorTo description: A secondary Git remote to push all refs of the repositories of this connection to after each update, for example a bare repository server kept for disaster recovery. Failed pushes are retried with backoff.
+	MirrorTo *OtherMirrorTo `json:"mirrorTo,omitempty"`
+	Repos    []string       `json:"repos"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Git clone base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
 	//
 	// For example, if your Git clone base URL is https://git.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://git.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/git.example.com/repos/my/repo.
@@ -1677,6 +1750,10 @@ type OtherExternalServiceConnection struct {
 	Root string `json:"root,omitempty"`
 	Url  string `json:"url,omitempty"`
 }
+type OtherMirrorTo struct {
+	// Url description: URL of the remote repository to push a repository to. `{repo}` is replaced with the name of the repository.
+	Url string `json:"url"`
+}
 type OutputVariable struct {
 	// Format description: The expected format of the output. If set, the output is being parsed in that format before being stored in the var. If not set, 'text' is assumed to the format.
 	Format string `json:"format,omitempty"`
@@ -2590,6 +2667,8 @@ type SiteConfiguration struct {
 	SearchLargeFiles []string `json:"search.largeFiles,omitempty"`
 	// SearchLimits description: Limits that search applies for number of repositories searched and timeouts.
 	SearchLimits *SearchLimits `json:"search.limits,omitempty"`
+	// SearchSmartSearchRules description: Additional rules Smart Search applies alongside its built-in rules when a query returns no results. Each rule rewrites the patterns of a query which match a regular expression into a query, for example to turn team names into repo: filters. Queries produced by a rule are reported with the rule's description in the Smart Search alert.
+	SearchSmartSearchRules []*SmartSearchRule `json:"search.smartSearch.rules,omitempty"`
 	// SyntaxHighlighting description: Syntax highlighting configuration
 	SyntaxHighlighting *SyntaxHighlighting `json:"syntaxHighlighting,omitempty"`
 	// UpdateChannel description: The channel on which to automatically check for Sourcegraph updates.
@@ -2754,6 +2833,7 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	delete(m, "search.index.symbols.enabled")
 	delete(m, "search.largeFiles")
 	delete(m, "search.limits")
+	delete(m, "search.smartSearch.rules")
 	delete(m, "syntaxHighlighting")
 	delete(m, "update.channel")
 	delete(m, "webhook.logging")
@@ -2766,6 +2846,21 @@ func (v *SiteConfiguration) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type SmartSearchRule struct {
+	// Description description: Describes the rule. Shown in the Smart Search alert for queries produced by the rule.
+	Description string `json:"description"`
+	// ForbidFilters description: Do not apply the rule to queries which have any of these filters, e.g. "lang".
+	ForbidFilters []string `json:"forbidFilters,omitempty"`
+	// Kind description: Whether the rule makes queries more specific (narrow) or more general (widen). Narrowing rules are combined with each other before widening rules are tried.
+	Kind string `json:"kind,omitempty"`
+	// Match description: A regular expression matched against each pattern of the query. The rule applies to the query if at least one non-negated pattern matches.
+	Match string `json:"match"`
+	// Replace description: The query which replaces the matched part of each matching pattern. Filters in it are added to the query. Use $1, ${name} and so on to refer to submatches of match.
+	Replace string `json:"replace"`
+	// RequireFilters description: Only apply the rule to queries which have all of these filters, e.g. "repo".
+	RequireFilters []string `json:"requireFilters,omitempty"`
+}
+
 // SrcCliVersionCache description: Configuration related to the src-cli version cache. This should only be used on sourcegraph.com.
 type SrcCliVersionCache struct {
 	// Enabled description: Enables the src-cli version cache API endpoint.